@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/explore"
+)
+
+func cmdExplore(args []string) {
+	fs := flag.NewFlagSet("explore", flag.ExitOnError)
+	mode := fs.String("mode", string(explore.Random), "Exploration mode: random or exhaustive")
+	walks := fs.Int("walks", explore.DefaultWalks, "Number of random walks")
+	depth := fs.Int("depth", 0, fmt.Sprintf("Steps per walk (default %d) or search depth (default %d)", explore.DefaultDepth, explore.DefaultSearchDepth))
+	seed := fs.Int64("seed", 1, "Random seed")
+	maxStates := fs.Int("max-states", explore.DefaultMaxStates, "Distinct states exhaustive search visits")
+	dataJSON := fs.String("data", "", "JSON object of initial data place values")
+	valuesJSON := fs.String("values", "", "JSON object of candidate values by binding name, e.g. {\"amount\":[0,1,1000]}")
+	jsonOutput := fs.Bool("json", false, "Output the report as JSON")
+
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintln(w, `petri-pilot explore - Fuzz a model for guard errors, constraint violations and deadlocks
+
+Usage:
+  petri-pilot explore [options] <model>
+
+  Fires transitions with bindings generated from their declared types, by
+  seeded random walks or a bounded breadth-first search. Each finding comes
+  with a minimized step list that petri_simulate replays unchanged.
+
+Arguments:
+  model    Path to the Petri net model file (.json or .pflow)
+
+Options:`)
+		fs.PrintDefaults()
+		fmt.Fprintln(w, `
+Examples:
+  petri-pilot explore model.json
+  petri-pilot explore -walks 1000 -depth 50 -seed 7 model.json
+  petri-pilot explore -mode exhaustive -depth 8 model.json
+  petri-pilot explore -data '{"balances":{"alice":100}}' -values '{"amount":[0,1,101]}' model.json`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Error: model file required")
+		fmt.Fprintln(os.Stderr, "Usage: petri-pilot explore [options] <model.json>")
+		os.Exit(1)
+	}
+
+	data, sources, err := readModel(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+		os.Exit(1)
+	}
+
+	model, _, err := parseModelWithExtensions(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing model: %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := explore.Options{
+		Mode:      explore.Mode(*mode),
+		Walks:     *walks,
+		Depth:     *depth,
+		Seed:      *seed,
+		MaxStates: *maxStates,
+	}
+	if *dataJSON != "" {
+		if err := json.Unmarshal([]byte(*dataJSON), &opts.Data); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing data: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *valuesJSON != "" {
+		if err := json.Unmarshal([]byte(*valuesJSON), &opts.Values); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing values: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	report, err := explore.Explore(metamodel.FromModel(model), opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Exploration error: %v\n", err)
+		os.Exit(1)
+	}
+	for i := range report.Findings {
+		report.Findings[i].Message = sources.Explain(report.Findings[i].Message)
+	}
+
+	if *jsonOutput {
+		output, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		printExploreReport(report)
+	}
+
+	// Guard errors and constraint violations fail the command; deadlocks
+	// are often just terminal states and are reported only.
+	for _, f := range report.Findings {
+		if f.Kind != explore.Deadlock {
+			os.Exit(1)
+		}
+	}
+}
+
+func printExploreReport(report *explore.Report) {
+	if report.Mode == explore.Random {
+		fmt.Printf("Mode: random (%d walks, depth %d, seed %d)\n", report.Walks, report.Depth, report.Seed)
+	} else {
+		fmt.Printf("Mode: exhaustive (depth %d)\n", report.Depth)
+	}
+	fmt.Printf("States: %d", report.States)
+	if report.Truncated {
+		fmt.Print(" (stopped at the state limit)")
+	}
+	fmt.Printf("\nFirings: %d\n", report.Fired)
+
+	ids := make([]string, 0, len(report.FireCounts))
+	for id := range report.FireCounts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	fmt.Println("\nTransitions:")
+	for _, id := range ids {
+		fmt.Printf("  %-24s %d\n", id, report.FireCounts[id])
+	}
+	if len(report.NeverFired) > 0 {
+		fmt.Printf("\nNever fired: %v\n", report.NeverFired)
+	}
+
+	if len(report.Findings) == 0 {
+		fmt.Println("\nNo findings.")
+		return
+	}
+	fmt.Printf("\nFindings (%d):\n", len(report.Findings))
+	for i, f := range report.Findings {
+		fmt.Printf("\n%d. %s", i+1, f.Kind)
+		if f.Transition != "" {
+			fmt.Printf(" on %s", f.Transition)
+		}
+		fmt.Printf(": %s\n", f.Message)
+		steps, _ := json.Marshal(f.Trace)
+		fmt.Printf("   steps (%d, minimized from %d): %s\n", len(f.Trace), f.FoundLength, steps)
+	}
+}
+
+// readScenarioFiles reads comma-separated scenario files for codegen.
+// Unnamed scenarios are named after their file.
+func readScenarioFiles(paths string) ([]explore.Scenario, error) {
+	var scenarios []explore.Scenario
+	for _, path := range strings.Split(paths, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := explore.ParseScenarios(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		for i := range parsed {
+			if parsed[i].Name == "" {
+				parsed[i].Name = base
+				if len(parsed) > 1 {
+					parsed[i].Name = fmt.Sprintf("%s %d", base, i+1)
+				}
+			}
+		}
+		scenarios = append(scenarios, parsed...)
+	}
+	return scenarios, nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/lint"
+	"github.com/pflow-xyz/petri-pilot/pkg/mcp"
+	ppmetamodel "github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+func cmdLint(args []string) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	fix := fs.Bool("fix", false, "Apply fixes that have petri_extend operations and write the model back")
+	output := fs.String("o", "", "Write the fixed model here instead of over the input (with -fix)")
+	rulesJSON := fs.String("rules", "", "JSON object of severities by rule ID, overriding the model's lint block")
+	jsonOutput := fs.Bool("json", false, "Output the report as JSON")
+
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintln(w, `petri-pilot lint - Check a model for likely mistakes
+
+Usage:
+  petri-pilot lint [options] <model>
+
+  Reports problems by stable rule ID. Severities come from the model's
+  "lint" block, e.g. "lint": {"naming": "off"}, or -rules. Fixable
+  problems carry petri_extend operations; -fix applies them.
+
+Arguments:
+  model    Path to the Petri net model file (.json or .pflow)
+
+Rules:`)
+		for _, r := range lint.Rules() {
+			fmt.Fprintf(w, "  %-26s %-8s %s\n", r.ID, r.Severity, r.Description)
+		}
+		fmt.Fprintln(w, "\nOptions:")
+		fs.PrintDefaults()
+		fmt.Fprintln(w, `
+Examples:
+  petri-pilot lint model.json
+  petri-pilot lint -rules '{"naming":"off"}' model.json
+  petri-pilot lint -fix model.json
+  petri-pilot lint -fix -o fixed.json model.json`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Error: model file required")
+		fmt.Fprintln(os.Stderr, "Usage: petri-pilot lint [options] <model.json>")
+		os.Exit(1)
+	}
+
+	path := fs.Arg(0)
+	data, sources, err := readModel(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+		os.Exit(1)
+	}
+
+	var overrides lint.Config
+	if *rulesJSON != "" {
+		if err := json.Unmarshal([]byte(*rulesJSON), &overrides); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing rules: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	report, err := lintModel(data, overrides)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *fix && report.Fixable > 0 {
+		if len(sources) > 0 && *output == "" {
+			fmt.Fprintln(os.Stderr, "Error: fixing a model with subnets writes the composed net; use -o to choose where")
+			os.Exit(1)
+		}
+		fixed, applied, skipped, err := mcp.ApplyFixes(string(data), report)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error applying fixes: %v\n", err)
+			os.Exit(1)
+		}
+		target := path
+		if *output != "" {
+			target = *output
+		}
+		if err := os.WriteFile(target, []byte(fixed+"\n"), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing model: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Fixed %d problem(s) in %s\n", len(applied), target)
+		for _, name := range skipped {
+			fmt.Fprintf(os.Stderr, "Skipped %s: its operations no longer apply\n", name)
+		}
+
+		// Report what is left
+		if report, err = lintModel([]byte(fixed), overrides); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	explainLint(report, sources)
+
+	if *jsonOutput {
+		out, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(out))
+	} else {
+		printLintReport(report)
+	}
+
+	if report.Errors > 0 {
+		os.Exit(1)
+	}
+}
+
+// lintModel lints a model file's contents. DSL models are linted as a bare net.
+func lintModel(data []byte, overrides lint.Config) (*lint.Report, error) {
+	var in *lint.Input
+	if !strings.HasPrefix(strings.TrimSpace(string(data)), "(") {
+		in, _ = lint.Parse(data)
+	}
+	if in == nil {
+		model, _, err := parseModelWithExtensions(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing model: %w", err)
+		}
+		in = &lint.Input{Net: model}
+	}
+	if len(overrides) > 0 && in.Config == nil {
+		in.Config = lint.Config{}
+	}
+	for id, sev := range overrides {
+		in.Config[id] = sev
+	}
+	return lint.Lint(in)
+}
+
+// explainLint points diagnostics about imported nodes at the sub-model
+// that defined them.
+func explainLint(report *lint.Report, sources ppmetamodel.SourceMap) {
+	for i := range report.Diagnostics {
+		report.Diagnostics[i].Message = sources.Explain(report.Diagnostics[i].Message)
+	}
+}
+
+func printLintReport(report *lint.Report) {
+	if len(report.Diagnostics) == 0 {
+		fmt.Println("No problems found.")
+		return
+	}
+	for _, d := range report.Diagnostics {
+		fmt.Printf("%-7s %s: %s [%s]\n", d.Severity, d.Element, d.Message, d.Rule)
+		if d.Fix != nil {
+			marker := ""
+			if len(d.Fix.Operations) > 0 {
+				marker = " (fixable)"
+			}
+			fmt.Printf("        fix%s: %s\n", marker, d.Fix.Description)
+		}
+	}
+	fmt.Printf("\n%d error(s), %d warning(s), %d fixable with -fix\n", report.Errors, report.Warnings, report.Fixable)
+}
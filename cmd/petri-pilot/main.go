@@ -21,6 +21,8 @@ import (
 	"github.com/pflow-xyz/petri-pilot/pkg/feedback"
 	"github.com/pflow-xyz/petri-pilot/pkg/generator"
 	"github.com/pflow-xyz/petri-pilot/pkg/mcp"
+	ppmetamodel "github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/explore"
 	"github.com/pflow-xyz/petri-pilot/pkg/validator"
 	jsonschema "github.com/pflow-xyz/petri-pilot/schema"
 
@@ -46,6 +48,14 @@ func main() {
 		cmdCodegen(os.Args[2:])
 	case "frontend":
 		cmdFrontend(os.Args[2:])
+	case "simulate":
+		cmdSimulate(os.Args[2:])
+	case "explore":
+		cmdExplore(os.Args[2:])
+	case "lint":
+		cmdLint(os.Args[2:])
+	case "migrate-events":
+		cmdMigrateEvents(os.Args[2:])
 	case "delegate":
 		cmdDelegate(os.Args[2:])
 	case "serve":
@@ -53,7 +63,7 @@ func main() {
 	case "service":
 		cmdService(os.Args[2:])
 	case "mcp":
-		cmdMcp()
+		cmdMcp(os.Args[2:])
 	case "schema":
 		cmdSchema()
 	case "services":
@@ -81,10 +91,15 @@ Commands:
   refine      Refine a model based on validation feedback
   codegen     Generate backend application code from a validated model
   frontend    Generate vanilla JavaScript ES modules frontend from a validated model
+  simulate    Run a stochastic (Gillespie) simulation of a model
+  explore     Fuzz a model for guard errors, constraint violations and deadlocks
+  lint        Check a model for likely mistakes, optionally applying fixes
+  migrate-events
+              Check event streams against a new model version and upcast them
   serve       Run a registered service by name
   service     Manage running services (list, stop, logs, stats, health)
   delegate    Delegate tasks to GitHub Copilot coding agent
-  mcp         Run as MCP server (for Claude Desktop, Cursor, etc.)
+  mcp         Run as MCP server over stdio, or HTTP/SSE with -http
   schema      Print the JSON Schema for Petri net models
   services    List or show embedded service models
 
@@ -114,9 +129,24 @@ Examples:
   # Generate OpenAPI spec only
   petri-pilot codegen -api-only model.json -o api.yaml
 
+  # Estimate completion times with 1000 stochastic replications
+  petri-pilot simulate -n 1000 model.json
+
+  # Fuzz a model with 1000 seeded random walks
+  petri-pilot explore -walks 1000 model.json
+
+  # Lint a model and apply the fixes that can be made mechanically
+  petri-pilot lint -fix model.json
+
+  # Dry-run a store's event streams under a new model, with a suggested plan
+  petri-pilot migrate-events -db orders.db -from v1.json v2.json
+
   # Run as MCP server
   petri-pilot mcp
 
+  # Share one MCP server over HTTP with bearer-token auth
+  petri-pilot mcp -http :8090 -token alice=s3cret
+
   # List available services
   petri-pilot serve
 
@@ -305,7 +335,7 @@ Examples:
 	modelPath := fs.Arg(0)
 
 	// Read model file
-	data, err := os.ReadFile(modelPath)
+	data, sources, err := readModel(modelPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
 		os.Exit(1)
@@ -329,6 +359,7 @@ Examples:
 		fmt.Fprintf(os.Stderr, "Validation error: %v\n", err)
 		os.Exit(1)
 	}
+	explainValidation(result, sources)
 
 	if *jsonOutput {
 		output, _ := json.MarshalIndent(result, "", "  ")
@@ -522,6 +553,8 @@ func cmdCodegen(args []string) {
 	apiOnly := fs.Bool("api-only", false, "Generate OpenAPI spec only")
 	includeFrontend := fs.Bool("frontend", false, "Generate ES modules frontend in frontend/ subdirectory")
 	asSubmodule := fs.Bool("submodule", false, "Skip go.mod generation (treat output as part of parent module)")
+	scenarioDepth := fs.Int("scenario-depth", explore.DefaultSearchDepth, "Longest path replayed by the generated scenario tests")
+	scenarioFiles := fs.String("scenarios", "", "Comma-separated scenario files to add to the generated scenario tests")
 
 	fs.Usage = func() {
 		w := fs.Output()
@@ -541,7 +574,8 @@ Examples:
   petri-pilot codegen model.pflow -o ./myapp        Generate from DSL file
   petri-pilot codegen -frontend model.json -o ./myapp
   petri-pilot codegen -api-only model.json -o openapi.yaml
-  petri-pilot codegen -submodule -o generated/myapp model.json`)
+  petri-pilot codegen -submodule -o generated/myapp model.json
+  petri-pilot codegen -scenarios checkout.json,refund.json model.json -o ./myapp`)
 	}
 
 	if err := fs.Parse(args); err != nil {
@@ -558,7 +592,7 @@ Examples:
 	modelPath := fs.Arg(0)
 
 	// Read model file
-	data, err := os.ReadFile(modelPath)
+	data, sources, err := readModel(modelPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
 		os.Exit(1)
@@ -571,7 +605,8 @@ Examples:
 		os.Exit(1)
 	}
 	hasExtensions := app.HasAdmin() || app.HasNavigation() || app.HasRoles() || app.HasViews() ||
-		app.HasGraphQL() || app.HasDebug()
+		app.HasReadPolicies() || app.HasRateLimits() || app.HasProjections() || app.HasSimulation() ||
+		app.HasGraphQL() || app.HasDebug() || app.HasPrediction()
 
 	// Package name is determined by generator if not specified
 	pkgName := *pkg
@@ -588,7 +623,7 @@ Examples:
 
 		content, err := gen.Preview(model, golang.TemplateOpenAPI)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating OpenAPI spec: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error generating OpenAPI spec: %v\n", sources.Explain(err.Error()))
 			os.Exit(1)
 		}
 
@@ -626,6 +661,7 @@ Examples:
 		os.Exit(1)
 	}
 
+	explainValidation(result, sources)
 	if !result.Valid {
 		fmt.Fprintln(os.Stderr, "Error: model validation failed")
 		for _, e := range result.Errors {
@@ -655,6 +691,12 @@ Examples:
 		}
 	}
 
+	scenarios, err := readScenarioFiles(*scenarioFiles)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading scenarios: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Create generator
 	gen, err := golang.New(golang.Options{
 		OutputDir:            *output,
@@ -667,6 +709,8 @@ Examples:
 		IncludeDeploy:        *includeDeploy,
 		IncludeRealtime:      *includeRealtime,
 		AsSubmodule:          *asSubmodule,
+		ScenarioDepth:        *scenarioDepth,
+		Scenarios:            scenarios,
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating generator: %v\n", err)
@@ -681,7 +725,7 @@ Examples:
 		paths, err = gen.Generate(model)
 	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error generating code: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error generating code: %v\n", sources.Explain(err.Error()))
 		os.Exit(1)
 	}
 
@@ -711,7 +755,7 @@ Examples:
 			frontendPaths, err = frontendGen.Generate(model)
 		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating frontend: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error generating frontend: %v\n", sources.Explain(err.Error()))
 			os.Exit(1)
 		}
 
@@ -761,7 +805,7 @@ Examples:
 	modelPath := fs.Arg(0)
 
 	// Read model file
-	data, err := os.ReadFile(modelPath)
+	data, sources, err := readModel(modelPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
 		os.Exit(1)
@@ -785,6 +829,7 @@ Examples:
 		os.Exit(1)
 	}
 
+	explainValidation(result, sources)
 	if !result.Valid {
 		fmt.Fprintln(os.Stderr, "Error: model validation failed")
 		for _, e := range result.Errors {
@@ -807,7 +852,7 @@ Examples:
 	// Generate files
 	paths, err := gen.Generate(model)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error generating frontend: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error generating frontend: %v\n", sources.Explain(err.Error()))
 		os.Exit(1)
 	}
 
@@ -839,8 +884,99 @@ func detectParentModule() string {
 	return ""
 }
 
-func cmdMcp() {
-	if err := mcp.Serve(); err != nil {
+// tokenFlags collects repeated -token client=secret flags.
+type tokenFlags map[string]string
+
+func (t tokenFlags) String() string {
+	clients := make([]string, 0, len(t))
+	for _, client := range t {
+		clients = append(clients, client)
+	}
+	return strings.Join(clients, ",")
+}
+
+func (t tokenFlags) Set(value string) error {
+	client, token, ok := strings.Cut(value, "=")
+	if !ok || client == "" || token == "" {
+		return fmt.Errorf("expected client=token, got %q", value)
+	}
+	t[token] = client
+	return nil
+}
+
+func cmdMcp(args []string) {
+	fs := flag.NewFlagSet("mcp", flag.ExitOnError)
+	httpAddr := fs.String("http", "", "Serve streamable HTTP/SSE on this address (e.g. :8090) instead of stdio")
+	path := fs.String("path", mcp.DefaultHTTPPath, "HTTP endpoint path")
+	corsOrigins := fs.String("cors", "", "Comma-separated origins allowed by CORS (* allows any)")
+	noAuth := fs.Bool("no-auth", false, "Serve HTTP without bearer-token authentication")
+	allowServices := fs.Bool("allow-services", false, "Serve the tools that build and run services or delegate to GitHub over HTTP (requires tokens)")
+	tokens := tokenFlags{}
+	fs.Var(tokens, "token", "Bearer token as client=token (repeatable; PETRI_MCP_TOKEN adds client 'default')")
+
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintln(w, `petri-pilot mcp - Run as MCP server
+
+Usage:
+  petri-pilot mcp [options]
+
+  Without -http, serves over stdio for desktop clients.
+  With -http, serves streamable HTTP/SSE so a team or a browser-based agent
+  can share one server. Each token names a client with its own sessions,
+  model workspace and services. Tools that build and run code on this
+  machine or use its GitHub credentials are left out unless -allow-services
+  is given.
+
+Options:`)
+		fs.PrintDefaults()
+		fmt.Fprintln(w, `
+Examples:
+  petri-pilot mcp
+  petri-pilot mcp -http :8090 -token alice=s3cret -token bob=t0ken
+  PETRI_MCP_TOKEN=s3cret petri-pilot mcp -http :8090 -cors https://agent.example`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *httpAddr == "" {
+		if err := mcp.Serve(); err != nil {
+			fmt.Fprintf(os.Stderr, "MCP server error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if token := os.Getenv("PETRI_MCP_TOKEN"); token != "" {
+		tokens[token] = "default"
+	}
+	if len(tokens) == 0 && !*noAuth {
+		fmt.Fprintln(os.Stderr, "Error: -http requires -token or PETRI_MCP_TOKEN (or -no-auth)")
+		os.Exit(1)
+	}
+
+	opts := mcp.HTTPOptions{
+		Addr:          *httpAddr,
+		Path:          *path,
+		Tokens:        tokens,
+		AllowServices: *allowServices,
+	}
+	if *corsOrigins != "" {
+		for _, origin := range strings.Split(*corsOrigins, ",") {
+			opts.AllowedOrigins = append(opts.AllowedOrigins, strings.TrimSpace(origin))
+		}
+	}
+
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(os.Stderr, "MCP server listening on http://%s%s (%d client token(s))\n", *httpAddr, *path, len(tokens))
+	if err := mcp.ServeHTTP(opts); err != nil {
 		fmt.Fprintf(os.Stderr, "MCP server error: %v\n", err)
 		os.Exit(1)
 	}
@@ -895,19 +1031,65 @@ func parseModelFile(data []byte) (*metamodel.Model, error) {
 	return &model, nil
 }
 
+// readModel reads a model file and flattens its subnets, loading imported
+// models relative to the file. The source map points IDs of imported
+// nodes back at the sub-model that defined them.
+func readModel(path string) ([]byte, ppmetamodel.SourceMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return flattenModel(data, ppmetamodel.DirLoader(filepath.Dir(path)))
+}
+
+// flattenModel flattens the subnets of a JSON model document, reading
+// imported models with load. DSL models are returned unchanged.
+func flattenModel(data []byte, load ppmetamodel.Loader) ([]byte, ppmetamodel.SourceMap, error) {
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "(") {
+		return data, nil, nil
+	}
+	flat, sources, err := ppmetamodel.Flatten(data, load)
+	if err != nil {
+		return nil, nil, fmt.Errorf("composing subnets: %w", err)
+	}
+	return flat, sources, nil
+}
+
+// explainValidation points validation messages about imported nodes at
+// the sub-model that defined them.
+func explainValidation(result *metamodel.ValidationResult, sources ppmetamodel.SourceMap) {
+	if len(sources) == 0 {
+		return
+	}
+	for i := range result.Errors {
+		result.Errors[i].Message = sources.Explain(result.Errors[i].Message)
+	}
+	for i := range result.Warnings {
+		result.Warnings[i].Message = sources.Explain(result.Warnings[i].Message)
+	}
+}
+
 // modelWithExtensions is a struct for parsing v1 model JSON that includes extension fields
-// like admin, navigation, roles, access, views, debug, and graphql at the top level.
+// like admin, navigation, roles, access, readPolicies, rateLimits, projections, simulation, views, debug, graphql, prediction and tokens at the top level.
 type modelWithExtensions struct {
 	metamodel.Model
 
 	// Extension fields that may appear at the top level of v1 model JSON
-	Admin      *extensions.Admin           `json:"admin,omitempty"`
-	Navigation *extensions.Navigation      `json:"navigation,omitempty"`
-	Roles      []extensions.Role           `json:"roles,omitempty"`
-	Access     []accessRule                `json:"access,omitempty"`
-	Views      []extensions.View           `json:"views,omitempty"`
-	Debug      *metamodel.Debug            `json:"debug,omitempty"`
-	GraphQL    *metamodel.GraphQLConfig    `json:"graphql,omitempty"`
+	Admin        *extensions.Admin           `json:"admin,omitempty"`
+	Navigation   *extensions.Navigation      `json:"navigation,omitempty"`
+	Roles        []extensions.Role           `json:"roles,omitempty"`
+	Access       []accessRule                `json:"access,omitempty"`
+	ReadPolicies []extensions.ReadPolicy     `json:"readPolicies,omitempty"`
+	RateLimits   *extensions.RateLimits      `json:"rateLimits,omitempty"`
+	Projections  json.RawMessage             `json:"projections,omitempty"`
+	Simulation   json.RawMessage             `json:"simulation,omitempty"`
+	Views        []extensions.View           `json:"views,omitempty"`
+	Debug        *metamodel.Debug            `json:"debug,omitempty"`
+	GraphQL      *metamodel.GraphQLConfig    `json:"graphql,omitempty"`
+	Prediction   *metamodel.PredictionConfig `json:"prediction,omitempty"`
+
+	// Initial tokens of colored places, by place ID
+	Tokens map[string][]ppmetamodel.Token `json:"tokens,omitempty"`
 }
 
 // accessRule is a simplified struct for parsing access rules from v1 model JSON.
@@ -959,6 +1141,43 @@ func parseModelWithExtensions(data []byte) (*metamodel.Model, *extensions.Applic
 		app.WithRoles(rolesExt)
 	}
 
+	// Add read policies and rate limits if present
+	if len(ext.ReadPolicies) > 0 || ext.RateLimits != nil {
+		policiesExt := extensions.NewPolicyExtension()
+		for _, p := range ext.ReadPolicies {
+			policiesExt.AddReadPolicy(p)
+		}
+		policiesExt.RateLimits = ext.RateLimits
+		if err := policiesExt.Validate(model); err != nil {
+			return nil, nil, err
+		}
+		app.WithPolicies(policiesExt)
+	}
+
+	// Add read-model projection settings if present
+	if len(ext.Projections) > 0 {
+		projectionsExt := extensions.NewProjectionExtension()
+		if err := json.Unmarshal(ext.Projections, projectionsExt); err != nil {
+			return nil, nil, fmt.Errorf("projections: %w", err)
+		}
+		if err := projectionsExt.Validate(model); err != nil {
+			return nil, nil, err
+		}
+		app.WithProjections(projectionsExt)
+	}
+
+	// Add simulation objective and players if present
+	if len(ext.Simulation) > 0 {
+		simulationExt := extensions.NewSimulationExtension()
+		if err := json.Unmarshal(ext.Simulation, simulationExt); err != nil {
+			return nil, nil, fmt.Errorf("simulation: %w", err)
+		}
+		if err := simulationExt.Validate(model); err != nil {
+			return nil, nil, err
+		}
+		app.WithSimulation(simulationExt)
+	}
+
 	// Add views and admin if present
 	if len(ext.Views) > 0 || ext.Admin != nil {
 		viewsExt := extensions.NewViewExtension()
@@ -988,5 +1207,26 @@ func parseModelWithExtensions(data []byte) (*metamodel.Model, *extensions.Applic
 		app.SetGraphQL(ext.GraphQL)
 	}
 
+	// Set prediction config if present
+	if ext.Prediction != nil {
+		app.SetPrediction(ext.Prediction)
+	}
+
+	// Seed colored places with their initial tokens; the places count them
+	if len(ext.Tokens) > 0 {
+		schema := ppmetamodel.FromModel(model)
+		if err := schema.SetInitialTokens(ext.Tokens); err != nil {
+			return nil, nil, fmt.Errorf("tokens: %w", err)
+		}
+		tokens := make(map[string][]ppmetamodel.Token, len(ext.Tokens))
+		for i, p := range model.Places {
+			if st := schema.StateByID(p.ID); st != nil && st.IsColored() && st.Initial != nil {
+				tokens[p.ID] = st.Initial.([]ppmetamodel.Token)
+				model.Places[i].Initial = len(tokens[p.ID])
+			}
+		}
+		app.SetInitialTokens(tokens)
+	}
+
 	return model, app, nil
 }
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/migrate"
+)
+
+func cmdMigrateEvents(args []string) {
+	fs := flag.NewFlagSet("migrate-events", flag.ExitOnError)
+	db := fs.String("db", "", "SQLite event store to migrate (required unless -suggest)")
+	planPath := fs.String("plan", "", "Migration plan with the upcasters to apply")
+	from := fs.String("from", "", "Previous model; without -plan, its diff with the new model suggests one")
+	suggest := fs.Bool("suggest", false, "Print the plan suggested by -from and exit")
+	rewrite := fs.Bool("rewrite", false, "Rewrite the store with upcast events once every stream replays")
+	jsonOutput := fs.Bool("json", false, "Output the report as JSON")
+
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintln(w, `petri-pilot migrate-events - Check and migrate event streams for a new model version
+
+Usage:
+  petri-pilot migrate-events [options] <new-model>
+
+  Replays every stream in an event store under the new model, with the
+  plan's upcasters applied on read, and reports the streams that fail.
+  Nothing is written unless -rewrite is given and no stream fails. A
+  rewrite copies every event, upcast, into a new store file and swaps it in
+  only once every stream is written; the original is kept as <db>.bak.
+
+  A plan is JSON: {"version": 2, "upcasters": [{"event_type": "Submited",
+  "version": 1, "rename_to": "Filed", "rename_fields": {"total": "amount"},
+  "defaults": {"currency": "EUR"}, "drop_fields": ["legacy"]}]}. Upcasters
+  apply to events written at their version; events record the version in
+  their schema_version metadata, and events without one are version 1.
+
+Arguments:
+  new-model    Path to the new model file (.json or .pflow)
+
+Options:`)
+		fs.PrintDefaults()
+		fmt.Fprintln(w, `
+Examples:
+  petri-pilot migrate-events -from v1.json -suggest v2.json > plan.json
+  petri-pilot migrate-events -db orders.db -plan plan.json v2.json
+  petri-pilot migrate-events -db orders.db -plan plan.json -rewrite v2.json`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Error: model file required")
+		fmt.Fprintln(os.Stderr, "Usage: petri-pilot migrate-events [options] <new-model.json>")
+		os.Exit(1)
+	}
+
+	next, err := readModelFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var plan *migrate.Plan
+	switch {
+	case *planPath != "":
+		plan, err = migrate.LoadPlan(*planPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading plan: %v\n", err)
+			os.Exit(1)
+		}
+	case *from != "":
+		prev, err := readModelFile(*from)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		plan = migrate.Suggest(migrate.Compare(prev, next), prev, next)
+	}
+
+	if *suggest {
+		if plan == nil {
+			fmt.Fprintln(os.Stderr, "Error: -suggest requires -from")
+			os.Exit(1)
+		}
+		output, _ := json.MarshalIndent(plan, "", "  ")
+		fmt.Println(string(output))
+		return
+	}
+
+	if *db == "" {
+		fmt.Fprintln(os.Stderr, "Error: -db is required")
+		os.Exit(1)
+	}
+	store, err := eventsource.NewSQLiteStore(*db)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening event store: %v\n", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	report, err := migrate.Check(ctx, store, next, plan)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	rewritten := 0
+	if *rewrite && report.OK() {
+		rewritten, err = rewriteStore(ctx, store, *db, plan)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error rewriting streams, %s is unchanged: %v\n", *db, err)
+			os.Exit(1)
+		}
+	}
+
+	if *jsonOutput {
+		output, _ := json.MarshalIndent(struct {
+			*migrate.Report
+			Rewritten int `json:"rewritten"`
+		}{report, rewritten}, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		printMigrationReport(report, plan, *rewrite, rewritten)
+	}
+	if !report.OK() {
+		os.Exit(1)
+	}
+}
+
+func printMigrationReport(report *migrate.Report, plan *migrate.Plan, rewrite bool, rewritten int) {
+	fmt.Printf("Streams: %d\nEvents: %d\nUpcast: %d\n", report.Streams, report.Events, report.Upcast)
+	if plan != nil && len(plan.Notes) > 0 {
+		fmt.Println("\nPlan notes:")
+		for _, note := range plan.Notes {
+			fmt.Printf("  - %s\n", note)
+		}
+	}
+
+	if !report.OK() {
+		fmt.Printf("\nFailed streams (%d):\n", len(report.Failures))
+		for _, f := range report.Failures {
+			fmt.Printf("  %s: %s\n", f.StreamID, f.Error)
+		}
+		if rewrite {
+			fmt.Println("\nNothing was rewritten.")
+		}
+		return
+	}
+
+	fmt.Println("\nEvery stream replays under the new model.")
+	if rewrite {
+		fmt.Printf("Rewrote %d streams.\n", rewritten)
+	} else if report.Upcast > 0 {
+		fmt.Println("Run again with -rewrite to store the upcast events, or upcast them on read with")
+		fmt.Println("serve -plan, or MIGRATION_PLAN in a generated service.")
+	}
+}
+
+// rewriteStore writes store's events, upcast, into a new SQLite file next to
+// path and swaps it in once every stream is written, keeping the original as
+// path.bak. store is closed on success.
+func rewriteStore(ctx context.Context, store eventsource.Store, path string, plan *migrate.Plan) (int, error) {
+	backup := path + ".bak"
+	if _, err := os.Stat(backup); err == nil {
+		return 0, fmt.Errorf("%s already exists", backup)
+	}
+
+	tmp := path + ".rewrite"
+	os.Remove(tmp)
+	dst, err := eventsource.NewSQLiteStore(tmp)
+	if err != nil {
+		return 0, err
+	}
+	rewritten, err := migrate.Rewrite(ctx, store, dst, plan)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return 0, err
+	}
+
+	// Keep the original under its backup name, then replace it atomically
+	if err := store.Close(); err != nil {
+		os.Remove(tmp)
+		return 0, err
+	}
+	if err := os.Link(path, backup); err != nil {
+		os.Remove(tmp)
+		return 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return 0, err
+	}
+	return rewritten, nil
+}
+
+// readModelFile reads and parses a model file.
+func readModelFile(path string) (*goflowmodel.Model, error) {
+	data, _, err := readModel(path)
+	if err != nil {
+		return nil, err
+	}
+	model, _, err := parseModelWithExtensions(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return model, nil
+}
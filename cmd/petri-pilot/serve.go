@@ -1,16 +1,69 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	ppmetamodel "github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/migrate"
 	"github.com/pflow-xyz/petri-pilot/pkg/serve"
 )
 
+// modelFlags collects repeated -model paths.
+type modelFlags []string
+
+func (m *modelFlags) String() string {
+	return strings.Join(*m, ",")
+}
+
+func (m *modelFlags) Set(value string) error {
+	*m = append(*m, value)
+	return nil
+}
+
+// fieldCostFlags collects repeated -graphql-field-cost Type.field=N values.
+type fieldCostFlags map[string]int
+
+func (f fieldCostFlags) String() string {
+	var parts []string
+	for field, cost := range f {
+		parts = append(parts, fmt.Sprintf("%s=%d", field, cost))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (f fieldCostFlags) Set(value string) error {
+	field, costStr, ok := strings.Cut(value, "=")
+	if !ok || !strings.Contains(field, ".") {
+		return fmt.Errorf("expected Type.field=cost, got %q", value)
+	}
+	cost, err := strconv.Atoi(costStr)
+	if err != nil || cost < 0 {
+		return fmt.Errorf("invalid cost %q", costStr)
+	}
+	f[field] = cost
+	return nil
+}
+
 func cmdServe(args []string) {
 	fs := flag.NewFlagSet("serve", flag.ExitOnError)
 	port := fs.Int("port", 0, "Port to run the service on (default: 8080 or PORT env)")
+	var models modelFlags
+	fs.Var(&models, "model", "Serve a model file without code generation (repeatable)")
+	watch := fs.Bool("watch", false, "Deploy -model files again when they change")
+	planPath := fs.String("plan", "", "Upcast -model events through a migration plan (see migrate-events)")
+	graphQLDefaults := serve.DefaultGraphQLOptions()
+	maxDepth := fs.Int("graphql-max-depth", graphQLDefaults.Limits.MaxDepth, "Reject GraphQL operations nested deeper than this (0: no limit)")
+	maxComplexity := fs.Int("graphql-max-complexity", graphQLDefaults.Limits.MaxComplexity, "Reject GraphQL operations more complex than this (0: no limit)")
+	fieldCosts := fieldCostFlags{}
+	fs.Var(fieldCosts, "graphql-field-cost", "Complexity of a GraphQL field as Type.field=cost (repeatable, default 1)")
+	persistedQueries := fs.Int("graphql-persisted-queries", graphQLDefaults.PersistedQueries, "Automatic persisted queries to keep (0: off)")
 
 	fs.Usage = func() {
 		w := fs.Output()
@@ -22,6 +75,17 @@ Usage:
   Without service names, lists all available services.
   With one service name, starts that service.
   With multiple service names, runs all on one port (mounted at /{name}/).
+  With -model, interprets a model at runtime instead of running generated
+  code: REST routes under /{name}/api/, OpenAPI at /{name}/api/openapi.json,
+  GraphQL at /graphql, and the model's access rules. Events are in memory.
+  Admins deploy new versions by POSTing a model to /{name}/api/admin/versions,
+  or with -watch by saving the file. Existing instances stay on the version
+  they were created under until migrated via /{name}/api/admin/migrate.
+  With -plan, events are read through the plan's upcasters.
+
+  GraphQL operations deeper or more complex than the limits are rejected.
+  A field costs 1 plus its selections, which list fields multiply by their
+  first, last, limit or perPage argument (or 10).
 
 Options:`)
 		fs.PrintDefaults()
@@ -30,7 +94,10 @@ Examples:
   petri-pilot serve                        List available services
   petri-pilot serve blog-post              Run the blog-post service
   petri-pilot serve -port 3000 myapp       Run myapp on port 3000
-  petri-pilot serve tic-tac-toe coffeeshop Run both services together`)
+  petri-pilot serve tic-tac-toe coffeeshop Run both services together
+  petri-pilot serve -model order.json      Prototype order.json without codegen
+  petri-pilot serve -watch -model order.json
+                                           Redeploy order.json on every save`)
 	}
 
 	if err := fs.Parse(args); err != nil {
@@ -38,8 +105,34 @@ Examples:
 		os.Exit(1)
 	}
 
+	var plan *migrate.Plan
+	if *planPath != "" {
+		var err error
+		plan, err = migrate.LoadPlan(*planPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading plan: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	serviceNames := fs.Args()
+	for _, path := range models {
+		svc, err := registerModel(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		if plan != nil {
+			svc.SetPlan(plan)
+		}
+		if *watch {
+			go svc.WatchModel(context.Background(), path, time.Second)
+		}
+		serviceNames = append(serviceNames, svc.Name())
+	}
+
 	// If no service name provided, list available services
-	if fs.NArg() == 0 {
+	if len(serviceNames) == 0 {
 		services := serve.List()
 		if len(services) == 0 {
 			fmt.Println("No services registered.")
@@ -58,8 +151,6 @@ Examples:
 		os.Exit(0)
 	}
 
-	serviceNames := fs.Args()
-
 	// Check if all services exist
 	for _, name := range serviceNames {
 		if _, ok := serve.Get(name); !ok {
@@ -77,9 +168,56 @@ Examples:
 	if *port > 0 {
 		opts.Port = *port
 	}
+	opts.GraphQL.Limits.MaxDepth = *maxDepth
+	opts.GraphQL.Limits.MaxComplexity = *maxComplexity
+	opts.GraphQL.Limits.FieldCosts = fieldCosts
+	opts.GraphQL.PersistedQueries = *persistedQueries
 
 	if err := serve.RunMultiple(serviceNames, opts); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// registerModel registers a model file as a runtime-interpreted service.
+func registerModel(path string) (*serve.ModelService, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// Subnets load from the model's directory, on reloads too. Uploaded
+	// documents come over the network, so no import may leave it.
+	imports := ppmetamodel.RootedLoader(filepath.Dir(path))
+	load := func(data []byte) (serve.ModelSpec, error) {
+		flat, _, err := flattenModel(data, imports)
+		if err != nil {
+			return serve.ModelSpec{}, err
+		}
+		return loadModelSpec(flat)
+	}
+	spec, err := load(data)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := serve.RegisterModel(spec)
+	if err != nil {
+		return nil, err
+	}
+	svc.SetLoader(load)
+	return svc, nil
+}
+
+// loadModelSpec parses a model document. JSON documents keep their roles
+// and access rules; DSL models are served without them.
+func loadModelSpec(data []byte) (serve.ModelSpec, error) {
+	if !strings.HasPrefix(strings.TrimSpace(string(data)), "(") {
+		if spec, err := serve.ParseModelSpec(data); err == nil {
+			return spec, nil
+		}
+	}
+	model, _, err := parseModelWithExtensions(data)
+	if err != nil {
+		return serve.ModelSpec{}, fmt.Errorf("parsing model: %w", err)
+	}
+	return serve.ModelSpec{Net: model}, nil
+}
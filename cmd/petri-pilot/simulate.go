@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/stochastic"
+)
+
+// rateFlags collects repeated -rate transition=value flags.
+type rateFlags map[string]float64
+
+func (r rateFlags) String() string {
+	parts := make([]string, 0, len(r))
+	for id, rate := range r {
+		parts = append(parts, fmt.Sprintf("%s=%g", id, rate))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func (r rateFlags) Set(value string) error {
+	id, raw, ok := strings.Cut(value, "=")
+	if !ok || id == "" {
+		return fmt.Errorf("expected transition=rate, got %q", value)
+	}
+	rate, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("invalid rate for %s: %v", id, err)
+	}
+	r[id] = rate
+	return nil
+}
+
+func cmdSimulate(args []string) {
+	fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+	replications := fs.Int("n", stochastic.DefaultReplications, "Number of replications")
+	duration := fs.Float64("duration", 0, "Simulated time per replication (default: run until completion)")
+	until := fs.String("until", "", "Completion condition over the marking, e.g. \"done >= 10\" (default: no transition enabled)")
+	kinetics := fs.String("kinetics", string(stochastic.MassAction), "Rate kinetics: mass-action or single-server")
+	seed := fs.Int64("seed", 1, "Random seed")
+	maxEvents := fs.Int("max-events", stochastic.DefaultMaxEvents, "Firings per replication before it is cut off")
+	markingJSON := fs.String("marking", "", "JSON object of token counts replacing the initial marking")
+	jsonOutput := fs.Bool("json", false, "Output the report as JSON")
+	rates := rateFlags{}
+	fs.Var(rates, "rate", "Transition rate as transition=value (repeatable; overrides the model)")
+
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintln(w, `petri-pilot simulate - Run a stochastic (Gillespie) simulation of a model
+
+Usage:
+  petri-pilot simulate [options] <model>
+
+  Fires transitions at random with exponential waiting times over many
+  replications and reports completion time, throughput and place occupancy.
+  Rates come from each transition's "rate", then the simulation solver rates,
+  then -rate flags. Transitions without a rate fire at rate 1.
+
+Arguments:
+  model    Path to the Petri net model file (.json or .pflow)
+
+Options:`)
+		fs.PrintDefaults()
+		fmt.Fprintln(w, `
+Examples:
+  petri-pilot simulate model.json
+  petri-pilot simulate -n 1000 -kinetics single-server -rate serve=2 model.json
+  petri-pilot simulate -duration 480 -rate arrive=0.5 -json model.json
+  petri-pilot simulate -until "done >= 10" -marking '{"waiting":10}' model.json`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Error: model file required")
+		fmt.Fprintln(os.Stderr, "Usage: petri-pilot simulate [options] <model.json>")
+		os.Exit(1)
+	}
+
+	data, sources, err := readModel(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+		os.Exit(1)
+	}
+
+	model, app, err := parseModelWithExtensions(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing model: %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := stochastic.Options{
+		Replications: *replications,
+		Duration:     *duration,
+		Until:        *until,
+		Kinetics:     stochastic.Kinetics(*kinetics),
+		Seed:         *seed,
+		MaxEvents:    *maxEvents,
+	}
+	var sim *metamodel.Simulation
+	if ext := app.Simulation(); ext != nil {
+		sim = &ext.Simulation
+	}
+	opts.Rates = stochastic.Rates(model, sim)
+	for id, rate := range rates {
+		opts.Rates[id] = rate
+	}
+	if *markingJSON != "" {
+		if err := json.Unmarshal([]byte(*markingJSON), &opts.Marking); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing marking: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	report, err := stochastic.Simulate(metamodel.FromModel(model), opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Simulation error: %v\n", sources.Explain(err.Error()))
+		os.Exit(1)
+	}
+
+	if *jsonOutput {
+		output, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(output))
+		return
+	}
+	printStochasticReport(report)
+}
+
+func printStochasticReport(report *stochastic.Report) {
+	fmt.Printf("Replications: %d (seed %d, %s kinetics)\n", report.Replications, report.Seed, report.Kinetics)
+	if report.Until != "" {
+		fmt.Printf("Until: %s\n", report.Until)
+	}
+	if report.Duration > 0 {
+		fmt.Printf("Duration: %g\n", report.Duration)
+	}
+	fmt.Printf("Completed: %d/%d\n", report.Completed, report.Replications)
+
+	fmt.Printf("\n%-24s %10s %10s %10s %10s %10s %10s\n", "", "mean", "p50", "p90", "p95", "p99", "max")
+	row := func(name string, s stochastic.Summary) {
+		fmt.Printf("%-24s %10.3f %10.3f %10.3f %10.3f %10.3f %10.3f\n", name, s.Mean, s.P50, s.P90, s.P95, s.P99, s.Max)
+	}
+	if report.CompletionTime != nil {
+		row("completion time", *report.CompletionTime)
+	}
+	row("simulated time", report.SimulatedTime)
+	row("events", report.Events)
+
+	section := func(title string, values map[string]stochastic.Summary) {
+		if len(values) == 0 {
+			return
+		}
+		fmt.Printf("\n%s:\n", title)
+		ids := make([]string, 0, len(values))
+		for id := range values {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			row("  "+id, values[id])
+		}
+	}
+	section("Throughput (firings per time unit)", report.Throughput)
+	section("Occupancy (time-averaged tokens)", report.Occupancy)
+	section("Final marking", report.Final)
+}
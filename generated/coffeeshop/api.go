@@ -5,6 +5,8 @@ package coffeeshop
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/fs"
 	"net/http"
 	"os"
@@ -13,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/pflow-xyz/petri-pilot/pkg/runtime/api"
+	"github.com/pflow-xyz/go-pflow/eventsource"
 )
 
 // BuildRouter creates an HTTP router for the coffeeshop workflow.
@@ -47,6 +50,13 @@ func BuildRouter(app *Application, debugBroker *DebugBroker) http.Handler {
 
 
 
+	// Prediction endpoints
+	r.GET("/api/coffeeshop/predict", "Run simulation prediction", HandlePredict(app, app))
+	r.GET("/api/coffeeshop/runout", "Get runout predictions", HandleRunout(app, app))
+	r.GET("/api/coffeeshop/{id}/predict", "Run simulation prediction for an instance", HandlePredict(app, nil))
+	r.GET("/api/coffeeshop/{id}/runout", "Get runout predictions for an instance", HandleRunout(app, nil))
+
+
 
 	// GraphQL API
 	r.Handle("POST", "/graphql", "GraphQL API endpoint", GraphQLHandler(app))
@@ -57,6 +67,8 @@ func BuildRouter(app *Application, debugBroker *DebugBroker) http.Handler {
 	r.GET("/ws/debug", "Debug WebSocket connection", HandleDebugWebSocket(debugBroker))
 	r.GET("/api/debug/sessions", "List debug sessions", HandleListSessions(debugBroker))
 	r.POST("/api/debug/sessions/{id}/eval", "Evaluate code in browser session", HandleSessionEval(debugBroker))
+
+
 	// Guest login endpoint (debug mode without access control)
 	r.POST("/api/debug/login", "Create debug guest session", HandleDebugGuestLogin())
 
@@ -76,6 +88,7 @@ func BuildRouter(app *Application, debugBroker *DebugBroker) http.Handler {
 
 
 
+
 	// Transition endpoints
 	r.Transition("order_espresso", "/api/order_espresso", "Customer orders espresso", HandleOrderEspresso(app))
 	r.Transition("order_latte", "/api/order_latte", "Customer orders latte", HandleOrderLatte(app))
@@ -90,6 +103,9 @@ func BuildRouter(app *Application, debugBroker *DebugBroker) http.Handler {
 	r.Transition("restock_milk", "/api/restock_milk", "Restock milk inventory", HandleRestockMilk(app))
 	r.Transition("restock_cups", "/api/restock_cups", "Restock cup inventory", HandleRestockCups(app))
 
+	// Fire transitions in order, all or none
+	r.POST("/api/coffeeshop/{id}/sequence", "Fire transitions in order, all or none", HandleFireSequence(app))
+
 	// Serve frontend static files
 	r.StaticFiles("/", StaticFileHandler())
 
@@ -638,6 +654,57 @@ func HandleRestockCups(app *Application) http.HandlerFunc {
 }
 
 
+// HandleFireSequence fires transitions on an aggregate in order, persisting
+// all of their events or none, and returns the marking after each one.
+func HandleFireSequence(app *Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id := r.PathValue("id")
+		if id == "" {
+			api.Error(w, http.StatusBadRequest, "MISSING_ID", "aggregate ID is required")
+			return
+		}
+
+		var req api.SequenceRequest
+		if err := api.DecodeJSON(r, &req); err != nil {
+			api.Error(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+		if len(req.Steps) > api.MaxSequenceSteps {
+			api.Error(w, http.StatusBadRequest, "TOO_MANY_STEPS", fmt.Sprintf("sequence has %d steps, at most %d are allowed", len(req.Steps), api.MaxSequenceSteps))
+			return
+		}
+		steps := make([]SequenceStep, len(req.Steps))
+		for i, step := range req.Steps {
+			steps[i] = SequenceStep{TransitionID: step.TransitionID, Data: step.Data}
+		}
+
+		agg, markings, err := app.ExecuteSequence(ctx, id, steps, nil)
+		if err != nil {
+			switch {
+			case errors.Is(err, eventsource.ErrStreamNotFound):
+				api.Error(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+			case errors.Is(err, eventsource.ErrConcurrency):
+				api.Error(w, http.StatusConflict, "CONCURRENT_MODIFICATION", err.Error())
+			case errors.Is(err, ErrStoreFailed):
+				api.Error(w, http.StatusInternalServerError, "STORE_FAILED", err.Error())
+			default:
+				api.Error(w, http.StatusConflict, "SEQUENCE_FAILED", err.Error())
+			}
+			return
+		}
+
+		api.JSON(w, http.StatusOK, api.SequenceResult{
+			Success:            true,
+			AggregateID:        agg.ID(),
+			Version:            agg.Version(),
+			Markings:           markings,
+			State:              agg.Places(),
+			EnabledTransitions: agg.EnabledTransitions(),
+		})
+	}
+}
+
 
 
 
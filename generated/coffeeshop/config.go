@@ -20,8 +20,9 @@ type Config struct {
 	ShutdownTimeout time.Duration
 
 	// Database settings
-	DatabaseURL  string
-	DatabaseType string // "sqlite", "postgres"
+	DatabaseURL   string
+	DatabaseType  string // "sqlite", "postgres"
+	MigrationPlan string // Plan whose upcasters apply to events on read
 
 	// GitHub OAuth settings
 	GitHubClientID     string
@@ -48,8 +49,9 @@ func LoadConfig() (*Config, error) {
 		ShutdownTimeout: getDurationEnv("SHUTDOWN_TIMEOUT", 30*time.Second),
 
 		// Database defaults (SQLite by default, use DATABASE_TYPE=memory for in-memory)
-		DatabaseURL:  getEnv("DATABASE_URL", "coffeeshop.db"),
-		DatabaseType: getEnv("DATABASE_TYPE", "sqlite"),
+		DatabaseURL:   getEnv("DATABASE_URL", "coffeeshop.db"),
+		DatabaseType:  getEnv("DATABASE_TYPE", "sqlite"),
+		MigrationPlan: getEnv("MIGRATION_PLAN", ""),
 
 		// GitHub OAuth defaults
 		GitHubClientID:     getEnv("GITHUB_CLIENT_ID", ""),
@@ -17,6 +17,7 @@ type Resolver struct {
 		Load(ctx context.Context, id string) (Aggregate, error)
 		GetState(ctx context.Context, id string) (Aggregate, error)
 		Execute(ctx context.Context, id, transition string, data map[string]any) (Aggregate, error)
+		ExecuteSequence(ctx context.Context, id string, steps []SequenceStep) (Aggregate, []map[string]int, error)
 		HealthCheck(ctx context.Context) error
 		GetStore() eventsource.Store
 	}
@@ -37,6 +38,7 @@ func NewResolver(app interface {
 	Load(ctx context.Context, id string) (Aggregate, error)
 	GetState(ctx context.Context, id string) (Aggregate, error)
 	Execute(ctx context.Context, id, transition string, data map[string]any) (Aggregate, error)
+	ExecuteSequence(ctx context.Context, id string, steps []SequenceStep) (Aggregate, []map[string]int, error)
 	HealthCheck(ctx context.Context) error
 	GetStore() eventsource.Store
 }) *Resolver {
@@ -479,6 +481,47 @@ func (r *Resolver) RestockCups(ctx context.Context, input RestockCupsInput) (*Tr
 }
 
 
+// FireSequence fires transitions on an aggregate in order, all of them or none.
+func (r *Resolver) FireSequence(ctx context.Context, aggregateID string, transitions []TransitionInput) (*SequenceResult, error) {
+	steps := make([]SequenceStep, len(transitions))
+	for i, t := range transitions {
+		steps[i] = SequenceStep{Transition: t.Transition, Data: make(map[string]any)}
+		if t.Data != nil && *t.Data != "" {
+			if err := json.Unmarshal([]byte(*t.Data), &steps[i].Data); err != nil {
+				errMsg := fmt.Sprintf("step %d: %v", i, err)
+				return &SequenceResult{
+					Success: false,
+					Error:   &errMsg,
+				}, nil
+			}
+		}
+	}
+
+	agg, markings, err := r.App.ExecuteSequence(ctx, aggregateID, steps)
+	if err != nil {
+		errMsg := err.Error()
+		return &SequenceResult{
+			Success: false,
+			Error:   &errMsg,
+		}, nil
+	}
+
+	models := make([]*Places, len(markings))
+	for i, m := range markings {
+		models[i] = placesToModel(m)
+	}
+	id := agg.ID()
+	version := agg.Version()
+
+	return &SequenceResult{
+		Success:            true,
+		AggregateID:        &id,
+		Version:            &version,
+		Markings:           models,
+		State:              placesToModel(agg.Places()),
+		EnabledTransitions: agg.EnabledTransitions(),
+	}, nil
+}
 
 // Helper functions
 
@@ -611,6 +654,22 @@ type TransitionResult struct {
 	Error              *string  `json:"error"`
 }
 
+type SequenceResult struct {
+	Success            bool      `json:"success"`
+	AggregateID        *string   `json:"aggregateId"`
+	Version            *int      `json:"version"`
+	Markings           []*Places `json:"markings"`
+	State              *Places   `json:"state"`
+	EnabledTransitions []string  `json:"enabledTransitions"`
+	Error              *string   `json:"error"`
+}
+
+// SequenceStep is one transition of a sequence with its decoded bindings.
+type SequenceStep struct {
+	Transition string
+	Data       map[string]any
+}
+
 type AggregateList struct {
 	Items   []*AggregateState `json:"items"`
 	Total   int               `json:"total"`
@@ -690,3 +749,7 @@ type RestockCupsInput struct {
 	AggregateID string
 }
 
+type TransitionInput struct {
+	Transition string
+	Data       *string
+}
@@ -30,10 +30,12 @@ CREATE TABLE IF NOT EXISTS snapshots (
 );
 
 -- Projection: coffeeshop aggregate state
--- Denormalized view for fast reads
+-- Denormalized view for fast reads, kept up to date by the projector
+-- (projections.go) when the petri-pilot/projections extension is enabled
 CREATE TABLE IF NOT EXISTS "coffeeshop_state" (
     id TEXT PRIMARY KEY,
     version INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT '',  -- Comma-separated places holding tokens
     "coffee_beans" INTEGER DEFAULT 0,
     "milk" INTEGER DEFAULT 0,
     "cups" INTEGER DEFAULT 0,
@@ -42,11 +44,13 @@ CREATE TABLE IF NOT EXISTS "coffeeshop_state" (
     "latte_ready" INTEGER DEFAULT 0,
     "cappuccino_ready" INTEGER DEFAULT 0,
     "orders_complete" INTEGER DEFAULT 0,
+    state TEXT NOT NULL DEFAULT '{}',  -- Full aggregate state
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE INDEX IF NOT EXISTS "idx_coffeeshop_state_updated" ON "coffeeshop_state"(updated_at);
+CREATE INDEX IF NOT EXISTS "idx_coffeeshop_state_status" ON "coffeeshop_state"(status);
 
 -- Place tokens tracking (Petri net state)
 CREATE TABLE IF NOT EXISTS "coffeeshop_places" (
@@ -57,6 +61,13 @@ CREATE TABLE IF NOT EXISTS "coffeeshop_places" (
     PRIMARY KEY (aggregate_id, place_id)
 );
 
+-- Projection checkpoints: events of the global log consumed per projection
+CREATE TABLE IF NOT EXISTS projection_checkpoints (
+    name TEXT PRIMARY KEY,
+    position INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Event type constants for reference:
 -- OrderEspressoed
 -- OrderLatteed
@@ -4,6 +4,8 @@ package coffeeshop
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/pflow-xyz/go-pflow/eventsource"
@@ -182,3 +184,173 @@ func TestCannotFireDisabledTransition(t *testing.T) {
 		t.Errorf("expected error when firing disabled transition %s", disabledTransition)
 	}
 }
+
+// firePrediction fires the first transition enabled in the initial state on
+// each of ids, so predictions have instances and firings to learn from.
+func firePrediction(t *testing.T, app *Application, ids ...string) string {
+	t.Helper()
+	ctx := context.Background()
+	enabled := NewAggregate("").EnabledTransitions()
+	if len(enabled) == 0 {
+		t.Skip("No transitions enabled in initial state")
+	}
+	for _, id := range ids {
+		if _, err := app.Execute(ctx, id, enabled[0], nil); err != nil {
+			t.Fatalf("Execute %s on %s failed: %v", enabled[0], id, err)
+		}
+	}
+	return enabled[0]
+}
+
+func TestPredictFromAggregateAndInstances(t *testing.T) {
+	store := eventsource.NewMemoryStore()
+	defer store.Close()
+	app := NewApplication(store)
+	ctx := context.Background()
+	req := PredictionRequest{Rates: RatesModel, Hours: 1}
+
+	result, err := app.Predict(ctx, app, nil, req)
+	if err != nil {
+		t.Fatalf("Predict failed: %v", err)
+	}
+	if result.Source != "initial" {
+		t.Errorf("expected the initial marking without instances, got %q", result.Source)
+	}
+
+	firePrediction(t, app, "p1", "p2")
+	// Internal streams are not instances
+	evt, err := eventsource.NewEvent("__roles", "RoleGranted", map[string]any{})
+	if err != nil {
+		t.Fatalf("NewEvent failed: %v", err)
+	}
+	if _, err := store.Append(ctx, "__roles", -1, []*eventsource.Event{evt}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	agg, err := app.Load(ctx, "p1")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	result, err = app.Predict(ctx, app, agg, req)
+	if err != nil {
+		t.Fatalf("Predict from aggregate failed: %v", err)
+	}
+	if result.Source != "aggregate" || result.AggregateID != "p1" {
+		t.Errorf("expected a prediction from p1, got %q %q", result.Source, result.AggregateID)
+	}
+	for place, tokens := range agg.Places() {
+		if result.Marking[place] != tokens {
+			t.Errorf("expected %s = %d from the aggregate, got %d", place, tokens, result.Marking[place])
+		}
+	}
+
+	result, err = app.Predict(ctx, app, nil, req)
+	if err != nil {
+		t.Fatalf("Predict from instances failed: %v", err)
+	}
+	if result.Source != "instances" || result.Instances != 2 {
+		t.Errorf("expected a prediction from 2 instances, got %q with %d", result.Source, result.Instances)
+	}
+	for place, tokens := range agg.Places() {
+		if result.Marking[place] != 2*tokens {
+			t.Errorf("expected %s = %d totaled over both instances, got %d", place, 2*tokens, result.Marking[place])
+		}
+	}
+}
+
+func TestObservedRates(t *testing.T) {
+	store := eventsource.NewMemoryStore()
+	defer store.Close()
+	app := NewApplication(store)
+	ctx := context.Background()
+
+	if _, err := app.Predict(ctx, app, nil, PredictionRequest{Rates: RatesObserved, Hours: 1}); !errors.Is(err, ErrNoObservedRates) {
+		t.Errorf("expected ErrNoObservedRates before anything fired, got %v", err)
+	}
+	result, err := app.Predict(ctx, app, nil, PredictionRequest{Hours: 1})
+	if err != nil {
+		t.Fatalf("Predict failed: %v", err)
+	}
+	if result.RateSource != RatesModel {
+		t.Errorf("expected model rates before anything fired, got %q", result.RateSource)
+	}
+
+	fired := firePrediction(t, app, "p1")
+	rates, err := app.ObservedRates(ctx)
+	if err != nil {
+		t.Fatalf("ObservedRates failed: %v", err)
+	}
+	for _, transition := range AllTransitions() {
+		if transition == fired {
+			if rates[transition] <= 0 {
+				t.Errorf("expected a positive rate for %s, got %v", transition, rates[transition])
+			}
+		} else if rates[transition] != 0 {
+			t.Errorf("expected no rate for %s, which never fired, got %v", transition, rates[transition])
+		}
+	}
+	result, err = app.Predict(ctx, app, nil, PredictionRequest{Hours: 1})
+	if err != nil {
+		t.Fatalf("Predict failed: %v", err)
+	}
+	if result.RateSource != RatesObserved {
+		t.Errorf("expected observed rates once a transition fired, got %q", result.RateSource)
+	}
+}
+
+func TestScanEventLogCatchesUp(t *testing.T) {
+	store := eventsource.NewMemoryStore()
+	defer store.Close()
+	app := NewApplication(store)
+	ctx := context.Background()
+
+	fired := firePrediction(t, app, "p1")
+	if _, n, err := app.PlaceTotals(ctx); err != nil || n != 1 {
+		t.Fatalf("expected 1 instance, got %d (%v)", n, err)
+	}
+	position := app.predictionLog.position
+
+	// Only the events appended since the last scan are read
+	firePrediction(t, app, "p2")
+	if _, n, err := app.PlaceTotals(ctx); err != nil || n != 2 {
+		t.Fatalf("expected 2 instances, got %d (%v)", n, err)
+	}
+	if app.predictionLog.position <= position {
+		t.Errorf("expected the log position to advance past %d, got %d", position, app.predictionLog.position)
+	}
+	if n := app.predictionLog.firings[fired]; n != 2 {
+		t.Errorf("expected each firing of %s to be counted once, got %d", fired, n)
+	}
+}
+
+func TestConfidenceBandsAreSeeded(t *testing.T) {
+	store := eventsource.NewMemoryStore()
+	defer store.Close()
+	app := NewApplication(store)
+	ctx := context.Background()
+	req := PredictionRequest{Rates: RatesModel, Hours: 1, Runs: 5, Seed: 7}
+
+	first, err := app.Predict(ctx, app, nil, req)
+	if err != nil {
+		t.Fatalf("Predict failed: %v", err)
+	}
+	second, err := app.Predict(ctx, app, nil, req)
+	if err != nil {
+		t.Fatalf("Predict failed: %v", err)
+	}
+	if !reflect.DeepEqual(first.Lower, second.Lower) || !reflect.DeepEqual(first.Upper, second.Upper) {
+		t.Error("expected the same seed to give the same bands")
+	}
+	for id, lower := range first.Lower {
+		upper := first.Upper[id]
+		if len(lower) != len(first.TimePoints) || len(upper) != len(first.TimePoints) {
+			t.Fatalf("expected %s's bands sampled at every time point", id)
+		}
+		for i := range lower {
+			if lower[i] > upper[i] {
+				t.Errorf("expected %s's lower band below its upper band at %v", id, first.TimePoints[i])
+				break
+			}
+		}
+	}
+}
@@ -5,10 +5,13 @@ package knapsack
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/api"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/eventstore"
 )
 
 // State holds the aggregate state for knapsack.
@@ -53,9 +56,9 @@ func NewAggregate(id string) *Aggregate {
 			PlaceCapacity: 2,
 		},
 		Outputs: map[string]int{
-			PlaceItem0Taken: 1,
 			PlaceTotalValue: 10,
 			PlaceTotalWeight: 2,
+			PlaceItem0Taken: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -101,19 +104,19 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionReset,
 		EventType: EventTypeReset,
 		Inputs: map[string]int{
+			PlaceTotalValue: 50,
+			PlaceTotalWeight: 15,
 			PlaceItem0Taken: 1,
 			PlaceItem1Taken: 1,
 			PlaceItem2Taken: 1,
 			PlaceItem3Taken: 1,
-			PlaceTotalValue: 50,
-			PlaceTotalWeight: 15,
 		},
 		Outputs: map[string]int{
+			PlaceCapacity: 15,
 			PlaceItem0: 1,
 			PlaceItem1: 1,
 			PlaceItem2: 1,
 			PlaceItem3: 1,
-			PlaceCapacity: 15,
 		},
 	})
 
@@ -221,6 +224,14 @@ func applyReset(state *State, event *eventsource.Event) error {
 // Application wires together the aggregate and event store.
 type Application struct {
 	store eventsource.Store
+
+	// onChange, if set, is called with an aggregate's ID after its events
+	// change, so the service can publish the update to subscribers.
+	onChange func(id string)
+
+	// predictionLog summarizes the event log for predictions and is
+	// caught up on each request.
+	predictionLog eventLogSummary
 }
 
 // NewApplication creates a new application instance.
@@ -281,10 +292,82 @@ func (app *Application) Execute(ctx context.Context, id, transitionID string, da
 	if err := agg.Apply(event); err != nil {
 		return nil, fmt.Errorf("applying event: %w", err)
 	}
+	app.changed(id)
 
 	return agg, nil
 }
 
+// ErrStoreFailed wraps the event store errors ExecuteSequence returns, so
+// callers can tell a failed read or append from a step that cannot fire.
+var ErrStoreFailed = errors.New("event store failed")
+
+// SequenceStep is one transition of a sequence.
+type SequenceStep struct {
+	TransitionID string
+	Data         any
+}
+
+// ExecuteSequence fires transitions on an aggregate in order and persists
+// all of their events in a single append, or none of them. The transitions
+// fire on a freshly loaded copy of the aggregate, so a step that cannot
+// fire leaves the stored history untouched. If authorize is not nil, it is
+// called before each step with the aggregate in the state that step fires
+// from, and an error from it fails the sequence.
+//
+// It returns the aggregate after the last step and the place markings
+// after each step.
+func (app *Application) ExecuteSequence(ctx context.Context, id string, steps []SequenceStep, authorize func(agg *Aggregate, step SequenceStep) error) (*Aggregate, []map[string]int, error) {
+	if len(steps) == 0 {
+		return nil, nil, fmt.Errorf("sequence has no steps")
+	}
+	if len(steps) > api.MaxSequenceSteps {
+		return nil, nil, fmt.Errorf("sequence has %d steps, at most %d are allowed", len(steps), api.MaxSequenceSteps)
+	}
+
+	agg, err := app.Load(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrStoreFailed, err)
+	}
+	version := agg.Version()
+
+	events := make([]*eventsource.Event, 0, len(steps))
+	markings := make([]map[string]int, 0, len(steps))
+	for i, step := range steps {
+		if authorize != nil {
+			if err := authorize(agg, step); err != nil {
+				return nil, nil, fmt.Errorf("step %d (%s): %w", i, step.TransitionID, err)
+			}
+		}
+		if !agg.CanFire(step.TransitionID) {
+			return nil, nil, fmt.Errorf("step %d: transition %s cannot fire from current state", i, step.TransitionID)
+		}
+		event, err := agg.Fire(step.TransitionID, step.Data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("step %d: firing transition: %w", i, err)
+		}
+
+		// Apply the event to the copy only, so the next step fires from
+		// this one's marking
+		if err := agg.Apply(event); err != nil {
+			return nil, nil, fmt.Errorf("step %d: applying event: %w", i, err)
+		}
+		events = append(events, event)
+		markings = append(markings, agg.Places())
+	}
+
+	if _, err := app.store.Append(ctx, id, version, events); err != nil {
+		return nil, nil, fmt.Errorf("%w: persisting events: %w", ErrStoreFailed, err)
+	}
+	app.changed(id)
+
+	// Reload so the aggregate carries the versions the store assigned
+	agg, err = app.Load(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrStoreFailed, err)
+	}
+	return agg, markings, nil
+}
+
 // GetState returns the current state of an aggregate.
 func (app *Application) GetState(ctx context.Context, id string) (*Aggregate, error) {
 	return app.Load(ctx, id)
@@ -324,10 +407,49 @@ func (app *Application) TruncateTo(ctx context.Context, id string, targetVersion
 		}
 	}
 
+	app.changed(id)
+
 	// Load and return the truncated aggregate
 	return app.Load(ctx, id)
 }
 
+// changed reports that an aggregate's events changed.
+func (app *Application) changed(id string) {
+	if app.onChange != nil {
+		app.onChange(id)
+	}
+}
+
+// positionedLog is implemented by event stores, such as the Postgres store,
+// that report the log position of each event they read.
+type positionedLog interface {
+	ReadAllPositioned(ctx context.Context, fromPosition int64, limit int) ([]eventstore.PositionedEvent, error)
+}
+
+// readLog reads up to limit events after position and returns the log
+// position of the last one.
+func (app *Application) readLog(ctx context.Context, position int64, limit int) ([]*eventsource.Event, int64, error) {
+	if positioned, ok := app.store.(positionedLog); ok {
+		entries, err := positioned.ReadAllPositioned(ctx, position, limit)
+		if err != nil || len(entries) == 0 {
+			return nil, position, err
+		}
+		events := make([]*eventsource.Event, len(entries))
+		for i, entry := range entries {
+			events[i] = entry.Event
+		}
+		return events, entries[len(entries)-1].Position, nil
+	}
+
+	// Other stores number their log contiguously, so the position advances
+	// by the number of events read
+	events, err := app.store.ReadAll(ctx, position, limit)
+	if err != nil {
+		return nil, position, err
+	}
+	return events, position + int64(len(events)), nil
+}
+
 // HealthCheck verifies the event store is accessible.
 func (app *Application) HealthCheck(ctx context.Context) error {
 	// Try to read from a non-existent stream - this exercises the store connection
@@ -30,10 +30,12 @@ CREATE TABLE IF NOT EXISTS snapshots (
 );
 
 -- Projection: knapsack aggregate state
--- Denormalized view for fast reads
+-- Denormalized view for fast reads, kept up to date by the projector
+-- (projections.go) when the petri-pilot/projections extension is enabled
 CREATE TABLE IF NOT EXISTS "knapsack_state" (
     id TEXT PRIMARY KEY,
     version INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT '',  -- Comma-separated places holding tokens
     "item0" INTEGER DEFAULT 0,
     "item1" INTEGER DEFAULT 0,
     "item2" INTEGER DEFAULT 0,
@@ -45,11 +47,13 @@ CREATE TABLE IF NOT EXISTS "knapsack_state" (
     "item3_taken" INTEGER DEFAULT 0,
     "total_value" INTEGER DEFAULT 0,
     "total_weight" INTEGER DEFAULT 0,
+    state TEXT NOT NULL DEFAULT '{}',  -- Full aggregate state
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE INDEX IF NOT EXISTS "idx_knapsack_state_updated" ON "knapsack_state"(updated_at);
+CREATE INDEX IF NOT EXISTS "idx_knapsack_state_status" ON "knapsack_state"(status);
 
 -- Place tokens tracking (Petri net state)
 CREATE TABLE IF NOT EXISTS "knapsack_places" (
@@ -60,6 +64,13 @@ CREATE TABLE IF NOT EXISTS "knapsack_places" (
     PRIMARY KEY (aggregate_id, place_id)
 );
 
+-- Projection checkpoints: events of the global log consumed per projection
+CREATE TABLE IF NOT EXISTS projection_checkpoints (
+    name TEXT PRIMARY KEY,
+    position INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Event type constants for reference:
 -- TakeItem0ed
 -- TakeItem1ed
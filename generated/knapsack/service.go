@@ -21,6 +21,7 @@ type Service struct {
 	store eventsource.Store
 	app   *Application
 	debugBroker *DebugBroker
+	updates *serve.Publisher
 }
 
 // NewService creates a new knapsack service instance.
@@ -32,6 +33,10 @@ func NewService() (serve.Service, error) {
 
 	// Create application
 	svc.app = NewApplication(svc.store)
+
+	// Publish instance updates to GraphQL subscribers
+	svc.updates = serve.NewPublisher()
+	svc.app.onChange = svc.updates.Publish
 	// Initialize debug broker
 	svc.debugBroker = NewDebugBroker()
 
@@ -66,3 +71,8 @@ func (s *Service) GraphQLResolvers() map[string]serve.GraphQLResolver {
 	return GraphQLResolversMap(s.app)
 }
 
+// GraphQLSubscriptions returns the GraphQL subscriptions for this service.
+func (s *Service) GraphQLSubscriptions() map[string]serve.GraphQLSubscriber {
+	return GraphQLSubscriptionsMap(s.app, s.updates)
+}
+
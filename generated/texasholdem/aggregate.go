@@ -5,10 +5,12 @@ package texasholdem
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/api"
 )
 
 // State holds the aggregate state for texas-holdem.
@@ -67,8 +69,8 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionDealFlop,
 		EventType: EventTypeDealFlop,
 		Inputs: map[string]int{
-			PlacePreflop: 1,
 			PlaceBettingDone: 1,
+			PlacePreflop: 1,
 		},
 		Outputs: map[string]int{
 			PlaceFlop: 1,
@@ -79,8 +81,8 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionDealTurn,
 		EventType: EventTypeDealTurn,
 		Inputs: map[string]int{
-			PlaceFlop: 1,
 			PlaceBettingDone: 1,
+			PlaceFlop: 1,
 		},
 		Outputs: map[string]int{
 			PlaceTurnRound: 1,
@@ -127,12 +129,12 @@ func NewAggregate(id string) *Aggregate {
 			PlaceComplete: 1,
 		},
 		Outputs: map[string]int{
-			PlaceWaiting: 1,
-			PlaceP0Active: 1,
-			PlaceP1Active: 1,
 			PlaceP2Active: 1,
 			PlaceP3Active: 1,
 			PlaceP4Active: 1,
+			PlaceWaiting: 1,
+			PlaceP0Active: 1,
+			PlaceP1Active: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -154,8 +156,8 @@ func NewAggregate(id string) *Aggregate {
 			PlaceP0Active: 1,
 		},
 		Outputs: map[string]int{
-			PlaceP0Active: 1,
 			PlaceP1Turn: 1,
+			PlaceP0Active: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -166,8 +168,8 @@ func NewAggregate(id string) *Aggregate {
 			PlaceP0Active: 1,
 		},
 		Outputs: map[string]int{
-			PlaceP0Active: 1,
 			PlaceP1Turn: 1,
+			PlaceP0Active: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -201,8 +203,8 @@ func NewAggregate(id string) *Aggregate {
 			PlaceP1Active: 1,
 		},
 		Outputs: map[string]int{
-			PlaceP1Active: 1,
 			PlaceP2Turn: 1,
+			PlaceP1Active: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -225,16 +227,16 @@ func NewAggregate(id string) *Aggregate {
 			PlaceP1Active: 1,
 		},
 		Outputs: map[string]int{
-			PlaceP1Active: 1,
 			PlaceP2Turn: 1,
+			PlaceP1Active: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
 		ID:        TransitionP2Fold,
 		EventType: EventTypeP2Fold,
 		Inputs: map[string]int{
-			PlaceP2Turn: 1,
 			PlaceP2Active: 1,
+			PlaceP2Turn: 1,
 		},
 		Outputs: map[string]int{
 			PlaceP3Turn: 1,
@@ -256,8 +258,8 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionP2Call,
 		EventType: EventTypeP2Call,
 		Inputs: map[string]int{
-			PlaceP2Turn: 1,
 			PlaceP2Active: 1,
+			PlaceP2Turn: 1,
 		},
 		Outputs: map[string]int{
 			PlaceP2Active: 1,
@@ -338,8 +340,8 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionP4Check,
 		EventType: EventTypeP4Check,
 		Inputs: map[string]int{
-			PlaceP4Turn: 1,
 			PlaceP4Active: 1,
+			PlaceP4Turn: 1,
 		},
 		Outputs: map[string]int{
 			PlaceP4Active: 1,
@@ -362,8 +364,8 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionP4Raise,
 		EventType: EventTypeP4Raise,
 		Inputs: map[string]int{
-			PlaceP4Turn: 1,
 			PlaceP4Active: 1,
+			PlaceP4Turn: 1,
 		},
 		Outputs: map[string]int{
 			PlaceP4Active: 1,
@@ -795,6 +797,10 @@ func applyP4Skip(state *State, event *eventsource.Event) error {
 // Application wires together the aggregate and event store.
 type Application struct {
 	store eventsource.Store
+
+	// onChange, if set, is called with an aggregate's ID after its events
+	// change, so the service can publish the update to subscribers.
+	onChange func(id string)
 }
 
 // NewApplication creates a new application instance.
@@ -855,10 +861,82 @@ func (app *Application) Execute(ctx context.Context, id, transitionID string, da
 	if err := agg.Apply(event); err != nil {
 		return nil, fmt.Errorf("applying event: %w", err)
 	}
+	app.changed(id)
 
 	return agg, nil
 }
 
+// ErrStoreFailed wraps the event store errors ExecuteSequence returns, so
+// callers can tell a failed read or append from a step that cannot fire.
+var ErrStoreFailed = errors.New("event store failed")
+
+// SequenceStep is one transition of a sequence.
+type SequenceStep struct {
+	TransitionID string
+	Data         any
+}
+
+// ExecuteSequence fires transitions on an aggregate in order and persists
+// all of their events in a single append, or none of them. The transitions
+// fire on a freshly loaded copy of the aggregate, so a step that cannot
+// fire leaves the stored history untouched. If authorize is not nil, it is
+// called before each step with the aggregate in the state that step fires
+// from, and an error from it fails the sequence.
+//
+// It returns the aggregate after the last step and the place markings
+// after each step.
+func (app *Application) ExecuteSequence(ctx context.Context, id string, steps []SequenceStep, authorize func(agg *Aggregate, step SequenceStep) error) (*Aggregate, []map[string]int, error) {
+	if len(steps) == 0 {
+		return nil, nil, fmt.Errorf("sequence has no steps")
+	}
+	if len(steps) > api.MaxSequenceSteps {
+		return nil, nil, fmt.Errorf("sequence has %d steps, at most %d are allowed", len(steps), api.MaxSequenceSteps)
+	}
+
+	agg, err := app.Load(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrStoreFailed, err)
+	}
+	version := agg.Version()
+
+	events := make([]*eventsource.Event, 0, len(steps))
+	markings := make([]map[string]int, 0, len(steps))
+	for i, step := range steps {
+		if authorize != nil {
+			if err := authorize(agg, step); err != nil {
+				return nil, nil, fmt.Errorf("step %d (%s): %w", i, step.TransitionID, err)
+			}
+		}
+		if !agg.CanFire(step.TransitionID) {
+			return nil, nil, fmt.Errorf("step %d: transition %s cannot fire from current state", i, step.TransitionID)
+		}
+		event, err := agg.Fire(step.TransitionID, step.Data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("step %d: firing transition: %w", i, err)
+		}
+
+		// Apply the event to the copy only, so the next step fires from
+		// this one's marking
+		if err := agg.Apply(event); err != nil {
+			return nil, nil, fmt.Errorf("step %d: applying event: %w", i, err)
+		}
+		events = append(events, event)
+		markings = append(markings, agg.Places())
+	}
+
+	if _, err := app.store.Append(ctx, id, version, events); err != nil {
+		return nil, nil, fmt.Errorf("%w: persisting events: %w", ErrStoreFailed, err)
+	}
+	app.changed(id)
+
+	// Reload so the aggregate carries the versions the store assigned
+	agg, err = app.Load(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrStoreFailed, err)
+	}
+	return agg, markings, nil
+}
+
 // GetState returns the current state of an aggregate.
 func (app *Application) GetState(ctx context.Context, id string) (*Aggregate, error) {
 	return app.Load(ctx, id)
@@ -898,10 +976,19 @@ func (app *Application) TruncateTo(ctx context.Context, id string, targetVersion
 		}
 	}
 
+	app.changed(id)
+
 	// Load and return the truncated aggregate
 	return app.Load(ctx, id)
 }
 
+// changed reports that an aggregate's events changed.
+func (app *Application) changed(id string) {
+	if app.onChange != nil {
+		app.onChange(id)
+	}
+}
+
 // HealthCheck verifies the event store is accessible.
 func (app *Application) HealthCheck(ctx context.Context) error {
 	// Try to read from a non-existent stream - this exercises the store connection
@@ -5,6 +5,8 @@ package texasholdem
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/fs"
 	"net/http"
 	"os"
@@ -13,14 +15,16 @@ import (
 	"strings"
 
 	"github.com/pflow-xyz/petri-pilot/pkg/runtime/api"
+	"github.com/pflow-xyz/go-pflow/eventsource"
 )
 
 // BuildRouter creates an HTTP router for the texas-holdem workflow.
-func BuildRouter(app *Application, middleware *Middleware, sessions SessionStore) http.Handler {
+func BuildRouter(app *Application, middleware *Middleware, sessions SessionStore, roleAssignments *RoleAssignments) http.Handler {
 	r := api.NewRouter()
 
-	// Apply auth middleware to extract user from token (optional, doesn't require auth)
-	r.Use(OptionalAuthMiddleware(sessions))
+	// Apply auth middleware to extract user from token (optional, doesn't require auth),
+	// then merge the user's persisted role grants
+	r.Use(OptionalAuthMiddleware(sessions), middleware.AssignRoles)
 
 	// Health check - always returns ok if server is running
 	r.GET("/health", "Health check", func(w http.ResponseWriter, r *http.Request) {
@@ -51,12 +55,24 @@ func BuildRouter(app *Application, middleware *Middleware, sessions SessionStore
 
 
 
+
 	// GraphQL API
 	r.Handle("POST", "/graphql", "GraphQL API endpoint", GraphQLHandler(app))
 	r.GET("/playground", "GraphQL Playground", PlaygroundHandler())
 
 
 
+	// Role assignment endpoints (restricted to admin roles)
+	requireAdmin := middleware.RequireRole("admin")
+	r.GET("/admin/roles", "List roles and assignments", requireAdmin(HandleListRoles(roleAssignments)).ServeHTTP)
+	r.GET("/admin/roles/assignments", "List role assignments", requireAdmin(HandleListRoleAssignments(roleAssignments)).ServeHTTP)
+	r.POST("/admin/roles/assignments", "Grant role to user", requireAdmin(HandleGrantRole(roleAssignments)).ServeHTTP)
+	r.DELETE("/admin/roles/assignments/{user}/{role}", "Revoke role from user", requireAdmin(HandleRevokeRole(roleAssignments)).ServeHTTP)
+	r.GET("/admin/roles/audit", "Role change audit log", requireAdmin(HandleRoleAudit(roleAssignments)).ServeHTTP)
+
+
+
+
 
 
 
@@ -106,6 +122,9 @@ func BuildRouter(app *Application, middleware *Middleware, sessions SessionStore
 	r.Transition("p3_skip", "/api/p3_skip", "Skip player 3 (all-in/eliminated)", HandleP3Skip(app))
 	r.Transition("p4_skip", "/api/p4_skip", "Skip player 4 (all-in/eliminated)", HandleP4Skip(app))
 
+	// Fire transitions in order, all or none
+	r.POST("/api/texasholdem/{id}/sequence", "Fire transitions in order, all or none", HandleFireSequence(app))
+
 	// Serve frontend static files
 	r.StaticFiles("/", StaticFileHandler())
 
@@ -1314,6 +1333,105 @@ func HandleP4Skip(app *Application) http.HandlerFunc {
 }
 
 
+// HandleFireSequence fires transitions on an aggregate in order, persisting
+// all of their events or none, and returns the marking after each one.
+// Each step is held to the permission checks
+// of its transition's own endpoint.
+func HandleFireSequence(app *Application) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id := r.PathValue("id")
+		if id == "" {
+			api.Error(w, http.StatusBadRequest, "MISSING_ID", "aggregate ID is required")
+			return
+		}
+
+		var req api.SequenceRequest
+		if err := api.DecodeJSON(r, &req); err != nil {
+			api.Error(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+		if len(req.Steps) > api.MaxSequenceSteps {
+			api.Error(w, http.StatusBadRequest, "TOO_MANY_STEPS", fmt.Sprintf("sequence has %d steps, at most %d are allowed", len(req.Steps), api.MaxSequenceSteps))
+			return
+		}
+		steps := make([]SequenceStep, len(req.Steps))
+		for i, step := range req.Steps {
+			steps[i] = SequenceStep{TransitionID: step.TransitionID, Data: step.Data}
+		}
+
+		agg, markings, err := app.ExecuteSequence(ctx, id, steps, authorizeSequenceStep(UserFromContext(ctx), id))
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrUnauthorized):
+				api.Error(w, http.StatusUnauthorized, "UNAUTHORIZED", err.Error())
+			case errors.Is(err, ErrForbidden):
+				api.Error(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+			case errors.Is(err, eventsource.ErrStreamNotFound):
+				api.Error(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+			case errors.Is(err, eventsource.ErrConcurrency):
+				api.Error(w, http.StatusConflict, "CONCURRENT_MODIFICATION", err.Error())
+			case errors.Is(err, ErrStoreFailed):
+				api.Error(w, http.StatusInternalServerError, "STORE_FAILED", err.Error())
+			default:
+				api.Error(w, http.StatusConflict, "SEQUENCE_FAILED", err.Error())
+			}
+			return
+		}
+
+		api.JSON(w, http.StatusOK, api.SequenceResult{
+			Success:            true,
+			AggregateID:        agg.ID(),
+			Version:            agg.Version(),
+			Markings:           markings,
+			State:              agg.Places(),
+			EnabledTransitions: agg.EnabledTransitions(),
+		})
+	}
+}
+
+// authorizeSequenceStep checks each step of a sequence against its
+// transition's access rule, including dynamic role grants, in the state
+// the step fires from.
+func authorizeSequenceStep(user *User, id string) func(agg *Aggregate, step SequenceStep) error {
+	return func(agg *Aggregate, step SequenceStep) error {
+		switch step.TransitionID {
+		}
+		return nil
+	}
+}
+
+// accessState returns an aggregate's places and state fields, as dynamic
+// role grants see them.
+func accessState(agg *Aggregate, id string) map[string]any {
+	state := make(map[string]any)
+	for k, v := range agg.Places() {
+		state[k] = v
+	}
+	state["aggregate_id"] = id
+	if typedState, ok := agg.State().(State); ok {
+		state["waiting"] = typedState.Waiting
+		state["preflop"] = typedState.Preflop
+		state["flop"] = typedState.Flop
+		state["turn_round"] = typedState.TurnRound
+		state["river"] = typedState.River
+		state["showdown"] = typedState.Showdown
+		state["complete"] = typedState.Complete
+		state["p0_turn"] = typedState.P0Turn
+		state["p1_turn"] = typedState.P1Turn
+		state["p2_turn"] = typedState.P2Turn
+		state["p3_turn"] = typedState.P3Turn
+		state["p4_turn"] = typedState.P4Turn
+		state["p0_active"] = typedState.P0Active
+		state["p1_active"] = typedState.P1Active
+		state["p2_active"] = typedState.P2Active
+		state["p3_active"] = typedState.P3Active
+		state["p4_active"] = typedState.P4Active
+		state["betting_done"] = typedState.BettingDone
+	}
+	return state
+}
+
 
 
 
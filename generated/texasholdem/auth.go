@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/api"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/github"
 )
@@ -24,6 +25,9 @@ type User struct {
 	Email     string   `json:"email"`
 	AvatarURL string   `json:"avatar_url"`
 	Roles     []string `json:"roles,omitempty"` // User roles for access control
+
+	// scopedRoles holds persisted grants scoped to one aggregate, by aggregate ID.
+	scopedRoles map[string][]string
 }
 
 // Session represents an authenticated session.
@@ -271,3 +275,82 @@ func RegisterAuthRoutes(mux *http.ServeMux, auth *AuthHandler) {
 	mux.HandleFunc("POST /auth/logout", auth.HandleLogout)
 	mux.HandleFunc("GET /auth/me", auth.HandleMe)
 }
+
+
+// testLoginRoleHierarchy defines which roles inherit from which.
+var testLoginRoleHierarchy = map[string][]string{
+	"admin": { "dealer" },
+}
+
+// expandTestRoles expands a list of roles to include inherited roles.
+func expandTestRoles(roles []string) []string {
+	seen := make(map[string]bool)
+	expanded := make([]string, 0)
+
+	var expand func(role string)
+	expand = func(role string) {
+		if seen[role] {
+			return
+		}
+		seen[role] = true
+		expanded = append(expanded, role)
+
+		// Add parent roles
+		if parents, ok := testLoginRoleHierarchy[role]; ok {
+			for _, parent := range parents {
+				expand(parent)
+			}
+		}
+	}
+
+	for _, role := range roles {
+		expand(role)
+	}
+	return expanded
+}
+
+// HandleTestLogin creates a test session with specified roles.
+func HandleTestLogin(sessions SessionStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Login string   `json:"login"`
+			Roles []string `json:"roles"`
+		}
+		if err := api.DecodeJSON(r, &req); err != nil {
+			api.Error(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+
+		if req.Login == "" {
+			req.Login = "test-user"
+		}
+		if len(req.Roles) == 0 {
+			req.Roles = []string{"admin", "fulfillment", "system", "customer"}
+		}
+
+		// Expand roles to include inherited roles
+		expandedRoles := expandTestRoles(req.Roles)
+
+		// Create a test user with the expanded roles
+		user := &User{
+			ID:    12345,
+			Login: req.Login,
+			Name:  "Test User",
+			Email: req.Login + "@test.local",
+			Roles: expandedRoles,
+		}
+
+		session, err := sessions.Create(r.Context(), user)
+		if err != nil {
+			api.Error(w, http.StatusInternalServerError, "SESSION_FAILED", err.Error())
+			return
+		}
+
+		api.JSON(w, http.StatusOK, map[string]interface{}{
+			"token":      session.Token,
+			"expires_at": session.ExpiresAt,
+			"user":       session.User,
+		})
+	}
+}
+
@@ -71,7 +71,6 @@ type graphQLApp struct {
 func (a *graphQLApp) Create(ctx context.Context) (string, error) {
 	return a.app.Create(ctx)
 }
-
 func (a *graphQLApp) Load(ctx context.Context, id string) (graph.Aggregate, error) {
 	return a.app.Load(ctx, id)
 }
@@ -84,6 +83,23 @@ func (a *graphQLApp) Execute(ctx context.Context, id, transition string, data ma
 	return a.app.Execute(ctx, id, transition, data)
 }
 
+func (a *graphQLApp) ExecuteSequence(ctx context.Context, id string, steps []graph.SequenceStep) (graph.Aggregate, []map[string]int, error) {
+	agg, markings, err := a.app.ExecuteSequence(ctx, id, sequenceSteps(steps), authorizeSequenceStep(UserFromContext(ctx), id))
+	if err != nil {
+		return nil, nil, err
+	}
+	return agg, markings, nil
+}
+
+// sequenceSteps converts GraphQL sequence steps to aggregate sequence steps.
+func sequenceSteps(steps []graph.SequenceStep) []SequenceStep {
+	out := make([]SequenceStep, len(steps))
+	for i, step := range steps {
+		out[i] = SequenceStep{TransitionID: step.Transition, Data: step.Data}
+	}
+	return out
+}
+
 func (a *graphQLApp) HealthCheck(ctx context.Context) error {
 	return a.app.HealthCheck(ctx)
 }
@@ -792,6 +808,19 @@ func (h *graphQLHandler) executeGraphQL(ctx context.Context, query, operationNam
 	}
 
 
+	// Handle sequence mutation (supports both "fireSequence" and "package_fireSequence" naming)
+	if isMutation && containsString(query, "fireSequence") {
+		id, _ := variables["aggregateId"].(string)
+		res, err := h.resolver.FireSequence(ctx, id, transitionInputs(variables["transitions"]))
+		if err != nil {
+			errors = append(errors, map[string]interface{}{"message": err.Error()})
+		} else if containsString(query, "texasholdem_fireSequence") {
+			data["texasholdem_fireSequence"] = res
+		} else {
+			data["fireSequence"] = res
+		}
+	}
+
 	// Handle query for single aggregate
 	if !isMutation && containsString(query, "texasholdem(") {
 		id := ""
@@ -862,6 +891,20 @@ func (h *graphQLHandler) executeGraphQL(ctx context.Context, query, operationNam
 	return result
 }
 
+// transitionInputs decodes a list of TransitionInput variables.
+func transitionInputs(v any) []graph.TransitionInput {
+	list, _ := v.([]any)
+	inputs := make([]graph.TransitionInput, len(list))
+	for i, item := range list {
+		vars, _ := item.(map[string]any)
+		inputs[i].Transition, _ = vars["transition"].(string)
+		if data, ok := vars["data"].(string); ok {
+			inputs[i].Data = &data
+		}
+	}
+	return inputs
+}
+
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsStringHelper(s, substr))
 }
@@ -1017,6 +1060,14 @@ type Mutation {
 
   # Skip player 4 (all-in/eliminated)
   p4Skip(input: P4SkipInput!): TransitionResult!
+
+  # Fire transitions in order, all of them or none
+  fireSequence(aggregateId: ID!, transitions: [TransitionInput!]!): SequenceResult!
+}
+
+type Subscription {
+  # Aggregate state after each change
+  texasholdemUpdated(id: ID!): AggregateState!
 }
 
 # Aggregate state representation
@@ -1082,6 +1133,17 @@ type TransitionResult {
   error: String
 }
 
+# Result of firing a sequence of transitions
+type SequenceResult {
+  success: Boolean!
+  aggregateId: ID
+  version: Int
+  markings: [Places!]
+  state: Places
+  enabledTransitions: [String!]
+  error: String
+}
+
 # Paginated list of aggregates
 type AggregateList {
   items: [AggregateState!]!
@@ -1263,6 +1325,11 @@ input P4SkipInput {
   aggregateId: ID!
 }
 
+# A transition of a sequence, with its bindings as a JSON object
+input TransitionInput {
+  transition: String!
+  data: String
+}
 `
 
 // GraphQLResolversMap returns a map of resolver functions for the unified GraphQL endpoint.
@@ -1686,6 +1753,12 @@ func GraphQLResolversMap(app *Application) map[string]serve.GraphQLResolver {
 	resolvers["p4Skip"] = resolvers["texasholdem_p4_skip"]
 
 
+	resolvers["texasholdem_fireSequence"] = func(ctx context.Context, variables map[string]any) (any, error) {
+		id, _ := variables["aggregateId"].(string)
+		return resolver.FireSequence(ctx, id, transitionInputs(variables["transitions"]))
+	}
+	resolvers["fireSequence"] = resolvers["texasholdem_fireSequence"]
+
 
 
 	// Events resolver (namespace for unified endpoint)
@@ -1704,3 +1777,20 @@ func GraphQLResolversMap(app *Application) map[string]serve.GraphQLResolver {
 
 	return resolvers
 }
+
+// GraphQLSubscriptionsMap returns a map of subscriber functions for the unified GraphQL endpoint.
+func GraphQLSubscriptionsMap(app *Application, updates *serve.Publisher) map[string]serve.GraphQLSubscriber {
+	resolver := graph.NewResolver(&graphQLApp{app: app})
+
+	subscriptions := make(map[string]serve.GraphQLSubscriber)
+
+	// Aggregate state, each time a transition changes it
+	subscriptions["texasholdemUpdated"] = func(ctx context.Context, variables map[string]any) (<-chan any, error) {
+		id, _ := variables["id"].(string)
+		return updates.Subscribe(ctx, id, func(ctx context.Context) (any, error) {
+			return resolver.TexasHoldem(ctx, id)
+		}), nil
+	}
+
+	return subscriptions
+}
@@ -17,6 +17,7 @@ type Middleware struct {
 	sessions      SessionStore
 	rules         map[string]*AccessControl // transition ID -> access control
 	roleHierarchy map[string][]string       // role -> parent roles
+	assignments   *RoleAssignments          // persisted role grants (optional)
 }
 
 // AccessControl defines who can execute a transition.
@@ -52,6 +53,23 @@ func NewMiddleware(sessions SessionStore, rules []*AccessControl) *Middleware {
 	}
 }
 
+// WithRoleAssignments merges persisted role grants into role checks.
+func (m *Middleware) WithRoleAssignments(ra *RoleAssignments) *Middleware {
+	m.assignments = ra
+	return m
+}
+
+// AssignRoles gives the request's user its persisted role grants, so every
+// later check sees them. It must run after the user is authenticated.
+func (m *Middleware) AssignRoles(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user := UserFromContext(r.Context()); user != nil {
+			r = r.WithContext(context.WithValue(r.Context(), userContextKey, m.assignments.Assign(user)))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // RequireAuth enforces authentication (any valid user).
 func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -109,7 +127,7 @@ func (m *Middleware) RequirePermission(transitionID string) func(http.Handler) h
 
 			// Check role requirements
 			if len(rule.Roles) > 0 {
-				userRoles := m.getUserRoles(user)
+				userRoles := m.getUserRolesFor(user, requestAggregateID(r))
 				if !m.hasAnyRole(userRoles, rule.Roles) {
 					http.Error(w, fmt.Sprintf("forbidden: action '%s' requires role: %s", transitionID, strings.Join(rule.Roles, " or ")), http.StatusForbidden)
 					return
@@ -140,9 +158,15 @@ func (m *Middleware) RequirePermission(transitionID string) func(http.Handler) h
 
 // getUserRoles extracts roles from a user with role hierarchy support.
 func (m *Middleware) getUserRoles(user *User) []string {
-	// First check if roles are embedded in user object
-	if len(user.Roles) > 0 {
-		return expandRolesWithInheritance(user.Roles, m.roleHierarchy)
+	return m.getUserRolesFor(user, "")
+}
+
+// getUserRolesFor returns the user's roles including persisted grants
+// scoped to the given aggregate instance.
+func (m *Middleware) getUserRolesFor(user *User, aggregateID string) []string {
+	roles := assignedRoles(user, aggregateID)
+	if len(roles) > 0 {
+		return expandRolesWithInheritance(roles, m.roleHierarchy)
 	}
 	
 	// Fallback to default role
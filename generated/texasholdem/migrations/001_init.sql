@@ -30,10 +30,12 @@ CREATE TABLE IF NOT EXISTS snapshots (
 );
 
 -- Projection: texas-holdem aggregate state
--- Denormalized view for fast reads
+-- Denormalized view for fast reads, kept up to date by the projector
+-- (projections.go) when the petri-pilot/projections extension is enabled
 CREATE TABLE IF NOT EXISTS "texasholdem_state" (
     id TEXT PRIMARY KEY,
     version INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT '',  -- Comma-separated places holding tokens
     "waiting" INTEGER DEFAULT 0,
     "preflop" INTEGER DEFAULT 0,
     "flop" INTEGER DEFAULT 0,
@@ -52,11 +54,13 @@ CREATE TABLE IF NOT EXISTS "texasholdem_state" (
     "p3_active" INTEGER DEFAULT 0,
     "p4_active" INTEGER DEFAULT 0,
     "betting_done" INTEGER DEFAULT 0,
+    state TEXT NOT NULL DEFAULT '{}',  -- Full aggregate state
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE INDEX IF NOT EXISTS "idx_texasholdem_state_updated" ON "texasholdem_state"(updated_at);
+CREATE INDEX IF NOT EXISTS "idx_texasholdem_state_status" ON "texasholdem_state"(status);
 
 -- Place tokens tracking (Petri net state)
 CREATE TABLE IF NOT EXISTS "texasholdem_places" (
@@ -67,6 +71,13 @@ CREATE TABLE IF NOT EXISTS "texasholdem_places" (
     PRIMARY KEY (aggregate_id, place_id)
 );
 
+-- Projection checkpoints: events of the global log consumed per projection
+CREATE TABLE IF NOT EXISTS projection_checkpoints (
+    name TEXT PRIMARY KEY,
+    position INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Event type constants for reference:
 -- hand_started
 -- flop_dealt
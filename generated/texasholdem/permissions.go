@@ -19,14 +19,26 @@ var AllRolesFor = map[string][]string{
 	RoleAdmin: { "admin", "dealer" },
 }
 
+// assignedRoles returns the user's roles plus the persisted grants scoped to
+// aggregateID. Global grants are already among the roles of a user that
+// passed through Middleware.AssignRoles.
+func assignedRoles(user *User, aggregateID string) []string {
+	roles := append([]string{}, user.Roles...)
+	return append(roles, user.scopedRoles[aggregateID]...)
+}
+
 // HasRole checks if a user has a specific role (including inherited roles).
 func HasRole(user *User, roleID string) bool {
 	if user == nil {
 		return false
 	}
+	return hasRoleIn(user.Roles, roleID)
+}
 
+// hasRoleIn checks whether roleID is among the effective roles of userRoles.
+func hasRoleIn(userRoles []string, roleID string) bool {
 	// Get effective roles for each of the user's roles
-	for _, userRole := range user.Roles {
+	for _, userRole := range userRoles {
 		effectiveRoles, ok := AllRolesFor[userRole]
 		if !ok {
 			// Role not in hierarchy, just check direct match
@@ -68,9 +80,13 @@ func HasAnyRoleWithState(user *User, roleIDs []string, state map[string]any) boo
 		return false
 	}
 
+	// Role grants scoped to this aggregate instance apply too
+	aggregateID, _ := state["aggregate_id"].(string)
+	userRoles := assignedRoles(user, aggregateID)
+
 	for _, roleID := range roleIDs {
-		// First check static roles
-		if HasRole(user, roleID) {
+		// First check static and assigned roles
+		if hasRoleIn(userRoles, roleID) {
 			return true
 		}
 		// Then check dynamic grants
@@ -88,6 +104,11 @@ func HasDynamicRole(user *User, roleID string, state map[string]any) bool {
 		return false
 	}
 
+	// Persisted grants scoped to this aggregate instance
+	if aggregateID, ok := state["aggregate_id"].(string); ok && hasRoleIn(user.scopedRoles[aggregateID], roleID) {
+		return true
+	}
+
 	// Build bindings for evaluation
 	bindings := map[string]any{
 		"user": map[string]any{
@@ -3,6 +3,7 @@
 package texasholdem
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/pflow-xyz/go-pflow/eventsource"
@@ -20,8 +21,10 @@ func init() {
 type Service struct {
 	store eventsource.Store
 	app   *Application
-	sessions   SessionStore
-	middleware *Middleware
+	sessions        SessionStore
+	middleware      *Middleware
+	roleAssignments *RoleAssignments
+	updates *serve.Publisher
 }
 
 // NewService creates a new texas-holdem service instance.
@@ -33,6 +36,10 @@ func NewService() (serve.Service, error) {
 
 	// Create application
 	svc.app = NewApplication(svc.store)
+
+	// Publish instance updates to GraphQL subscribers
+	svc.updates = serve.NewPublisher()
+	svc.app.onChange = svc.updates.Publish
 	// Initialize sessions for authentication
 	svc.sessions = NewInMemorySessionStore()
 
@@ -43,6 +50,13 @@ func NewService() (serve.Service, error) {
 	// Initialize middleware
 	svc.middleware = NewMiddleware(svc.sessions, accessRules)
 
+	// Load persisted role assignments from the event store
+	svc.roleAssignments = NewRoleAssignments(svc.store)
+	if err := svc.roleAssignments.Load(context.Background()); err != nil {
+		return nil, err
+	}
+	svc.middleware.WithRoleAssignments(svc.roleAssignments)
+
 	return svc, nil
 }
 
@@ -53,7 +67,7 @@ func (s *Service) Name() string {
 
 // BuildHandler returns the HTTP handler for this service.
 func (s *Service) BuildHandler() http.Handler {
-	return BuildRouter(s.app, s.middleware, s.sessions)
+	return BuildRouter(s.app, s.middleware, s.sessions, s.roleAssignments)
 }
 
 // Close cleans up resources used by the service.
@@ -74,3 +88,8 @@ func (s *Service) GraphQLResolvers() map[string]serve.GraphQLResolver {
 	return GraphQLResolversMap(s.app)
 }
 
+// GraphQLSubscriptions returns the GraphQL subscriptions for this service.
+func (s *Service) GraphQLSubscriptions() map[string]serve.GraphQLSubscriber {
+	return GraphQLSubscriptionsMap(s.app, s.updates)
+}
+
@@ -4,6 +4,9 @@ package texasholdem
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/pflow-xyz/go-pflow/eventsource"
@@ -200,3 +203,115 @@ func TestCannotFireDisabledTransition(t *testing.T) {
 		t.Errorf("expected error when firing disabled transition %s", disabledTransition)
 	}
 }
+
+func TestRoleAssignmentsGrantRevokeAudit(t *testing.T) {
+	store := eventsource.NewMemoryStore()
+	defer store.Close()
+	ctx := context.Background()
+	role := "dealer"
+
+	ra := NewRoleAssignments(store)
+	if err := ra.Grant(ctx, RoleChange{User: "alice", Role: role, Actor: "root"}); err != nil {
+		t.Fatalf("Grant failed: %v", err)
+	}
+	// Granting an existing assignment records nothing
+	if err := ra.Grant(ctx, RoleChange{User: "alice", Role: role}); err != nil {
+		t.Fatalf("repeated Grant failed: %v", err)
+	}
+	if err := ra.Grant(ctx, RoleChange{User: "bob", Role: role, AggregateID: "a1"}); err != nil {
+		t.Fatalf("scoped Grant failed: %v", err)
+	}
+	if err := ra.Grant(ctx, RoleChange{Role: role}); !errors.Is(err, ErrRoleChangeInvalid) {
+		t.Errorf("expected ErrRoleChangeInvalid without a user, got %v", err)
+	}
+	if !ra.HasRole("alice", role, "") {
+		t.Error("expected alice to hold the role globally")
+	}
+	if ra.HasRole("bob", role, "") || !ra.HasRole("bob", role, "a1") {
+		t.Error("expected bob to hold the role for a1 only")
+	}
+
+	if err := ra.Revoke(ctx, RoleChange{User: "alice", Role: role, Reason: "left"}); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if err := ra.Revoke(ctx, RoleChange{User: "alice", Role: role}); !errors.Is(err, ErrRoleNotAssigned) {
+		t.Errorf("expected ErrRoleNotAssigned, got %v", err)
+	}
+
+	entries, err := ra.Audit(ctx)
+	if err != nil {
+		t.Fatalf("Audit failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 audit entries, got %d", len(entries))
+	}
+	for i, want := range []string{"granted", "granted", "revoked"} {
+		if entries[i].Action != want || entries[i].Version != i+1 {
+			t.Errorf("entry %d: expected %s at version %d, got %s at version %d", i, want, i+1, entries[i].Action, entries[i].Version)
+		}
+	}
+	if entries[0].Actor != "root" || entries[2].Reason != "left" {
+		t.Errorf("expected actor and reason in the audit log, got %+v", entries)
+	}
+
+	// A new registry rebuilds the assignments from the stream
+	reloaded := NewRoleAssignments(store)
+	if err := reloaded.Load(ctx); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := reloaded.List("", ""); len(got) != 1 || got[0].User != "bob" || got[0].AggregateID != "a1" {
+		t.Errorf("expected only bob's scoped grant after reload, got %+v", got)
+	}
+}
+
+func TestRoleAssignmentsAuthorize(t *testing.T) {
+	store := eventsource.NewMemoryStore()
+	defer store.Close()
+	ctx := context.Background()
+	role := "dealer"
+
+	ra := NewRoleAssignments(store)
+	rule := &AccessControl{TransitionID: "guarded", Roles: []string{role}}
+	m := NewMiddleware(nil, []*AccessControl{rule}).WithRoleAssignments(ra)
+	handler := m.AssignRoles(m.RequirePermission("guarded")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	fire := func(aggregateID string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.SetPathValue("id", aggregateID)
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &User{Login: "alice"}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := fire("a1"); code != http.StatusForbidden {
+		t.Errorf("expected 403 without a grant, got %d", code)
+	}
+
+	// A grant scoped to one aggregate authorizes only that aggregate
+	if err := ra.Grant(ctx, RoleChange{User: "alice", Role: role, AggregateID: "a1"}); err != nil {
+		t.Fatalf("Grant failed: %v", err)
+	}
+	if code := fire("a1"); code != http.StatusNoContent {
+		t.Errorf("expected the scoped grant to authorize a1, got %d", code)
+	}
+	if code := fire("a2"); code != http.StatusForbidden {
+		t.Errorf("expected the scoped grant not to authorize a2, got %d", code)
+	}
+	user := ra.Assign(&User{Login: "alice"})
+	if HasRole(user, role) || !HasAnyRoleWithState(user, []string{role}, map[string]any{"aggregate_id": "a1"}) {
+		t.Error("expected the state-based check to see the scoped grant only")
+	}
+
+	// A global grant authorizes every aggregate
+	if err := ra.Grant(ctx, RoleChange{User: "alice", Role: role}); err != nil {
+		t.Fatalf("Grant failed: %v", err)
+	}
+	if code := fire("a2"); code != http.StatusNoContent {
+		t.Errorf("expected the global grant to authorize a2, got %d", code)
+	}
+	if !HasRole(ra.Assign(&User{Login: "alice"}), role) {
+		t.Error("expected HasRole to see the global grant")
+	}
+}
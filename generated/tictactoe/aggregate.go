@@ -5,10 +5,12 @@ package tictactoe
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/api"
 )
 
 // State holds the aggregate state for tic-tac-toe.
@@ -76,9 +78,9 @@ func NewAggregate(id string) *Aggregate {
 			PlaceXTurn: 1,
 		},
 		Outputs: map[string]int{
+			PlaceMoveTokens: 1,
 			PlaceX00: 1,
 			PlaceOTurn: 1,
-			PlaceMoveTokens: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -154,9 +156,9 @@ func NewAggregate(id string) *Aggregate {
 			PlaceXTurn: 1,
 		},
 		Outputs: map[string]int{
-			PlaceX20: 1,
 			PlaceOTurn: 1,
 			PlaceMoveTokens: 1,
+			PlaceX20: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -193,9 +195,9 @@ func NewAggregate(id string) *Aggregate {
 			PlaceOTurn: 1,
 		},
 		Outputs: map[string]int{
+			PlaceMoveTokens: 1,
 			PlaceO00: 1,
 			PlaceXTurn: 1,
-			PlaceMoveTokens: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -206,9 +208,9 @@ func NewAggregate(id string) *Aggregate {
 			PlaceOTurn: 1,
 		},
 		Outputs: map[string]int{
+			PlaceMoveTokens: 1,
 			PlaceO01: 1,
 			PlaceXTurn: 1,
-			PlaceMoveTokens: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -219,22 +221,22 @@ func NewAggregate(id string) *Aggregate {
 			PlaceOTurn: 1,
 		},
 		Outputs: map[string]int{
-			PlaceO02: 1,
 			PlaceXTurn: 1,
 			PlaceMoveTokens: 1,
+			PlaceO02: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
 		ID:        TransitionOPlay10,
 		EventType: EventTypeOPlay10,
 		Inputs: map[string]int{
-			PlaceP10: 1,
 			PlaceOTurn: 1,
+			PlaceP10: 1,
 		},
 		Outputs: map[string]int{
-			PlaceO10: 1,
 			PlaceXTurn: 1,
 			PlaceMoveTokens: 1,
+			PlaceO10: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -254,13 +256,13 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionOPlay12,
 		EventType: EventTypeOPlay12,
 		Inputs: map[string]int{
-			PlaceP12: 1,
 			PlaceOTurn: 1,
+			PlaceP12: 1,
 		},
 		Outputs: map[string]int{
-			PlaceO12: 1,
 			PlaceXTurn: 1,
 			PlaceMoveTokens: 1,
+			PlaceO12: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -293,13 +295,13 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionOPlay22,
 		EventType: EventTypeOPlay22,
 		Inputs: map[string]int{
-			PlaceP22: 1,
 			PlaceOTurn: 1,
+			PlaceP22: 1,
 		},
 		Outputs: map[string]int{
-			PlaceO22: 1,
 			PlaceXTurn: 1,
 			PlaceMoveTokens: 1,
+			PlaceO22: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -340,10 +342,10 @@ func NewAggregate(id string) *Aggregate {
 			PlaceOTurn: 1,
 		},
 		Outputs: map[string]int{
-			PlaceWinX: 1,
 			PlaceX10: 1,
 			PlaceX11: 1,
 			PlaceX12: 1,
+			PlaceWinX: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -374,10 +376,10 @@ func NewAggregate(id string) *Aggregate {
 			PlaceOTurn: 1,
 		},
 		Outputs: map[string]int{
-			PlaceWinX: 1,
 			PlaceX00: 1,
 			PlaceX10: 1,
 			PlaceX20: 1,
+			PlaceWinX: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -391,21 +393,21 @@ func NewAggregate(id string) *Aggregate {
 			PlaceOTurn: 1,
 		},
 		Outputs: map[string]int{
-			PlaceWinX: 1,
-			PlaceX01: 1,
 			PlaceX11: 1,
 			PlaceX21: 1,
+			PlaceWinX: 1,
+			PlaceX01: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
 		ID:        TransitionXWinCol2,
 		EventType: EventTypeXWinCol2,
 		Inputs: map[string]int{
+			PlaceGameActive: 1,
+			PlaceOTurn: 1,
 			PlaceX02: 1,
 			PlaceX12: 1,
 			PlaceX22: 1,
-			PlaceGameActive: 1,
-			PlaceOTurn: 1,
 		},
 		Outputs: map[string]int{
 			PlaceWinX: 1,
@@ -418,17 +420,17 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionXWinDiag,
 		EventType: EventTypeXWinDiag,
 		Inputs: map[string]int{
+			PlaceOTurn: 1,
 			PlaceX00: 1,
 			PlaceX11: 1,
 			PlaceX22: 1,
 			PlaceGameActive: 1,
-			PlaceOTurn: 1,
 		},
 		Outputs: map[string]int{
-			PlaceWinX: 1,
-			PlaceX00: 1,
 			PlaceX11: 1,
 			PlaceX22: 1,
+			PlaceWinX: 1,
+			PlaceX00: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -442,10 +444,10 @@ func NewAggregate(id string) *Aggregate {
 			PlaceOTurn: 1,
 		},
 		Outputs: map[string]int{
-			PlaceWinX: 1,
 			PlaceX02: 1,
 			PlaceX11: 1,
 			PlaceX20: 1,
+			PlaceWinX: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
@@ -486,11 +488,11 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionOWinRow2,
 		EventType: EventTypeOWinRow2,
 		Inputs: map[string]int{
+			PlaceGameActive: 1,
+			PlaceXTurn: 1,
 			PlaceO20: 1,
 			PlaceO21: 1,
 			PlaceO22: 1,
-			PlaceGameActive: 1,
-			PlaceXTurn: 1,
 		},
 		Outputs: map[string]int{
 			PlaceWinO: 1,
@@ -503,11 +505,11 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionOWinCol0,
 		EventType: EventTypeOWinCol0,
 		Inputs: map[string]int{
-			PlaceO00: 1,
-			PlaceO10: 1,
 			PlaceO20: 1,
 			PlaceGameActive: 1,
 			PlaceXTurn: 1,
+			PlaceO00: 1,
+			PlaceO10: 1,
 		},
 		Outputs: map[string]int{
 			PlaceWinO: 1,
@@ -520,28 +522,28 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionOWinCol1,
 		EventType: EventTypeOWinCol1,
 		Inputs: map[string]int{
+			PlaceXTurn: 1,
 			PlaceO01: 1,
 			PlaceO11: 1,
 			PlaceO21: 1,
 			PlaceGameActive: 1,
-			PlaceXTurn: 1,
 		},
 		Outputs: map[string]int{
+			PlaceO21: 1,
 			PlaceWinO: 1,
 			PlaceO01: 1,
 			PlaceO11: 1,
-			PlaceO21: 1,
 		},
 	})
 	sm.AddTransition(eventsource.Transition{
 		ID:        TransitionOWinCol2,
 		EventType: EventTypeOWinCol2,
 		Inputs: map[string]int{
-			PlaceO02: 1,
 			PlaceO12: 1,
 			PlaceO22: 1,
 			PlaceGameActive: 1,
 			PlaceXTurn: 1,
+			PlaceO02: 1,
 		},
 		Outputs: map[string]int{
 			PlaceWinO: 1,
@@ -554,11 +556,11 @@ func NewAggregate(id string) *Aggregate {
 		ID:        TransitionOWinDiag,
 		EventType: EventTypeOWinDiag,
 		Inputs: map[string]int{
+			PlaceGameActive: 1,
+			PlaceXTurn: 1,
 			PlaceO00: 1,
 			PlaceO11: 1,
 			PlaceO22: 1,
-			PlaceGameActive: 1,
-			PlaceXTurn: 1,
 		},
 		Outputs: map[string]int{
 			PlaceWinO: 1,
@@ -1010,6 +1012,10 @@ func applyDraw(state *State, event *eventsource.Event) error {
 // Application wires together the aggregate and event store.
 type Application struct {
 	store eventsource.Store
+
+	// onChange, if set, is called with an aggregate's ID after its events
+	// change, so the service can publish the update to subscribers.
+	onChange func(id string)
 }
 
 // NewApplication creates a new application instance.
@@ -1070,10 +1076,86 @@ func (app *Application) Execute(ctx context.Context, id, transitionID string, da
 	if err := agg.Apply(event); err != nil {
 		return nil, fmt.Errorf("applying event: %w", err)
 	}
+	app.changed(id)
 
 	return agg, nil
 }
 
+// ErrStoreFailed wraps the event store errors ExecuteSequence returns, so
+// callers can tell a failed read or append from a step that cannot fire.
+var ErrStoreFailed = errors.New("event store failed")
+
+// SequenceStep is one transition of a sequence.
+type SequenceStep struct {
+	TransitionID string
+	Data         any
+}
+
+// ExecuteSequence fires transitions on an aggregate in order and persists
+// all of their events in a single append, or none of them. The transitions
+// fire on a freshly loaded copy of the aggregate, so a step that cannot
+// fire leaves the stored history untouched. If authorize is not nil, it is
+// called before each step with the aggregate in the state that step fires
+// from, and an error from it fails the sequence.
+//
+// It returns the aggregate after the last step and the place markings
+// after each step.
+func (app *Application) ExecuteSequence(ctx context.Context, id string, steps []SequenceStep, authorize func(agg *Aggregate, step SequenceStep) error) (*Aggregate, []map[string]int, error) {
+	if len(steps) == 0 {
+		return nil, nil, fmt.Errorf("sequence has no steps")
+	}
+	if len(steps) > api.MaxSequenceSteps {
+		return nil, nil, fmt.Errorf("sequence has %d steps, at most %d are allowed", len(steps), api.MaxSequenceSteps)
+	}
+
+	agg, err := app.Load(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrStoreFailed, err)
+	}
+	version := agg.Version()
+
+	events := make([]*eventsource.Event, 0, len(steps))
+	markings := make([]map[string]int, 0, len(steps))
+	for i, step := range steps {
+		switch step.TransitionID {
+		case TransitionReset:
+			return nil, nil, fmt.Errorf("step %d: transition %s clears history and cannot be part of a sequence", i, step.TransitionID)
+		}
+		if authorize != nil {
+			if err := authorize(agg, step); err != nil {
+				return nil, nil, fmt.Errorf("step %d (%s): %w", i, step.TransitionID, err)
+			}
+		}
+		if !agg.CanFire(step.TransitionID) {
+			return nil, nil, fmt.Errorf("step %d: transition %s cannot fire from current state", i, step.TransitionID)
+		}
+		event, err := agg.Fire(step.TransitionID, step.Data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("step %d: firing transition: %w", i, err)
+		}
+
+		// Apply the event to the copy only, so the next step fires from
+		// this one's marking
+		if err := agg.Apply(event); err != nil {
+			return nil, nil, fmt.Errorf("step %d: applying event: %w", i, err)
+		}
+		events = append(events, event)
+		markings = append(markings, agg.Places())
+	}
+
+	if _, err := app.store.Append(ctx, id, version, events); err != nil {
+		return nil, nil, fmt.Errorf("%w: persisting events: %w", ErrStoreFailed, err)
+	}
+	app.changed(id)
+
+	// Reload so the aggregate carries the versions the store assigned
+	agg, err = app.Load(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrStoreFailed, err)
+	}
+	return agg, markings, nil
+}
+
 // GetState returns the current state of an aggregate.
 func (app *Application) GetState(ctx context.Context, id string) (*Aggregate, error) {
 	return app.Load(ctx, id)
@@ -1085,6 +1167,7 @@ func (app *Application) ResetStream(ctx context.Context, id string) (*Aggregate,
 	if err := app.store.DeleteStream(ctx, id); err != nil {
 		return nil, fmt.Errorf("deleting stream: %w", err)
 	}
+	app.changed(id)
 	return NewAggregate(id), nil
 }
 
@@ -1122,10 +1205,19 @@ func (app *Application) TruncateTo(ctx context.Context, id string, targetVersion
 		}
 	}
 
+	app.changed(id)
+
 	// Load and return the truncated aggregate
 	return app.Load(ctx, id)
 }
 
+// changed reports that an aggregate's events changed.
+func (app *Application) changed(id string) {
+	if app.onChange != nil {
+		app.onChange(id)
+	}
+}
+
 // HealthCheck verifies the event store is accessible.
 func (app *Application) HealthCheck(ctx context.Context) error {
 	// Try to read from a non-existent stream - this exercises the store connection
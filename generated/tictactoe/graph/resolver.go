@@ -8,6 +8,8 @@ import (
 	"fmt"
 
 	"github.com/pflow-xyz/go-pflow/eventsource"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/advisor"
 )
 
 // Resolver is the root resolver for GraphQL queries and mutations.
@@ -17,8 +19,10 @@ type Resolver struct {
 		Load(ctx context.Context, id string) (Aggregate, error)
 		GetState(ctx context.Context, id string) (Aggregate, error)
 		Execute(ctx context.Context, id, transition string, data map[string]any) (Aggregate, error)
+		ExecuteSequence(ctx context.Context, id string, steps []SequenceStep) (Aggregate, []map[string]int, error)
 		HealthCheck(ctx context.Context) error
 		GetStore() eventsource.Store
+		Advise(ctx context.Context, id string, opts advisor.Options) (*advisor.Advice, error)
 	}
 }
 
@@ -37,8 +41,10 @@ func NewResolver(app interface {
 	Load(ctx context.Context, id string) (Aggregate, error)
 	GetState(ctx context.Context, id string) (Aggregate, error)
 	Execute(ctx context.Context, id, transition string, data map[string]any) (Aggregate, error)
+	ExecuteSequence(ctx context.Context, id string, steps []SequenceStep) (Aggregate, []map[string]int, error)
 	HealthCheck(ctx context.Context) error
 	GetStore() eventsource.Store
+	Advise(ctx context.Context, id string, opts advisor.Options) (*advisor.Advice, error)
 }) *Resolver {
 	return &Resolver{App: app}
 }
@@ -105,6 +111,25 @@ func (r *Resolver) TicTacToeList(ctx context.Context, place *string, page *int,
 	}, nil
 }
 
+// TicTacToeAdvice ranks the current player's moves for an aggregate.
+func (r *Resolver) TicTacToeAdvice(ctx context.Context, id string, method *string, depth *int, player *string) (*advisor.Advice, error) {
+	opts := advisor.Options{}
+	if method != nil {
+		m, err := advisor.ParseMethod(*method)
+		if err != nil {
+			return nil, err
+		}
+		opts.Method = m
+	}
+	if depth != nil {
+		opts.Depth = *depth
+	}
+	if player != nil {
+		opts.Player = *player
+	}
+	return r.App.Advise(ctx, id, opts)
+}
+
 // AdminStats returns admin statistics.
 func (r *Resolver) AdminStats(ctx context.Context) (*AdminStats, error) {
 	store := r.App.GetStore()
@@ -1205,6 +1230,47 @@ func (r *Resolver) Draw(ctx context.Context, input DrawInput) (*TransitionResult
 }
 
 
+// FireSequence fires transitions on an aggregate in order, all of them or none.
+func (r *Resolver) FireSequence(ctx context.Context, aggregateID string, transitions []TransitionInput) (*SequenceResult, error) {
+	steps := make([]SequenceStep, len(transitions))
+	for i, t := range transitions {
+		steps[i] = SequenceStep{Transition: t.Transition, Data: make(map[string]any)}
+		if t.Data != nil && *t.Data != "" {
+			if err := json.Unmarshal([]byte(*t.Data), &steps[i].Data); err != nil {
+				errMsg := fmt.Sprintf("step %d: %v", i, err)
+				return &SequenceResult{
+					Success: false,
+					Error:   &errMsg,
+				}, nil
+			}
+		}
+	}
+
+	agg, markings, err := r.App.ExecuteSequence(ctx, aggregateID, steps)
+	if err != nil {
+		errMsg := err.Error()
+		return &SequenceResult{
+			Success: false,
+			Error:   &errMsg,
+		}, nil
+	}
+
+	models := make([]*Places, len(markings))
+	for i, m := range markings {
+		models[i] = placesToModel(m)
+	}
+	id := agg.ID()
+	version := agg.Version()
+
+	return &SequenceResult{
+		Success:            true,
+		AggregateID:        &id,
+		Version:            &version,
+		Markings:           models,
+		State:              placesToModel(agg.Places()),
+		EnabledTransitions: agg.EnabledTransitions(),
+	}, nil
+}
 
 // Helper functions
 
@@ -1545,6 +1611,22 @@ type TransitionResult struct {
 	Error              *string  `json:"error"`
 }
 
+type SequenceResult struct {
+	Success            bool      `json:"success"`
+	AggregateID        *string   `json:"aggregateId"`
+	Version            *int      `json:"version"`
+	Markings           []*Places `json:"markings"`
+	State              *Places   `json:"state"`
+	EnabledTransitions []string  `json:"enabledTransitions"`
+	Error              *string   `json:"error"`
+}
+
+// SequenceStep is one transition of a sequence with its decoded bindings.
+type SequenceStep struct {
+	Transition string
+	Data       map[string]any
+}
+
 type AggregateList struct {
 	Items   []*AggregateState `json:"items"`
 	Total   int               `json:"total"`
@@ -1754,3 +1836,7 @@ type DrawInput struct {
 	AggregateID string
 }
 
+type TransitionInput struct {
+	Transition string
+	Data       *string
+}
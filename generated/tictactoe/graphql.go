@@ -9,6 +9,7 @@ import (
 
 	"github.com/pflow-xyz/petri-pilot/generated/tictactoe/graph"
 	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/advisor"
 	"github.com/pflow-xyz/petri-pilot/pkg/serve"
 )
 
@@ -71,7 +72,6 @@ type graphQLApp struct {
 func (a *graphQLApp) Create(ctx context.Context) (string, error) {
 	return a.app.Create(ctx)
 }
-
 func (a *graphQLApp) Load(ctx context.Context, id string) (graph.Aggregate, error) {
 	return a.app.Load(ctx, id)
 }
@@ -84,10 +84,31 @@ func (a *graphQLApp) Execute(ctx context.Context, id, transition string, data ma
 	return a.app.Execute(ctx, id, transition, data)
 }
 
+func (a *graphQLApp) ExecuteSequence(ctx context.Context, id string, steps []graph.SequenceStep) (graph.Aggregate, []map[string]int, error) {
+	agg, markings, err := a.app.ExecuteSequence(ctx, id, sequenceSteps(steps), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return agg, markings, nil
+}
+
+// sequenceSteps converts GraphQL sequence steps to aggregate sequence steps.
+func sequenceSteps(steps []graph.SequenceStep) []SequenceStep {
+	out := make([]SequenceStep, len(steps))
+	for i, step := range steps {
+		out[i] = SequenceStep{TransitionID: step.Transition, Data: step.Data}
+	}
+	return out
+}
+
 func (a *graphQLApp) HealthCheck(ctx context.Context) error {
 	return a.app.HealthCheck(ctx)
 }
 
+func (a *graphQLApp) Advise(ctx context.Context, id string, opts advisor.Options) (*advisor.Advice, error) {
+	return a.app.Advise(ctx, id, opts)
+}
+
 func (a *graphQLApp) GetStore() eventsource.Store {
 	return a.app.store
 }
@@ -872,6 +893,19 @@ func (h *graphQLHandler) executeGraphQL(ctx context.Context, query, operationNam
 	}
 
 
+	// Handle sequence mutation (supports both "fireSequence" and "package_fireSequence" naming)
+	if isMutation && containsString(query, "fireSequence") {
+		id, _ := variables["aggregateId"].(string)
+		res, err := h.resolver.FireSequence(ctx, id, transitionInputs(variables["transitions"]))
+		if err != nil {
+			errors = append(errors, map[string]interface{}{"message": err.Error()})
+		} else if containsString(query, "tictactoe_fireSequence") {
+			data["tictactoe_fireSequence"] = res
+		} else {
+			data["fireSequence"] = res
+		}
+	}
+
 	// Handle query for single aggregate
 	if !isMutation && containsString(query, "tictactoe(") {
 		id := ""
@@ -911,6 +945,29 @@ func (h *graphQLHandler) executeGraphQL(ctx context.Context, query, operationNam
 		}
 	}
 
+	// Handle move advice query
+	if !isMutation && containsString(query, "tictactoeAdvice") {
+		id, _ := variables["id"].(string)
+		var method, player *string
+		var depth *int
+		if m, ok := variables["method"].(string); ok {
+			method = &m
+		}
+		if d, ok := variables["depth"].(float64); ok {
+			di := int(d)
+			depth = &di
+		}
+		if p, ok := variables["player"].(string); ok {
+			player = &p
+		}
+		advice, err := h.resolver.TicTacToeAdvice(ctx, id, method, depth, player)
+		if err != nil {
+			errors = append(errors, map[string]interface{}{"message": err.Error()})
+		} else {
+			data["tictactoeAdvice"] = advice
+		}
+	}
+
 	// Handle admin stats query
 	if !isMutation && containsString(query, "adminStats") {
 		stats, err := h.resolver.AdminStats(ctx)
@@ -952,6 +1009,20 @@ func (h *graphQLHandler) executeGraphQL(ctx context.Context, query, operationNam
 	return result
 }
 
+// transitionInputs decodes a list of TransitionInput variables.
+func transitionInputs(v any) []graph.TransitionInput {
+	list, _ := v.([]any)
+	inputs := make([]graph.TransitionInput, len(list))
+	for i, item := range list {
+		vars, _ := item.(map[string]any)
+		inputs[i].Transition, _ = vars["transition"].(string)
+		if data, ok := vars["data"].(string); ok {
+			inputs[i].Data = &data
+		}
+	}
+	return inputs
+}
+
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsStringHelper(s, substr))
 }
@@ -1001,6 +1072,9 @@ type Query {
   # List aggregates with optional filtering
   tictactoeList(place: String, page: Int, perPage: Int): AggregateList!
 
+  # Rank the current player's moves against the simulation objective
+  tictactoeAdvice(id: ID!, method: String, depth: Int, player: String): Advice
+
   # Admin statistics
   adminStats: AdminStats!
 
@@ -1122,6 +1196,14 @@ type Mutation {
 
   # Game ends in draw (all 9 moves played, no winner)
   draw(input: DrawInput!): TransitionResult!
+
+  # Fire transitions in order, all of them or none
+  fireSequence(aggregateId: ID!, transitions: [TransitionInput!]!): SequenceResult!
+}
+
+type Subscription {
+  # Aggregate state after each change
+  tictactoeUpdated(id: ID!): AggregateState!
 }
 
 # Aggregate state representation
@@ -1219,6 +1301,17 @@ type TransitionResult {
   error: String
 }
 
+# Result of firing a sequence of transitions
+type SequenceResult {
+  success: Boolean!
+  aggregateId: ID
+  version: Int
+  markings: [Places!]
+  state: Places
+  enabledTransitions: [String!]
+  error: String
+}
+
 # Paginated list of aggregates
 type AggregateList {
   items: [AggregateState!]!
@@ -1227,6 +1320,22 @@ type AggregateList {
   perPage: Int!
 }
 
+# Ranked moves for the current player
+type Advice {
+  player: String
+  maximizes: Boolean!
+  method: String!
+  depth: Int
+  objective: Float!
+  best: String
+  moves: [Move!]!
+}
+
+type Move {
+  action: String!
+  score: Float!
+}
+
 # Admin statistics
 type AdminStats {
   totalInstances: Int!
@@ -1431,6 +1540,11 @@ input DrawInput {
   aggregateId: ID!
 }
 
+# A transition of a sequence, with its bindings as a JSON object
+input TransitionInput {
+  transition: String!
+  data: String
+}
 `
 
 // GraphQLResolversMap returns a map of resolver functions for the unified GraphQL endpoint.
@@ -1463,6 +1577,23 @@ func GraphQLResolversMap(app *Application) map[string]serve.GraphQLResolver {
 		return resolver.TicTacToeList(ctx, place, page, perPage)
 	}
 
+	resolvers["tictactoeAdvice"] = func(ctx context.Context, variables map[string]any) (any, error) {
+		id, _ := variables["id"].(string)
+		var method, player *string
+		var depth *int
+		if m, ok := variables["method"].(string); ok {
+			method = &m
+		}
+		if d, ok := variables["depth"].(float64); ok {
+			di := int(d)
+			depth = &di
+		}
+		if p, ok := variables["player"].(string); ok {
+			player = &p
+		}
+		return resolver.TicTacToeAdvice(ctx, id, method, depth, player)
+	}
+
 	// Mutation resolvers (register both underscore and camelCase names for compatibility)
 	resolvers["tictactoe_create"] = func(ctx context.Context, _ map[string]any) (any, error) {
 		return resolver.CreateTictactoe(ctx)
@@ -1902,6 +2033,12 @@ func GraphQLResolversMap(app *Application) map[string]serve.GraphQLResolver {
 	resolvers["draw"] = resolvers["tictactoe_draw"]
 
 
+	resolvers["tictactoe_fireSequence"] = func(ctx context.Context, variables map[string]any) (any, error) {
+		id, _ := variables["aggregateId"].(string)
+		return resolver.FireSequence(ctx, id, transitionInputs(variables["transitions"]))
+	}
+	resolvers["fireSequence"] = resolvers["tictactoe_fireSequence"]
+
 
 	// Admin stats resolver (namespace for unified endpoint)
 	resolvers["tictactoe_adminStats"] = func(ctx context.Context, _ map[string]any) (any, error) {
@@ -1927,3 +2064,20 @@ func GraphQLResolversMap(app *Application) map[string]serve.GraphQLResolver {
 
 	return resolvers
 }
+
+// GraphQLSubscriptionsMap returns a map of subscriber functions for the unified GraphQL endpoint.
+func GraphQLSubscriptionsMap(app *Application, updates *serve.Publisher) map[string]serve.GraphQLSubscriber {
+	resolver := graph.NewResolver(&graphQLApp{app: app})
+
+	subscriptions := make(map[string]serve.GraphQLSubscriber)
+
+	// Aggregate state, each time a transition changes it
+	subscriptions["tictactoeUpdated"] = func(ctx context.Context, variables map[string]any) (<-chan any, error) {
+		id, _ := variables["id"].(string)
+		return updates.Subscribe(ctx, id, func(ctx context.Context) (any, error) {
+			return resolver.TicTacToe(ctx, id)
+		}), nil
+	}
+
+	return subscriptions
+}
@@ -30,10 +30,12 @@ CREATE TABLE IF NOT EXISTS snapshots (
 );
 
 -- Projection: tic-tac-toe aggregate state
--- Denormalized view for fast reads
+-- Denormalized view for fast reads, kept up to date by the projector
+-- (projections.go) when the petri-pilot/projections extension is enabled
 CREATE TABLE IF NOT EXISTS "tictactoe_state" (
     id TEXT PRIMARY KEY,
     version INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT '',  -- Comma-separated places holding tokens
     "p00" INTEGER DEFAULT 0,
     "p01" INTEGER DEFAULT 0,
     "p02" INTEGER DEFAULT 0,
@@ -68,11 +70,13 @@ CREATE TABLE IF NOT EXISTS "tictactoe_state" (
     "can_reset" INTEGER DEFAULT 0,
     "game_active" INTEGER DEFAULT 0,
     "move_tokens" INTEGER DEFAULT 0,
+    state TEXT NOT NULL DEFAULT '{}',  -- Full aggregate state
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE INDEX IF NOT EXISTS "idx_tictactoe_state_updated" ON "tictactoe_state"(updated_at);
+CREATE INDEX IF NOT EXISTS "idx_tictactoe_state_status" ON "tictactoe_state"(status);
 
 -- Place tokens tracking (Petri net state)
 CREATE TABLE IF NOT EXISTS "tictactoe_places" (
@@ -83,6 +87,13 @@ CREATE TABLE IF NOT EXISTS "tictactoe_places" (
     PRIMARY KEY (aggregate_id, place_id)
 );
 
+-- Projection checkpoints: events of the global log consumed per projection
+CREATE TABLE IF NOT EXISTS projection_checkpoints (
+    name TEXT PRIMARY KEY,
+    position INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Event type constants for reference:
 -- XPlayed
 -- OPlayed
@@ -296,6 +296,18 @@ func NewContextFromApp(app *extensions.ApplicationSpec, opts ContextOptions) (*C
 	if app.HasAdmin() {
 		ctx.HasAdmin = true
 	}
+	if app.HasPrediction() {
+		pred := app.Prediction()
+		ctx.HasPrediction = true
+		ctx.Prediction = &PredictionContext{
+			Enabled:   pred.Enabled,
+			TimeHours: pred.TimeHours,
+			RateScale: pred.RateScale,
+		}
+		if ctx.Prediction.TimeHours == 0 {
+			ctx.Prediction.TimeHours = 8.0
+		}
+	}
 
 	return ctx, nil
 }
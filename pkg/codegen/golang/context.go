@@ -3,12 +3,17 @@ package golang
 import (
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pflow-xyz/go-pflow/metamodel"
 	"github.com/pflow-xyz/petri-pilot/pkg/bridge"
 	"github.com/pflow-xyz/petri-pilot/pkg/extensions"
+	ppmetamodel "github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/explore"
 )
 
 // Context holds all data needed for code generation templates.
@@ -46,6 +51,25 @@ type Context struct {
 	AccessRules []AccessRuleContext
 	Roles       []RoleContext
 
+	// Read policies (field-level redaction of state and events)
+	ReadPolicies []ReadPolicyContext
+
+	// Rate limits (token-bucket throttling of transitions)
+	RateLimits     []RateLimitContext
+	RateLimitStore string // "memory" or "sql"
+
+	// Read-model projection (checkpointed state table)
+	Projection *ProjectionContext
+
+	// Simulation objective and players (move advice)
+	Simulation *SimulationContext
+
+	// Scenario regression tests replayed against the aggregate
+	Scenarios []ScenarioContext
+
+	// Realtime is set when SSE and WebSocket handlers are generated
+	Realtime bool
+
 	// Views (Phase 13)
 	Views []ViewContext
 
@@ -166,6 +190,8 @@ type WorkflowStepContext struct {
 	Input      map[string]string // Input field mappings
 	OnSuccess  string            // Next step ID on success
 	OnFailure  string            // Next step ID on failure
+	Compensate string            // Action on Entity that undoes the step
+	Parallel   []string          // Step IDs run by a parallel step
 }
 
 // ViewContext provides template-friendly access to view definitions.
@@ -216,6 +242,89 @@ type RoleContext struct {
 	HasDynamicGrant bool     // True if DynamicGrant is set
 }
 
+// ReadPolicyContext provides template-friendly access to read policies.
+type ReadPolicyContext struct {
+	Place     string   // Token place to hide
+	Field     string   // State field, or event payload field when EventType is set
+	EventType string   // Event type whose payload is restricted
+	Roles     []string // Roles that may read the target
+	When      string   // Expression granting read access
+	Key       string   // Expression granting read access per map entry
+}
+
+// RateLimitContext provides template-friendly access to rate limit rules.
+type RateLimitContext struct {
+	Name          string // Rule name used in bucket keys and headers
+	Transition    string // Transition ID, "create", or "*" for all
+	Role          string // Role the rule applies to; empty for every caller
+	Limit         int
+	WindowSeconds int64
+	Burst         int
+	Global        bool // One bucket shared by all callers
+}
+
+// ProjectionContext provides template-friendly access to projection settings.
+type ProjectionContext struct {
+	Database   string // SQLite file holding the projection
+	PollMillis int64  // Interval between catch-up passes
+	BatchSize  int    // Events read per catch-up query
+}
+
+// SimulationContext provides template-friendly access to the simulation block.
+type SimulationContext struct {
+	Objective string
+	Players   []SimulationPlayerContext // Sorted by name
+	Solver    *SimulationSolverContext  // Nil when the model sets no solver parameters
+	Method    string                    // Default advisor method
+	Depth     int                       // Default advisor depth (0 means the advisor default)
+}
+
+// SimulationPlayerContext describes one player of the simulation.
+type SimulationPlayerContext struct {
+	Name        string
+	Maximizes   bool
+	TurnPlace   string
+	Transitions []string
+}
+
+// SimulationSolverContext holds ODE solver parameters.
+type SimulationSolverContext struct {
+	TspanStart float64
+	TspanEnd   float64
+	Dt         float64
+	Rates      []SimulationRateContext // Sorted by transition
+}
+
+// SimulationRateContext is the firing rate of one transition.
+type SimulationRateContext struct {
+	Transition string
+	Rate       float64
+}
+
+// ScenarioContext is a scenario regression test: a firing sequence and
+// the state expected after each step.
+type ScenarioContext struct {
+	Name   string
+	Source string // "file", "deadlock" or "path"
+	Steps  []ScenarioStepContext
+}
+
+// ScenarioStepContext is one step of a scenario and its expected state.
+type ScenarioStepContext struct {
+	Transition string                 // Transition constant name
+	Data       string                 // Bindings as JSON, empty for none
+	Refused    string                 // Why the step does not fire, empty if it does
+	Places     []ScenarioValueContext // Non-empty token places by constant name
+	Enabled    []string               // Enabled transition constant names, sorted by ID
+	State      []ScenarioValueContext // Data places by JSON name, values as JSON
+}
+
+// ScenarioValueContext is an expected value in a scenario step.
+type ScenarioValueContext struct {
+	Name  string
+	Value string
+}
+
 // WebhookContext provides template-friendly access to webhook configuration.
 type WebhookContext struct {
 	ID          string
@@ -487,13 +596,18 @@ type PlaceContext struct {
 	ID          string
 	Description string
 	Initial     int
-	Kind        string // "token" or "data"
+	Kind        string // "token", "data" or "colored"
 	Type        string // Go type
 	IsToken     bool
 	IsData      bool
+	IsColored   bool   // Colored places are counted like token places
+	Color       string // Token color of a colored place, e.g. "{id string, total int64}"
 	Persisted   bool
 	Exported    bool
 
+	// Initial tokens of a colored place, as Go literals
+	InitialTokens []string
+
 	// Resource tracking for prediction/simulation
 	Capacity int  // Maximum tokens (for inventory modeling)
 	Resource bool // True if this is a consumable resource
@@ -528,6 +642,9 @@ type TransitionContext struct {
 	// Guard info (if present)
 	GuardInfo *GuardContext
 
+	// IsColored is true if the transition moves colored tokens
+	IsColored bool
+
 	// Typed event data (from entity fields)
 	EventData *EventDataContext
 
@@ -711,6 +828,10 @@ type ContextOptions struct {
 
 // NewContext creates a Context from a model with computed template data.
 func NewContext(model *metamodel.Model, opts ContextOptions) (*Context, error) {
+	// Colored places are counted as token places by the state machine;
+	// the metamodel runtime tracks their tokens
+	model, colors := uncolorModel(model)
+
 	// Enrich the model with defaults
 	enriched := metamodel.EnrichModel(model)
 
@@ -748,6 +869,13 @@ func NewContext(model *metamodel.Model, opts ContextOptions) (*Context, error) {
 
 	// Build place contexts
 	ctx.Places = buildPlaceContexts(enriched.Places)
+	for i := range ctx.Places {
+		if color, ok := colors[ctx.Places[i].ID]; ok {
+			ctx.Places[i].Kind = string(ppmetamodel.ColoredState)
+			ctx.Places[i].IsColored = true
+			ctx.Places[i].Color = color
+		}
+	}
 
 	// Build place ID set for quick lookups and track data state places
 	placeIDs := make(map[string]bool)
@@ -781,6 +909,18 @@ func NewContext(model *metamodel.Model, opts ContextOptions) (*Context, error) {
 	ctx.DataArcs = buildDataArcContexts(ormSpec.Operations)
 	ctx.Guards = buildGuardContexts(enriched.Transitions, ormSpec.Collections)
 
+	// Guards of colored transitions read the tokens they bind, so the
+	// metamodel runtime evaluates them while binding
+	if colors != nil {
+		guards := ctx.Guards[:0]
+		for _, g := range ctx.Guards {
+			if !transitionIsColored(g.TransitionID, enriched.Arcs, colors) {
+				guards = append(guards, g)
+			}
+		}
+		ctx.Guards = guards
+	}
+
 	// Populate data arcs, guard info, and event data on transitions
 	for i := range ctx.Transitions {
 		tid := ctx.Transitions[i].ID
@@ -788,6 +928,7 @@ func NewContext(model *metamodel.Model, opts ContextOptions) (*Context, error) {
 		ctx.Transitions[i].OutputDataArcs = ctx.OutputDataArcs(tid)
 		ctx.Transitions[i].GuardInfo = ctx.GuardForTransition(tid)
 		ctx.Transitions[i].EventData = ctx.EventDataForTransition(tid)
+		ctx.Transitions[i].IsColored = transitionIsColored(tid, enriched.Arcs, colors)
 	}
 
 	// Note: Application-level constructs (debug, admin, navigation, roles, access, views)
@@ -844,6 +985,38 @@ func NewContextFromApp(app *extensions.ApplicationSpec, opts ContextOptions) (*C
 		ctx.Navigation = buildNavigationContextFromExtension(pagesExt.Navigation)
 	}
 
+	// Read policies from policies extension
+	if policiesExt := app.Policies(); policiesExt != nil {
+		ctx.ReadPolicies = buildReadPolicyContexts(policiesExt, ctx.Transitions)
+		if policiesExt.RateLimits != nil {
+			ctx.RateLimits = buildRateLimitContexts(policiesExt.RateLimits)
+			ctx.RateLimitStore = policiesExt.RateLimits.Store
+			if ctx.RateLimitStore == "" {
+				ctx.RateLimitStore = "memory"
+			}
+		}
+	}
+
+	// Read-model projection from projections extension
+	if projectionsExt := app.Projections(); projectionsExt != nil && projectionsExt.Enabled {
+		ctx.Projection = buildProjectionContext(projectionsExt)
+	}
+
+	// Simulation objective and players from simulation extension
+	if app.HasSimulation() {
+		ctx.Simulation = buildSimulationContext(app.Simulation())
+	}
+
+	// Initial tokens of colored places; the places count them
+	if app.HasInitialTokens() {
+		for i, p := range ctx.Places {
+			if tokens, ok := app.InitialTokens()[p.ID]; ok && p.IsColored {
+				ctx.Places[i].Initial = len(tokens)
+				ctx.Places[i].InitialTokens = tokenLiterals(tokens)
+			}
+		}
+	}
+
 	// Entity fields and access rules from entities extension
 	if entitiesExt := app.Entities(); entitiesExt != nil {
 		ctx.AccessRules = buildAccessRuleContextsFromEntities(entitiesExt)
@@ -861,6 +1034,11 @@ func NewContextFromApp(app *extensions.ApplicationSpec, opts ContextOptions) (*C
 		ctx.Debug = buildDebugContext(app.Debug())
 	}
 
+	// Prediction config from app
+	if app.HasPrediction() {
+		ctx.Prediction = buildPredictionContext(app.Prediction())
+	}
+
 	// GraphQL config from app
 	if app.HasGraphQL() {
 		ctx.GraphQL = buildGraphQLContext(app.GraphQL())
@@ -890,6 +1068,225 @@ func buildRoleContextsFromExtension(ext *extensions.RoleExtension) []RoleContext
 	return result
 }
 
+// buildReadPolicyContexts converts read policies to ReadPolicyContexts.
+// Policies may name an event by transition ID; those resolve to the
+// transition's event type so generated code can match stored events.
+func buildReadPolicyContexts(ext *extensions.PolicyExtension, transitions []TransitionContext) []ReadPolicyContext {
+	eventTypes := make(map[string]string)
+	for _, t := range transitions {
+		eventTypes[t.ID] = t.EventType
+	}
+
+	result := make([]ReadPolicyContext, len(ext.ReadPolicies))
+	for i, p := range ext.ReadPolicies {
+		eventType := p.Event
+		if resolved, ok := eventTypes[p.Event]; ok {
+			eventType = resolved
+		}
+		result[i] = ReadPolicyContext{
+			Place:     p.Place,
+			Field:     p.Field,
+			EventType: eventType,
+			Roles:     p.Roles,
+			When:      p.When,
+			Key:       p.Key,
+		}
+	}
+	return result
+}
+
+// buildRateLimitContexts converts rate limit rules to RateLimitContexts.
+// Rules with an invalid window are skipped; the extension validates them.
+func buildRateLimitContexts(limits *extensions.RateLimits) []RateLimitContext {
+	var result []RateLimitContext
+	for i, r := range limits.Rules {
+		window, err := r.Window()
+		if err != nil {
+			continue
+		}
+		transition := r.Transition
+		if transition == "" {
+			transition = "*"
+		}
+		result = append(result, RateLimitContext{
+			Name:          fmt.Sprintf("rl%d", i),
+			Transition:    transition,
+			Role:          r.Role,
+			Limit:         r.Limit,
+			WindowSeconds: int64(window.Seconds()),
+			Burst:         r.Burst,
+			Global:        r.Global,
+		})
+	}
+	return result
+}
+
+// buildProjectionContext applies defaults to the projection settings.
+func buildProjectionContext(p *extensions.ProjectionExtension) *ProjectionContext {
+	result := &ProjectionContext{
+		Database:   p.Database,
+		PollMillis: 1000,
+		BatchSize:  p.BatchSize,
+	}
+	if result.Database == "" {
+		result.Database = "projections.db"
+	}
+	if poll, err := p.PollInterval(); err == nil {
+		result.PollMillis = poll.Milliseconds()
+	}
+	if result.BatchSize <= 0 {
+		result.BatchSize = 500
+	}
+	return result
+}
+
+// buildSimulationContext flattens the simulation block into sorted slices.
+func buildSimulationContext(sim *extensions.SimulationExtension) *SimulationContext {
+	result := &SimulationContext{Objective: sim.Objective}
+
+	names := make([]string, 0, len(sim.Players))
+	for name := range sim.Players {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := sim.Players[name]
+		result.Players = append(result.Players, SimulationPlayerContext{
+			Name:        name,
+			Maximizes:   p.Maximizes,
+			TurnPlace:   p.TurnPlace,
+			Transitions: p.Transitions,
+		})
+	}
+
+	if sim.Solver != nil {
+		result.Solver = &SimulationSolverContext{
+			TspanStart: sim.Solver.Tspan[0],
+			TspanEnd:   sim.Solver.Tspan[1],
+			Dt:         sim.Solver.Dt,
+		}
+		transitions := make([]string, 0, len(sim.Solver.Rates))
+		for t := range sim.Solver.Rates {
+			transitions = append(transitions, t)
+		}
+		sort.Strings(transitions)
+		for _, t := range transitions {
+			result.Solver.Rates = append(result.Solver.Rates, SimulationRateContext{Transition: t, Rate: sim.Solver.Rates[t]})
+		}
+	}
+
+	if sim.Advisor != nil {
+		result.Method = sim.Advisor.Method
+		result.Depth = sim.Advisor.Depth
+	}
+	return result
+}
+
+// buildScenarioContexts derives scenario test cases from the model and
+// renders each step's expected marking, enabled transitions and data in
+// terms of the generated constants and State fields.
+func buildScenarioContexts(ctx *Context, opts explore.SuiteOptions) ([]ScenarioContext, error) {
+	cases, err := explore.Suite(ppmetamodel.FromModel(ctx.Model), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make(map[string]TransitionContext, len(ctx.Transitions))
+	for _, t := range ctx.Transitions {
+		transitions[t.ID] = t
+	}
+	places := make(map[string]string, len(ctx.Places))
+	for _, p := range ctx.Places {
+		places[p.ID] = p.ConstName
+	}
+	dataFields := make(map[string]bool)
+	for _, f := range ctx.ScenarioStateFields() {
+		dataFields[f.Name] = true
+	}
+
+	var result []ScenarioContext
+	for _, c := range cases {
+		// The exploration counts colored tokens but cannot choose their
+		// bindings, so generated paths through colored transitions are
+		// left out; scenario files bind the tokens themselves
+		if c.Source != explore.FromFile && firesColored(c, transitions) {
+			continue
+		}
+		sc := ScenarioContext{Name: c.Name, Source: string(c.Source)}
+		for _, out := range c.Steps {
+			t := transitions[out.Transition]
+			step := ScenarioStepContext{Transition: t.ConstName, Refused: out.Refused}
+
+			// Typed event data rejects events missing a required string field
+			bindings := out.Bindings
+			if t.EventData != nil {
+				bindings = make(map[string]any, len(out.Bindings))
+				for k, v := range out.Bindings {
+					bindings[k] = v
+				}
+				for _, f := range t.EventData.Fields {
+					if _, ok := bindings[f.JSONName]; !ok && f.Required && f.Type == "string" {
+						bindings[f.JSONName] = "test-" + f.JSONName
+					}
+				}
+			}
+			if len(bindings) > 0 {
+				data, err := json.Marshal(bindings)
+				if err != nil {
+					return nil, fmt.Errorf("scenario %q: %w", c.Name, err)
+				}
+				step.Data = string(data)
+			}
+
+			ids := make([]string, 0, len(out.Tokens))
+			for id, n := range out.Tokens {
+				if n != 0 && places[id] != "" {
+					ids = append(ids, id)
+				}
+			}
+			sort.Strings(ids)
+			for _, id := range ids {
+				step.Places = append(step.Places, ScenarioValueContext{Name: places[id], Value: fmt.Sprint(out.Tokens[id])})
+			}
+
+			enabled := append([]string(nil), out.Enabled...)
+			sort.Strings(enabled)
+			for _, id := range enabled {
+				step.Enabled = append(step.Enabled, transitions[id].ConstName)
+			}
+
+			ids = ids[:0]
+			for id := range out.Data {
+				if dataFields[id] {
+					ids = append(ids, id)
+				}
+			}
+			sort.Strings(ids)
+			for _, id := range ids {
+				value, err := json.Marshal(out.Data[id])
+				if err != nil {
+					return nil, fmt.Errorf("scenario %q: %w", c.Name, err)
+				}
+				step.State = append(step.State, ScenarioValueContext{Name: id, Value: string(value)})
+			}
+			sc.Steps = append(sc.Steps, step)
+		}
+		result = append(result, sc)
+	}
+	return result, nil
+}
+
+// firesColored reports whether any step of a scenario fires a colored
+// transition.
+func firesColored(c explore.Case, transitions map[string]TransitionContext) bool {
+	for _, out := range c.Steps {
+		if transitions[out.Transition].IsColored {
+			return true
+		}
+	}
+	return false
+}
+
 // buildAccessRuleContextsFromEntities extracts access rules from entities.
 func buildAccessRuleContextsFromEntities(ext *extensions.EntityExtension) []AccessRuleContext {
 	var result []AccessRuleContext
@@ -1251,6 +1648,68 @@ func buildViewContexts(views []metamodel.View) []ViewContext {
 	return result
 }
 
+// uncolorModel returns a copy of the model whose colored places are token
+// places, and the colors of those places. A model without colored places
+// is returned as is.
+func uncolorModel(model *metamodel.Model) (*metamodel.Model, map[string]string) {
+	var colors map[string]string
+	for _, p := range model.Places {
+		if p.Kind == ppmetamodel.ColoredKind {
+			if colors == nil {
+				colors = make(map[string]string)
+			}
+			colors[p.ID] = p.Type
+		}
+	}
+	if colors == nil {
+		return model, nil
+	}
+
+	uncolored := *model
+	uncolored.Places = append([]metamodel.Place(nil), model.Places...)
+	for i := range uncolored.Places {
+		if _, ok := colors[uncolored.Places[i].ID]; ok {
+			uncolored.Places[i].Kind = metamodel.TokenKind
+			uncolored.Places[i].Type = ""
+		}
+	}
+	return &uncolored, colors
+}
+
+// tokenLiterals returns colored tokens as Go metamodel.Token literals,
+// fields sorted by name. Whole floats print as integers; the runtime
+// converts values to the color when it seeds the tokens.
+func tokenLiterals(tokens []ppmetamodel.Token) []string {
+	result := make([]string, len(tokens))
+	for i, tok := range tokens {
+		names := make([]string, 0, len(tok))
+		for name := range tok {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fields := make([]string, len(names))
+		for j, name := range names {
+			fields[j] = fmt.Sprintf("%q: %#v", name, tok[name])
+		}
+		result[i] = "{" + strings.Join(fields, ", ") + "}"
+	}
+	return result
+}
+
+// transitionIsColored reports whether any arc connects a transition to a
+// colored place.
+func transitionIsColored(transitionID string, arcs []metamodel.Arc, colors map[string]string) bool {
+	for _, arc := range arcs {
+		if arc.From == transitionID && colors[arc.To] != "" {
+			return true
+		}
+		if arc.To == transitionID && colors[arc.From] != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func buildPlaceContexts(places []metamodel.Place) []PlaceContext {
 	result := make([]PlaceContext, len(places))
 	for i, p := range places {
@@ -1463,6 +1922,12 @@ func eventFieldTypeToGo(typ string) string {
 	}
 }
 
+// APISlug returns the path segment a generated service uses for a model's
+// aggregate endpoints, e.g. "Order-Flow" serves /api/orderflow.
+func APISlug(name string) string {
+	return sanitizeAPISlug(name)
+}
+
 // sanitizeAPISlug converts a model name to a URL-safe slug for API paths.
 // This removes hyphens, underscores, and spaces to create a consistent identifier.
 func sanitizeAPISlug(name string) string {
@@ -1845,11 +2310,42 @@ func (c *Context) HasEventData() bool {
 	return len(c.EventData) > 0
 }
 
+// HasInitialColoredTokens returns true if any colored place starts with tokens.
+func (c *Context) HasInitialColoredTokens() bool {
+	for _, p := range c.Places {
+		if len(p.InitialTokens) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasColoredPlaces returns true if any place holds colored tokens.
+func (c *Context) HasColoredPlaces() bool {
+	for _, p := range c.Places {
+		if p.IsColored {
+			return true
+		}
+	}
+	return false
+}
+
+// ColoredTransitions returns the transitions that move colored tokens.
+func (c *Context) ColoredTransitions() []TransitionContext {
+	var result []TransitionContext
+	for _, t := range c.Transitions {
+		if t.IsColored {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
 // UsesMetamodelRuntime returns true if the generated code should use
 // go-pflow's metamodel.Runtime for execution.
 func (c *Context) UsesMetamodelRuntime() bool {
-	// Use metamodel runtime when we have data places or guards
-	return c.HasDataPlaces() || c.HasGuards()
+	// Use metamodel runtime when we have data places, guards or colored tokens
+	return c.HasDataPlaces() || c.HasGuards() || c.HasColoredPlaces()
 }
 
 // HasWorkflows returns true if the context has any workflows defined.
@@ -1857,9 +2353,100 @@ func (c *Context) HasWorkflows() bool {
 	return len(c.Workflows) > 0
 }
 
-// HasAccessControl returns true if any access rules or roles are defined.
+// WorkflowHandlerKey returns the handler key a workflow reaches an action
+// by: the action itself for this model's transitions, whatever entity the
+// workflow names, and "entity.action" for actions of other entities.
+func (c *Context) WorkflowHandlerKey(entity, action string) string {
+	for _, t := range c.Transitions {
+		if t.ID == action {
+			return action
+		}
+	}
+	return entity + "." + action
+}
+
+// WaitExpr returns the Go expression for a wait step's duration. Steps
+// without a duration wait one second.
+func (s WorkflowStepContext) WaitExpr() (string, error) {
+	if s.Duration == "" {
+		return "time.Second", nil
+	}
+	d, err := time.ParseDuration(s.Duration)
+	if err != nil {
+		return "", fmt.Errorf("step %s: invalid duration %q: %w", s.ID, s.Duration, err)
+	}
+	for _, unit := range []struct {
+		d    time.Duration
+		name string
+	}{{time.Hour, "time.Hour"}, {time.Minute, "time.Minute"}, {time.Second, "time.Second"}, {time.Millisecond, "time.Millisecond"}} {
+		if d%unit.d == 0 {
+			return fmt.Sprintf("%d * %s", d/unit.d, unit.name), nil
+		}
+	}
+	return fmt.Sprintf("%d", d), nil
+}
+
+// HasAccessControl returns true if any access rules, roles or read policies are defined.
 func (c *Context) HasAccessControl() bool {
-	return len(c.AccessRules) > 0 || len(c.Roles) > 0
+	return len(c.AccessRules) > 0 || len(c.Roles) > 0 || len(c.ReadPolicies) > 0
+}
+
+// HasReadPolicies returns true if any read policies are defined.
+func (c *Context) HasReadPolicies() bool {
+	return len(c.ReadPolicies) > 0
+}
+
+// HasKeyedReadPolicies returns true if any read policy restricts a map
+// field per entry.
+func (c *Context) HasKeyedReadPolicies() bool {
+	for _, p := range c.ReadPolicies {
+		if p.Key != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRateLimits returns true if any rate limits are defined.
+func (c *Context) HasRateLimits() bool {
+	return len(c.RateLimits) > 0
+}
+
+// RateLimitsUseSQL returns true if rate limit buckets are stored in SQL.
+func (c *Context) RateLimitsUseSQL() bool {
+	return c.HasRateLimits() && c.RateLimitStore == "sql"
+}
+
+// HasProjections returns true if the read-model projection is enabled.
+func (c *Context) HasProjections() bool {
+	return c.Projection != nil
+}
+
+// HasSimulation returns true if the model declares a simulation objective.
+func (c *Context) HasSimulation() bool {
+	return c.Simulation != nil
+}
+
+// HasRealtime returns true if SSE and WebSocket handlers are generated.
+func (c *Context) HasRealtime() bool {
+	return c.Realtime
+}
+
+// HasScenarios returns true if scenario regression tests were derived.
+func (c *Context) HasScenarios() bool {
+	return len(c.Scenarios) > 0
+}
+
+// ScenarioStateFields returns the State fields scenario tests compare: those
+// holding data places rather than token counts.
+func (c *Context) ScenarioStateFields() []StateFieldContext {
+	var fields []StateFieldContext
+	for _, f := range c.StateFields {
+		if !f.IsToken {
+			fields = append(fields, f)
+		}
+	}
+	return fields
 }
 
 // HasRoles returns true if any roles are defined.
@@ -1877,6 +2464,17 @@ func (c *Context) TransitionRequiresAuth(transitionID string) bool {
 	return false
 }
 
+// RestrictedTransitions returns the transitions that have access control rules.
+func (c *Context) RestrictedTransitions() []TransitionContext {
+	var transitions []TransitionContext
+	for _, t := range c.Transitions {
+		if c.TransitionRequiresAuth(t.ID) {
+			transitions = append(transitions, t)
+		}
+	}
+	return transitions
+}
+
 // TransitionHasDynamicRoles returns true if a transition's access control involves roles with dynamic grants.
 func (c *Context) TransitionHasDynamicRoles(transitionID string) bool {
 	// Find the access rule for this transition
@@ -2004,6 +2602,15 @@ func (c *Context) HasAdmin() bool {
 return c.Admin != nil && c.Admin.Enabled
 }
 
+// AdminRoles returns the roles allowed to use the admin API.
+// Defaults to "admin" when the admin block does not list any.
+func (c *Context) AdminRoles() []string {
+	if c.Admin != nil && len(c.Admin.Roles) > 0 {
+		return c.Admin.Roles
+	}
+	return []string{"admin"}
+}
+
 // HasEventSourcing returns true if event sourcing is enabled.
 // Always returns true since the runtime always uses event sourcing.
 func (c *Context) HasEventSourcing() bool {
@@ -2050,6 +2657,17 @@ func (c *Context) HasClearsHistoryTransitions() bool {
 	return false
 }
 
+// ClearsHistoryTransitions returns the transitions that clear event history.
+func (c *Context) ClearsHistoryTransitions() []TransitionContext {
+	var transitions []TransitionContext
+	for _, t := range c.Transitions {
+		if t.ClearsHistory {
+			transitions = append(transitions, t)
+		}
+	}
+	return transitions
+}
+
 // HasPrediction returns true if the model has prediction configuration enabled.
 func (c *Context) HasPrediction() bool {
 	return c.Prediction != nil && c.Prediction.Enabled
@@ -7,6 +7,7 @@ import (
 
 	"github.com/pflow-xyz/go-pflow/metamodel"
 	"github.com/pflow-xyz/petri-pilot/pkg/extensions"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/explore"
 )
 
 // Options configures the Go code generator.
@@ -23,9 +24,17 @@ type Options struct {
 	// If empty, will be inferred from model name.
 	PackageName string
 
-	// IncludeTests generates workflow_test.go if true.
+	// IncludeTests generates workflow_test.go if true, and scenarios_test.go
+	// replaying paths through the net against the generated aggregate.
 	IncludeTests bool
 
+	// ScenarioDepth bounds the simple paths and deadlock search behind
+	// scenarios_test.go. Zero uses explore.DefaultSearchDepth.
+	ScenarioDepth int
+
+	// Scenarios are user-supplied firing sequences added to scenarios_test.go.
+	Scenarios []explore.Scenario
+
 	// IncludeInfra generates migrations if true.
 	IncludeInfra bool
 
@@ -78,7 +87,7 @@ func (g *Generator) Generate(model *metamodel.Model) ([]string, error) {
 	}
 
 	// Validate model for code generation
-	if issues := metamodel.ValidateForCodegen(model); len(issues) > 0 {
+	if issues := validateForCodegen(model); len(issues) > 0 {
 		return nil, fmt.Errorf("model validation failed: %v", issues)
 	}
 
@@ -155,7 +164,7 @@ func (g *Generator) GenerateFromApp(app *extensions.ApplicationSpec) ([]string,
 // Useful for testing and preview functionality.
 func (g *Generator) GenerateFiles(model *metamodel.Model) ([]GeneratedFile, error) {
 	// Validate model for code generation
-	if issues := metamodel.ValidateForCodegen(model); len(issues) > 0 {
+	if issues := validateForCodegen(model); len(issues) > 0 {
 		return nil, fmt.Errorf("model validation failed: %v", issues)
 	}
 
@@ -187,6 +196,12 @@ func (g *Generator) GenerateFiles(model *metamodel.Model) ([]GeneratedFile, erro
 	}
 	if g.opts.IncludeTests {
 		templateNames = append(templateNames, TestTemplateNames()...)
+		if ctx.Scenarios, err = buildScenarioContexts(ctx, g.suiteOptions()); err != nil {
+			return nil, fmt.Errorf("building scenarios: %w", err)
+		}
+		if ctx.HasScenarios() {
+			templateNames = append(templateNames, ScenarioTemplateNames()...)
+		}
 	}
 	if g.opts.IncludeInfra {
 		templateNames = append(templateNames, InfraTemplateNames()...)
@@ -201,6 +216,7 @@ func (g *Generator) GenerateFiles(model *metamodel.Model) ([]GeneratedFile, erro
 		templateNames = append(templateNames, DeployTemplateNames()...)
 	}
 	if g.opts.IncludeRealtime {
+		ctx.Realtime = true
 		templateNames = append(templateNames, RealtimeTemplateNames()...)
 	}
 	
@@ -219,6 +235,21 @@ func (g *Generator) GenerateFiles(model *metamodel.Model) ([]GeneratedFile, erro
 		templateNames = append(templateNames, ViewTemplateNames()...)
 	}
 
+	// Include rate limits template if context has rate limits
+	if ctx.HasRateLimits() {
+		templateNames = append(templateNames, RateLimitTemplateNames()...)
+	}
+
+	// Include projections template if the read-model projection is enabled
+	if ctx.HasProjections() {
+		templateNames = append(templateNames, ProjectionTemplateNames()...)
+	}
+
+	// Include advice template if the model declares a simulation objective
+	if ctx.HasSimulation() {
+		templateNames = append(templateNames, AdviceTemplateNames()...)
+	}
+
 	// Include navigation template if context has navigation (Phase 14)
 	if ctx.HasNavigation() {
 		templateNames = append(templateNames, NavigationTemplateNames()...)
@@ -301,7 +332,7 @@ func (g *Generator) GenerateFilesFromApp(app *extensions.ApplicationSpec) ([]Gen
 	}
 
 	// Validate model for code generation
-	if issues := metamodel.ValidateForCodegen(app.Net); len(issues) > 0 {
+	if issues := validateForCodegen(app.Net); len(issues) > 0 {
 		return nil, fmt.Errorf("model validation failed: %v", issues)
 	}
 
@@ -330,6 +361,12 @@ func (g *Generator) GenerateFilesFromApp(app *extensions.ApplicationSpec) ([]Gen
 	}
 	if g.opts.IncludeTests {
 		templateNames = append(templateNames, TestTemplateNames()...)
+		if ctx.Scenarios, err = buildScenarioContexts(ctx, g.suiteOptions()); err != nil {
+			return nil, fmt.Errorf("building scenarios: %w", err)
+		}
+		if ctx.HasScenarios() {
+			templateNames = append(templateNames, ScenarioTemplateNames()...)
+		}
 	}
 	if g.opts.IncludeInfra {
 		templateNames = append(templateNames, InfraTemplateNames()...)
@@ -344,6 +381,7 @@ func (g *Generator) GenerateFilesFromApp(app *extensions.ApplicationSpec) ([]Gen
 		templateNames = append(templateNames, DeployTemplateNames()...)
 	}
 	if g.opts.IncludeRealtime {
+		ctx.Realtime = true
 		templateNames = append(templateNames, RealtimeTemplateNames()...)
 	}
 
@@ -357,6 +395,15 @@ func (g *Generator) GenerateFilesFromApp(app *extensions.ApplicationSpec) ([]Gen
 	if ctx.HasViews() {
 		templateNames = append(templateNames, ViewTemplateNames()...)
 	}
+	if ctx.HasRateLimits() {
+		templateNames = append(templateNames, RateLimitTemplateNames()...)
+	}
+	if ctx.HasProjections() {
+		templateNames = append(templateNames, ProjectionTemplateNames()...)
+	}
+	if ctx.HasSimulation() {
+		templateNames = append(templateNames, AdviceTemplateNames()...)
+	}
 	if ctx.HasNavigation() {
 		templateNames = append(templateNames, NavigationTemplateNames()...)
 	}
@@ -406,6 +453,14 @@ func (g *Generator) GenerateFilesFromApp(app *extensions.ApplicationSpec) ([]Gen
 	return files, nil
 }
 
+// suiteOptions returns the options for deriving scenario test cases.
+func (g *Generator) suiteOptions() explore.SuiteOptions {
+	return explore.SuiteOptions{
+		Depth:     g.opts.ScenarioDepth,
+		Scenarios: g.opts.Scenarios,
+	}
+}
+
 // Preview generates a preview of a single template without writing to disk.
 func (g *Generator) Preview(model *metamodel.Model, templateName string) ([]byte, error) {
 	ctx, err := NewContext(model, ContextOptions{
@@ -426,7 +481,14 @@ func (g *Generator) GetTemplates() *Templates {
 
 // ValidateModel checks if a model is suitable for Go code generation.
 func ValidateModel(model *metamodel.Model) []string {
-	return metamodel.ValidateForCodegen(model)
+	return validateForCodegen(model)
+}
+
+// validateForCodegen checks a model against go-pflow's codegen rules,
+// which know colored places as the token places that count them.
+func validateForCodegen(model *metamodel.Model) []string {
+	uncolored, _ := uncolorModel(model)
+	return metamodel.ValidateForCodegen(uncolored)
 }
 
 // GenerateToDir is a convenience function that creates a generator and writes files.
@@ -0,0 +1,332 @@
+package golang
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/extensions"
+	ppmetamodel "github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+func TestGenerateFiles_RealtimeFansOutPostgresNotifications(t *testing.T) {
+	data, err := os.ReadFile("../../../services/tic-tac-toe.json")
+	if err != nil {
+		t.Fatalf("failed to read model: %v", err)
+	}
+
+	var model metamodel.Model
+	if err := json.Unmarshal(data, &model); err != nil {
+		t.Fatalf("failed to parse model: %v", err)
+	}
+
+	gen, err := New(Options{
+		PackageName:     "main",
+		IncludeTests:    true,
+		IncludeRealtime: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create generator: %v", err)
+	}
+
+	files, err := gen.GenerateFiles(&model)
+	if err != nil {
+		t.Fatalf("failed to generate files: %v", err)
+	}
+
+	generated := make(map[string]string)
+	for _, file := range files {
+		generated[file.Name] = string(file.Content)
+	}
+
+	// main.go subscribes to the Postgres store and publishes to the broker
+	mainGo := generated["main.go"]
+	for _, want := range []string{
+		`events, err := pgStore.Subscribe(realtimeCtx, "")`,
+		"go broker.PublishEvents(realtimeCtx, app, events)",
+		"RegisterRealtimeRoutes(mux, broker)",
+		"stopRealtime()",
+	} {
+		if !strings.Contains(mainGo, want) {
+			t.Errorf("main.go missing %q", want)
+		}
+	}
+
+	if _, ok := generated["realtime.go"]; !ok {
+		t.Error("missing realtime.go")
+	}
+	if !strings.Contains(generated["workflow_test.go"], "func TestBrokerPublishesSubscribedEvents") {
+		t.Error("workflow_test.go missing the broker fan-out test")
+	}
+}
+
+func TestGenerateFiles_NoRealtimeWithoutOption(t *testing.T) {
+	data, err := os.ReadFile("../../../services/tic-tac-toe.json")
+	if err != nil {
+		t.Fatalf("failed to read model: %v", err)
+	}
+
+	var model metamodel.Model
+	if err := json.Unmarshal(data, &model); err != nil {
+		t.Fatalf("failed to parse model: %v", err)
+	}
+
+	gen, err := New(Options{PackageName: "main"})
+	if err != nil {
+		t.Fatalf("failed to create generator: %v", err)
+	}
+
+	files, err := gen.GenerateFiles(&model)
+	if err != nil {
+		t.Fatalf("failed to generate files: %v", err)
+	}
+
+	for _, file := range files {
+		if file.Name == "main.go" && strings.Contains(string(file.Content), "PublishEvents") {
+			t.Error("main.go publishes realtime events without IncludeRealtime")
+		}
+	}
+}
+
+func TestGenerateFilesFromApp_KeyedReadPolicy(t *testing.T) {
+	data, err := os.ReadFile("../../../services/tic-tac-toe.json")
+	if err != nil {
+		t.Fatalf("failed to read model: %v", err)
+	}
+
+	var model metamodel.Model
+	if err := json.Unmarshal(data, &model); err != nil {
+		t.Fatalf("failed to parse model: %v", err)
+	}
+
+	// Each player sees only their own hand
+	policies := extensions.NewPolicyExtension()
+	policies.AddReadPolicy(extensions.ReadPolicy{Field: "hands", Roles: []string{"admin"}, Key: "key == user.login"})
+	app := extensions.NewApplicationSpec(&model)
+	if err := app.WithPolicies(policies); err != nil {
+		t.Fatalf("failed to add policies: %v", err)
+	}
+
+	gen, err := New(Options{PackageName: "main", IncludeTests: true})
+	if err != nil {
+		t.Fatalf("failed to create generator: %v", err)
+	}
+
+	files, err := gen.GenerateFilesFromApp(app)
+	if err != nil {
+		t.Fatalf("failed to generate files: %v", err)
+	}
+
+	generated := make(map[string]string)
+	for _, file := range files {
+		generated[file.Name] = string(file.Content)
+	}
+
+	if want := `{target: readTarget{Field: "hands"}, roles: []string{"admin"}, key: "key == user.login"},`; !strings.Contains(generated["read_policies.go"], want) {
+		t.Errorf("read_policies.go missing %q", want)
+	}
+	if !strings.Contains(generated["workflow_test.go"], "func TestReadViewRedactsMapEntries") {
+		t.Error("workflow_test.go missing the map entry redaction test")
+	}
+}
+
+func TestGenerateFiles_ColoredGuardSeesDataPlaces(t *testing.T) {
+	// Orders ship only to customers with credit: a colored place and a data place
+	model := &metamodel.Model{
+		Name: "credit-orders",
+		Places: []metamodel.Place{
+			{ID: "pending", Kind: ppmetamodel.ColoredKind, Type: "{id string, customer string}"},
+			{ID: "shipped", Kind: ppmetamodel.ColoredKind, Type: "{id string}"},
+			{ID: "credit", Kind: metamodel.DataKind, Type: "map[string]int"},
+		},
+		Transitions: []metamodel.Transition{
+			{ID: "ship", Guard: "credit[order.customer] > 0"},
+		},
+		Arcs: []metamodel.Arc{
+			{From: "pending", To: "ship", Keys: []string{"id"}, Value: "order"},
+			{From: "ship", To: "shipped", Value: "order"},
+		},
+	}
+
+	gen, err := New(Options{PackageName: "main"})
+	if err != nil {
+		t.Fatalf("failed to create generator: %v", err)
+	}
+
+	files, err := gen.GenerateFiles(model)
+	if err != nil {
+		t.Fatalf("failed to generate files: %v", err)
+	}
+
+	var aggregate string
+	for _, file := range files {
+		if file.Name == "aggregate.go" {
+			aggregate = string(file.Content)
+		}
+	}
+
+	// The runtime evaluates the guard while binding, so it must see the data place
+	if want := `a.rt.Snapshot.Data["credit"] = state.Credit`; !strings.Contains(aggregate, want) {
+		t.Errorf("aggregate.go missing %q", want)
+	}
+	if !strings.Contains(aggregate, `Guard: "credit[order.customer] > 0"`) {
+		t.Error("aggregate.go does not hand the colored guard to the runtime")
+	}
+}
+
+func TestGenerateFiles_RoleAssignments(t *testing.T) {
+	data, err := os.ReadFile("../../../services/texas-holdem.json")
+	if err != nil {
+		t.Fatalf("failed to read model: %v", err)
+	}
+
+	var model metamodel.Model
+	if err := json.Unmarshal(data, &model); err != nil {
+		t.Fatalf("failed to parse model: %v", err)
+	}
+
+	roles := extensions.NewRoleExtension()
+	roles.AddRole(extensions.Role{ID: "dealer", Name: "Dealer", Description: `Deals the "flop"`})
+	roles.AddRole(extensions.Role{ID: "admin", Name: "Admin", Inherits: []string{"dealer"}})
+	app := extensions.NewApplicationSpec(&model)
+	if err := app.WithRoles(roles); err != nil {
+		t.Fatalf("failed to add roles: %v", err)
+	}
+
+	gen, err := New(Options{PackageName: "main", IncludeTests: true})
+	if err != nil {
+		t.Fatalf("failed to create generator: %v", err)
+	}
+
+	files, err := gen.GenerateFilesFromApp(app)
+	if err != nil {
+		t.Fatalf("failed to generate files: %v", err)
+	}
+
+	generated := make(map[string]string)
+	for _, file := range files {
+		generated[file.Name] = string(file.Content)
+	}
+
+	// Grants reach role checks only through the middleware's registry
+	if !strings.Contains(generated["api.go"], "r.Use(OptionalAuthMiddleware(sessions), middleware.AssignRoles)") {
+		t.Error("api.go does not merge persisted grants into the request's user")
+	}
+	if strings.Contains(generated["permissions.go"], "UseRoleAssignments") {
+		t.Error("permissions.go keeps a package-level role assignment registry")
+	}
+
+	// Without debug there is no way to mint a session with arbitrary roles
+	if strings.Contains(generated["api.go"], "/api/debug/login") {
+		t.Error("api.go serves the test login without debug enabled")
+	}
+
+	if want := `"description": "Deals the \"flop\""`; !strings.Contains(generated["role_assignments.go"], want) {
+		t.Errorf("role_assignments.go missing %q", want)
+	}
+	for _, test := range []string{"func TestRoleAssignmentsGrantRevokeAudit", "func TestRoleAssignmentsAuthorize"} {
+		if !strings.Contains(generated["workflow_test.go"], test) {
+			t.Errorf("workflow_test.go missing %s", test)
+		}
+	}
+}
+
+func TestGenerate_WorkflowsResolveModelTransitions(t *testing.T) {
+	data, err := os.ReadFile("../../../services/tic-tac-toe.json")
+	if err != nil {
+		t.Fatalf("failed to read model: %v", err)
+	}
+	var model metamodel.Model
+	if err := json.Unmarshal(data, &model); err != nil {
+		t.Fatalf("failed to parse model: %v", err)
+	}
+
+	// The entity names the model, not the Go package, and the scoring
+	// step reaches another entity
+	ctx, err := NewContext(&model, ContextOptions{
+		PackageName: "tictactoe",
+		Workflows: []WorkflowContext{{
+			ID:          "reply",
+			TriggerType: "event",
+			Trigger:     WorkflowTriggerContext{Type: "event", Entity: "tic-tac-toe", Action: "x_play_00"},
+			Steps: []WorkflowStepContext{
+				{ID: "answer", Type: "action", Entity: "tic-tac-toe", Action: "o_play_11", Compensate: "reset"},
+				{ID: "score", Type: "action", Entity: "league", Action: "record"},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to build context: %v", err)
+	}
+	gen, err := New(Options{PackageName: "tictactoe"})
+	if err != nil {
+		t.Fatalf("failed to create generator: %v", err)
+	}
+	render := func(name string) string {
+		t.Helper()
+		out, err := gen.GetTemplates().Execute(name, ctx)
+		if err != nil {
+			t.Fatalf("failed to render %s: %v", name, err)
+		}
+		return string(out)
+	}
+
+	workflows := render(TemplateWorkflows)
+	for _, want := range []string{
+		`r.RegisterHandler("o_play_11", workflowTransitionHandler(app, "o_play_11"))`,
+		`Action: "o_play_11",`,
+		`Compensate: "reset",`,
+		`Action: "league.record",`,
+		`if action == "x_play_00" {`,
+		`app.onFired = r.fired`,
+	} {
+		if !strings.Contains(workflows, want) {
+			t.Errorf("workflows.go missing %q", want)
+		}
+	}
+	if want := "app.onFired(ctx, id, transitionID, event)"; !strings.Contains(render(TemplateAggregate), want) {
+		t.Errorf("aggregate.go missing %q", want)
+	}
+}
+
+func TestGenerate_SequenceHonorsStaticAccessRules(t *testing.T) {
+	data, err := os.ReadFile("../../../services/tic-tac-toe.json")
+	if err != nil {
+		t.Fatalf("failed to read model: %v", err)
+	}
+	var model metamodel.Model
+	if err := json.Unmarshal(data, &model); err != nil {
+		t.Fatalf("failed to parse model: %v", err)
+	}
+
+	ctx, err := NewContext(&model, ContextOptions{
+		PackageName: "tictactoe",
+		AccessRules: []AccessRuleContext{{TransitionID: "reset", Roles: []string{"admin"}}},
+	})
+	if err != nil {
+		t.Fatalf("failed to build context: %v", err)
+	}
+	gen, err := New(Options{PackageName: "tictactoe"})
+	if err != nil {
+		t.Fatalf("failed to create generator: %v", err)
+	}
+	out, err := gen.GetTemplates().Execute(TemplateAPI, ctx)
+	if err != nil {
+		t.Fatalf("failed to render api: %v", err)
+	}
+
+	// HTTP and GraphQL sequences share authorizeSequenceStep, so a rule
+	// without dynamic roles must be checked there too
+	api := string(out)
+	for _, want := range []string{
+		"case TransitionReset:\n\t\t\treturn CheckAccessReset(user, accessState(agg, id))",
+		"case errors.Is(err, ErrForbidden):",
+		"case errors.Is(err, ErrStoreFailed):",
+	} {
+		if !strings.Contains(api, want) {
+			t.Errorf("api.go missing %q", want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package golang
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
 )
@@ -154,3 +155,12 @@ func SanitizeModulePath(name, defaultBase string) string {
 	}
 	return defaultBase + "/" + name
 }
+
+// ToStringLiteral returns s as a Go string literal, using a raw string
+// when possible so embedded JSON stays readable.
+func ToStringLiteral(s string) string {
+	if strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
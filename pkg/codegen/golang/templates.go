@@ -22,15 +22,19 @@ const (
 	TemplateAPI       = "api"
 	TemplateOpenAPI   = "openapi"
 	TemplateTest      = "test"
+	TemplateScenarios = "scenarios"
 
 	// Infrastructure templates (Phase 7)
-	TemplateConfig     = "config"
-	TemplateMigrations = "migrations"
+	TemplateConfig             = "config"
+	TemplateMigrations         = "migrations"
+	TemplateMigrationsPostgres = "migrations_postgres"
 
 	// Auth templates (Phase 9)
-	TemplateAuth        = "auth"
-	TemplateMiddleware  = "middleware"
-	TemplatePermissions = "permissions"
+	TemplateAuth            = "auth"
+	TemplateMiddleware      = "middleware"
+	TemplatePermissions     = "permissions"
+	TemplateRoleAssignments = "role_assignments"
+	TemplateReadPolicies    = "read_policies"
 
 	// Observability templates (Phase 10)
 	TemplateObservability = "observability"
@@ -52,6 +56,15 @@ const (
 	// Views templates (Phase 13)
 	TemplateViews = "views"
 
+	// Rate limit templates
+	TemplateRateLimits = "rate_limits"
+
+	// Read-model projection templates
+	TemplateProjections = "projections"
+
+	// Move advice templates
+	TemplateAdvice = "advice"
+
 	// Navigation templates (Phase 14)
 	TemplateNavigation = "navigation"
 
@@ -103,15 +116,19 @@ var templateInfo = map[string]struct {
 	TemplateAPI:       {File: "api.tmpl", Output: "api.go"},
 	TemplateOpenAPI:   {File: "openapi.tmpl", Output: "openapi.yaml"},
 	TemplateTest:      {File: "test.tmpl", Output: "workflow_test.go"},
+	TemplateScenarios: {File: "scenarios.tmpl", Output: "scenarios_test.go"},
 
 	// Infrastructure templates
-	TemplateConfig:     {File: "config.tmpl", Output: "config.go"},
-	TemplateMigrations: {File: "migrations.tmpl", Output: "migrations/001_init.sql"},
+	TemplateConfig:             {File: "config.tmpl", Output: "config.go"},
+	TemplateMigrations:         {File: "migrations.tmpl", Output: "migrations/001_init.sql"},
+	TemplateMigrationsPostgres: {File: "migrations_postgres.tmpl", Output: "migrations/postgres/001_init.sql"},
 
 	// Auth templates
-	TemplateAuth:        {File: "auth.tmpl", Output: "auth.go"},
-	TemplateMiddleware:  {File: "middleware.tmpl", Output: "middleware.go"},
-	TemplatePermissions: {File: "permissions.tmpl", Output: "permissions.go"},
+	TemplateAuth:            {File: "auth.tmpl", Output: "auth.go"},
+	TemplateMiddleware:      {File: "middleware.tmpl", Output: "middleware.go"},
+	TemplatePermissions:     {File: "permissions.tmpl", Output: "permissions.go"},
+	TemplateRoleAssignments: {File: "role_assignments.tmpl", Output: "role_assignments.go"},
+	TemplateReadPolicies:    {File: "read_policies.tmpl", Output: "read_policies.go"},
 
 	// Observability templates
 	TemplateObservability: {File: "observability.tmpl", Output: "observability.go"},
@@ -133,6 +150,15 @@ var templateInfo = map[string]struct {
 	// Views templates (Phase 13)
 	TemplateViews: {File: "views.tmpl", Output: "views.go"},
 
+	// Rate limit templates
+	TemplateRateLimits: {File: "rate_limits.tmpl", Output: "rate_limits.go"},
+
+	// Read-model projection templates
+	TemplateProjections: {File: "projections.tmpl", Output: "projections.go"},
+
+	// Move advice templates
+	TemplateAdvice: {File: "advice.tmpl", Output: "advice.go"},
+
 	// Navigation templates (Phase 14)
 	TemplateNavigation: {File: "navigation.tmpl", Output: "navigation.go"},
 
@@ -189,6 +215,7 @@ func NewTemplates() (*Templates, error) {
 		"typeName":    ToTypeName,
 		"sanitize":    SanitizePackageName,
 		"graphqlType": GoTypeToGraphQL,
+		"literal":     ToStringLiteral,
 		"lower":       strings.ToLower,
 		"upper":       strings.ToUpper,
 	}
@@ -243,6 +270,7 @@ func AllTemplateNames() []string {
 		TemplateTest,
 		TemplateConfig,
 		TemplateMigrations,
+		TemplateMigrationsPostgres,
 	}
 }
 
@@ -280,6 +308,8 @@ func AuthTemplateNames() []string {
 		TemplateAuth,
 		TemplateMiddleware,
 		TemplatePermissions,
+		TemplateRoleAssignments,
+		TemplateReadPolicies,
 	}
 }
 
@@ -327,6 +357,27 @@ func ViewTemplateNames() []string {
 	}
 }
 
+// RateLimitTemplateNames returns template names for rate limiting files.
+func RateLimitTemplateNames() []string {
+	return []string{
+		TemplateRateLimits,
+	}
+}
+
+// ProjectionTemplateNames returns template names for read-model projection files.
+func ProjectionTemplateNames() []string {
+	return []string{
+		TemplateProjections,
+	}
+}
+
+// AdviceTemplateNames returns template names for move advice files.
+func AdviceTemplateNames() []string {
+	return []string{
+		TemplateAdvice,
+	}
+}
+
 // TestTemplateNames returns template names that generate test files.
 func TestTemplateNames() []string {
 	return []string{
@@ -334,10 +385,18 @@ func TestTemplateNames() []string {
 	}
 }
 
+// ScenarioTemplateNames returns template names for scenario regression tests.
+func ScenarioTemplateNames() []string {
+	return []string{
+		TemplateScenarios,
+	}
+}
+
 // InfraTemplateNames returns template names for infrastructure files.
 func InfraTemplateNames() []string {
 	return []string{
 		TemplateMigrations,
+		TemplateMigrationsPostgres,
 	}
 }
 
@@ -369,6 +369,22 @@ func EvaluateObjective(expr string, marking Marking) (float64, error) {
 	return EvaluateNumeric(expr, bindings, funcs)
 }
 
+// EvaluateObjectiveContinuous evaluates an objective expression against a
+// continuous marking, such as the final state of an ODE simulation.
+// Place values and the aggregate functions see fractional token counts.
+func EvaluateObjectiveContinuous(expr string, state map[string]float64) (float64, error) {
+	if expr == "" {
+		return 0, fmt.Errorf("empty objective expression")
+	}
+
+	bindings := make(map[string]any, len(state))
+	for placeID, value := range state {
+		bindings[placeID] = value
+	}
+
+	return EvaluateNumeric(expr, bindings, MakeContinuousAggregates(state))
+}
+
 // Marking is a type alias for token state values.
 type Marking map[string]int
 
@@ -403,6 +419,90 @@ func MakeAggregates(marking Marking) map[string]GuardFunc {
 	}
 }
 
+// MakeContinuousAggregates creates the sum, count, tokens, minOf and maxOf
+// functions over a continuous marking.
+func MakeContinuousAggregates(state map[string]float64) map[string]GuardFunc {
+	matching := func(name string, args []any) ([]float64, error) {
+		if len(args) < 1 {
+			return nil, fmt.Errorf("%s requires 1 argument (place prefix)", name)
+		}
+		prefix, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("%s argument must be a string, got %T", name, args[0])
+		}
+		var values []float64
+		for placeID, value := range state {
+			if strings.HasPrefix(placeID, prefix) {
+				values = append(values, value)
+			}
+		}
+		return values, nil
+	}
+
+	return map[string]GuardFunc{
+		"sum": func(args ...any) (any, error) {
+			values, err := matching("sum", args)
+			if err != nil {
+				return nil, err
+			}
+			var total float64
+			for _, v := range values {
+				total += v
+			}
+			return total, nil
+		},
+		"count": func(args ...any) (any, error) {
+			values, err := matching("count", args)
+			if err != nil {
+				return nil, err
+			}
+			var count int64
+			for _, v := range values {
+				if v > 0 {
+					count++
+				}
+			}
+			return count, nil
+		},
+		"tokens": func(args ...any) (any, error) {
+			if len(args) < 1 {
+				return nil, fmt.Errorf("tokens requires 1 argument (place ID)")
+			}
+			placeID, ok := args[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("tokens argument must be a string, got %T", args[0])
+			}
+			return state[placeID], nil
+		},
+		"minOf": func(args ...any) (any, error) {
+			values, err := matching("minOf", args)
+			if err != nil {
+				return nil, err
+			}
+			var minVal float64
+			for i, v := range values {
+				if i == 0 || v < minVal {
+					minVal = v
+				}
+			}
+			return minVal, nil
+		},
+		"maxOf": func(args ...any) (any, error) {
+			values, err := matching("maxOf", args)
+			if err != nil {
+				return nil, err
+			}
+			var maxVal float64
+			for _, v := range values {
+				if v > maxVal {
+					maxVal = v
+				}
+			}
+			return maxVal, nil
+		},
+	}
+}
+
 // makeSumFunc returns a function that sums all values in places matching a prefix.
 // Usage: sum("balances") - sums all places starting with "balances"
 func makeSumFunc(marking Marking) GuardFunc {
@@ -251,6 +251,33 @@ func TestEvaluateObjective(t *testing.T) {
 	}
 }
 
+func TestEvaluateObjectiveContinuous(t *testing.T) {
+	state := map[string]float64{"win_x": 0.75, "win_o": 0.25, "score_a": 1.5, "score_b": 0.5}
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"win_x - win_o", 0.5},
+		{"sum('score')", 2.0},
+		{"tokens('win_x') * 2", 1.5},
+		{"maxOf('score') - minOf('score')", 1.0},
+		{"count('win')", 2},
+	}
+	for _, tt := range tests {
+		got, err := EvaluateObjectiveContinuous(tt.expr, state)
+		if err != nil {
+			t.Errorf("%s: %v", tt.expr, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 0.0001 {
+			t.Errorf("%s = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+	if _, err := EvaluateObjectiveContinuous("", state); err == nil {
+		t.Error("expected an error for an empty objective")
+	}
+}
+
 func TestEvaluateNumeric(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -28,6 +28,7 @@ import (
 	"encoding/json"
 
 	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
 )
 
 // ApplicationSpec represents a complete application specification.
@@ -41,6 +42,12 @@ type ApplicationSpec struct {
 
 	// GraphQL configuration (not an extension, simple config)
 	GraphQLConfig *goflowmodel.GraphQLConfig `json:"graphql,omitempty"`
+
+	// Prediction configuration (not an extension, simple config)
+	PredictionConfig *goflowmodel.PredictionConfig `json:"prediction,omitempty"`
+
+	// Initial tokens of colored places, by place ID (not an extension, simple config)
+	ColoredTokens map[string][]metamodel.Token `json:"tokens,omitempty"`
 }
 
 // NewApplicationSpec creates a new ApplicationSpec from a model.
@@ -104,6 +111,33 @@ func (a *ApplicationSpec) Views() *ViewExtension {
 	return ext.(*ViewExtension)
 }
 
+// Policies returns the policy extension, or nil if not present.
+func (a *ApplicationSpec) Policies() *PolicyExtension {
+	ext := a.GetExtension(PoliciesExtensionName)
+	if ext == nil {
+		return nil
+	}
+	return ext.(*PolicyExtension)
+}
+
+// Projections returns the projection extension, or nil if not present.
+func (a *ApplicationSpec) Projections() *ProjectionExtension {
+	ext := a.GetExtension(ProjectionsExtensionName)
+	if ext == nil {
+		return nil
+	}
+	return ext.(*ProjectionExtension)
+}
+
+// Simulation returns the simulation extension, or nil if not present.
+func (a *ApplicationSpec) Simulation() *SimulationExtension {
+	ext := a.GetExtension(SimulationExtensionName)
+	if ext == nil {
+		return nil
+	}
+	return ext.(*SimulationExtension)
+}
+
 // HasEntities returns true if the application has entities.
 func (a *ApplicationSpec) HasEntities() bool {
 	return a.Entities() != nil && len(a.Entities().Entities) > 0
@@ -129,6 +163,27 @@ func (a *ApplicationSpec) HasViews() bool {
 	return a.Views() != nil && len(a.Views().Views) > 0
 }
 
+// HasReadPolicies returns true if the application has read policies.
+func (a *ApplicationSpec) HasReadPolicies() bool {
+	return a.Policies() != nil && len(a.Policies().ReadPolicies) > 0
+}
+
+// HasRateLimits returns true if the application has rate limits.
+func (a *ApplicationSpec) HasRateLimits() bool {
+	p := a.Policies()
+	return p != nil && p.RateLimits != nil && len(p.RateLimits.Rules) > 0
+}
+
+// HasProjections returns true if the read-model projection is enabled.
+func (a *ApplicationSpec) HasProjections() bool {
+	return a.Projections() != nil && a.Projections().Enabled
+}
+
+// HasSimulation returns true if the application declares a simulation objective.
+func (a *ApplicationSpec) HasSimulation() bool {
+	return a.Simulation() != nil && a.Simulation().Objective != ""
+}
+
 // HasAdmin returns true if admin is enabled.
 func (a *ApplicationSpec) HasAdmin() bool {
 	views := a.Views()
@@ -151,6 +206,16 @@ func (a *ApplicationSpec) HasGraphQL() bool {
 	return a.GraphQLConfig != nil && a.GraphQLConfig.Enabled
 }
 
+// HasPrediction returns true if ODE prediction is enabled.
+func (a *ApplicationSpec) HasPrediction() bool {
+	return a.PredictionConfig != nil && a.PredictionConfig.Enabled
+}
+
+// HasInitialTokens returns true if any colored place starts with tokens.
+func (a *ApplicationSpec) HasInitialTokens() bool {
+	return len(a.ColoredTokens) > 0
+}
+
 // Debug returns the debug config.
 func (a *ApplicationSpec) Debug() *goflowmodel.Debug {
 	return a.DebugConfig
@@ -161,6 +226,11 @@ func (a *ApplicationSpec) GraphQL() *goflowmodel.GraphQLConfig {
 	return a.GraphQLConfig
 }
 
+// Prediction returns the prediction config.
+func (a *ApplicationSpec) Prediction() *goflowmodel.PredictionConfig {
+	return a.PredictionConfig
+}
+
 // SetDebug sets the debug config.
 func (a *ApplicationSpec) SetDebug(debug *goflowmodel.Debug) {
 	a.DebugConfig = debug
@@ -171,6 +241,21 @@ func (a *ApplicationSpec) SetGraphQL(graphql *goflowmodel.GraphQLConfig) {
 	a.GraphQLConfig = graphql
 }
 
+// SetPrediction sets the prediction config.
+func (a *ApplicationSpec) SetPrediction(prediction *goflowmodel.PredictionConfig) {
+	a.PredictionConfig = prediction
+}
+
+// InitialTokens returns the initial tokens of colored places.
+func (a *ApplicationSpec) InitialTokens() map[string][]metamodel.Token {
+	return a.ColoredTokens
+}
+
+// SetInitialTokens sets the initial tokens of colored places.
+func (a *ApplicationSpec) SetInitialTokens(tokens map[string][]metamodel.Token) {
+	a.ColoredTokens = tokens
+}
+
 // WithEntities adds or replaces the entity extension.
 func (a *ApplicationSpec) WithEntities(entities *EntityExtension) error {
 	return a.AddExtension(entities)
@@ -196,6 +281,21 @@ func (a *ApplicationSpec) WithViews(views *ViewExtension) error {
 	return a.AddExtension(views)
 }
 
+// WithPolicies adds or replaces the policy extension.
+func (a *ApplicationSpec) WithPolicies(policies *PolicyExtension) error {
+	return a.AddExtension(policies)
+}
+
+// WithProjections adds or replaces the projection extension.
+func (a *ApplicationSpec) WithProjections(projections *ProjectionExtension) error {
+	return a.AddExtension(projections)
+}
+
+// WithSimulation adds or replaces the simulation extension.
+func (a *ApplicationSpec) WithSimulation(simulation *SimulationExtension) error {
+	return a.AddExtension(simulation)
+}
+
 // ToJSON serializes the application spec to JSON.
 func (a *ApplicationSpec) ToJSON() ([]byte, error) {
 	return json.MarshalIndent(a.ExtendedModel, "", "  ")
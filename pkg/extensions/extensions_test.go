@@ -3,8 +3,10 @@ package extensions
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
 )
 
 func TestEntityExtension(t *testing.T) {
@@ -164,6 +166,197 @@ func TestRoleExtension(t *testing.T) {
 	})
 }
 
+func TestPolicyExtension(t *testing.T) {
+	model := &goflowmodel.Model{
+		Places: []goflowmodel.Place{
+			{ID: "hole_cards"},
+		},
+	}
+
+	t.Run("valid policies", func(t *testing.T) {
+		ext := NewPolicyExtension()
+		ext.AddReadPolicy(ReadPolicy{Place: "hole_cards", Roles: []string{"dealer"}})
+		ext.AddReadPolicy(ReadPolicy{Field: "salary", When: "user.login == owner"})
+		ext.AddReadPolicy(ReadPolicy{Event: "CardsDealt", Field: "cards", Roles: []string{"dealer"}})
+		ext.AddReadPolicy(ReadPolicy{Field: "hands", Key: "key == user.login"})
+
+		if err := ext.Validate(model); err != nil {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	})
+
+	t.Run("validation missing target", func(t *testing.T) {
+		ext := NewPolicyExtension()
+		ext.AddReadPolicy(ReadPolicy{Roles: []string{"admin"}})
+
+		if err := ext.Validate(model); err == nil {
+			t.Error("expected validation error for missing target")
+		}
+	})
+
+	t.Run("validation missing grant", func(t *testing.T) {
+		ext := NewPolicyExtension()
+		ext.AddReadPolicy(ReadPolicy{Field: "salary"})
+
+		if err := ext.Validate(model); err == nil {
+			t.Error("expected validation error for missing roles and when")
+		}
+	})
+
+	t.Run("validation key without state field", func(t *testing.T) {
+		ext := NewPolicyExtension()
+		ext.AddReadPolicy(ReadPolicy{Event: "CardsDealt", Field: "cards", Key: "key == user.login"})
+
+		if err := ext.Validate(model); err == nil {
+			t.Error("expected validation error for key on an event field")
+		}
+	})
+
+	t.Run("validation unknown place", func(t *testing.T) {
+		ext := NewPolicyExtension()
+		ext.AddReadPolicy(ReadPolicy{Place: "nowhere", Roles: []string{"admin"}})
+
+		if err := ext.Validate(model); err == nil {
+			t.Error("expected validation error for unknown place")
+		}
+	})
+
+	t.Run("rate limits", func(t *testing.T) {
+		ext := NewPolicyExtension()
+		ext.AddRateLimit(RateLimit{Transition: RateLimitCreate, Role: "guest", Limit: 10, Per: "minute"})
+		ext.AddRateLimit(RateLimit{Limit: 1000, Per: "24h", Global: true})
+
+		if err := ext.Validate(model); err != nil {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+		if w, _ := ext.RateLimits.Rules[1].Window(); w != 24*time.Hour {
+			t.Errorf("expected 24h window, got %v", w)
+		}
+	})
+
+	t.Run("validation invalid rate limit", func(t *testing.T) {
+		ext := NewPolicyExtension()
+		ext.AddRateLimit(RateLimit{Transition: "*", Limit: 5, Per: "fortnight"})
+
+		if err := ext.Validate(model); err == nil {
+			t.Error("expected validation error for invalid window")
+		}
+	})
+
+	t.Run("JSON roundtrip", func(t *testing.T) {
+		ext := NewPolicyExtension()
+		ext.AddReadPolicy(ReadPolicy{Event: "CardsDealt", Field: "cards", When: "user.login == player"})
+		ext.AddRateLimit(RateLimit{Role: "guest", Limit: 10, Per: "minute"})
+
+		data, err := json.Marshal(ext)
+		if err != nil {
+			t.Fatalf("marshal error: %v", err)
+		}
+
+		parsed := NewPolicyExtension()
+		if err := json.Unmarshal(data, parsed); err != nil {
+			t.Fatalf("unmarshal error: %v", err)
+		}
+		if len(parsed.ReadPolicies) != 1 || parsed.ReadPolicies[0].Field != "cards" {
+			t.Errorf("unexpected policies after roundtrip: %+v", parsed.ReadPolicies)
+		}
+		if parsed.RateLimits == nil || len(parsed.RateLimits.Rules) != 1 {
+			t.Errorf("unexpected rate limits after roundtrip: %+v", parsed.RateLimits)
+		}
+	})
+}
+
+func TestProjectionExtension(t *testing.T) {
+	model := &goflowmodel.Model{}
+
+	t.Run("defaults", func(t *testing.T) {
+		ext := NewProjectionExtension()
+		if err := ext.Validate(model); err != nil {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+		if d, _ := ext.PollInterval(); d != time.Second {
+			t.Errorf("expected 1s poll interval, got %v", d)
+		}
+	})
+
+	t.Run("validation invalid poll", func(t *testing.T) {
+		ext := NewProjectionExtension()
+		ext.Poll = "often"
+
+		if err := ext.Validate(model); err == nil {
+			t.Error("expected validation error for invalid poll interval")
+		}
+	})
+
+	t.Run("JSON roundtrip", func(t *testing.T) {
+		ext := NewProjectionExtension()
+		ext.Database = "read.db"
+		ext.Poll = "250ms"
+		ext.BatchSize = 100
+
+		data, err := json.Marshal(ext)
+		if err != nil {
+			t.Fatalf("marshal error: %v", err)
+		}
+
+		parsed := &ProjectionExtension{}
+		if err := json.Unmarshal(data, parsed); err != nil {
+			t.Fatalf("unmarshal error: %v", err)
+		}
+		if !parsed.Enabled || parsed.Database != "read.db" || parsed.Poll != "250ms" || parsed.BatchSize != 100 {
+			t.Errorf("unexpected settings after roundtrip: %+v", parsed)
+		}
+	})
+}
+
+func TestSimulationExtension(t *testing.T) {
+	model := &goflowmodel.Model{
+		Places:      []goflowmodel.Place{{ID: "x_turn"}, {ID: "win_x"}},
+		Transitions: []goflowmodel.Transition{{ID: "x_play"}},
+	}
+
+	t.Run("parses the model simulation block", func(t *testing.T) {
+		ext := NewSimulationExtension()
+		data := `{"objective":"win_x","players":{"x":{"maximizes":true,"turnPlace":"x_turn","transitions":["x_play"]}},"solver":{"tspan":[0,5]},"advisor":{"method":"hybrid","depth":3}}`
+		if err := json.Unmarshal([]byte(data), ext); err != nil {
+			t.Fatalf("unmarshal error: %v", err)
+		}
+		if err := ext.Validate(model); err != nil {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+		if ext.Objective != "win_x" || !ext.Players["x"].Maximizes || ext.Solver.Tspan[1] != 5 || ext.Advisor.Depth != 3 {
+			t.Errorf("unexpected settings: %+v", ext)
+		}
+
+		out, err := json.Marshal(ext)
+		if err != nil {
+			t.Fatalf("marshal error: %v", err)
+		}
+		parsed := NewSimulationExtension()
+		if err := json.Unmarshal(out, parsed); err != nil || parsed.Advisor.Method != "hybrid" || parsed.Players["x"].TurnPlace != "x_turn" {
+			t.Errorf("unexpected settings after roundtrip: %+v, %v", parsed, err)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		for name, ext := range map[string]*SimulationExtension{
+			"missing objective": {},
+			"bad objective":     {Simulation: metamodel.Simulation{Objective: "win_x -"}},
+			"unknown turn place": {Simulation: metamodel.Simulation{Objective: "win_x", Players: map[string]metamodel.Player{
+				"x": {TurnPlace: "y_turn"},
+			}}},
+			"unknown transition": {Simulation: metamodel.Simulation{Objective: "win_x", Players: map[string]metamodel.Player{
+				"x": {Transitions: []string{"y_play"}},
+			}}},
+			"bad method": {Simulation: metamodel.Simulation{Objective: "win_x"}, Advisor: &AdvisorConfig{Method: "random"}},
+		} {
+			if err := ext.Validate(model); err == nil {
+				t.Errorf("%s: expected validation error", name)
+			}
+		}
+	})
+}
+
 func TestPageExtension(t *testing.T) {
 	t.Run("basic page", func(t *testing.T) {
 		ext := NewPageExtension()
@@ -276,6 +469,23 @@ func TestWorkflowExtension(t *testing.T) {
 			t.Error("expected validation error for unknown step reference")
 		}
 	})
+
+	t.Run("validation unknown parallel step", func(t *testing.T) {
+		ext := NewWorkflowExtension()
+		ext.AddWorkflow(Workflow{
+			ID:      "wf1",
+			Trigger: WorkflowTrigger{Type: "manual"},
+			Steps: []WorkflowStep{
+				{ID: "notify", Type: "parallel", Parallel: []string{"email", "sms"}},
+				{ID: "email", Type: "action", Entity: "user", Action: "email", Compensate: "unemail"},
+			},
+		})
+
+		err := ext.Validate(&goflowmodel.Model{})
+		if err == nil {
+			t.Error("expected validation error for unknown parallel step")
+		}
+	})
 }
 
 func TestViewExtension(t *testing.T) {
@@ -493,6 +703,7 @@ func TestExtensionRegistration(t *testing.T) {
 		PagesExtensionName,
 		WorkflowsExtensionName,
 		ViewsExtensionName,
+		PoliciesExtensionName,
 	}
 
 	for _, name := range tests {
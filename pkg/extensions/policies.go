@@ -0,0 +1,198 @@
+package extensions
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+)
+
+const (
+	// PoliciesExtensionName is the extension name for read policies.
+	PoliciesExtensionName = "petri-pilot/policies"
+)
+
+// PolicyExtension adds attribute-based read policies and rate limits to a
+// Petri net model. Read policies decide, per viewer and aggregate, which
+// places, state fields and event fields are visible; anything a policy does
+// not allow is redacted. Rate limits throttle how often callers may fire
+// transitions.
+type PolicyExtension struct {
+	goflowmodel.BaseExtension
+	ReadPolicies []ReadPolicy `json:"read"`
+	RateLimits   *RateLimits  `json:"rateLimits,omitempty"`
+}
+
+// ReadPolicy restricts who can see part of an aggregate.
+// Place selects a token place, Field a data-state field, and Event an event
+// type (or the transition that emits it). Event may be combined with Field
+// to redact a single payload field instead of the whole payload.
+//
+// A viewer may read the target if they hold one of Roles (static, assigned
+// or dynamically granted) or if the When expression evaluates to true
+// against the viewer and the current aggregate state.
+//
+// Key restricts a map-valued state field entry by entry: a viewer the
+// policy does not otherwise allow sees the entries for which the Key
+// expression, with key bound to the entry's key, evaluates to true, e.g.
+// "key == user.login" for a map of per-player hands.
+type ReadPolicy struct {
+	Place string   `json:"place,omitempty"`
+	Field string   `json:"field,omitempty"`
+	Event string   `json:"event,omitempty"`
+	Roles []string `json:"roles,omitempty"` // Roles that may read the target
+	When  string   `json:"when,omitempty"`  // Expression granting read access
+	Key   string   `json:"key,omitempty"`   // Expression granting read access per map entry
+}
+
+// RateLimitCreate is the transition name rate limits use for instance creation.
+const RateLimitCreate = "create"
+
+// RateLimits configures token-bucket throttling of transitions.
+type RateLimits struct {
+	Store string      `json:"store,omitempty"` // "memory" (default) or "sql"
+	Rules []RateLimit `json:"rules"`
+}
+
+// RateLimit allows Limit requests per Per window, e.g. "guest may fire
+// create 10/min". Every matching rule is enforced with its own bucket.
+type RateLimit struct {
+	Transition string `json:"transition,omitempty"` // Transition ID, "create", or "*"/empty for all
+	Role       string `json:"role,omitempty"`       // Only callers with this role; empty for everyone. "guest" and "anonymous" also match callers who are not logged in
+	Limit      int    `json:"limit"`                // Requests allowed per window
+	Per        string `json:"per"`                  // Window: "second", "minute", "hour", "day" or a duration like "15m"
+	Burst      int    `json:"burst,omitempty"`      // Bucket capacity (default: limit)
+	Global     bool   `json:"global,omitempty"`     // Share one bucket across all callers
+}
+
+// Window returns the rate limit window as a duration.
+func (r RateLimit) Window() (time.Duration, error) {
+	switch r.Per {
+	case "second", "sec", "s":
+		return time.Second, nil
+	case "minute", "min", "m":
+		return time.Minute, nil
+	case "hour", "h":
+		return time.Hour, nil
+	case "day", "d":
+		return 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(r.Per)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window %q", r.Per)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("window must be positive: %q", r.Per)
+	}
+	return d, nil
+}
+
+// NewPolicyExtension creates a new PolicyExtension.
+func NewPolicyExtension() *PolicyExtension {
+	return &PolicyExtension{
+		BaseExtension: goflowmodel.NewBaseExtension(PoliciesExtensionName),
+		ReadPolicies:  make([]ReadPolicy, 0),
+	}
+}
+
+// Validate checks that every read policy has a single target and a grant.
+func (p *PolicyExtension) Validate(model *goflowmodel.Model) error {
+	placeIDs := make(map[string]bool)
+	for _, place := range model.Places {
+		placeIDs[place.ID] = true
+	}
+
+	for i, policy := range p.ReadPolicies {
+		switch {
+		case policy.Place == "" && policy.Field == "" && policy.Event == "":
+			return fmt.Errorf("read policy %d: one of place, field or event is required", i)
+		case policy.Place != "" && (policy.Field != "" || policy.Event != ""):
+			return fmt.Errorf("read policy %d: place cannot be combined with field or event", i)
+		}
+		if policy.Place != "" && len(model.Places) > 0 && !placeIDs[policy.Place] {
+			return fmt.Errorf("read policy %d: unknown place: %s", i, policy.Place)
+		}
+		if policy.Key != "" && (policy.Field == "" || policy.Event != "") {
+			return fmt.Errorf("read policy %d: key requires a state field", i)
+		}
+		if len(policy.Roles) == 0 && policy.When == "" && policy.Key == "" {
+			return fmt.Errorf("read policy %d: roles, when or key is required", i)
+		}
+	}
+
+	if p.RateLimits != nil {
+		switch p.RateLimits.Store {
+		case "", "memory", "sql":
+		default:
+			return fmt.Errorf("rate limits: invalid store: %s", p.RateLimits.Store)
+		}
+
+		transitionIDs := make(map[string]bool)
+		for _, t := range model.Transitions {
+			transitionIDs[t.ID] = true
+		}
+		for i, limit := range p.RateLimits.Rules {
+			if limit.Limit <= 0 {
+				return fmt.Errorf("rate limit %d: limit must be positive", i)
+			}
+			if _, err := limit.Window(); err != nil {
+				return fmt.Errorf("rate limit %d: %w", i, err)
+			}
+			switch limit.Transition {
+			case "", "*", RateLimitCreate:
+			default:
+				if len(model.Transitions) > 0 && !transitionIDs[limit.Transition] {
+					return fmt.Errorf("rate limit %d: unknown transition: %s", i, limit.Transition)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON serializes the policies.
+func (p *PolicyExtension) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ReadPolicies []ReadPolicy `json:"read"`
+		RateLimits   *RateLimits  `json:"rateLimits,omitempty"`
+	}{
+		ReadPolicies: p.ReadPolicies,
+		RateLimits:   p.RateLimits,
+	})
+}
+
+// UnmarshalJSON deserializes the policies.
+func (p *PolicyExtension) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ReadPolicies []ReadPolicy `json:"read"`
+		RateLimits   *RateLimits  `json:"rateLimits,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.ReadPolicies = raw.ReadPolicies
+	p.RateLimits = raw.RateLimits
+	return nil
+}
+
+// AddReadPolicy adds a read policy to the extension.
+func (p *PolicyExtension) AddReadPolicy(policy ReadPolicy) {
+	p.ReadPolicies = append(p.ReadPolicies, policy)
+}
+
+// AddRateLimit adds a rate limit rule to the extension.
+func (p *PolicyExtension) AddRateLimit(limit RateLimit) {
+	if p.RateLimits == nil {
+		p.RateLimits = &RateLimits{}
+	}
+	p.RateLimits.Rules = append(p.RateLimits.Rules, limit)
+}
+
+// init registers the policy extension with the default registry.
+func init() {
+	goflowmodel.Register(PoliciesExtensionName, func() goflowmodel.ModelExtension {
+		return NewPolicyExtension()
+	})
+}
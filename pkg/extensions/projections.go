@@ -0,0 +1,101 @@
+package extensions
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+)
+
+const (
+	// ProjectionsExtensionName is the extension name for read-model projections.
+	ProjectionsExtensionName = "petri-pilot/projections"
+)
+
+// ProjectionExtension enables a checkpointed read-model projection.
+// The generated projector follows the global event log, keeps the
+// <package>_state and <package>_places tables up to date, and serves
+// list, search and admin queries from them instead of replaying aggregates.
+type ProjectionExtension struct {
+	goflowmodel.BaseExtension
+	Enabled   bool   `json:"enabled"`
+	Database  string `json:"database,omitempty"`  // SQLite file holding the projection (default: projections.db)
+	Poll      string `json:"poll,omitempty"`      // Interval between catch-up passes (default: 1s)
+	BatchSize int    `json:"batchSize,omitempty"` // Events read per catch-up query (default: 500)
+}
+
+// NewProjectionExtension creates a new ProjectionExtension.
+func NewProjectionExtension() *ProjectionExtension {
+	return &ProjectionExtension{
+		BaseExtension: goflowmodel.NewBaseExtension(ProjectionsExtensionName),
+		Enabled:       true,
+	}
+}
+
+// PollInterval returns the catch-up interval as a duration.
+func (p *ProjectionExtension) PollInterval() (time.Duration, error) {
+	if p.Poll == "" {
+		return time.Second, nil
+	}
+	d, err := time.ParseDuration(p.Poll)
+	if err != nil {
+		return 0, fmt.Errorf("invalid poll interval %q", p.Poll)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("poll interval must be positive: %q", p.Poll)
+	}
+	return d, nil
+}
+
+// Validate checks the projection settings.
+func (p *ProjectionExtension) Validate(model *goflowmodel.Model) error {
+	if _, err := p.PollInterval(); err != nil {
+		return fmt.Errorf("projections: %w", err)
+	}
+	if p.BatchSize < 0 {
+		return fmt.Errorf("projections: batch size must not be negative")
+	}
+	return nil
+}
+
+// MarshalJSON serializes the projection settings.
+func (p *ProjectionExtension) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Enabled   bool   `json:"enabled"`
+		Database  string `json:"database,omitempty"`
+		Poll      string `json:"poll,omitempty"`
+		BatchSize int    `json:"batchSize,omitempty"`
+	}{
+		Enabled:   p.Enabled,
+		Database:  p.Database,
+		Poll:      p.Poll,
+		BatchSize: p.BatchSize,
+	})
+}
+
+// UnmarshalJSON deserializes the projection settings.
+func (p *ProjectionExtension) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Enabled   *bool  `json:"enabled"`
+		Database  string `json:"database,omitempty"`
+		Poll      string `json:"poll,omitempty"`
+		BatchSize int    `json:"batchSize,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	// A projections block without "enabled" turns the projection on
+	p.Enabled = raw.Enabled == nil || *raw.Enabled
+	p.Database = raw.Database
+	p.Poll = raw.Poll
+	p.BatchSize = raw.BatchSize
+	return nil
+}
+
+// init registers the projection extension with the default registry.
+func init() {
+	goflowmodel.Register(ProjectionsExtensionName, func() goflowmodel.ModelExtension {
+		return NewProjectionExtension()
+	})
+}
@@ -0,0 +1,114 @@
+package extensions
+
+import (
+	"encoding/json"
+	"fmt"
+
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/dsl"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+const (
+	// SimulationExtensionName is the extension name for simulation and move advice.
+	SimulationExtensionName = "petri-pilot/simulation"
+)
+
+// SimulationExtension carries the model's simulation block: the objective,
+// the players and the ODE solver settings. Generated services use it to
+// rank the current player's moves at /api/{slug}/{id}/advice.
+type SimulationExtension struct {
+	goflowmodel.BaseExtension
+	metamodel.Simulation
+	Advisor *AdvisorConfig `json:"advisor,omitempty"`
+}
+
+// AdvisorConfig sets the defaults used when an advice request names no method or depth.
+type AdvisorConfig struct {
+	Method string `json:"method,omitempty"` // minimax, ode or hybrid (default: minimax)
+	Depth  int    `json:"depth,omitempty"`  // Plies searched by minimax and hybrid (default: 4)
+}
+
+// NewSimulationExtension creates a new SimulationExtension.
+func NewSimulationExtension() *SimulationExtension {
+	return &SimulationExtension{
+		BaseExtension: goflowmodel.NewBaseExtension(SimulationExtensionName),
+	}
+}
+
+// Validate checks the objective, players and advisor defaults against the model.
+func (s *SimulationExtension) Validate(model *goflowmodel.Model) error {
+	if s.Objective == "" {
+		return fmt.Errorf("simulation: objective is required")
+	}
+	if _, err := dsl.Compile(s.Objective); err != nil {
+		return fmt.Errorf("simulation: objective: %w", err)
+	}
+
+	placeIDs := make(map[string]bool)
+	for _, place := range model.Places {
+		placeIDs[place.ID] = true
+	}
+	transitionIDs := make(map[string]bool)
+	for _, t := range model.Transitions {
+		transitionIDs[t.ID] = true
+	}
+	for name, player := range s.Players {
+		if player.TurnPlace != "" && len(model.Places) > 0 && !placeIDs[player.TurnPlace] {
+			return fmt.Errorf("simulation: player %s: unknown turn place: %s", name, player.TurnPlace)
+		}
+		for _, t := range player.Transitions {
+			if len(model.Transitions) > 0 && !transitionIDs[t] {
+				return fmt.Errorf("simulation: player %s: unknown transition: %s", name, t)
+			}
+		}
+	}
+
+	if s.Solver != nil && s.Solver.Tspan[1] < s.Solver.Tspan[0] {
+		return fmt.Errorf("simulation: solver tspan must not end before it starts")
+	}
+
+	if s.Advisor != nil {
+		switch s.Advisor.Method {
+		case "", "minimax", "ode", "hybrid":
+		default:
+			return fmt.Errorf("simulation: invalid advisor method: %s", s.Advisor.Method)
+		}
+		if s.Advisor.Depth < 0 || s.Advisor.Depth > 10 {
+			return fmt.Errorf("simulation: advisor depth must be between 0 (default) and 10")
+		}
+	}
+	return nil
+}
+
+// MarshalJSON serializes the simulation block.
+func (s *SimulationExtension) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		metamodel.Simulation
+		Advisor *AdvisorConfig `json:"advisor,omitempty"`
+	}{
+		Simulation: s.Simulation,
+		Advisor:    s.Advisor,
+	})
+}
+
+// UnmarshalJSON deserializes the simulation block.
+func (s *SimulationExtension) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		metamodel.Simulation
+		Advisor *AdvisorConfig `json:"advisor,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.Simulation = raw.Simulation
+	s.Advisor = raw.Advisor
+	return nil
+}
+
+// init registers the simulation extension with the default registry.
+func init() {
+	goflowmodel.Register(SimulationExtensionName, func() goflowmodel.ModelExtension {
+		return NewSimulationExtension()
+	})
+}
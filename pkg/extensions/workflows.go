@@ -41,15 +41,17 @@ type WorkflowTrigger struct {
 
 // WorkflowStep defines a single step in a workflow.
 type WorkflowStep struct {
-	ID        string            `json:"id"`
-	Type      string            `json:"type"` // action, condition, parallel, wait
-	Entity    string            `json:"entity,omitempty"`
-	Action    string            `json:"action,omitempty"`
-	Condition string            `json:"condition,omitempty"`
-	Duration  string            `json:"duration,omitempty"` // For wait steps
-	Input     map[string]string `json:"input,omitempty"`    // Mapping from workflow context
-	OnSuccess string            `json:"on_success,omitempty"`
-	OnFailure string            `json:"on_failure,omitempty"`
+	ID         string            `json:"id"`
+	Type       string            `json:"type"` // action, condition, parallel, wait
+	Entity     string            `json:"entity,omitempty"`
+	Action     string            `json:"action,omitempty"`
+	Condition  string            `json:"condition,omitempty"`
+	Duration   string            `json:"duration,omitempty"` // For wait steps
+	Input      map[string]string `json:"input,omitempty"`    // Mapping from workflow context
+	OnSuccess  string            `json:"on_success,omitempty"`
+	OnFailure  string            `json:"on_failure,omitempty"`
+	Compensate string            `json:"compensate,omitempty"` // Action on the same entity that undoes this step
+	Parallel   []string          `json:"parallel,omitempty"`   // Step IDs run together by a parallel step
 }
 
 // NewWorkflowExtension creates a new WorkflowExtension.
@@ -96,6 +98,12 @@ func (w *WorkflowExtension) Validate(model *goflowmodel.Model) error {
 				return fmt.Errorf("workflow %s step %s: unknown on_failure step: %s",
 					wf.ID, step.ID, step.OnFailure)
 			}
+			for _, id := range step.Parallel {
+				if !stepIDs[id] {
+					return fmt.Errorf("workflow %s step %s: unknown parallel step: %s",
+						wf.ID, step.ID, id)
+				}
+			}
 		}
 	}
 	return nil
@@ -0,0 +1,173 @@
+// Package lint checks models for problems that are legal but usually
+// mistakes: names that break conventions, transitions that can never fire,
+// events and bindings nothing uses, roles that grant nothing and views
+// showing fields that do not exist.
+//
+// Each rule has a stable ID and a default severity, which a model can
+// override with a lint block:
+//
+//	"lint": {"naming": "off", "unused-event": "error"}
+//
+// Where a problem has a mechanical fix, the diagnostic carries it as
+// petri_extend operations, so the CLI can apply it with lint -fix and
+// agents can pass it to petri_extend unchanged.
+package lint
+
+import (
+	"fmt"
+
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/extensions"
+)
+
+// ExtensionName is the v2 extension holding the lint block.
+const ExtensionName = "petri-pilot/lint"
+
+// Severity is how much a rule's diagnostics matter.
+type Severity string
+
+const (
+	Off     Severity = "off"
+	Info    Severity = "info"
+	Warning Severity = "warning"
+	Error   Severity = "error"
+)
+
+// Rule IDs. They appear in lint blocks and reports and must not change.
+const (
+	RuleNaming                 = "naming"
+	RuleUnreachableTransition  = "unreachable-transition"
+	RuleUnusedEvent            = "unused-event"
+	RuleUnusedBinding          = "unused-binding"
+	RuleRoleWithoutPermissions = "role-without-permissions"
+	RuleViewMissingField       = "view-missing-field"
+)
+
+// Rule describes one check.
+type Rule struct {
+	ID          string   `json:"id"`
+	Severity    Severity `json:"severity"` // Default severity
+	Description string   `json:"description"`
+	check       func(*Input) []Diagnostic
+}
+
+// Rules lists every rule in the order Lint runs them.
+func Rules() []Rule {
+	return []Rule{
+		{RuleNaming, Warning, "Place, transition and event IDs are lower snake_case", checkNaming},
+		{RuleUnreachableTransition, Warning, "Every transition can fire from the initial marking", checkUnreachable},
+		{RuleUnusedEvent, Warning, "Every event is emitted by a transition", checkUnusedEvents},
+		{RuleUnusedBinding, Warning, "Every binding is used by an arc, the guard or the event", checkUnusedBindings},
+		{RuleRoleWithoutPermissions, Warning, "Every role, directly or through inheritance, is granted something", checkRoles},
+		{RuleViewMissingField, Error, "View fields bind to places, bindings or event fields that exist", checkViews},
+	}
+}
+
+// Config maps rule IDs to severities, overriding the defaults.
+type Config map[string]Severity
+
+// Validate checks that the config names known rules and severities.
+func (c Config) Validate() error {
+	known := make(map[string]bool)
+	for _, r := range Rules() {
+		known[r.ID] = true
+	}
+	for id, sev := range c {
+		if !known[id] {
+			return fmt.Errorf("lint: unknown rule: %s", id)
+		}
+		switch sev {
+		case Off, Info, Warning, Error:
+		default:
+			return fmt.Errorf("lint: rule %s: invalid severity: %s", id, sev)
+		}
+	}
+	return nil
+}
+
+// Input is what the rules inspect: the net and the parts of its
+// extensions that refer to it.
+type Input struct {
+	Net        *goflowmodel.Model
+	Roles      []extensions.Role
+	Access     []extensions.AccessRule // Action is a transition ID
+	AdminRoles []string
+	Views      []extensions.View
+	Config     Config
+}
+
+// Diagnostic is one problem found by a rule.
+type Diagnostic struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Element  string   `json:"element,omitempty"`
+	Fix      *Fix     `json:"fix,omitempty"`
+}
+
+// Fix says how to resolve a diagnostic. Operations are petri_extend
+// operations; a fix without them needs a human decision.
+type Fix struct {
+	Description string      `json:"description"`
+	Operations  []Operation `json:"operations,omitempty"`
+}
+
+// Operation is a petri_extend operation, e.g. {"op": "remove_event", "id": "shipped"}.
+type Operation map[string]any
+
+// Report is the result of linting a model.
+type Report struct {
+	Diagnostics []Diagnostic `json:"diagnostics"`
+	Errors      int          `json:"errors"`
+	Warnings    int          `json:"warnings"`
+	Fixable     int          `json:"fixable"` // Diagnostics with operations
+}
+
+// Operations returns the operations of every fixable diagnostic, in order.
+func (r *Report) Operations() []Operation {
+	var ops []Operation
+	for _, d := range r.Diagnostics {
+		if d.Fix != nil {
+			ops = append(ops, d.Fix.Operations...)
+		}
+	}
+	return ops
+}
+
+// Lint runs every rule not turned off and returns their diagnostics,
+// grouped by rule in the order of Rules and by model order within each.
+func Lint(in *Input) (*Report, error) {
+	if in.Net == nil {
+		return nil, fmt.Errorf("lint: no model")
+	}
+	if err := in.Config.Validate(); err != nil {
+		return nil, err
+	}
+
+	report := &Report{Diagnostics: []Diagnostic{}}
+	for _, rule := range Rules() {
+		severity := rule.Severity
+		if sev, ok := in.Config[rule.ID]; ok {
+			severity = sev
+		}
+		if severity == Off {
+			continue
+		}
+
+		for _, d := range rule.check(in) {
+			d.Rule = rule.ID
+			d.Severity = severity
+			switch severity {
+			case Error:
+				report.Errors++
+			case Warning:
+				report.Warnings++
+			}
+			if d.Fix != nil && len(d.Fix.Operations) > 0 {
+				report.Fixable++
+			}
+			report.Diagnostics = append(report.Diagnostics, d)
+		}
+	}
+	return report, nil
+}
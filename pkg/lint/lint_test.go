@@ -0,0 +1,176 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+)
+
+const orders = `{
+  "name": "orders",
+  "places": [
+    {"id": "pending", "initial": 1},
+    {"id": "shipped"},
+    {"id": "archived"},
+    {"id": "totals", "kind": "data", "type": "map[string]number"}
+  ],
+  "transitions": [
+    {"id": "ship", "event": "order_shipped", "bindings": [
+      {"name": "id", "type": "string"},
+      {"name": "carrier", "type": "string"}
+    ]},
+    {"id": "record", "guard": "amount > 0", "bindings": [
+      {"name": "id", "type": "string"},
+      {"name": "amount", "type": "number"},
+      {"name": "note", "type": "string"}
+    ]},
+    {"id": "restoreOrder"}
+  ],
+  "arcs": [
+    {"from": "pending", "to": "ship"},
+    {"from": "ship", "to": "shipped"},
+    {"from": "record", "to": "totals", "keys": ["id"], "value": "amount"},
+    {"from": "archived", "to": "restoreOrder"},
+    {"from": "restoreOrder", "to": "pending"}
+  ],
+  "events": [
+    {"id": "order_shipped", "fields": [{"name": "carrier", "type": "string"}]},
+    {"id": "OrderCancelled"}
+  ],
+  "roles": [
+    {"id": "clerk"},
+    {"id": "manager", "inherits": ["clerk"]},
+    {"id": "auditor"}
+  ],
+  "access": [{"transition": "ship", "roles": ["clerk"]}],
+  "views": [{"id": "order", "groups": [{"id": "main", "fields": [
+    {"binding": "carrier"}, {"binding": "totals.sum"}, {"binding": "customer"}
+  ]}]}],
+  "lint": {"view-missing-field": "warning"}
+}`
+
+func TestLint(t *testing.T) {
+	in, err := Parse([]byte(orders))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	report, err := Lint(in)
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+
+	var got [][2]string
+	for _, d := range report.Diagnostics {
+		got = append(got, [2]string{d.Rule, d.Element})
+	}
+	want := [][2]string{
+		{RuleNaming, "restoreOrder"},
+		{RuleNaming, "OrderCancelled"},
+		{RuleUnreachableTransition, "restoreOrder"},
+		{RuleUnusedEvent, "OrderCancelled"},
+		{RuleUnusedBinding, "ship.id"},
+		{RuleUnusedBinding, "record.note"},
+		{RuleRoleWithoutPermissions, "auditor"},
+		{RuleViewMissingField, "order.customer"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected diagnostics %v, got %v", want, got)
+	}
+	if report.Errors != 0 || report.Warnings != len(want) || report.Fixable != 6 {
+		t.Errorf("expected 0 errors, %d warnings and 6 fixable, got %+v", len(want), report)
+	}
+
+	rename := report.Diagnostics[0].Fix.Operations
+	if !reflect.DeepEqual(rename, []Operation{{"op": "rename_transition", "id": "restoreOrder", "to": "restore_order"}}) {
+		t.Errorf("unexpected rename: %v", rename)
+	}
+	remove := report.Diagnostics[2].Fix.Operations
+	if len(remove) != 3 || remove[2]["op"] != "remove_transition" {
+		t.Errorf("expected two arc removals and a transition removal, got %v", remove)
+	}
+	if report.Diagnostics[6].Fix.Operations != nil {
+		t.Errorf("expected role fixes to need a human, got %v", report.Diagnostics[6].Fix)
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	in, _ := Parse([]byte(orders))
+	in.Config = Config{RuleNaming: Off, RuleUnusedEvent: Error}
+	report, err := Lint(in)
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	for _, d := range report.Diagnostics {
+		if d.Rule == RuleNaming {
+			t.Errorf("expected naming to be off, got %+v", d)
+		}
+	}
+	if report.Errors != 2 {
+		t.Errorf("expected the unused event and missing view field as errors, got %d", report.Errors)
+	}
+
+	for _, cfg := range []Config{{"no-such-rule": Error}, {RuleNaming: "fatal"}} {
+		in.Config = cfg
+		if _, err := Lint(in); err == nil {
+			t.Errorf("%v: expected an error", cfg)
+		}
+	}
+}
+
+func TestUnreachableUnbounded(t *testing.T) {
+	// mint grows supply without bound; spend needs two tokens and burn an
+	// empty supply, which the abstraction must not rule out
+	in, err := Parse([]byte(`{
+	  "places": [{"id": "supply"}, {"id": "spent"}, {"id": "never"}],
+	  "transitions": [{"id": "mint"}, {"id": "spend"}, {"id": "burn"}, {"id": "stuck"}],
+	  "arcs": [
+	    {"from": "mint", "to": "supply"},
+	    {"from": "supply", "to": "spend", "weight": 2},
+	    {"from": "spend", "to": "spent"},
+	    {"from": "supply", "to": "burn", "type": "inhibitor"},
+	    {"from": "never", "to": "stuck"}
+	  ]
+	}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	in.Config = Config{RuleNaming: Off}
+	report, _ := Lint(in)
+	if len(report.Diagnostics) != 1 || report.Diagnostics[0].Element != "stuck" {
+		t.Errorf("expected only stuck to be unreachable, got %+v", report.Diagnostics)
+	}
+}
+
+func TestParseV2(t *testing.T) {
+	in, err := Parse([]byte(`{
+	  "version": "2.0",
+	  "net": {"name": "x", "places": [{"id": "a", "initial": 1}]},
+	  "extensions": {
+	    "petri-pilot/roles": [{"id": "admin"}],
+	    "petri-pilot/views": {"views": [], "admin": {"enabled": true, "roles": ["admin"]}},
+	    "petri-pilot/entities": [{"id": "e", "fields": [], "actions": [], "access": [{"action": "go", "roles": ["admin"]}]}],
+	    "petri-pilot/lint": {"naming": "error"}
+	  }
+	}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if in.Net.Name != "x" || len(in.Roles) != 1 || !reflect.DeepEqual(in.AdminRoles, []string{"admin"}) ||
+		len(in.Access) != 1 || in.Access[0].Action != "go" || in.Config[RuleNaming] != Error {
+		t.Errorf("unexpected input: %+v", in)
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"restoreOrder":   "restore_order",
+		"OrderCancelled": "order_cancelled",
+		"HTTPRequest":    "http_request",
+		"ship-it":        "ship_it",
+		"step2Done":      "step2_done",
+		"__x__":          "x",
+	} {
+		if got := toSnakeCase(in); got != want {
+			t.Errorf("%s: expected %s, got %s", in, want, got)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/extensions"
+)
+
+// accessRule reads access rules keyed by "transition", as in flat models,
+// or by "action", as in entities.
+type accessRule struct {
+	Transition string   `json:"transition"`
+	Action     string   `json:"action"`
+	Roles      []string `json:"roles"`
+	Guard      string   `json:"guard,omitempty"`
+}
+
+func (a accessRule) rule() extensions.AccessRule {
+	action := a.Transition
+	if action == "" {
+		action = a.Action
+	}
+	return extensions.AccessRule{Action: action, Roles: a.Roles, Guard: a.Guard}
+}
+
+// Parse reads a model document for linting: a flat model with roles,
+// access, admin, views and lint at the top level, or a v2 document with
+// the same under extensions.
+func Parse(data []byte) (*Input, error) {
+	var v2 struct {
+		Version    string                     `json:"version"`
+		Net        *goflowmodel.Model         `json:"net"`
+		Extensions map[string]json.RawMessage `json:"extensions"`
+	}
+	if err := json.Unmarshal(data, &v2); err == nil && v2.Version == "2.0" && v2.Net != nil {
+		return parseV2(v2.Net, v2.Extensions)
+	}
+
+	var flat struct {
+		goflowmodel.Model
+		Roles  []extensions.Role `json:"roles"`
+		Access []accessRule      `json:"access"`
+		Admin  *extensions.Admin `json:"admin"`
+		Views  []extensions.View `json:"views"`
+		Lint   Config            `json:"lint"`
+	}
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return nil, err
+	}
+	in := &Input{Net: &flat.Model, Roles: flat.Roles, Views: flat.Views, Config: flat.Lint}
+	for _, a := range flat.Access {
+		in.Access = append(in.Access, a.rule())
+	}
+	if flat.Admin != nil {
+		in.AdminRoles = flat.Admin.Roles
+	}
+	return in, nil
+}
+
+func parseV2(net *goflowmodel.Model, exts map[string]json.RawMessage) (*Input, error) {
+	in := &Input{Net: net}
+	for name, data := range exts {
+		var err error
+		switch name {
+		case extensions.RolesExtensionName:
+			err = json.Unmarshal(data, &in.Roles)
+
+		case extensions.ViewsExtensionName:
+			// Either a list of views or {"views": [...], "admin": {...}}
+			views := extensions.NewViewExtension()
+			if err = json.Unmarshal(data, &in.Views); err != nil {
+				if err = json.Unmarshal(data, views); err == nil {
+					in.Views = views.Views
+					if views.Admin != nil {
+						in.AdminRoles = views.Admin.Roles
+					}
+				}
+			}
+
+		case extensions.EntitiesExtensionName:
+			var entities extensions.EntityExtension
+			if err = json.Unmarshal(data, &entities); err == nil {
+				for _, e := range entities.Entities {
+					in.Access = append(in.Access, e.Access...)
+				}
+			}
+
+		case "petri-pilot/access":
+			var rules []accessRule
+			if err = json.Unmarshal(data, &rules); err == nil {
+				for _, a := range rules {
+					in.Access = append(in.Access, a.rule())
+				}
+			}
+
+		case ExtensionName:
+			err = json.Unmarshal(data, &in.Config)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+	}
+	return in, nil
+}
@@ -0,0 +1,411 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/extensions"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+// MaxStates bounds the markings explored for unreachable transitions.
+// Nets with more are not checked.
+const MaxStates = 10000
+
+var snakeCase = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// checkNaming flags IDs that are not lower snake_case. Renames are only
+// offered when nothing outside the net refers to the ID, since
+// petri_extend rewrites the net alone.
+func checkNaming(in *Input) []Diagnostic {
+	taken := make(map[string]bool)
+	for _, p := range in.Net.Places {
+		taken[p.ID] = true
+	}
+	for _, t := range in.Net.Transitions {
+		taken[t.ID] = true
+	}
+	external := externalRefs(in)
+
+	var found []Diagnostic
+	check := func(kind, id string, ids map[string]bool) {
+		if snakeCase.MatchString(id) {
+			return
+		}
+		d := Diagnostic{
+			Message: fmt.Sprintf("%s '%s' is not lower snake_case", strings.ToUpper(kind[:1])+kind[1:], id),
+			Element: id,
+		}
+		name := toSnakeCase(id)
+		switch {
+		case !snakeCase.MatchString(name) || ids[name]:
+			d.Fix = &Fix{Description: fmt.Sprintf("Rename %s '%s' to a lower snake_case ID", kind, id)}
+		case external[id]:
+			d.Fix = &Fix{Description: fmt.Sprintf("Rename %s '%s' to '%s' and update the access rules and views that use it", kind, id, name)}
+		default:
+			d.Fix = &Fix{
+				Description: fmt.Sprintf("Rename %s '%s' to '%s'", kind, id, name),
+				Operations:  []Operation{{"op": "rename_" + kind, "id": id, "to": name}},
+			}
+		}
+		found = append(found, d)
+	}
+
+	for _, p := range in.Net.Places {
+		check("place", p.ID, taken)
+	}
+	for _, t := range in.Net.Transitions {
+		check("transition", t.ID, taken)
+	}
+	events := make(map[string]bool)
+	for _, e := range in.Net.Events {
+		events[e.ID] = true
+	}
+	for _, e := range in.Net.Events {
+		check("event", e.ID, events)
+	}
+	return found
+}
+
+// toSnakeCase converts camelCase, PascalCase and kebab-case IDs.
+func toSnakeCase(id string) string {
+	runes := []rune(id)
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ' || r == '.' || r == '_':
+			b.WriteRune('_')
+		case unicode.IsUpper(r):
+			// Start a word at aB and at the B of ABc
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	parts := strings.FieldsFunc(b.String(), func(r rune) bool { return r == '_' })
+	return strings.Join(parts, "_")
+}
+
+// externalRefs returns the place and transition IDs used by access rules,
+// view actions and view fields.
+func externalRefs(in *Input) map[string]bool {
+	refs := make(map[string]bool)
+	for _, a := range in.Access {
+		refs[a.Action] = true
+	}
+	for _, v := range in.Views {
+		for _, action := range v.Actions {
+			refs[action] = true
+		}
+		for _, g := range v.Groups {
+			for _, f := range g.Fields {
+				refs[bindingRoot(f.Binding)] = true
+			}
+		}
+	}
+	return refs
+}
+
+// checkUnreachable flags transitions that no reachable marking enables.
+// Guards, data and constraints are ignored and token counts above the
+// largest arc weight are treated as unbounded, so the search
+// over-approximates what the net can do: a transition it never enables
+// can never fire. Nets whose abstract state space exceeds MaxStates are
+// skipped rather than guessed at.
+func checkUnreachable(in *Input) []Diagnostic {
+	schema := metamodel.FromModel(in.Net)
+	enabled, complete := reachableActions(schema)
+	if !complete {
+		return nil
+	}
+	external := externalRefs(in)
+
+	var found []Diagnostic
+	for _, t := range in.Net.Transitions {
+		if enabled[t.ID] {
+			continue
+		}
+		d := Diagnostic{
+			Message: fmt.Sprintf("Transition '%s' is never enabled from the initial marking", t.ID),
+			Element: t.ID,
+		}
+		if external[t.ID] {
+			d.Fix = &Fix{Description: fmt.Sprintf("Add tokens or arcs so '%s' can fire, or remove it and the access rules and views that use it", t.ID)}
+		} else {
+			var ops []Operation
+			seen := make(map[string]bool)
+			for _, a := range in.Net.Arcs {
+				key := a.From + "\x00" + a.To
+				if (a.From == t.ID || a.To == t.ID) && !seen[key] {
+					seen[key] = true
+					ops = append(ops, Operation{"op": "remove_arc", "from": a.From, "to": a.To})
+				}
+			}
+			ops = append(ops, Operation{"op": "remove_transition", "id": t.ID})
+			d.Fix = &Fix{
+				Description: fmt.Sprintf("Remove transition '%s' and its arcs, or add tokens or arcs so it can fire", t.ID),
+				Operations:  ops,
+			}
+		}
+		found = append(found, d)
+	}
+	return found
+}
+
+// omega marks a token count too large to track.
+const omega = -1
+
+// reachableActions explores the abstract markings of a schema and returns
+// the actions enabled in any of them, and whether the search finished.
+func reachableActions(s *metamodel.Schema) (map[string]bool, bool) {
+	states := s.TokenStates()
+	index := make(map[string]int, len(states))
+	bound := 1
+	for _, arc := range s.Arcs {
+		bound = max(bound, arc.Weight)
+	}
+	initial := make([]int, len(states))
+	for i, st := range states {
+		index[st.ID] = i
+		initial[i] = st.InitialTokens()
+		if initial[i] > bound {
+			initial[i] = omega
+		}
+	}
+
+	weight := func(arc metamodel.Arc) int {
+		if arc.Weight == 0 {
+			return 1
+		}
+		return arc.Weight
+	}
+	enabledIn := func(m []int, actionID string) bool {
+		for _, arc := range s.InputArcs(actionID) {
+			i, ok := index[arc.Source]
+			if !ok {
+				continue // Data state
+			}
+			switch {
+			case m[i] == omega:
+				// Could hold any count, so never blocks
+			case arc.IsInhibitor():
+				if m[i] > 0 {
+					return false
+				}
+			case m[i] < weight(arc):
+				return false
+			}
+		}
+		return true
+	}
+	fire := func(m []int, actionID string) []int {
+		next := append([]int(nil), m...)
+		for _, arc := range s.InputArcs(actionID) {
+			if i, ok := index[arc.Source]; ok && !arc.IsInhibitor() && next[i] != omega {
+				next[i] -= weight(arc)
+			}
+		}
+		for _, arc := range s.OutputArcs(actionID) {
+			if i, ok := index[arc.Target]; ok && next[i] != omega {
+				if next[i] += weight(arc); next[i] > bound {
+					next[i] = omega
+				}
+			}
+		}
+		return next
+	}
+
+	enabled := make(map[string]bool)
+	seen := map[string]bool{fmt.Sprint(initial): true}
+	queue := [][]int{initial}
+	for len(queue) > 0 {
+		m := queue[0]
+		queue = queue[1:]
+		for _, a := range s.Actions {
+			if !enabledIn(m, a.ID) {
+				continue
+			}
+			enabled[a.ID] = true
+			next := fire(m, a.ID)
+			key := fmt.Sprint(next)
+			if seen[key] {
+				continue
+			}
+			if len(seen) >= MaxStates {
+				return enabled, false
+			}
+			seen[key] = true
+			queue = append(queue, next)
+		}
+	}
+	return enabled, true
+}
+
+// checkUnusedEvents flags events no transition emits, by event reference
+// or explicit event type.
+func checkUnusedEvents(in *Input) []Diagnostic {
+	emitted := make(map[string]bool)
+	for _, t := range in.Net.Transitions {
+		emitted[t.Event] = true
+		emitted[t.EventType] = true
+	}
+	var found []Diagnostic
+	for _, e := range in.Net.Events {
+		if emitted[e.ID] {
+			continue
+		}
+		found = append(found, Diagnostic{
+			Message: fmt.Sprintf("Event '%s' is not emitted by any transition", e.ID),
+			Element: e.ID,
+			Fix: &Fix{
+				Description: fmt.Sprintf("Remove event '%s', or set it as a transition's event", e.ID),
+				Operations:  []Operation{{"op": "remove_event", "id": e.ID}},
+			},
+		})
+	}
+	return found
+}
+
+// checkUnusedBindings flags bindings that no arc of their transition
+// reads, that the guard does not mention and that the transition's event
+// does not carry.
+func checkUnusedBindings(in *Input) []Diagnostic {
+	eventFields := make(map[string]map[string]bool)
+	for _, e := range in.Net.Events {
+		eventFields[e.ID] = make(map[string]bool)
+		for _, f := range e.Fields {
+			eventFields[e.ID][f.Name] = true
+		}
+	}
+
+	var found []Diagnostic
+	for _, t := range in.Net.Transitions {
+		used := make(map[string]bool)
+		for _, a := range in.Net.Arcs {
+			if a.From != t.ID && a.To != t.ID {
+				continue
+			}
+			for _, k := range a.Keys {
+				used[k] = true
+			}
+			used[a.Value] = true
+		}
+		for _, b := range t.Bindings {
+			if used[b.Name] || eventFields[t.Event][b.Name] || mentions(t.Guard, b.Name) {
+				continue
+			}
+			element := t.ID + "." + b.Name
+			found = append(found, Diagnostic{
+				Message: fmt.Sprintf("Transition '%s' binding '%s' is not used by any arc, its guard or its event", t.ID, b.Name),
+				Element: element,
+				Fix: &Fix{
+					Description: fmt.Sprintf("Remove binding '%s' from '%s', or use it in an arc's keys or value", b.Name, t.ID),
+					Operations:  []Operation{{"op": "remove_binding", "transition": t.ID, "name": b.Name}},
+				},
+			})
+		}
+	}
+	return found
+}
+
+// mentions reports whether an expression uses name as an identifier.
+func mentions(expr, name string) bool {
+	if expr == "" || name == "" {
+		return false
+	}
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`).MatchString(expr)
+}
+
+// checkRoles flags roles that are granted nothing by access rules or the
+// admin dashboard, either directly or through a role they inherit.
+func checkRoles(in *Input) []Diagnostic {
+	granted := make(map[string]bool)
+	for _, a := range in.Access {
+		for _, r := range a.Roles {
+			granted[r] = true
+		}
+	}
+	for _, r := range in.AdminRoles {
+		granted[r] = true
+	}
+
+	hierarchy := extensions.NewRoleExtension()
+	hierarchy.Roles = in.Roles
+
+	var found []Diagnostic
+	for _, role := range in.Roles {
+		ok := false
+		for _, id := range hierarchy.FlattenHierarchy(role.ID) {
+			if granted[id] {
+				ok = true
+				break
+			}
+		}
+		if ok {
+			continue
+		}
+		found = append(found, Diagnostic{
+			Message: fmt.Sprintf("Role '%s' is not granted access to any transition", role.ID),
+			Element: role.ID,
+			Fix:     &Fix{Description: fmt.Sprintf("Add '%s' to an access rule, have it inherit a role that has one, or remove it", role.ID)},
+		})
+	}
+	return found
+}
+
+// standardFields are bound by views without being declared in the model.
+var standardFields = map[string]bool{
+	"id": true, "aggregate_id": true, "version": true, "state": true,
+	"status": true, "timestamp": true, "created_at": true, "updated_at": true,
+}
+
+// checkViews flags view fields bound to names the model does not define.
+func checkViews(in *Input) []Diagnostic {
+	known := make(map[string]bool)
+	for _, p := range in.Net.Places {
+		known[p.ID] = true
+	}
+	for _, t := range in.Net.Transitions {
+		for _, b := range t.Bindings {
+			known[b.Name] = true
+		}
+		for _, f := range t.Fields {
+			known[f.Name] = true
+		}
+	}
+	for _, e := range in.Net.Events {
+		for _, f := range e.Fields {
+			known[f.Name] = true
+		}
+	}
+
+	var found []Diagnostic
+	for _, v := range in.Views {
+		for _, g := range v.Groups {
+			for _, f := range g.Fields {
+				root := bindingRoot(f.Binding)
+				if known[root] || standardFields[root] {
+					continue
+				}
+				found = append(found, Diagnostic{
+					Message: fmt.Sprintf("View '%s' field '%s' is not a place, binding or event field", v.ID, f.Binding),
+					Element: v.ID + "." + f.Binding,
+					Fix:     &Fix{Description: fmt.Sprintf("Add an event field named '%s', or bind the field to an existing one", root)},
+				})
+			}
+		}
+	}
+	return found
+}
+
+// bindingRoot returns the first segment of a binding path such as "order.total".
+func bindingRoot(binding string) string {
+	root, _, _ := strings.Cut(binding, ".")
+	return root
+}
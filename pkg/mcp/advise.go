@@ -0,0 +1,161 @@
+package mcp
+
+// This file implements the petri_advise MCP tool, which ranks the moves
+// available to the current player of a model that declares a simulation
+// objective. It reaches a position by firing transitions (or by setting a
+// marking directly) and then scores each enabled action with the same
+// advisor generated services expose at /api/{slug}/{id}/advice.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pflow-xyz/petri-pilot/pkg/extensions"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/advisor"
+)
+
+func adviseTool() mcp.Tool {
+	return mcp.NewTool("petri_advise",
+		mcp.WithDescription("Rank the moves available to the current player using the model's simulation objective. Scores each enabled action by bounded minimax (alpha-beta), ODE lookahead, or minimax with ODE-scored leaves."),
+		mcp.WithString("model",
+			mcp.Required(),
+			mcp.Description("The Petri net model as JSON or a workspace handle. Its 'simulation' block (v1) or 'petri-pilot/simulation' extension (v2) supplies the objective and players."),
+		),
+		mcp.WithString("simulation",
+			mcp.Description("Optional JSON simulation block overriding the model's: {\"objective\":\"win_x - win_o\",\"players\":{\"x\":{\"maximizes\":true,\"turnPlace\":\"x_turn\"}}}"),
+		),
+		mcp.WithString("transitions",
+			mcp.Description("JSON array of transition IDs to fire from the initial marking to reach the position to advise on"),
+		),
+		mcp.WithString("marking",
+			mcp.Description("JSON object of place token counts applied after the transitions, e.g. {\"x_turn\":0,\"o_turn\":1}"),
+		),
+		mcp.WithString("method",
+			mcp.Description("Scoring method: minimax (default), ode or hybrid"),
+		),
+		mcp.WithNumber("depth",
+			mcp.Description(fmt.Sprintf("Plies searched by minimax and hybrid, including the advised move (default %d, max %d, or %d for hybrid)", advisor.DefaultDepth, advisor.MaxDepth, advisor.MaxHybridDepth)),
+		),
+		mcp.WithString("player",
+			mcp.Description("Advise this player instead of the one whose turn it is"),
+		),
+	)
+}
+
+// handleAdvise handles the petri_advise tool request.
+func handleAdvise(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	modelJSON, err := requireModel(ctx, request, "model")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	parsed, err := parseModelV2(modelJSON)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid model JSON: %v", err)), nil
+	}
+
+	simulation, err := adviceSimulation(modelJSON, parsed, request.GetString("simulation", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := simulation.Validate(parsed.Model); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	schema := metamodel.FromModel(parsed.Model)
+	schema.Simulation = &simulation.Simulation
+	rt := metamodel.NewRuntime(schema)
+
+	if transitionsJSON := request.GetString("transitions", ""); transitionsJSON != "" {
+		var transitions []string
+		if err := json.Unmarshal([]byte(transitionsJSON), &transitions); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid transitions JSON: %v", err)), nil
+		}
+		for i, t := range transitions {
+			if err := rt.Execute(t); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("transition %d (%s): %v", i, t, err)), nil
+			}
+		}
+	}
+
+	if markingJSON := request.GetString("marking", ""); markingJSON != "" {
+		var marking map[string]int
+		if err := json.Unmarshal([]byte(markingJSON), &marking); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid marking JSON: %v", err)), nil
+		}
+		for place, tokens := range marking {
+			if st := schema.StateByID(place); st == nil || !st.IsToken() {
+				return mcp.NewToolResultError(fmt.Sprintf("unknown token place: %s", place)), nil
+			}
+			rt.SetTokens(place, tokens)
+		}
+	}
+
+	opts := advisor.Options{
+		Method: advisor.Method(request.GetString("method", "")),
+		Depth:  request.GetInt("depth", 0),
+		Player: request.GetString("player", ""),
+	}
+	if opts.Method == "" && simulation.Advisor != nil {
+		opts.Method = advisor.Method(simulation.Advisor.Method)
+	}
+	if opts.Depth == 0 && simulation.Advisor != nil {
+		opts.Depth = simulation.Advisor.Depth
+	}
+
+	advice, err := advisor.Advise(ctx, rt, opts)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	output, err := json.MarshalIndent(struct {
+		*advisor.Advice
+		Marking map[string]int `json:"marking"`
+	}{advice, rt.Snapshot.Tokens}, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal advice: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(output)), nil
+}
+
+// adviceSimulation returns the model's simulation block, which advice requires.
+func adviceSimulation(modelJSON string, parsed *ParseResult, override string) (*extensions.SimulationExtension, error) {
+	simulation, err := modelSimulation(modelJSON, parsed, override)
+	if err != nil {
+		return nil, err
+	}
+	if simulation == nil {
+		return nil, fmt.Errorf("model has no simulation block; pass one with the 'simulation' parameter")
+	}
+	return simulation, nil
+}
+
+// modelSimulation picks the simulation block from the override parameter,
+// the v2 extensions, or the v1 top-level "simulation" key, in that order.
+// It returns nil when the model has none.
+func modelSimulation(modelJSON string, parsed *ParseResult, override string) (*extensions.SimulationExtension, error) {
+	var data json.RawMessage
+	switch {
+	case override != "":
+		data = json.RawMessage(override)
+	case parsed.Extensions[extensions.SimulationExtensionName] != nil:
+		data = parsed.Extensions[extensions.SimulationExtensionName]
+	default:
+		var v1Data map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(modelJSON), &v1Data); err == nil {
+			data = v1Data["simulation"]
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	simulation := extensions.NewSimulationExtension()
+	if err := json.Unmarshal(data, simulation); err != nil {
+		return nil, fmt.Errorf("invalid simulation JSON: %w", err)
+	}
+	return simulation, nil
+}
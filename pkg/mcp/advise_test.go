@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/advisor"
+)
+
+// adviseModel is a one-move game: taking the big prize scores 3, the small one 1.
+const adviseModel = `{
+	"name": "prizes",
+	"places": [
+		{"id": "ready", "initial": 1},
+		{"id": "score", "initial": 0}
+	],
+	"transitions": [
+		{"id": "take_small"},
+		{"id": "take_big"}
+	],
+	"arcs": [
+		{"from": "ready", "to": "take_small"},
+		{"from": "take_small", "to": "score"},
+		{"from": "ready", "to": "take_big"},
+		{"from": "take_big", "to": "score", "weight": 3}
+	],
+	"simulation": {"objective": "score"}
+}`
+
+func callAdvise(t *testing.T, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	result, err := handleAdvise(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: args},
+	})
+	if err != nil {
+		t.Fatalf("handleAdvise returned error: %v", err)
+	}
+	return result
+}
+
+func TestAdvise(t *testing.T) {
+	result := callAdvise(t, map[string]any{"model": adviseModel})
+	if result.IsError {
+		t.Fatalf("expected success, got %v", result.Content[0])
+	}
+
+	var advice advisor.Advice
+	text := result.Content[0].(mcp.TextContent).Text
+	if err := json.Unmarshal([]byte(text), &advice); err != nil {
+		t.Fatalf("failed to parse advice: %v\n%s", err, text)
+	}
+	if advice.Best != "take_big" || len(advice.Moves) != 2 || advice.Moves[0].Score != 3 {
+		t.Errorf("expected take_big to rank first, got %+v", advice.Moves)
+	}
+
+	// Once a prize is taken there is nothing left to advise
+	result = callAdvise(t, map[string]any{"model": adviseModel, "transitions": `["take_small"]`})
+	text = result.Content[0].(mcp.TextContent).Text
+	if result.IsError || !strings.Contains(text, `"moves": []`) || !strings.Contains(text, `"score": 1`) {
+		t.Errorf("expected no moves after the game ends, got %s", text)
+	}
+}
+
+func TestAdviseErrors(t *testing.T) {
+	noSimulation := strings.Replace(adviseModel, `"simulation": {"objective": "score"}`, `"description": "none"`, 1)
+	for name, args := range map[string]map[string]any{
+		"no simulation":  {"model": noSimulation},
+		"bad objective":  {"model": adviseModel, "simulation": `{"objective": "score -"}`},
+		"unknown player": {"model": adviseModel, "player": "z"},
+		"disabled move":  {"model": adviseModel, "transitions": `["take_small", "take_big"]`},
+		"unknown place":  {"model": adviseModel, "marking": `{"nowhere": 1}`},
+		"bad method":     {"model": adviseModel, "method": "random"},
+	} {
+		if result := callAdvise(t, args); !result.IsError {
+			t.Errorf("%s: expected an error result", name)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package mcp
+
+// This file implements the petri_explore MCP tool, which fuzzes a model.
+// Where petri_simulate fires a fixed list of steps, petri_explore generates
+// its own: seeded random walks or a bounded exhaustive search, with binding
+// values drawn from each transition's declared binding types. It reports
+// guard errors, constraint violations, deadlocks and transitions that never
+// fired, each finding with a minimized step list petri_simulate can replay.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/explore"
+)
+
+func exploreTool() mcp.Tool {
+	return mcp.NewTool("petri_explore",
+		mcp.WithDescription("Fuzz a model: fire transitions with generated bindings by seeded random walks or bounded exhaustive search, and report guard errors, constraint violations, deadlocks and transitions never fired. Each finding includes a minimized step list that petri_simulate replays as its 'steps'."),
+		mcp.WithString("model",
+			mcp.Required(),
+			mcp.Description("The Petri net model as JSON or a workspace handle"),
+		),
+		mcp.WithString("mode",
+			mcp.Description("random (default) for seeded random walks, or exhaustive for breadth-first search over every enabled transition and binding combination"),
+		),
+		mcp.WithNumber("walks",
+			mcp.Description(fmt.Sprintf("Random walks (default %d, max %d)", explore.DefaultWalks, explore.MaxWalks)),
+		),
+		mcp.WithNumber("depth",
+			mcp.Description(fmt.Sprintf("Steps per random walk (default %d) or exhaustive search depth (default %d)", explore.DefaultDepth, explore.DefaultSearchDepth)),
+		),
+		mcp.WithNumber("seed",
+			mcp.Description("Random seed; the same seed gives the same report (default 1)"),
+		),
+		mcp.WithNumber("max_states",
+			mcp.Description(fmt.Sprintf("Distinct states exhaustive search visits before stopping (default %d)", explore.DefaultMaxStates)),
+		),
+		mcp.WithString("data",
+			mcp.Description("JSON object of initial data place values, e.g. {\"balances\":{\"alice\":100}}"),
+		),
+		mcp.WithString("values",
+			mcp.Description("JSON object of candidate values by binding name, replacing generated ones, e.g. {\"amount\":[0,1,1000]}"),
+		),
+	)
+}
+
+// handleExplore handles the petri_explore tool request.
+func handleExplore(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	modelJSON, err := requireModel(ctx, request, "model")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	parsed, err := parseModelV2(modelJSON)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid model JSON: %v", err)), nil
+	}
+
+	opts := explore.Options{
+		Mode:      explore.Mode(request.GetString("mode", "")),
+		Walks:     request.GetInt("walks", 0),
+		Depth:     request.GetInt("depth", 0),
+		Seed:      int64(request.GetInt("seed", 1)),
+		MaxStates: request.GetInt("max_states", 0),
+	}
+	if dataJSON := request.GetString("data", ""); dataJSON != "" {
+		if err := json.Unmarshal([]byte(dataJSON), &opts.Data); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid data JSON: %v", err)), nil
+		}
+	}
+	if valuesJSON := request.GetString("values", ""); valuesJSON != "" {
+		if err := json.Unmarshal([]byte(valuesJSON), &opts.Values); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid values JSON: %v", err)), nil
+		}
+	}
+
+	report, err := explore.Explore(metamodel.FromModel(parsed.Model), opts)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal report: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(output)), nil
+}
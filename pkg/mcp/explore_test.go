@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/explore"
+)
+
+func TestExplore(t *testing.T) {
+	text := callTool(t, handleExplore, map[string]any{"model": tokenModel, "walks": float64(30), "depth": float64(8)})
+	var report explore.Report
+	if err := json.Unmarshal([]byte(text), &report); err != nil {
+		t.Fatalf("failed to parse report: %v\n%s", err, text)
+	}
+
+	var violation *explore.Finding
+	for i, f := range report.Findings {
+		if f.Kind == explore.ConstraintViolation {
+			violation = &report.Findings[i]
+		}
+	}
+	if violation == nil || violation.Transition != "mint" || len(violation.Trace) != 3 {
+		t.Fatalf("expected a three-mint violation of mint_cap, got %+v", report.Findings)
+	}
+
+	// The minimized trace replays in petri_simulate
+	steps, _ := json.Marshal(violation.Trace)
+	sim := simulateData(t, map[string]any{"model": tokenModel, "steps": string(steps)})
+	last := sim.Steps[len(sim.Steps)-1]
+	if len(last.Violations) != 1 || last.Violations[0] != "mint_cap" {
+		t.Errorf("expected petri_simulate to reproduce the violation, got %+v", last)
+	}
+}
+
+func TestExploreErrors(t *testing.T) {
+	for name, args := range map[string]map[string]any{
+		"model":  {"model": "not json"},
+		"mode":   {"model": tokenModel, "mode": "dfs"},
+		"data":   {"model": tokenModel, "data": `{"minted": 1}`},
+		"values": {"model": tokenModel, "values": `[1]`},
+	} {
+		result, _ := handleExplore(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: args},
+		})
+		if !result.IsError {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
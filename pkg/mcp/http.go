@@ -0,0 +1,192 @@
+// HTTP transport for MCP server.
+// Serves the same tools as stdio over mcp-go's streamable HTTP transport
+// (POST for requests, GET for the SSE notification stream), so one server
+// can be shared by a team or reached from browser-based agents. Clients
+// authenticate with bearer tokens; each token names a client whose sessions,
+// workspace and services are kept apart from every other client's. Tools
+// that build and run code or use the server's credentials are served only
+// when HTTPOptions.AllowServices is set.
+
+package mcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultHTTPPath is the endpoint path used when HTTPOptions.Path is empty.
+const DefaultHTTPPath = "/mcp"
+
+// HTTPOptions configures the streamable HTTP transport.
+type HTTPOptions struct {
+	Addr           string            // Listen address, e.g. ":8090"
+	Path           string            // Endpoint path (default: DefaultHTTPPath)
+	Tokens         map[string]string // Bearer token to client name; empty disables authentication
+	AllowedOrigins []string          // Origins allowed by CORS; "*" allows any; empty sends no CORS headers
+	AllowServices  bool              // Serve the delegate, service and instance tools; requires Tokens
+}
+
+// Validate checks that every token names a usable client.
+func (o HTTPOptions) Validate() error {
+	for token, client := range o.Tokens {
+		if token == "" {
+			return fmt.Errorf("empty bearer token for client %q", client)
+		}
+		// Client names become workspace directory names
+		if !handlePattern.MatchString(client) {
+			return fmt.Errorf("invalid client name %q (use letters, digits, '.', '_' and '-')", client)
+		}
+	}
+	// Services belong to the client that started them, so clients must be known
+	if o.AllowServices && len(o.Tokens) == 0 {
+		return fmt.Errorf("serving service tools requires bearer tokens")
+	}
+	if o.Path != "" && !strings.HasPrefix(o.Path, "/") {
+		return fmt.Errorf("path must start with '/': %s", o.Path)
+	}
+	return nil
+}
+
+type clientKey struct{}
+
+// WithClient returns a context carrying the authenticated client name.
+func WithClient(ctx context.Context, client string) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// ClientFromContext returns the authenticated client name, or "" over stdio
+// and unauthenticated HTTP.
+func ClientFromContext(ctx context.Context) string {
+	client, _ := ctx.Value(clientKey{}).(string)
+	return client
+}
+
+// NewHTTPHandler returns a handler serving the MCP server at opts.Path.
+func NewHTTPHandler(opts HTTPOptions) (http.Handler, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	path := opts.Path
+	if path == "" {
+		path = DefaultHTTPPath
+	}
+
+	streamable := server.NewStreamableHTTPServer(newServer(opts.AllowServices),
+		server.WithEndpointPath(path),
+		server.WithSessionIdManagerResolver(&clientSessions{}),
+		server.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+			return WithClient(ctx, ClientFromContext(r.Context()))
+		}),
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle(path, authenticate(opts.Tokens, streamable))
+	return cors(opts.AllowedOrigins, mux), nil
+}
+
+// ServeHTTP runs the MCP server over streamable HTTP on opts.Addr.
+func ServeHTTP(opts HTTPOptions) error {
+	handler, err := NewHTTPHandler(opts)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Addr:              opts.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
+
+// authenticate requires a known bearer token and records its client name in
+// the request context. With no tokens configured every request is allowed.
+func authenticate(tokens map[string]string, next http.Handler) http.Handler {
+	if len(tokens) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		client := ""
+		if ok {
+			// Compare against every token so timing does not reveal which matched
+			for token, name := range tokens {
+				if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+					client = name
+				}
+			}
+		}
+		if client == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="petri-pilot"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithClient(r.Context(), client)))
+	})
+}
+
+// cors adds CORS headers for allowed origins and answers preflight requests,
+// which browsers send without credentials, before authentication runs.
+func cors(origins []string, next http.Handler) http.Handler {
+	if len(origins) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		allowed := false
+		for _, o := range origins {
+			if o == "*" || o == origin {
+				allowed = true
+				break
+			}
+		}
+
+		if allowed && origin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Mcp-Session-Id, Mcp-Protocol-Version, Last-Event-ID")
+			w.Header().Set("Access-Control-Expose-Headers", "Mcp-Session-Id")
+			w.Header().Add("Vary", "Origin")
+		}
+
+		if r.Method == http.MethodOptions {
+			if !allowed {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientSessions keeps a separate session table per client, so a session ID
+// issued to one client is rejected when presented with another client's token.
+type clientSessions struct {
+	mu       sync.Mutex
+	managers map[string]server.SessionIdManager
+}
+
+func (c *clientSessions) ResolveSessionIdManager(r *http.Request) server.SessionIdManager {
+	client := ClientFromContext(r.Context())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.managers == nil {
+		c.managers = make(map[string]server.SessionIdManager)
+	}
+	m, ok := c.managers[client]
+	if !ok {
+		m = &server.InsecureStatefulSessionIdManager{}
+		c.managers[client] = m
+	}
+	return m
+}
@@ -0,0 +1,274 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var httpTokens = map[string]string{"alice-token": "alice", "bob-token": "bob"}
+
+func newHTTPTestServer(t *testing.T, opts HTTPOptions) *httptest.Server {
+	t.Helper()
+	handler, err := NewHTTPHandler(opts)
+	if err != nil {
+		t.Fatalf("NewHTTPHandler: %v", err)
+	}
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// connect starts and initializes an MCP client that sends the given bearer token.
+func connect(t *testing.T, ts *httptest.Server, token string) *client.Client {
+	t.Helper()
+	c, err := client.NewStreamableHttpClient(ts.URL+DefaultHTTPPath,
+		transport.WithHTTPHeaders(map[string]string{"Authorization": "Bearer " + token}),
+	)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	init := mcp.InitializeRequest{}
+	init.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	init.Params.ClientInfo = mcp.Implementation{Name: "test", Version: "1.0.0"}
+	if _, err := c.Initialize(ctx, init); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	return c
+}
+
+func callHTTPTool(t *testing.T, c *client.Client, name string, args map[string]any) string {
+	t.Helper()
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	result, err := c.CallTool(context.Background(), req)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("%s: %s", name, text)
+	}
+	return text
+}
+
+func TestHTTPRequiresToken(t *testing.T) {
+	ts := newHTTPTestServer(t, HTTPOptions{Tokens: httpTokens})
+
+	for _, auth := range []string{"", "Bearer wrong", "alice-token"} {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+DefaultHTTPPath, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized || resp.Header.Get("WWW-Authenticate") == "" {
+			t.Errorf("%q: expected 401 with a challenge, got %d", auth, resp.StatusCode)
+		}
+	}
+}
+
+func TestHTTPTools(t *testing.T) {
+	useTempWorkspace(t)
+	ts := newHTTPTestServer(t, HTTPOptions{Tokens: httpTokens})
+	alice := connect(t, ts, "alice-token")
+	bob := connect(t, ts, "bob-token")
+
+	tools, err := alice.ListTools(context.Background(), mcp.ListToolsRequest{})
+	if err != nil {
+		t.Fatalf("list tools: %v", err)
+	}
+	found := false
+	for _, tool := range tools.Tools {
+		found = found || tool.Name == "petri_workspace_save"
+	}
+	if !found {
+		t.Error("expected petri_workspace_save over HTTP")
+	}
+
+	callHTTPTool(t, alice, "petri_workspace_save", map[string]any{"model": workspaceModel})
+
+	// Each client has its own workspace
+	var summaries []WorkspaceSummary
+	if err := json.Unmarshal([]byte(callHTTPTool(t, alice, "petri_workspace_list", nil)), &summaries); err != nil {
+		t.Fatalf("parse list: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].Handle != "order-flow" {
+		t.Errorf("expected alice's model, got %+v", summaries)
+	}
+	if err := json.Unmarshal([]byte(callHTTPTool(t, bob, "petri_workspace_list", nil)), &summaries); err != nil {
+		t.Fatalf("parse list: %v", err)
+	}
+	if len(summaries) != 0 {
+		t.Errorf("expected bob's workspace to be empty, got %+v", summaries)
+	}
+	if summaries, _ := workspace.List(); len(summaries) != 0 {
+		t.Errorf("expected the shared workspace to be untouched, got %+v", summaries)
+	}
+}
+
+func TestHTTPSessionsPerClient(t *testing.T) {
+	ts := newHTTPTestServer(t, HTTPOptions{Tokens: httpTokens})
+
+	post := func(token, session, body string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+DefaultHTTPPath, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+		req.Header.Set("Authorization", "Bearer "+token)
+		if session != "" {
+			req.Header.Set("Mcp-Session-Id", session)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := post("alice-token", "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"`+mcp.LATEST_PROTOCOL_VERSION+`","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	session := resp.Header.Get("Mcp-Session-Id")
+	if resp.StatusCode != http.StatusOK || session == "" {
+		t.Fatalf("expected a session from initialize, got %d %q", resp.StatusCode, session)
+	}
+
+	ping := `{"jsonrpc":"2.0","id":2,"method":"ping"}`
+	if resp := post("alice-token", session, ping); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected alice to use her session, got %d", resp.StatusCode)
+	}
+	if resp := post("bob-token", session, ping); resp.StatusCode == http.StatusOK {
+		t.Error("expected bob to be refused alice's session")
+	}
+}
+
+func TestHTTPCORS(t *testing.T) {
+	ts := newHTTPTestServer(t, HTTPOptions{Tokens: httpTokens, AllowedOrigins: []string{"https://agent.example"}})
+
+	preflight := func(origin string) *http.Response {
+		req, _ := http.NewRequest(http.MethodOptions, ts.URL+DefaultHTTPPath, nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := preflight("https://agent.example")
+	if resp.StatusCode != http.StatusNoContent || resp.Header.Get("Access-Control-Allow-Origin") != "https://agent.example" {
+		t.Errorf("expected an allowed preflight, got %d %v", resp.StatusCode, resp.Header)
+	}
+	if !strings.Contains(resp.Header.Get("Access-Control-Allow-Headers"), "Mcp-Session-Id") {
+		t.Errorf("expected the session header to be allowed, got %q", resp.Header.Get("Access-Control-Allow-Headers"))
+	}
+	if resp := preflight("https://evil.example"); resp.StatusCode != http.StatusForbidden || resp.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("expected a refused preflight, got %d", resp.StatusCode)
+	}
+}
+
+func TestHTTPOptionsValidate(t *testing.T) {
+	for name, opts := range map[string]HTTPOptions{
+		"client":   {Tokens: map[string]string{"t": "../alice"}},
+		"token":    {Tokens: map[string]string{"": "alice"}},
+		"path":     {Path: "mcp"},
+		"services": {AllowServices: true},
+	} {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestHTTPServiceToolsOptIn(t *testing.T) {
+	toolNames := func(opts HTTPOptions) map[string]bool {
+		t.Helper()
+		c := connect(t, newHTTPTestServer(t, opts), "alice-token")
+		tools, err := c.ListTools(context.Background(), mcp.ListToolsRequest{})
+		if err != nil {
+			t.Fatalf("list tools: %v", err)
+		}
+		names := make(map[string]bool)
+		for _, tool := range tools.Tools {
+			names[tool.Name] = true
+		}
+		return names
+	}
+
+	// Tools that run code or use the server's credentials need an opt-in
+	local := []string{"service_start", "service_stop", "service_fire", "delegate_app"}
+	names := toolNames(HTTPOptions{Tokens: httpTokens})
+	for _, name := range local {
+		if names[name] {
+			t.Errorf("expected %s to be left out over HTTP", name)
+		}
+	}
+	if !names["petri_validate"] {
+		t.Error("expected the model tools over HTTP")
+	}
+
+	names = toolNames(HTTPOptions{Tokens: httpTokens, AllowServices: true})
+	for _, name := range local {
+		if !names[name] {
+			t.Errorf("expected %s with AllowServices", name)
+		}
+	}
+}
+
+func TestHTTPServicesPerClient(t *testing.T) {
+	saved := svcManager
+	svcManager = &ServiceManager{stateFile: filepath.Join(t.TempDir(), "services.json")}
+	t.Cleanup(func() { svcManager = saved })
+	err := svcManager.saveState(map[string]*ServiceState{
+		"svc-1": {ID: "svc-1", Name: "order-flow", URL: "http://localhost:1", PID: os.Getpid(), Client: "alice"},
+	})
+	if err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	ts := newHTTPTestServer(t, HTTPOptions{Tokens: httpTokens, AllowServices: true})
+	alice := connect(t, ts, "alice-token")
+	bob := connect(t, ts, "bob-token")
+
+	if text := callHTTPTool(t, alice, "service_list", nil); !strings.Contains(text, `"svc-1"`) {
+		t.Errorf("expected alice to see her service, got %s", text)
+	}
+	if text := callHTTPTool(t, bob, "service_list", nil); text != "[]" {
+		t.Errorf("expected bob to see no services, got %s", text)
+	}
+
+	// Bob cannot stop or inspect alice's service
+	for _, name := range []string{"service_stop", "service_logs", "service_health"} {
+		req := mcp.CallToolRequest{}
+		req.Params.Name = name
+		req.Params.Arguments = map[string]any{"service_id": "svc-1"}
+		result, err := bob.CallTool(context.Background(), req)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "service not found") {
+			t.Errorf("%s: expected bob to be refused, got %v", name, result.Content)
+		}
+	}
+}
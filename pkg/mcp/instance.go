@@ -0,0 +1,543 @@
+// Instance tools for MCP server.
+// Drive the workflow inside a service started by service_start: create
+// aggregate instances, fire transitions, read state and event history, and
+// replay a scripted scenario over the service's generated REST API. Routes
+// come from the schema the service serves at /api/schema, so the tools work
+// with any generated service without knowing its model in advance.
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	goflowmetamodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/codegen/golang"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/api"
+)
+
+// serviceClient talks to one running service through its generated API.
+type serviceClient struct {
+	url    string
+	http   *http.Client
+	token  string
+	slug   string
+	routes map[string]string // transition ID -> HTTP path
+}
+
+// connectService looks up a running service and reads its schema.
+func connectService(ctx context.Context, serviceID string) (*serviceClient, error) {
+	svc, err := clientService(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &serviceClient{
+		url:  strings.TrimRight(svc.URL, "/"),
+		http: &http.Client{Timeout: 30 * time.Second},
+	}
+
+	var schema json.RawMessage
+	if err := c.do(ctx, http.MethodGet, "/api/schema", nil, &schema); err != nil {
+		return nil, fmt.Errorf("failed to read service schema: %v", err)
+	}
+	parsed, err := parseModelV2(string(schema))
+	if err != nil {
+		return nil, fmt.Errorf("invalid service schema: %v", err)
+	}
+
+	c.slug = golang.APISlug(parsed.Model.Name)
+	c.routes = make(map[string]string)
+	for _, route := range goflowmetamodel.InferAPIRoutes(parsed.Model) {
+		if route.TransitionID != "" {
+			c.routes[route.TransitionID] = route.Path
+		}
+	}
+	return c, nil
+}
+
+// do sends a JSON request and decodes a JSON response into out.
+// Non-2xx responses become errors carrying the service's error code.
+func (c *serviceClient) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr api.ErrorResponse
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Code != "" {
+			return fmt.Errorf("%s %s: %d %s: %s", method, path, resp.StatusCode, apiErr.Code, apiErr.Message)
+		}
+		return fmt.Errorf("%s %s: %d %s", method, path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// Login creates a test session through /api/debug/login and uses its token
+// for later requests. Empty roles leave the choice to the service.
+func (c *serviceClient) Login(ctx context.Context, login string, roles []string) (string, error) {
+	var session struct {
+		Token string `json:"token"`
+	}
+	body := map[string]any{"login": login, "roles": roles}
+	if err := c.do(ctx, http.MethodPost, "/api/debug/login", body, &session); err != nil {
+		return "", fmt.Errorf("login failed: %v", err)
+	}
+	if session.Token == "" {
+		return "", fmt.Errorf("login failed: service returned no token")
+	}
+	c.token = session.Token
+	return session.Token, nil
+}
+
+// Create starts a new aggregate instance.
+func (c *serviceClient) Create(ctx context.Context) (*api.StateResponse, error) {
+	var state api.StateResponse
+	if err := c.do(ctx, http.MethodPost, "/api/"+c.slug, map[string]any{}, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// State returns an instance's current state and enabled transitions.
+func (c *serviceClient) State(ctx context.Context, id string) (*api.StateResponse, error) {
+	var state api.StateResponse
+	if err := c.do(ctx, http.MethodGet, "/api/"+c.slug+"/"+id, nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Events returns an instance's event history.
+func (c *serviceClient) Events(ctx context.Context, id string) ([]json.RawMessage, error) {
+	var history struct {
+		Events []json.RawMessage `json:"events"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/"+c.slug+"/"+id+"/events", nil, &history); err != nil {
+		return nil, err
+	}
+	return history.Events, nil
+}
+
+// Fire fires a transition on an instance with the given bindings as event data.
+func (c *serviceClient) Fire(ctx context.Context, id, transition string, bindings map[string]any) (*api.TransitionResult, error) {
+	path, ok := c.routes[transition]
+	if !ok {
+		return nil, fmt.Errorf("transition not found in model: %s", transition)
+	}
+	if bindings == nil {
+		bindings = map[string]any{}
+	}
+	var result api.TransitionResult
+	body := map[string]any{"aggregate_id": id, "data": bindings}
+	if err := c.do(ctx, http.MethodPost, path, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ScenarioStep is one scripted firing against a running service. Login and
+// Roles switch to a fresh test session before the step fires.
+type ScenarioStep struct {
+	SimulationStep
+	Login string   `json:"login,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// ScenarioResult is the trace of a scenario run against a service. It has the
+// same shape as petri_simulate output, so the two can be compared directly.
+type ScenarioResult struct {
+	ServiceID   string `json:"service_id"`
+	AggregateID string `json:"aggregate_id"`
+	SimulationResult
+	Events []json.RawMessage `json:"events,omitempty"`
+}
+
+// runScenario fires each step in order on one instance, recording the place
+// marking before and after. Like petri_simulate, a failed step is recorded
+// and the run continues with the next one.
+func runScenario(ctx context.Context, c *serviceClient, id string, steps []ScenarioStep) (*ScenarioResult, error) {
+	state, err := c.State(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ScenarioResult{
+		AggregateID: id,
+		SimulationResult: SimulationResult{
+			Success:        true,
+			Steps:          make([]StepResult, 0, len(steps)),
+			Fired:          make([]string, 0, len(steps)),
+			Failed:         make([]FailedStep, 0),
+			InitialMarking: state.Places,
+		},
+	}
+
+	for _, step := range steps {
+		if step.Login != "" || len(step.Roles) > 0 {
+			if _, err := c.Login(ctx, step.Login, step.Roles); err != nil {
+				return nil, err
+			}
+		}
+
+		stepResult := StepResult{
+			Transition:  step.Transition,
+			Enabled:     slices.Contains(state.EnabledTransitions, step.Transition),
+			StateBefore: state.Places,
+			StateAfter:  state.Places,
+		}
+		if _, known := c.routes[step.Transition]; !known {
+			stepResult.Error = "transition not found in model"
+		} else if !stepResult.Enabled {
+			stepResult.Error = fmt.Sprintf("transition not enabled (enabled: %s)", strings.Join(state.EnabledTransitions, ", "))
+		} else if _, err := c.Fire(ctx, id, step.Transition, step.Bindings); err != nil {
+			stepResult.Error = err.Error()
+		} else if state, err = c.State(ctx, id); err != nil {
+			return nil, err
+		} else {
+			stepResult.StateAfter = state.Places
+		}
+
+		result.Steps = append(result.Steps, stepResult)
+		if stepResult.Error == "" {
+			result.Fired = append(result.Fired, step.Transition)
+		} else {
+			result.Success = false
+			result.Failed = append(result.Failed, FailedStep{TransitionID: step.Transition, Reason: stepResult.Error})
+		}
+	}
+
+	result.FinalState = state.Places
+	result.FinalMarking = state.Places
+	result.Enabled = state.EnabledTransitions
+	result.IsDeadlock = len(state.EnabledTransitions) == 0
+
+	if result.Events, err = c.Events(ctx, id); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// connectFromRequest connects to the requested service and, when the request
+// carries a token or test identity, authenticates as that user.
+func connectFromRequest(ctx context.Context, request mcp.CallToolRequest) (*serviceClient, error) {
+	serviceID, err := request.RequireString("service_id")
+	if err != nil {
+		return nil, fmt.Errorf("missing service_id parameter")
+	}
+	c, err := connectService(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if token := request.GetString("token", ""); token != "" {
+		c.token = token
+		return c, nil
+	}
+	login := request.GetString("login", "")
+	roles := splitRoles(request.GetString("roles", ""))
+	if login != "" || len(roles) > 0 {
+		if _, err := c.Login(ctx, login, roles); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// splitRoles parses a comma-separated role list.
+func splitRoles(value string) []string {
+	var roles []string
+	for _, role := range strings.Split(value, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func toolJSON(v any) (*mcp.CallToolResult, error) {
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(output)), nil
+}
+
+// MCP Tool Definitions
+
+// authOptions are the parameters shared by tools that may need a session.
+func authOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("service_id",
+			mcp.Required(),
+			mcp.Description("The service ID from service_start (e.g., svc-1)"),
+		),
+		mcp.WithString("token",
+			mcp.Description("Bearer token from service_login"),
+		),
+		mcp.WithString("roles",
+			mcp.Description("Comma-separated roles to log in with through /api/debug/login before the call (e.g., customer,admin)"),
+		),
+		mcp.WithString("login",
+			mcp.Description("Test user login for /api/debug/login (default: chosen by the service)"),
+		),
+	}
+}
+
+func serviceLoginTool() mcp.Tool {
+	return mcp.NewTool("service_login",
+		mcp.WithDescription("Create a test session on a running service through its /api/debug/login endpoint and return the bearer token for the other service tools. Requires a service generated with debug enabled."),
+		mcp.WithString("service_id",
+			mcp.Required(),
+			mcp.Description("The service ID from service_start (e.g., svc-1)"),
+		),
+		mcp.WithString("roles",
+			mcp.Description("Comma-separated roles for the test user (e.g., customer,admin)"),
+		),
+		mcp.WithString("login",
+			mcp.Description("Test user login (default: chosen by the service)"),
+		),
+	)
+}
+
+func handleServiceLogin(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	serviceID, err := request.RequireString("service_id")
+	if err != nil {
+		return mcp.NewToolResultError("missing service_id parameter"), nil
+	}
+	c, err := connectService(ctx, serviceID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	roles := splitRoles(request.GetString("roles", ""))
+	token, err := c.Login(ctx, request.GetString("login", ""), roles)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return toolJSON(map[string]any{"token": token, "roles": roles})
+}
+
+func serviceCreateInstanceTool() mcp.Tool {
+	return mcp.NewTool("service_create_instance",
+		append([]mcp.ToolOption{
+			mcp.WithDescription("Create a new workflow instance on a running service. Returns the aggregate ID, initial place marking and enabled transitions."),
+		}, authOptions()...)...,
+	)
+}
+
+func handleServiceCreateInstance(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c, err := connectFromRequest(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	state, err := c.Create(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to create instance: %v", err)), nil
+	}
+	return toolJSON(state)
+}
+
+func serviceInstanceStateTool() mcp.Tool {
+	return mcp.NewTool("service_instance_state",
+		append([]mcp.ToolOption{
+			mcp.WithDescription("Get the current state of a workflow instance on a running service, including its place marking and the transitions that can fire next."),
+			mcp.WithString("aggregate_id",
+				mcp.Required(),
+				mcp.Description("The instance's aggregate ID"),
+			),
+		}, authOptions()...)...,
+	)
+}
+
+func handleServiceInstanceState(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := request.RequireString("aggregate_id")
+	if err != nil {
+		return mcp.NewToolResultError("missing aggregate_id parameter"), nil
+	}
+	c, err := connectFromRequest(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	state, err := c.State(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get state: %v", err)), nil
+	}
+	return toolJSON(state)
+}
+
+func serviceInstanceEventsTool() mcp.Tool {
+	return mcp.NewTool("service_instance_events",
+		append([]mcp.ToolOption{
+			mcp.WithDescription("Get the event history of a workflow instance on a running service."),
+			mcp.WithString("aggregate_id",
+				mcp.Required(),
+				mcp.Description("The instance's aggregate ID"),
+			),
+		}, authOptions()...)...,
+	)
+}
+
+func handleServiceInstanceEvents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := request.RequireString("aggregate_id")
+	if err != nil {
+		return mcp.NewToolResultError("missing aggregate_id parameter"), nil
+	}
+	c, err := connectFromRequest(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	events, err := c.Events(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get events: %v", err)), nil
+	}
+	return toolJSON(map[string]any{"aggregate_id": id, "events": events})
+}
+
+func serviceFireTool() mcp.Tool {
+	return mcp.NewTool("service_fire",
+		append([]mcp.ToolOption{
+			mcp.WithDescription("Fire a transition on a workflow instance of a running service. Returns the new place marking and enabled transitions, or the service's error (e.g. TRANSITION_FAILED, FORBIDDEN)."),
+			mcp.WithString("aggregate_id",
+				mcp.Required(),
+				mcp.Description("The instance's aggregate ID"),
+			),
+			mcp.WithString("transition",
+				mcp.Required(),
+				mcp.Description("The transition ID to fire"),
+			),
+			mcp.WithString("bindings",
+				mcp.Description("JSON object of event data for the transition, e.g. {\"amount\": 10}"),
+			),
+		}, authOptions()...)...,
+	)
+}
+
+func handleServiceFire(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := request.RequireString("aggregate_id")
+	if err != nil {
+		return mcp.NewToolResultError("missing aggregate_id parameter"), nil
+	}
+	transition, err := request.RequireString("transition")
+	if err != nil {
+		return mcp.NewToolResultError("missing transition parameter"), nil
+	}
+	var bindings map[string]any
+	if bindingsJSON := request.GetString("bindings", ""); bindingsJSON != "" {
+		if err := json.Unmarshal([]byte(bindingsJSON), &bindings); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid bindings JSON: %v", err)), nil
+		}
+	}
+
+	c, err := connectFromRequest(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result, err := c.Fire(ctx, id, transition, bindings)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fire %s: %v", transition, err)), nil
+	}
+	return toolJSON(result)
+}
+
+func serviceScenarioTool() mcp.Tool {
+	return mcp.NewTool("service_scenario",
+		append([]mcp.ToolOption{
+			mcp.WithDescription("Run a scripted scenario against a running service: fire each step in order on one instance and return a step-by-step trace in the same shape as petri_simulate, plus the resulting event history. Failed steps are recorded and the run continues."),
+			mcp.WithString("steps",
+				mcp.Required(),
+				mcp.Description("JSON array of steps, e.g. [{\"transition\":\"submit\",\"bindings\":{\"amount\":10}},{\"transition\":\"approve\",\"roles\":[\"admin\"]}]. A step's login/roles switch to a new test session before it fires."),
+			),
+			mcp.WithString("aggregate_id",
+				mcp.Description("Existing instance to drive (default: create a new one)"),
+			),
+		}, authOptions()...)...,
+	)
+}
+
+func handleServiceScenario(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	stepsJSON, err := request.RequireString("steps")
+	if err != nil {
+		return mcp.NewToolResultError("missing steps parameter"), nil
+	}
+	var steps []ScenarioStep
+	if err := json.Unmarshal([]byte(stepsJSON), &steps); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid steps JSON: %v", err)), nil
+	}
+
+	c, err := connectFromRequest(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	id := request.GetString("aggregate_id", "")
+	if id == "" {
+		state, err := c.Create(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create instance: %v", err)), nil
+		}
+		id = state.AggregateID
+	}
+
+	result, err := runScenario(ctx, c, id, steps)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("scenario failed: %v", err)), nil
+	}
+	result.ServiceID = request.GetString("service_id", "")
+	return toolJSON(result)
+}
+
+// InstanceTools returns the tools that drive workflow instances on running services.
+func InstanceTools() []struct {
+	Tool    mcp.Tool
+	Handler func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+} {
+	return []struct {
+		Tool    mcp.Tool
+		Handler func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	}{
+		{serviceLoginTool(), handleServiceLogin},
+		{serviceCreateInstanceTool(), handleServiceCreateInstance},
+		{serviceInstanceStateTool(), handleServiceInstanceState},
+		{serviceInstanceEventsTool(), handleServiceInstanceEvents},
+		{serviceFireTool(), handleServiceFire},
+		{serviceScenarioTool(), handleServiceScenario},
+	}
+}
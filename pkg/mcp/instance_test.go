@@ -0,0 +1,286 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	goflowmetamodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/api"
+)
+
+// instanceModel ships an order (clerks only) and then delivers it.
+const instanceModel = `{
+	"name": "Order-Flow",
+	"places": [{"id": "pending", "initial": 1}, {"id": "shipped"}, {"id": "delivered"}],
+	"transitions": [{"id": "ship"}, {"id": "deliver"}],
+	"arcs": [
+		{"from": "pending", "to": "ship"}, {"from": "ship", "to": "shipped"},
+		{"from": "shipped", "to": "deliver"}, {"from": "deliver", "to": "delivered"}
+	]
+}`
+
+// fakeService serves the parts of a generated service's API the instance
+// tools use, over an in-memory token net.
+type fakeService struct {
+	mu       sync.Mutex
+	model    *goflowmetamodel.Model
+	markings map[string]map[string]int
+	events   map[string][]map[string]any
+	sessions map[string][]string
+}
+
+func newFakeService(t *testing.T) *httptest.Server {
+	t.Helper()
+	parsed, err := parseModelV2(instanceModel)
+	if err != nil {
+		t.Fatalf("parse model: %v", err)
+	}
+	f := &fakeService{
+		model:    parsed.Model,
+		markings: make(map[string]map[string]int),
+		events:   make(map[string][]map[string]any),
+		sessions: make(map[string][]string),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/schema", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(instanceModel))
+	})
+	mux.HandleFunc("POST /api/debug/login", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Roles []string `json:"roles"`
+		}
+		api.DecodeJSON(r, &req)
+		f.mu.Lock()
+		token := fmt.Sprintf("token-%d", len(f.sessions)+1)
+		f.sessions[token] = req.Roles
+		f.mu.Unlock()
+		api.JSON(w, http.StatusOK, map[string]any{"token": token})
+	})
+	mux.HandleFunc("POST /api/orderflow", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		id := fmt.Sprintf("order-%d", len(f.markings)+1)
+		f.markings[id] = map[string]int{"pending": 1, "shipped": 0, "delivered": 0}
+		f.mu.Unlock()
+		api.JSON(w, http.StatusCreated, f.state(id))
+	})
+	mux.HandleFunc("GET /api/orderflow/{id}", func(w http.ResponseWriter, r *http.Request) {
+		api.JSON(w, http.StatusOK, f.state(r.PathValue("id")))
+	})
+	mux.HandleFunc("GET /api/orderflow/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		api.JSON(w, http.StatusOK, map[string]any{"events": f.events[r.PathValue("id")]})
+	})
+	for _, route := range goflowmetamodel.InferAPIRoutes(parsed.Model) {
+		mux.HandleFunc("POST "+route.Path, f.fire(route.TransitionID))
+	}
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func (f *fakeService) state(id string) api.StateResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return api.StateResponse{AggregateID: id, Places: f.markings[id], EnabledTransitions: f.enabled(id)}
+}
+
+func (f *fakeService) enabled(id string) []string {
+	var enabled []string
+	for _, t := range f.model.Transitions {
+		ok := true
+		for _, arc := range f.model.Arcs {
+			if arc.To == t.ID && f.markings[id][arc.From] < 1 {
+				ok = false
+			}
+		}
+		if ok {
+			enabled = append(enabled, t.ID)
+		}
+	}
+	return enabled
+}
+
+func (f *fakeService) fire(transition string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			AggregateID string         `json:"aggregate_id"`
+			Data        map[string]any `json:"data"`
+		}
+		if err := api.DecodeJSON(r, &req); err != nil {
+			api.Error(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		roles := f.sessions[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+		if transition == "ship" && !slices.Contains(roles, "clerk") {
+			api.Error(w, http.StatusForbidden, "FORBIDDEN", "requires role clerk")
+			return
+		}
+		if !slices.Contains(f.enabled(req.AggregateID), transition) {
+			api.Error(w, http.StatusConflict, "TRANSITION_FAILED", "transition not enabled")
+			return
+		}
+		for _, arc := range f.model.Arcs {
+			if arc.To == transition {
+				f.markings[req.AggregateID][arc.From]--
+			}
+			if arc.From == transition {
+				f.markings[req.AggregateID][arc.To]++
+			}
+		}
+		f.events[req.AggregateID] = append(f.events[req.AggregateID], map[string]any{"type": transition, "data": req.Data})
+		api.JSON(w, http.StatusOK, api.TransitionResult{Success: true, AggregateID: req.AggregateID, EnabledTransitions: f.enabled(req.AggregateID)})
+	}
+}
+
+// useFakeService registers a fake service as svc-1 in a temporary service state.
+func useFakeService(t *testing.T) {
+	t.Helper()
+	ts := newFakeService(t)
+
+	saved := svcManager
+	svcManager = &ServiceManager{stateFile: filepath.Join(t.TempDir(), "services.json")}
+	t.Cleanup(func() { svcManager = saved })
+
+	err := svcManager.saveState(map[string]*ServiceState{
+		"svc-1": {ID: "svc-1", Name: "order-flow", URL: ts.URL, PID: os.Getpid()},
+	})
+	if err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+}
+
+func TestInstanceFire(t *testing.T) {
+	useFakeService(t)
+
+	var created api.StateResponse
+	text := callTool(t, handleServiceCreateInstance, map[string]any{"service_id": "svc-1"})
+	if err := json.Unmarshal([]byte(text), &created); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if created.AggregateID == "" || created.Places["pending"] != 1 {
+		t.Fatalf("unexpected new instance: %+v", created)
+	}
+
+	// Firing without the clerk role is refused by the service
+	result, _ := handleServiceFire(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{
+			"service_id": "svc-1", "aggregate_id": created.AggregateID, "transition": "ship",
+		}},
+	})
+	if !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "FORBIDDEN") {
+		t.Errorf("expected a FORBIDDEN error, got %v", result.Content[0])
+	}
+
+	var login struct {
+		Token string `json:"token"`
+	}
+	text = callTool(t, handleServiceLogin, map[string]any{"service_id": "svc-1", "roles": "clerk"})
+	if err := json.Unmarshal([]byte(text), &login); err != nil || login.Token == "" {
+		t.Fatalf("expected a token, got %s", text)
+	}
+
+	callTool(t, handleServiceFire, map[string]any{
+		"service_id":   "svc-1",
+		"aggregate_id": created.AggregateID,
+		"transition":   "ship",
+		"bindings":     `{"carrier": "ups"}`,
+		"token":        login.Token,
+	})
+
+	var state api.StateResponse
+	text = callTool(t, handleServiceInstanceState, map[string]any{"service_id": "svc-1", "aggregate_id": created.AggregateID})
+	if err := json.Unmarshal([]byte(text), &state); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if state.Places["shipped"] != 1 || !slices.Equal(state.EnabledTransitions, []string{"deliver"}) {
+		t.Errorf("expected the order shipped with deliver enabled, got %+v", state)
+	}
+
+	text = callTool(t, handleServiceInstanceEvents, map[string]any{"service_id": "svc-1", "aggregate_id": created.AggregateID})
+	if !strings.Contains(text, `"carrier": "ups"`) {
+		t.Errorf("expected the ship event with its bindings, got %s", text)
+	}
+}
+
+func TestInstanceScenario(t *testing.T) {
+	useFakeService(t)
+
+	text := callTool(t, handleServiceScenario, map[string]any{
+		"service_id": "svc-1",
+		"steps":      `[{"transition":"deliver"},{"transition":"ship","roles":["clerk"]},{"transition":"deliver"}]`,
+	})
+	var trace ScenarioResult
+	if err := json.Unmarshal([]byte(text), &trace); err != nil {
+		t.Fatalf("parse: %v\n%s", err, text)
+	}
+
+	if trace.Success || len(trace.Steps) != 3 || len(trace.Events) != 2 {
+		t.Fatalf("expected one failed step and two events, got %s", text)
+	}
+	if trace.Steps[0].Enabled || trace.Steps[0].Error == "" {
+		t.Errorf("expected deliver to start disabled, got %+v", trace.Steps[0])
+	}
+	if !slices.Equal(trace.Fired, []string{"ship", "deliver"}) {
+		t.Errorf("expected ship then deliver to fire, got %v", trace.Fired)
+	}
+	if trace.FinalState["delivered"] != 1 || !trace.IsDeadlock {
+		t.Errorf("expected the order delivered and the net dead, got %+v", trace.FinalState)
+	}
+
+	// The trace matches petri_simulate over the same model
+	simulated := callTool(t, handleSimulateWithSteps, map[string]any{
+		"model": instanceModel,
+		"steps": `[{"transition":"deliver"},{"transition":"ship"},{"transition":"deliver"}]`,
+	})
+	var sim SimulationResult
+	if err := json.Unmarshal([]byte(simulated), &sim); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for i := range sim.Steps {
+		if fmt.Sprint(sim.Steps[i].StateAfter) != fmt.Sprint(trace.Steps[i].StateAfter) {
+			t.Errorf("step %d: simulated %v, service %v", i, sim.Steps[i].StateAfter, trace.Steps[i].StateAfter)
+		}
+	}
+}
+
+func TestInstanceErrors(t *testing.T) {
+	useFakeService(t)
+
+	for name, args := range map[string]map[string]any{
+		"service":    {"service_id": "svc-9", "steps": `[]`},
+		"steps":      {"service_id": "svc-1", "steps": `{"transition":"ship"}`},
+		"no service": {"steps": `[]`},
+	} {
+		result, _ := handleServiceScenario(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: args},
+		})
+		if !result.IsError {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	result, _ := handleServiceFire(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{
+			"service_id": "svc-1", "aggregate_id": "order-1", "transition": "missing",
+		}},
+	})
+	if !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "not found") {
+		t.Errorf("expected an unknown transition error, got %v", result.Content[0])
+	}
+}
@@ -0,0 +1,269 @@
+package mcp
+
+// This file implements the petri_lint MCP tool and petri_extend's rename
+// operations. petri_lint reports problems by stable rule ID; fixable ones
+// carry petri_extend operations, which ApplyFixes applies for lint -fix.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	goflowmetamodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/lint"
+)
+
+func lintTool() mcp.Tool {
+	var rules []string
+	for _, r := range lint.Rules() {
+		rules = append(rules, fmt.Sprintf("%s (%s)", r.ID, r.Severity))
+	}
+	return mcp.NewTool("petri_lint",
+		mcp.WithDescription("Lint a model for legal but likely mistaken constructs. Rules, with default severity: "+strings.Join(rules, ", ")+". Models set severities in a \"lint\" block. Fixable diagnostics include petri_extend operations that can be passed to petri_extend as they are."),
+		mcp.WithString("model",
+			mcp.Required(),
+			mcp.Description("The Petri net model as JSON or a workspace handle"),
+		),
+		mcp.WithString("rules",
+			mcp.Description("JSON object of severities by rule ID (off, info, warning, error), overriding the model's lint block, e.g. {\"naming\":\"off\"}"),
+		),
+		mcp.WithBoolean("fix",
+			mcp.Description("Apply every fix that has operations and return the fixed model; a workspace handle gets a new revision (default false)"),
+		),
+	)
+}
+
+// handleLint handles the petri_lint tool request.
+func handleLint(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	modelJSON, err := requireModel(ctx, request, "model")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	in, err := lintInput(modelJSON)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid model JSON: %v", err)), nil
+	}
+	if rulesJSON := request.GetString("rules", ""); rulesJSON != "" {
+		var overrides lint.Config
+		if err := json.Unmarshal([]byte(rulesJSON), &overrides); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid rules JSON: %v", err)), nil
+		}
+		if in.Config == nil {
+			in.Config = lint.Config{}
+		}
+		for id, sev := range overrides {
+			in.Config[id] = sev
+		}
+	}
+
+	report, err := lint.Lint(in)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := struct {
+		*lint.Report
+		Operations []lint.Operation `json:"operations,omitempty"`
+		Applied    []string         `json:"applied,omitempty"`
+		Skipped    []string         `json:"skipped,omitempty"`
+		Model      string           `json:"model,omitempty"`
+		Handle     string           `json:"handle,omitempty"`
+		Revision   int              `json:"revision,omitempty"`
+	}{Report: report, Operations: report.Operations()}
+
+	if request.GetBool("fix", false) && report.Fixable > 0 {
+		fixed, applied, skipped, err := ApplyFixes(modelJSON, report)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result.Applied, result.Skipped, result.Model = applied, skipped, fixed
+
+		ref, fromWorkspace := parseModelRef(request.GetString("model", ""))
+		if fromWorkspace && len(applied) > 0 {
+			m, err := workspaceFor(ctx).Save(ref.Handle, in.Net.Name, fixed, "petri_lint: "+strings.Join(applied, ", "))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to save revision: %v", err)), nil
+			}
+			result.Handle = m.Handle
+			result.Revision = m.Head().Number
+		}
+	}
+
+	output, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal report: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(output)), nil
+}
+
+// lintInput reads a model document for linting. Documents lint.Parse
+// cannot read, such as DSL and pflow.xyz models, are linted as a bare net.
+func lintInput(modelJSON string) (*lint.Input, error) {
+	if !strings.HasPrefix(strings.TrimSpace(modelJSON), "(") {
+		if in, err := lint.Parse([]byte(modelJSON)); err == nil {
+			return in, nil
+		}
+	}
+	parsed, err := parseModelV2(modelJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &lint.Input{Net: parsed.Model}, nil
+}
+
+// ApplyFixes applies the operations of each fixable diagnostic to a model
+// document, keeping its extensions, and returns the fixed document. A fix
+// whose operations fail, typically because an earlier fix removed or
+// renamed what it refers to, is skipped as a whole. applied and skipped
+// name the diagnostics as rule:element.
+func ApplyFixes(document string, report *lint.Report) (fixed string, applied, skipped []string, err error) {
+	parsed, err := parseModelV2(document)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("invalid model JSON: %w", err)
+	}
+	before, err := json.Marshal(parsed.Model)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	model := parsed.Model
+	for _, d := range report.Diagnostics {
+		if d.Fix == nil || len(d.Fix.Operations) == 0 {
+			continue
+		}
+		name := d.Rule + ":" + d.Element
+		next, err := cloneModel(model)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		ok := true
+		for _, op := range d.Fix.Operations {
+			opType, _ := op["op"].(string)
+			if err := applyOperation(next, opType, op); err != nil {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			skipped = append(skipped, name)
+			continue
+		}
+		model = next
+		applied = append(applied, name)
+	}
+
+	after, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return "", nil, nil, err
+	}
+	fixed, err = extendedModelJSON(document, before, after)
+	return fixed, applied, skipped, err
+}
+
+func cloneModel(model *goflowmetamodel.Model) (*goflowmetamodel.Model, error) {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	var clone goflowmetamodel.Model
+	err = json.Unmarshal(data, &clone)
+	return &clone, err
+}
+
+// renameElement renames a place, transition or event and every reference
+// to it in the net: arcs, binding places, transition events, and guard and
+// constraint expressions. References in extensions are left alone.
+func renameElement(model *goflowmetamodel.Model, kind, id, to string) error {
+	switch kind {
+	case "place", "transition":
+		for _, p := range model.Places {
+			if p.ID == to {
+				return fmt.Errorf("'%s' is already a place", to)
+			}
+		}
+		for _, t := range model.Transitions {
+			if t.ID == to {
+				return fmt.Errorf("'%s' is already a transition", to)
+			}
+		}
+	case "event":
+		for _, e := range model.Events {
+			if e.ID == to {
+				return fmt.Errorf("'%s' is already an event", to)
+			}
+		}
+	}
+
+	found := false
+	switch kind {
+	case "place":
+		for i := range model.Places {
+			if model.Places[i].ID == id {
+				model.Places[i].ID = to
+				found = true
+			}
+		}
+		if !found {
+			return renameNotFound(kind, id)
+		}
+		ident := regexp.MustCompile(`\b` + regexp.QuoteMeta(id) + `\b`)
+		for i := range model.Transitions {
+			t := &model.Transitions[i]
+			t.Guard = ident.ReplaceAllLiteralString(t.Guard, to)
+			for j := range t.Bindings {
+				if t.Bindings[j].Place == id {
+					t.Bindings[j].Place = to
+				}
+			}
+		}
+		for i := range model.Constraints {
+			model.Constraints[i].Expr = ident.ReplaceAllLiteralString(model.Constraints[i].Expr, to)
+		}
+
+	case "transition":
+		for i := range model.Transitions {
+			if model.Transitions[i].ID == id {
+				model.Transitions[i].ID = to
+				found = true
+			}
+		}
+		if !found {
+			return renameNotFound(kind, id)
+		}
+
+	case "event":
+		for i := range model.Events {
+			if model.Events[i].ID == id {
+				model.Events[i].ID = to
+				found = true
+			}
+		}
+		if !found {
+			return renameNotFound(kind, id)
+		}
+		for i := range model.Transitions {
+			if model.Transitions[i].Event == id {
+				model.Transitions[i].Event = to
+			}
+		}
+		return nil
+	}
+
+	for i := range model.Arcs {
+		if model.Arcs[i].From == id {
+			model.Arcs[i].From = to
+		}
+		if model.Arcs[i].To == id {
+			model.Arcs[i].To = to
+		}
+	}
+	return nil
+}
+
+func renameNotFound(kind, id string) error {
+	return fmt.Errorf("%s '%s' not found for rename_%s", kind, id, kind)
+}
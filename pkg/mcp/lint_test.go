@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/lint"
+)
+
+const lintModel = `{
+	"name": "payments",
+	"places": [{"id": "Pending", "initial": 1}, {"id": "paid"}, {"id": "balances", "kind": "data", "type": "map[string]number"}],
+	"transitions": [
+		{"id": "pay", "event": "paid_event", "guard": "balances[from] >= 0", "bindings": [{"name": "from", "type": "string"}, {"name": "memo", "type": "string"}]},
+		{"id": "refund"}
+	],
+	"arcs": [{"from": "Pending", "to": "pay"}, {"from": "pay", "to": "paid"}, {"from": "Pending", "to": "refund"}],
+	"events": [{"id": "paid_event", "fields": [{"name": "from", "type": "string"}]}, {"id": "refunded"}],
+	"roles": [{"id": "clerk"}],
+	"access": [{"transition": "pay", "roles": ["clerk"]}]
+}`
+
+func TestLintFix(t *testing.T) {
+	useTempWorkspace(t)
+	callTool(t, handleWorkspaceSave, map[string]any{"model": lintModel})
+
+	text := callTool(t, handleLint, map[string]any{"model": "payments", "fix": true})
+	var result struct {
+		lint.Report
+		Operations []lint.Operation `json:"operations"`
+		Applied    []string         `json:"applied"`
+		Model      string           `json:"model"`
+		Revision   int              `json:"revision"`
+	}
+	if err := json.Unmarshal([]byte(text), &result); err != nil {
+		t.Fatalf("failed to parse result: %v\n%s", err, text)
+	}
+	want := []string{"naming:Pending", "unused-event:refunded", "unused-binding:pay.memo"}
+	if strings.Join(result.Applied, ",") != strings.Join(want, ",") || result.Revision != 2 {
+		t.Fatalf("expected %v applied as revision 2, got %+v", want, result)
+	}
+	if len(result.Operations) != 3 || result.Operations[0]["op"] != "rename_place" {
+		t.Errorf("expected the fixes as petri_extend operations, got %v", result.Operations)
+	}
+
+	// The fixed revision is clean and keeps its roles and access rules
+	m, _ := workspace.Get("payments")
+	if head := m.Head().Model; head != result.Model || !strings.Contains(head, `"clerk"`) {
+		t.Errorf("expected the fixed model with its roles saved, got %s", head)
+	}
+	var again lint.Report
+	if err := json.Unmarshal([]byte(callTool(t, handleLint, map[string]any{"model": "payments"})), &again); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+	if len(again.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics after fixing, got %+v", again.Diagnostics)
+	}
+
+	// The same operations work through petri_extend
+	ops, _ := json.Marshal(result.Operations)
+	text = callTool(t, handleExtend, map[string]any{"model": lintModel, "operations": string(ops)})
+	if !strings.Contains(text, `"success": true`) {
+		t.Errorf("expected petri_extend to apply the fixes, got %s", text)
+	}
+}
+
+func TestLintRules(t *testing.T) {
+	text := callTool(t, handleLint, map[string]any{"model": lintModel, "rules": `{"naming": "off", "unused-event": "error"}`})
+	var report lint.Report
+	if err := json.Unmarshal([]byte(text), &report); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+	if len(report.Diagnostics) != 2 || report.Errors != 1 {
+		t.Errorf("expected an unused event error and an unused binding, got %+v", report)
+	}
+}
+
+func TestRenameOperations(t *testing.T) {
+	model, err := parseModel(lintModel)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for _, op := range []map[string]any{
+		{"op": "rename_place", "id": "balances", "to": "accounts"},
+		{"op": "rename_transition", "id": "pay", "to": "settle"},
+		{"op": "rename_event", "id": "paid_event", "to": "settled"},
+	} {
+		if err := applyOperation(model, op["op"].(string), op); err != nil {
+			t.Fatalf("%v: %v", op, err)
+		}
+	}
+	pay := model.Transitions[0]
+	if pay.ID != "settle" || pay.Event != "settled" || pay.Guard != "accounts[from] >= 0" {
+		t.Errorf("unexpected transition after renames: %+v", pay)
+	}
+	if arc := model.Arcs[0]; arc.From != "Pending" || arc.To != "settle" {
+		t.Errorf("expected arcs to follow the rename, got %+v", model.Arcs[0])
+	}
+
+	for _, op := range []map[string]any{
+		{"op": "rename_place", "id": "paid", "to": "refund"},
+		{"op": "rename_transition", "id": "missing", "to": "found"},
+		{"op": "rename_event", "id": "settled"},
+	} {
+		if err := applyOperation(model, op["op"].(string), op); err == nil {
+			t.Errorf("%v: expected an error", op)
+		}
+	}
+}
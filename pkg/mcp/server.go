@@ -4,6 +4,7 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -20,12 +21,21 @@ import (
 	"github.com/pflow-xyz/petri-pilot/pkg/delegate"
 	"github.com/pflow-xyz/petri-pilot/pkg/extensions"
 	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/explore"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/migrate"
 	"github.com/pflow-xyz/petri-pilot/pkg/validator"
 	jsonschema "github.com/pflow-xyz/petri-pilot/schema"
 )
 
 // NewServer creates a new MCP server with Petri net tools.
 func NewServer() *server.MCPServer {
+	return newServer(true)
+}
+
+// newServer creates the MCP server. Without services it leaves out the
+// tools that build and run code on this machine or act with its
+// credentials: delegation, service management and instance tools.
+func newServer(services bool) *server.MCPServer {
 	s := server.NewMCPServer(
 		"petri-pilot",
 		"1.0.0",
@@ -37,6 +47,10 @@ func NewServer() *server.MCPServer {
 	s.AddTool(validateTool(), handleValidate)
 	s.AddTool(analyzeTool(), handleAnalyze)
 	s.AddTool(simulateTool(), handleSimulateWithSteps)
+	s.AddTool(adviseTool(), handleAdvise)
+	s.AddTool(stochasticTool(), handleStochastic)
+	s.AddTool(exploreTool(), handleExplore)
+	s.AddTool(lintTool(), handleLint)
 	s.AddTool(previewTool(), handlePreview)
 	s.AddTool(diffTool(), handleDiff)
 	s.AddTool(extendTool(), handleExtend)
@@ -47,14 +61,29 @@ func NewServer() *server.MCPServer {
 	s.AddTool(docsTool(), handleDocs)
 	s.AddTool(migrateTool(), handleMigrate)
 
-	// Delegate tools for GitHub Copilot integration
-	s.AddTool(delegateAppTool(), handleDelegateApp)
-	s.AddTool(delegateStatusTool(), handleDelegateStatus)
-	s.AddTool(delegateTasksTool(), handleDelegateTasks)
+	// Workspace tools for storing models under handles with undo history
+	s.AddTool(workspaceSaveTool(), handleWorkspaceSave)
+	s.AddTool(workspaceGetTool(), handleWorkspaceGet)
+	s.AddTool(workspaceListTool(), handleWorkspaceList)
+	s.AddTool(workspaceDeleteTool(), handleWorkspaceDelete)
+	s.AddTool(undoTool(), handleUndo)
+	s.AddTool(revisionDiffTool(), handleRevisionDiff)
+
+	if services {
+		// Delegate tools for GitHub Copilot integration
+		s.AddTool(delegateAppTool(), handleDelegateApp)
+		s.AddTool(delegateStatusTool(), handleDelegateStatus)
+		s.AddTool(delegateTasksTool(), handleDelegateTasks)
 
-	// Service management tools for controlling generated services
-	for _, st := range ServiceTools() {
-		s.AddTool(st.Tool, st.Handler)
+		// Service management tools for controlling generated services
+		for _, st := range ServiceTools() {
+			s.AddTool(st.Tool, st.Handler)
+		}
+
+		// Instance tools for driving workflows on running services
+		for _, it := range InstanceTools() {
+			s.AddTool(it.Tool, it.Handler)
+		}
 	}
 
 	// Register prompts for guided workflows
@@ -119,6 +148,26 @@ func registerResources(s *server.MCPServer) {
 		handleExamplesIndexResource,
 	)
 
+	// Workspace models, addressable by handle or handle@revision
+	s.AddResource(
+		mcp.NewResource(
+			"petri://workspace",
+			"Workspace Index",
+			mcp.WithResourceDescription("Models stored in the workspace with petri_workspace_save, with their latest revision numbers."),
+			mcp.WithMIMEType("application/json"),
+		),
+		handleWorkspaceIndexResource,
+	)
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			workspaceURIPrefix+"{handle}",
+			"Workspace model",
+			mcp.WithTemplateDescription("A model stored in the workspace. Append @revision to the handle for an earlier revision."),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		handleWorkspaceModelResource,
+	)
+
 	// Individual example resources
 	for _, name := range services.List() {
 		exampleName := name // capture for closure
@@ -192,7 +241,7 @@ func validateTool() mcp.Tool {
 		mcp.WithDescription("Validate a Petri net model for structural correctness. Checks for empty models, unconnected elements, and invalid arc references."),
 		mcp.WithString("model",
 			mcp.Required(),
-			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '(')"),
+			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '('), or a workspace handle such as 'orders' or 'orders@3'"),
 		),
 	)
 }
@@ -202,7 +251,7 @@ func analyzeTool() mcp.Tool {
 		mcp.WithDescription("Analyze a Petri net model for behavioral properties including reachability, deadlocks, liveness, boundedness, and element importance."),
 		mcp.WithString("model",
 			mcp.Required(),
-			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '(')"),
+			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '('), or a workspace handle such as 'orders' or 'orders@3'"),
 		),
 		mcp.WithBoolean("full",
 			mcp.Description("Include sensitivity analysis (element importance, symmetry groups)"),
@@ -212,10 +261,10 @@ func analyzeTool() mcp.Tool {
 
 func simulateTool() mcp.Tool {
 	return mcp.NewTool("petri_simulate",
-		mcp.WithDescription("Simulate firing transitions and see state changes. Returns detailed step-by-step state trace. Use this to verify workflow behavior before code generation."),
+		mcp.WithDescription("Simulate firing transitions and see state changes. Returns detailed step-by-step state trace, including changes to data places (maps and scalars) driven by bindings, guard failures and constraint violations, with the same semantics as the generated service. Use this to verify workflow behavior before code generation."),
 		mcp.WithString("model",
 			mcp.Required(),
-			mcp.Description("The Petri net model as JSON"),
+			mcp.Description("The Petri net model as JSON, or a workspace handle such as 'orders' or 'orders@3'"),
 		),
 		mcp.WithString("steps",
 			mcp.Description("JSON array of simulation steps with optional bindings: [{\"transition\":\"id\",\"bindings\":{...}}]. For simple cases, you can also use 'transitions' parameter."),
@@ -223,6 +272,9 @@ func simulateTool() mcp.Tool {
 		mcp.WithString("transitions",
 			mcp.Description("JSON array of transition IDs to fire in order (simple alternative to 'steps')"),
 		),
+		mcp.WithString("data",
+			mcp.Description("JSON object of initial data place values, e.g. {\"balances\":{\"alice\":100}} (default: empty maps and zero values)"),
+		),
 	)
 }
 
@@ -231,7 +283,7 @@ func previewTool() mcp.Tool {
 		mcp.WithDescription("Preview a single generated file without full code generation. Use this to check specific files before committing to full generation. Available templates: main, workflow, events, aggregate, api, openapi, test, config, migrations, auth, middleware, permissions, views, navigation, admin, debug"),
 		mcp.WithString("model",
 			mcp.Required(),
-			mcp.Description("The Petri net model as JSON"),
+			mcp.Description("The Petri net model as JSON, or a workspace handle such as 'orders' or 'orders@3'"),
 		),
 		mcp.WithString("file",
 			mcp.Required(),
@@ -242,28 +294,28 @@ func previewTool() mcp.Tool {
 
 func diffTool() mcp.Tool {
 	return mcp.NewTool("petri_diff",
-		mcp.WithDescription("Compare two Petri net models and show structural differences. Reports added, removed, and modified places, transitions, arcs, roles, and access rules."),
+		mcp.WithDescription("Compare two Petri net models and show structural differences. Reports added, removed, and modified places, transitions, arcs, bindings, roles, and access rules, with a suggested migration_plan of event upcasters for petri-pilot migrate-events."),
 		mcp.WithString("model_a",
 			mcp.Required(),
-			mcp.Description("First model as JSON (the 'before' or 'base' model)"),
+			mcp.Description("First model as JSON or a workspace handle (the 'before' or 'base' model)"),
 		),
 		mcp.WithString("model_b",
 			mcp.Required(),
-			mcp.Description("Second model as JSON (the 'after' or 'new' model)"),
+			mcp.Description("Second model as JSON or a workspace handle (the 'after' or 'new' model)"),
 		),
 	)
 }
 
 func extendTool() mcp.Tool {
 	return mcp.NewTool("petri_extend",
-		mcp.WithDescription("Modify an existing Petri net model by applying operations. Operations: add_place, add_transition, add_arc, add_event, add_event_field, add_binding, remove_place, remove_transition, remove_arc, remove_event, remove_binding. Returns the modified model."),
+		mcp.WithDescription("Modify an existing Petri net model by applying operations. Operations: add_place, add_transition, add_arc, add_event, add_event_field, add_binding, remove_place, remove_transition, remove_arc, remove_event, remove_binding, rename_place, rename_transition, rename_event. Renames update every reference in the net. Returns the modified model."),
 		mcp.WithString("model",
 			mcp.Required(),
-			mcp.Description("The Petri net model as JSON"),
+			mcp.Description("The Petri net model as JSON, or a workspace handle such as 'orders' or 'orders@3'"),
 		),
 		mcp.WithString("operations",
 			mcp.Required(),
-			mcp.Description("JSON array of operations. Each operation has 'op' (operation type) and operation-specific fields. Examples: {\"op\":\"add_place\",\"id\":\"new_state\"}, {\"op\":\"add_transition\",\"id\":\"transfer\",\"event\":\"transferred\",\"guard\":\"balances[from] >= amount\",\"bindings\":[{\"name\":\"from\",\"type\":\"string\",\"keys\":[\"from\"]},{\"name\":\"amount\",\"type\":\"number\",\"value\":true}]}, {\"op\":\"add_arc\",\"from\":\"pending\",\"to\":\"approve\"}, {\"op\":\"add_event\",\"id\":\"transferred\",\"fields\":[{\"name\":\"from\",\"type\":\"string\"},{\"name\":\"amount\",\"type\":\"number\"}]}, {\"op\":\"add_binding\",\"transition\":\"transfer\",\"name\":\"to\",\"type\":\"string\",\"keys\":[\"to\"]}"),
+			mcp.Description("JSON array of operations. Each operation has 'op' (operation type) and operation-specific fields. Examples: {\"op\":\"add_place\",\"id\":\"new_state\"}, {\"op\":\"add_transition\",\"id\":\"transfer\",\"event\":\"transferred\",\"guard\":\"balances[from] >= amount\",\"bindings\":[{\"name\":\"from\",\"type\":\"string\",\"keys\":[\"from\"]},{\"name\":\"amount\",\"type\":\"number\",\"value\":true}]}, {\"op\":\"add_arc\",\"from\":\"pending\",\"to\":\"approve\"}, {\"op\":\"add_event\",\"id\":\"transferred\",\"fields\":[{\"name\":\"from\",\"type\":\"string\"},{\"name\":\"amount\",\"type\":\"number\"}]}, {\"op\":\"add_binding\",\"transition\":\"transfer\",\"name\":\"to\",\"type\":\"string\",\"keys\":[\"to\"]}, {\"op\":\"rename_transition\",\"id\":\"doTransfer\",\"to\":\"transfer\"}. petri_lint returns fixes in this form."),
 		),
 	)
 }
@@ -273,7 +325,7 @@ func codegenTool() mcp.Tool {
 		mcp.WithDescription("Generate executable code from a validated Petri net model. Produces event-sourced application code with state machine, events, and API handlers."),
 		mcp.WithString("model",
 			mcp.Required(),
-			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '(')"),
+			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '('), or a workspace handle such as 'orders' or 'orders@3'"),
 		),
 		mcp.WithString("language",
 			mcp.Description("Target language: go, javascript, python (default: go)"),
@@ -282,7 +334,13 @@ func codegenTool() mcp.Tool {
 			mcp.Description("Package/module name for generated code"),
 		),
 		mcp.WithString("extensions",
-			mcp.Description("Optional JSON object with extensions: {\"roles\":[...], \"views\":[...], \"admin\":{...}, \"navigation\":{...}}. These add authentication, views, and UI features to the generated code."),
+			mcp.Description("Optional JSON object with extensions: {\"roles\":[...], \"readPolicies\":[...], \"rateLimits\":{...}, \"projections\":{...}, \"simulation\":{...}, \"views\":[...], \"admin\":{...}, \"navigation\":{...}}. These add authentication, field-level read redaction, rate limits, read-model projections, move advice, views, and UI features to the generated code."),
+		),
+		mcp.WithString("scenarios",
+			mcp.Description("Optional JSON scenarios added to the generated scenarios_test.go: a {\"name\", \"steps\"} object, a list of them, or a bare step list as petri_simulate takes"),
+		),
+		mcp.WithNumber("scenario_depth",
+			mcp.Description(fmt.Sprintf("Longest simple path and deadlock search replayed by scenarios_test.go (default %d)", explore.DefaultSearchDepth)),
 		),
 	)
 }
@@ -292,7 +350,7 @@ func frontendTool() mcp.Tool {
 		mcp.WithDescription("Generate a vanilla JavaScript ES modules frontend application from a Petri net model. Produces a Vite + ES modules project with API client, state display, and transition forms using plain JavaScript."),
 		mcp.WithString("model",
 			mcp.Required(),
-			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '(')"),
+			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '('), or a workspace handle such as 'orders' or 'orders@3'"),
 		),
 		mcp.WithString("project",
 			mcp.Description("Project name for package.json (default: model name)"),
@@ -311,7 +369,7 @@ func visualizeTool() mcp.Tool {
 		mcp.WithDescription("Generate an SVG visualization of a Petri net model showing places, transitions, and arcs."),
 		mcp.WithString("model",
 			mcp.Required(),
-			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '(')"),
+			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '('), or a workspace handle such as 'orders' or 'orders@3'"),
 		),
 	)
 }
@@ -321,7 +379,7 @@ func docsTool() mcp.Tool {
 		mcp.WithDescription("Generate markdown documentation from a Petri net model with mermaid diagrams for visualization. Useful for exploring and understanding models."),
 		mcp.WithString("model",
 			mcp.Required(),
-			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '(')"),
+			mcp.Description("The Petri net model as JSON or tokenmodel DSL (S-expression format starting with '('), or a workspace handle such as 'orders' or 'orders@3'"),
 		),
 		mcp.WithBoolean("include_metadata",
 			mcp.Description("Include model metadata in documentation (default: true)"),
@@ -334,7 +392,7 @@ func migrateTool() mcp.Tool {
 		mcp.WithDescription("Migrate a Petri net model from v1 (flat) to v2 (nested) schema format. V2 format separates the net definition from extensions like roles and views."),
 		mcp.WithString("model",
 			mcp.Required(),
-			mcp.Description("The v1 Petri net model as a JSON string"),
+			mcp.Description("The v1 Petri net model as a JSON string, or a workspace handle"),
 		),
 	)
 }
@@ -361,9 +419,9 @@ func applicationTool() mcp.Tool {
 // --- Tool Handlers ---
 
 func handleValidate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	modelJSON, err := request.RequireString("model")
+	modelJSON, err := requireModel(ctx, request, "model")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	parsed, err := parseModelV2(modelJSON)
@@ -389,9 +447,9 @@ func handleValidate(ctx context.Context, request mcp.CallToolRequest) (*mcp.Call
 }
 
 func handleAnalyze(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	modelJSON, err := request.RequireString("model")
+	modelJSON, err := requireModel(ctx, request, "model")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	parsed, err := parseModelV2(modelJSON)
@@ -437,9 +495,9 @@ func handleAnalyze(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallT
 }
 
 func handlePreview(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	modelJSON, err := request.RequireString("model")
+	modelJSON, err := requireModel(ctx, request, "model")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	templateName, err := request.RequireString("file")
@@ -486,30 +544,37 @@ func handlePreview(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallT
 }
 
 func handleDiff(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	modelAJSON, err := request.RequireString("model_a")
+	modelAJSON, err := requireModel(ctx, request, "model_a")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model_a parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	modelBJSON, err := request.RequireString("model_b")
+	modelBJSON, err := requireModel(ctx, request, "model_b")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model_b parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	modelA, err := parseModel(modelAJSON)
+	parsedA, err := parseModelV2(modelAJSON)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("invalid model_a JSON: %v", err)), nil
 	}
 
-	modelB, err := parseModel(modelBJSON)
+	parsedB, err := parseModelV2(modelBJSON)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("invalid model_b JSON: %v", err)), nil
 	}
 
-	// Compare models
-	diff := compareModels(modelA, modelB)
+	// Compare models, and draft the event migration the change needs
+	diff := compareModels(parsedA.Model, parsedB.Model)
+	output := struct {
+		ModelDiff
+		MigrationPlan *migrate.Plan `json:"migration_plan,omitempty"`
+	}{ModelDiff: diff}
+	if diff.HasChanges {
+		output.MigrationPlan = migrate.Suggest(diff, parsedA.Model, parsedB.Model)
+	}
 
-	outputJSON, err := json.MarshalIndent(diff, "", "  ")
+	outputJSON, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal result: %v", err)), nil
 	}
@@ -518,101 +583,16 @@ func handleDiff(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTool
 }
 
 // ModelDiff represents the differences between two models.
-type ModelDiff struct {
-	PlacesAdded        []string `json:"places_added,omitempty"`
-	PlacesRemoved      []string `json:"places_removed,omitempty"`
-	TransitionsAdded   []string `json:"transitions_added,omitempty"`
-	TransitionsRemoved []string `json:"transitions_removed,omitempty"`
-	ArcsAdded          []string `json:"arcs_added,omitempty"`
-	ArcsRemoved        []string `json:"arcs_removed,omitempty"`
-	RolesAdded         []string `json:"roles_added,omitempty"`
-	RolesRemoved       []string `json:"roles_removed,omitempty"`
-	AccessAdded        []string `json:"access_added,omitempty"`
-	AccessRemoved      []string `json:"access_removed,omitempty"`
-	HasChanges         bool     `json:"has_changes"`
-}
+type ModelDiff = migrate.ModelDiff
 
 func compareModels(a, b *goflowmetamodel.Model) ModelDiff {
-	diff := ModelDiff{}
-
-	// Compare places
-	placesA := make(map[string]bool)
-	for _, p := range a.Places {
-		placesA[p.ID] = true
-	}
-	placesB := make(map[string]bool)
-	for _, p := range b.Places {
-		placesB[p.ID] = true
-	}
-	for id := range placesB {
-		if !placesA[id] {
-			diff.PlacesAdded = append(diff.PlacesAdded, id)
-		}
-	}
-	for id := range placesA {
-		if !placesB[id] {
-			diff.PlacesRemoved = append(diff.PlacesRemoved, id)
-		}
-	}
-
-	// Compare transitions
-	transA := make(map[string]bool)
-	for _, t := range a.Transitions {
-		transA[t.ID] = true
-	}
-	transB := make(map[string]bool)
-	for _, t := range b.Transitions {
-		transB[t.ID] = true
-	}
-	for id := range transB {
-		if !transA[id] {
-			diff.TransitionsAdded = append(diff.TransitionsAdded, id)
-		}
-	}
-	for id := range transA {
-		if !transB[id] {
-			diff.TransitionsRemoved = append(diff.TransitionsRemoved, id)
-		}
-	}
-
-	// Compare arcs
-	arcKey := func(arc goflowmetamodel.Arc) string {
-		return fmt.Sprintf("%s->%s", arc.From, arc.To)
-	}
-	arcsA := make(map[string]bool)
-	for _, arc := range a.Arcs {
-		arcsA[arcKey(arc)] = true
-	}
-	arcsB := make(map[string]bool)
-	for _, arc := range b.Arcs {
-		arcsB[arcKey(arc)] = true
-	}
-	for key := range arcsB {
-		if !arcsA[key] {
-			diff.ArcsAdded = append(diff.ArcsAdded, key)
-		}
-	}
-	for key := range arcsA {
-		if !arcsB[key] {
-			diff.ArcsRemoved = append(diff.ArcsRemoved, key)
-		}
-	}
-
-	// Note: Roles and Access are now stored in extensions, not in the core Model.
-	// Diff for those constructs would require extension-aware comparison.
-
-	// Check if there are any changes
-	diff.HasChanges = len(diff.PlacesAdded) > 0 || len(diff.PlacesRemoved) > 0 ||
-		len(diff.TransitionsAdded) > 0 || len(diff.TransitionsRemoved) > 0 ||
-		len(diff.ArcsAdded) > 0 || len(diff.ArcsRemoved) > 0
-
-	return diff
+	return migrate.Compare(a, b)
 }
 
 func handleExtend(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	modelJSON, err := request.RequireString("model")
+	modelJSON, err := requireModel(ctx, request, "model")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	opsJSON, err := request.RequireString("operations")
@@ -625,6 +605,11 @@ func handleExtend(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTo
 		return mcp.NewToolResultError(fmt.Sprintf("invalid model JSON: %v", err)), nil
 	}
 	model := parsed.Model
+	ref, fromWorkspace := parseModelRef(request.GetString("model", ""))
+	before, err := json.Marshal(model)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal model: %v", err)), nil
+	}
 
 	// Parse operations
 	var operations []map[string]any
@@ -668,6 +653,8 @@ func handleExtend(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTo
 		Errors     []string `json:"errors,omitempty"`
 		Valid      bool     `json:"valid"`
 		Model      string   `json:"model"`
+		Handle     string   `json:"handle,omitempty"`
+		Revision   int      `json:"revision,omitempty"`
 	}{
 		Success:    len(errors) == 0,
 		Applied:    applied,
@@ -676,6 +663,21 @@ func handleExtend(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTo
 		Model:      string(modelOutput),
 	}
 
+	// Extending a workspace handle records the result as a new revision,
+	// unless any operation failed
+	if fromWorkspace && len(applied) > 0 && len(errors) == 0 {
+		doc, err := extendedModelJSON(modelJSON, before, modelOutput)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to update model: %v", err)), nil
+		}
+		m, err := workspaceFor(ctx).Save(ref.Handle, model.Name, doc, "petri_extend: "+strings.Join(applied, ", "))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to save revision: %v", err)), nil
+		}
+		result.Handle = m.Handle
+		result.Revision = m.Head().Number
+	}
+
 	outputJSON, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal result: %v", err)), nil
@@ -946,6 +948,14 @@ func applyOperation(model *goflowmetamodel.Model, opType string, op map[string]a
 			return fmt.Errorf("transition '%s' not found for remove_binding", transitionID)
 		}
 
+	case "rename_place", "rename_transition", "rename_event":
+		id, _ := op["id"].(string)
+		to, _ := op["to"].(string)
+		if id == "" || to == "" {
+			return fmt.Errorf("missing 'id' or 'to' for %s", opType)
+		}
+		return renameElement(model, strings.TrimPrefix(opType, "rename_"), id, to)
+
 	default:
 		return fmt.Errorf("unknown operation: %s", opType)
 	}
@@ -1003,9 +1013,9 @@ func getOptStringArray(m map[string]any, key string) []string {
 }
 
 func handleCodegen(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	modelJSON, err := request.RequireString("model")
+	modelJSON, err := requireModel(ctx, request, "model")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Parse model (supports both v1 flat and v2 nested formats)
@@ -1019,6 +1029,13 @@ func handleCodegen(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallT
 	pkgName := request.GetString("package", model.Name)
 	extensionsJSON := request.GetString("extensions", "")
 
+	var scenarios []explore.Scenario
+	if scenariosJSON := request.GetString("scenarios", ""); scenariosJSON != "" {
+		if scenarios, err = explore.ParseScenarios([]byte(scenariosJSON)); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
 	// Supported languages: go, zk-go
 	if language != "go" && language != "golang" && language != "zk-go" {
 		return mcp.NewToolResultError(fmt.Sprintf("unsupported language: %s (supported: 'go', 'zk-go')", language)), nil
@@ -1088,8 +1105,10 @@ func handleCodegen(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallT
 
 	// Create Go generator
 	gen, err := golang.New(golang.Options{
-		PackageName:  pkgName,
-		IncludeTests: true,
+		PackageName:   pkgName,
+		IncludeTests:  true,
+		ScenarioDepth: request.GetInt("scenario_depth", 0),
+		Scenarios:     scenarios,
 	})
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to create generator: %v", err)), nil
@@ -1120,9 +1139,9 @@ func handleCodegen(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallT
 }
 
 func handleFrontend(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	modelJSON, err := request.RequireString("model")
+	modelJSON, err := requireModel(ctx, request, "model")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Parse model (supports both v1 flat and v2 nested formats)
@@ -1200,9 +1219,9 @@ func handleFrontend(ctx context.Context, request mcp.CallToolRequest) (*mcp.Call
 }
 
 func handleVisualize(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	modelJSON, err := request.RequireString("model")
+	modelJSON, err := requireModel(ctx, request, "model")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	parsed, err := parseModelV2(modelJSON)
@@ -1218,9 +1237,9 @@ func handleVisualize(ctx context.Context, request mcp.CallToolRequest) (*mcp.Cal
 }
 
 func handleDocs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	modelJSON, err := request.RequireString("model")
+	modelJSON, err := requireModel(ctx, request, "model")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	parsed, err := parseModelV2(modelJSON)
@@ -1238,9 +1257,9 @@ func handleDocs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTool
 }
 
 func handleMigrate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	modelJSON, err := request.RequireString("model")
+	modelJSON, err := requireModel(ctx, request, "model")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Parse as v1 or v2 to get the model
@@ -1290,6 +1309,37 @@ func handleMigrate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallT
 		}
 	}
 
+	policies := map[string]any{}
+	if readPolicies, ok := v1Data["readPolicies"]; ok {
+		var policiesSlice []any
+		if err := json.Unmarshal(readPolicies, &policiesSlice); err == nil && len(policiesSlice) > 0 {
+			policies["read"] = policiesSlice
+		}
+	}
+	if rateLimits, ok := v1Data["rateLimits"]; ok {
+		var rateLimitsObj any
+		if err := json.Unmarshal(rateLimits, &rateLimitsObj); err == nil && rateLimitsObj != nil {
+			policies["rateLimits"] = rateLimitsObj
+		}
+	}
+	if len(policies) > 0 {
+		extensions["petri-pilot/policies"] = policies
+	}
+
+	if projections, ok := v1Data["projections"]; ok {
+		var projectionsObj any
+		if err := json.Unmarshal(projections, &projectionsObj); err == nil && projectionsObj != nil {
+			extensions["petri-pilot/projections"] = projectionsObj
+		}
+	}
+
+	if simulation, ok := v1Data["simulation"]; ok {
+		var simulationObj any
+		if err := json.Unmarshal(simulation, &simulationObj); err == nil && simulationObj != nil {
+			extensions["petri-pilot/simulation"] = simulationObj
+		}
+	}
+
 	if navigation, ok := v1Data["navigation"]; ok {
 		var navObj any
 		if err := json.Unmarshal(navigation, &navObj); err == nil && navObj != nil {
@@ -1667,6 +1717,11 @@ func parseModelV2(input string) (*ParseResult, error) {
 		}, nil
 	}
 
+	// Pasted models have no files to import sub-nets from
+	if _, _, err := metamodel.Flatten([]byte(input), nil); errors.Is(err, metamodel.ErrNoLoader) {
+		return nil, err
+	}
+
 	// First check if this is v2 format (has "version" and "net" keys)
 	var v2 schemaV2
 	if err := json.Unmarshal([]byte(input), &v2); err == nil && v2.Version == "2.0" && v2.Net != nil {
@@ -1746,10 +1801,14 @@ func parseModel(jsonStr string) (*goflowmetamodel.Model, error) {
 
 // extensionsInput is the JSON structure for the extensions parameter.
 type extensionsInput struct {
-	Roles      []extensions.Role      `json:"roles,omitempty"`
-	Views      []extensions.View      `json:"views,omitempty"`
-	Admin      *extensions.Admin      `json:"admin,omitempty"`
-	Navigation *extensions.Navigation `json:"navigation,omitempty"`
+	Roles        []extensions.Role       `json:"roles,omitempty"`
+	ReadPolicies []extensions.ReadPolicy `json:"readPolicies,omitempty"`
+	RateLimits   *extensions.RateLimits  `json:"rateLimits,omitempty"`
+	Projections  json.RawMessage         `json:"projections,omitempty"`
+	Simulation   json.RawMessage         `json:"simulation,omitempty"`
+	Views        []extensions.View       `json:"views,omitempty"`
+	Admin        *extensions.Admin       `json:"admin,omitempty"`
+	Navigation   *extensions.Navigation  `json:"navigation,omitempty"`
 }
 
 // parseExtensions parses extension JSON and adds them to the ApplicationSpec.
@@ -1768,6 +1827,34 @@ func parseExtensions(app *extensions.ApplicationSpec, jsonStr string) error {
 		app.WithRoles(rolesExt)
 	}
 
+	// Add read policies and rate limits if provided
+	if len(ext.ReadPolicies) > 0 || ext.RateLimits != nil {
+		policiesExt := extensions.NewPolicyExtension()
+		for _, p := range ext.ReadPolicies {
+			policiesExt.AddReadPolicy(p)
+		}
+		policiesExt.RateLimits = ext.RateLimits
+		app.WithPolicies(policiesExt)
+	}
+
+	// Add read-model projection if provided
+	if len(ext.Projections) > 0 {
+		projectionsExt := extensions.NewProjectionExtension()
+		if err := json.Unmarshal(ext.Projections, projectionsExt); err != nil {
+			return fmt.Errorf("projections: %w", err)
+		}
+		app.WithProjections(projectionsExt)
+	}
+
+	// Add simulation objective and players if provided
+	if len(ext.Simulation) > 0 {
+		simulationExt := extensions.NewSimulationExtension()
+		if err := json.Unmarshal(ext.Simulation, simulationExt); err != nil {
+			return fmt.Errorf("simulation: %w", err)
+		}
+		app.WithSimulation(simulationExt)
+	}
+
 	// Add views if provided
 	if len(ext.Views) > 0 || ext.Admin != nil {
 		viewsExt := extensions.NewViewExtension()
@@ -1831,6 +1918,27 @@ func parseV2Extensions(app *extensions.ApplicationSpec, exts map[string]json.Raw
 			}
 			app.WithEntities(&entities)
 
+		case extensions.PoliciesExtensionName: // "petri-pilot/policies"
+			policiesExt := extensions.NewPolicyExtension()
+			if err := json.Unmarshal(data, policiesExt); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+			app.WithPolicies(policiesExt)
+
+		case extensions.ProjectionsExtensionName: // "petri-pilot/projections"
+			projectionsExt := extensions.NewProjectionExtension()
+			if err := json.Unmarshal(data, projectionsExt); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+			app.WithProjections(projectionsExt)
+
+		case extensions.SimulationExtensionName: // "petri-pilot/simulation"
+			simulationExt := extensions.NewSimulationExtension()
+			if err := json.Unmarshal(data, simulationExt); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+			app.WithSimulation(simulationExt)
+
 		case extensions.WorkflowsExtensionName: // "petri-pilot/workflows"
 			var workflows extensions.WorkflowExtension
 			if err := json.Unmarshal(data, &workflows); err != nil {
@@ -2103,6 +2211,8 @@ func handleApplication(ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 							Input:      step.Input,
 							OnSuccess:  step.OnSuccess,
 							OnFailure:  step.OnFailure,
+							Compensate: step.Compensate,
+							Parallel:   step.Parallel,
 						})
 					}
 					
@@ -2241,6 +2351,7 @@ func generateBackendWithAccessControl(gen *golang.Generator, model *goflowmetamo
 		golang.TemplateOpenAPI,
 		golang.TemplateTest,
 		golang.TemplateMigrations,
+		golang.TemplateMigrationsPostgres,
 	}
 	
 	// Add auth templates if we have access rules or roles
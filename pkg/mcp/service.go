@@ -34,6 +34,7 @@ type ServiceState struct {
 	Port      int               `json:"port"`
 	StartedAt time.Time         `json:"started_at"`
 	Env       map[string]string `json:"env,omitempty"`
+	Client    string            `json:"client,omitempty"` // MCP client that started it; "" over stdio
 }
 
 // ServiceStats contains runtime statistics for a service.
@@ -281,6 +282,7 @@ func (m *ServiceManager) Start(ctx context.Context, directory string, port int,
 		Port:      port,
 		StartedAt: time.Now(),
 		Env:       env,
+		Client:    ClientFromContext(ctx),
 	}
 
 	// Save state immediately so we track the PID
@@ -421,6 +423,19 @@ func (m *ServiceManager) Get(id string) (*ServiceState, bool, error) {
 	return svc, true, nil
 }
 
+// clientService returns a running service started by the calling MCP
+// client. Other clients' services are reported as not found.
+func clientService(ctx context.Context, id string) (*ServiceState, error) {
+	svc, ok, err := svcManager.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %v", err)
+	}
+	if !ok || svc.Client != ClientFromContext(ctx) {
+		return nil, fmt.Errorf("service not found: %s", id)
+	}
+	return svc, nil
+}
+
 // Stats returns runtime statistics for a service.
 func (m *ServiceManager) Stats(id string) (*ServiceStats, error) {
 	svc, ok, err := m.Get(id)
@@ -657,6 +672,9 @@ func handleServiceStop(ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 		return mcp.NewToolResultError("missing service_id parameter"), nil
 	}
 
+	if _, err := clientService(ctx, serviceID); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to stop service: %v", err)), nil
+	}
 	if err := svcManager.Stop(serviceID); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to stop service: %v", err)), nil
 	}
@@ -676,13 +694,16 @@ func handleServiceList(ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 		return mcp.NewToolResultError(fmt.Sprintf("failed to list services: %v", err)), nil
 	}
 
-	result := make([]map[string]any, len(services))
-	for i, svc := range services {
+	result := make([]map[string]any, 0, len(services))
+	for _, svc := range services {
+		if svc.Client != ClientFromContext(ctx) {
+			continue
+		}
 		status := "stopped"
 		if isRunning(svc.PID) {
 			status = "running"
 		}
-		result[i] = map[string]any{
+		result = append(result, map[string]any{
 			"id":         svc.ID,
 			"name":       svc.Name,
 			"directory":  svc.Directory,
@@ -690,7 +711,7 @@ func handleServiceList(ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 			"pid":        svc.PID,
 			"status":     status,
 			"started_at": svc.StartedAt.Format(time.RFC3339),
-		}
+		})
 	}
 
 	output, _ := json.MarshalIndent(result, "", "  ")
@@ -713,6 +734,9 @@ func handleServiceStats(ctx context.Context, request mcp.CallToolRequest) (*mcp.
 		return mcp.NewToolResultError("missing service_id parameter"), nil
 	}
 
+	if _, err := clientService(ctx, serviceID); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get stats: %v", err)), nil
+	}
 	stats, err := svcManager.Stats(serviceID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to get stats: %v", err)), nil
@@ -747,6 +771,9 @@ func handleServiceLogs(ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 	lines := getIntParam(request, "lines", 50)
 	stream := request.GetString("stream", "both")
 
+	if _, err := clientService(ctx, serviceID); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get logs: %v", err)), nil
+	}
 	logLines, err := svcManager.Logs(serviceID, lines, stream)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to get logs: %v", err)), nil
@@ -777,12 +804,9 @@ func handleServiceHealth(ctx context.Context, request mcp.CallToolRequest) (*mcp
 		return mcp.NewToolResultError("missing service_id parameter"), nil
 	}
 
-	svc, ok, err := svcManager.Get(serviceID)
+	svc, err := clientService(ctx, serviceID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("failed to get service: %v", err)), nil
-	}
-	if !ok {
-		return mcp.NewToolResultError(fmt.Sprintf("service not found: %s", serviceID)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	if !isRunning(svc.PID) {
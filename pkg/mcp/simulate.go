@@ -9,16 +9,25 @@ package mcp
 //
 // The tool provides detailed step-by-step state traces showing the state before
 // and after each transition, making it easy to understand the simulation execution.
+//
+// Steps run on pkg/metamodel.Runtime, the same semantics generated services
+// use: bindings drive data arcs on map and scalar data states, guards are
+// evaluated with pkg/dsl against the bindings and current data, and a step
+// that breaks a constraint is rejected and reported like the service would.
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
-	"github.com/pflow-xyz/go-pflow/metamodel"
-	pflowTokenmodel "github.com/pflow-xyz/go-pflow/tokenmodel"
+	goflowmetamodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/dsl"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
 )
 
 // SimulationStep represents a single step in a simulation.
@@ -43,6 +52,10 @@ type SimulationResult struct {
 	Failed         []FailedStep   `json:"failed,omitempty"`
 	IsDeadlock     bool           `json:"is_deadlock,omitempty"`
 	Enabled        []string       `json:"enabled,omitempty"`
+
+	// Data state values, for models with data places
+	InitialData map[string]any `json:"initial_data,omitempty"`
+	FinalData   map[string]any `json:"final_data,omitempty"`
 }
 
 // FailedStep represents a failed transition for backwards compatibility.
@@ -59,15 +72,34 @@ type StepResult struct {
 	StateBefore map[string]int `json:"state_before"`
 	StateAfter  map[string]int `json:"state_after"`
 	Error       string         `json:"error,omitempty"`
+
+	// DataChanges lists the data values the step changed.
+	DataChanges []DataChange `json:"data_changes,omitempty"`
+	// Violations lists the constraints the step would have broken.
+	Violations []string `json:"violations,omitempty"`
 }
 
-// simulate executes a simulation given a model and a list of steps.
-func simulate(model *metamodel.Model, steps []SimulationStep) SimulationResult {
-	// Convert to go-pflow tokenmodel
-	metaSchema := metamodel.ToTokenModel(model)
+// DataChange is one changed value in a data state. Path uses guard syntax,
+// e.g. "balances[alice]" or "allowances[alice][bob]"; Before is nil for a
+// new key.
+type DataChange struct {
+	Path   string `json:"path"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
 
-	// Create runtime
-	runtime := pflowTokenmodel.NewRuntime(metaSchema)
+// simulate executes a simulation given a model and a list of steps.
+// Data overrides the initial value of data states by ID.
+func simulate(model *goflowmetamodel.Model, steps []SimulationStep, data map[string]any) (SimulationResult, error) {
+	runtime := metamodel.NewRuntime(metamodel.FromModel(model))
+	runtime.GuardEvaluator = dsl.NewEvaluator()
+	for id, value := range data {
+		st := runtime.Schema.StateByID(id)
+		if st == nil || !st.IsData() {
+			return SimulationResult{}, fmt.Errorf("unknown data state: %s", id)
+		}
+		runtime.SetData(id, value)
+	}
 
 	result := SimulationResult{
 		Success: true,
@@ -77,13 +109,15 @@ func simulate(model *metamodel.Model, steps []SimulationStep) SimulationResult {
 	}
 
 	// Capture initial marking for backwards compatibility
-	result.InitialMarking = captureMarking(runtime, metaSchema)
+	result.InitialMarking = captureMarking(runtime)
+	result.InitialData = captureData(runtime)
 
 	// Execute each step
 	for _, step := range steps {
-		stepResult := executeStep(runtime, metaSchema, step)
+		var stepResult StepResult
+		stepResult, runtime = executeStep(runtime, step)
 		result.Steps = append(result.Steps, stepResult)
-		
+
 		// Track fired and failed for backwards compatibility
 		if stepResult.Enabled && stepResult.Error == "" {
 			result.Fired = append(result.Fired, stepResult.Transition)
@@ -101,89 +135,159 @@ func simulate(model *metamodel.Model, steps []SimulationStep) SimulationResult {
 	}
 
 	// Capture final state
-	result.FinalState = captureMarking(runtime, metaSchema)
+	result.FinalState = captureMarking(runtime)
 	result.FinalMarking = result.FinalState // Backwards compatibility
+	result.FinalData = captureData(runtime)
 
 	// Check for deadlock (no enabled transitions)
 	enabledTransitions := runtime.EnabledActions()
 	result.IsDeadlock = len(enabledTransitions) == 0
 	result.Enabled = enabledTransitions
 
-	return result
+	return result, nil
 }
 
-// executeStep executes a single simulation step and returns its result.
-func executeStep(runtime *pflowTokenmodel.Runtime, metaSchema *pflowTokenmodel.Schema, step SimulationStep) StepResult {
+// executeStep executes a single simulation step and returns its result with
+// the runtime to continue from. The step fires on a copy, so a rejected step
+// leaves the state untouched, as a generated service would.
+func executeStep(runtime *metamodel.Runtime, step SimulationStep) (StepResult, *metamodel.Runtime) {
 	stepResult := StepResult{
 		Transition: step.Transition,
 	}
 
 	// Capture state before execution
-	stepResult.StateBefore = captureMarking(runtime, metaSchema)
+	stepResult.StateBefore = captureMarking(runtime)
+	stepResult.StateAfter = stepResult.StateBefore
 
 	// Check if action exists
-	action := metaSchema.ActionByID(step.Transition)
+	action := runtime.Schema.ActionByID(step.Transition)
 	if action == nil {
 		stepResult.Enabled = false
 		stepResult.Error = "transition not found in model"
-		stepResult.StateAfter = stepResult.StateBefore
-		return stepResult
+		return stepResult, runtime
 	}
 
 	// Check if enabled
 	if !runtime.Enabled(step.Transition) {
 		stepResult.Enabled = false
-		stepResult.Error = determineDisabledReason(runtime, metaSchema, step.Transition)
-		stepResult.StateAfter = stepResult.StateBefore
-		return stepResult
+		stepResult.Error = determineDisabledReason(runtime, step.Transition)
+		return stepResult, runtime
 	}
 
 	stepResult.Enabled = true
 
 	// Execute the transition
-	if err := runtime.Execute(step.Transition); err != nil {
+	next := runtime.Clone()
+	err := next.ExecuteWithBindings(step.Transition, metamodel.Bindings(step.Bindings))
+	switch {
+	case errors.Is(err, metamodel.ErrGuardNotSatisfied):
+		stepResult.Error = fmt.Sprintf("guard not satisfied: %s", action.Guard)
+		return stepResult, runtime
+	case errors.Is(err, metamodel.ErrConstraintViolated), errors.Is(err, metamodel.ErrConstraintEvaluation):
+		for _, v := range next.Constraints() {
+			stepResult.Violations = append(stepResult.Violations, v.Constraint.ID)
+		}
+		stepResult.Error = fmt.Sprintf("constraint violated: %s", strings.Join(stepResult.Violations, ", "))
+		return stepResult, runtime
+	case err != nil:
 		stepResult.Error = fmt.Sprintf("execution error: %v", err)
-		stepResult.StateAfter = captureMarking(runtime, metaSchema)
-		return stepResult
+		return stepResult, runtime
 	}
 
 	// Capture state after execution
-	stepResult.StateAfter = captureMarking(runtime, metaSchema)
+	stepResult.StateAfter = captureMarking(next)
+	stepResult.DataChanges = diffData(runtime.Snapshot.Data, next.Snapshot.Data)
 
-	return stepResult
+	return stepResult, next
 }
 
 // captureMarking captures the current marking (token counts) of all places.
-func captureMarking(runtime *pflowTokenmodel.Runtime, metaSchema *pflowTokenmodel.Schema) map[string]int {
+func captureMarking(runtime *metamodel.Runtime) map[string]int {
 	marking := make(map[string]int)
-	for _, state := range metaSchema.States {
-		if state.IsToken() {
-			marking[state.ID] = runtime.Tokens(state.ID)
-		}
+	for _, state := range runtime.Schema.TokenStates() {
+		marking[state.ID] = runtime.Tokens(state.ID)
 	}
 	return marking
 }
 
+// captureData copies the current value of every data state, or returns nil
+// for models without data states.
+func captureData(runtime *metamodel.Runtime) map[string]any {
+	if len(runtime.Schema.DataStates()) == 0 {
+		return nil
+	}
+	return runtime.Snapshot.Clone().Data
+}
+
+// diffData lists the values that differ between two data snapshots,
+// descending into maps so each changed key is reported on its own.
+func diffData(before, after map[string]any) []DataChange {
+	var changes []DataChange
+	diffValue("", before, after, &changes)
+	return changes
+}
+
+func diffValue(path string, before, after any, changes *[]DataChange) {
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+	if !beforeIsMap || !afterIsMap {
+		if !reflect.DeepEqual(before, after) {
+			*changes = append(*changes, DataChange{Path: path, Before: before, After: after})
+		}
+		return
+	}
+
+	keys := make([]string, 0, len(beforeMap)+len(afterMap))
+	for k := range beforeMap {
+		keys = append(keys, k)
+	}
+	for k := range afterMap {
+		if _, ok := beforeMap[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		child := k
+		if path != "" {
+			child = path + "[" + k + "]"
+		}
+		diffValue(child, beforeMap[k], afterMap[k], changes)
+	}
+}
+
 // determineDisabledReason determines why a transition is disabled.
-func determineDisabledReason(runtime *pflowTokenmodel.Runtime, metaSchema *pflowTokenmodel.Schema, transitionID string) string {
-	inputArcs := metaSchema.InputArcs(transitionID)
+func determineDisabledReason(runtime *metamodel.Runtime, transitionID string) string {
+	inputArcs := runtime.Schema.InputArcs(transitionID)
 	if len(inputArcs) == 0 {
 		return "transition has no input arcs"
 	}
 
-	var missingTokens []string
+	var missingTokens, inhibited []string
 	for _, arc := range inputArcs {
-		st := metaSchema.StateByID(arc.Source)
-		if st != nil && st.IsToken() {
-			if runtime.Tokens(arc.Source) < 1 {
-				missingTokens = append(missingTokens, arc.Source)
-			}
+		st := runtime.Schema.StateByID(arc.Source)
+		if st == nil || !st.IsToken() {
+			continue
+		}
+		weight := arc.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		switch {
+		case arc.IsInhibitor() && runtime.Tokens(arc.Source) > 0:
+			inhibited = append(inhibited, arc.Source)
+		case !arc.IsInhibitor() && runtime.Tokens(arc.Source) < weight:
+			missingTokens = append(missingTokens, arc.Source)
 		}
 	}
 
 	if len(missingTokens) > 0 {
 		return fmt.Sprintf("insufficient tokens in: %s", strings.Join(missingTokens, ", "))
 	}
+	if len(inhibited) > 0 {
+		return fmt.Sprintf("inhibited by tokens in: %s", strings.Join(inhibited, ", "))
+	}
 
 	return "insufficient tokens in input places"
 }
@@ -192,9 +296,9 @@ func determineDisabledReason(runtime *pflowTokenmodel.Runtime, metaSchema *pflow
 // This supports both the old "transitions" parameter (array of strings) and new "steps" parameter
 // (array of SimulationStep objects with optional bindings).
 func handleSimulateWithSteps(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	modelJSON, err := request.RequireString("model")
+	modelJSON, err := requireModel(ctx, request, "model")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("missing model parameter: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Parse model (supports both v1 and v2 schemas)
@@ -227,8 +331,18 @@ func handleSimulateWithSteps(ctx context.Context, request mcp.CallToolRequest) (
 		return mcp.NewToolResultError("missing 'steps' or 'transitions' parameter"), nil
 	}
 
+	var data map[string]any
+	if dataJSON := request.GetString("data", ""); dataJSON != "" {
+		if err := json.Unmarshal([]byte(dataJSON), &data); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid data JSON: %v", err)), nil
+		}
+	}
+
 	// Run simulation
-	result := simulate(model, steps)
+	result, err := simulate(model, steps, data)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	// Marshal result
 	outputJSON, err := json.MarshalIndent(result, "", "  ")
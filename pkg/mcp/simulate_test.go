@@ -322,3 +322,115 @@ func TestSimulateBackwardsCompatibility(t *testing.T) {
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+// tokenModel is an ERC-20 style ledger: mints are capped at two by a constraint
+// and transfers are guarded by the sender's balance.
+const tokenModel = `{
+	"name": "token",
+	"places": [
+		{"id": "balances", "kind": "data", "type": "map[string]int64"},
+		{"id": "last_mint", "kind": "data", "type": "int64"},
+		{"id": "minted", "initial": 0}
+	],
+	"transitions": [
+		{"id": "mint"},
+		{"id": "transfer", "guard": "balances[from] >= amount"}
+	],
+	"arcs": [
+		{"from": "mint", "to": "balances", "keys": ["to"], "value": "amount"},
+		{"from": "mint", "to": "last_mint", "value": "amount"},
+		{"from": "mint", "to": "minted"},
+		{"from": "balances", "to": "transfer", "keys": ["from"], "value": "amount"},
+		{"from": "transfer", "to": "balances", "keys": ["to"], "value": "amount"}
+	],
+	"constraints": [{"id": "mint_cap", "expr": "minted <= 2"}]
+}`
+
+func simulateData(t *testing.T, args map[string]any) SimulationResult {
+	t.Helper()
+	result, err := handleSimulateWithSteps(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: args},
+	})
+	if err != nil {
+		t.Fatalf("handleSimulateWithSteps returned error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("Expected success but got error: %s", text)
+	}
+	var simResult SimulationResult
+	if err := json.Unmarshal([]byte(text), &simResult); err != nil {
+		t.Fatalf("Failed to parse result JSON: %v\nJSON: %s", err, text)
+	}
+	return simResult
+}
+
+func TestSimulateDataStates(t *testing.T) {
+	simResult := simulateData(t, map[string]any{
+		"model": tokenModel,
+		"steps": `[
+			{"transition": "mint", "bindings": {"to": "alice", "amount": 100}},
+			{"transition": "transfer", "bindings": {"from": "alice", "to": "bob", "amount": 30}},
+			{"transition": "transfer", "bindings": {"from": "bob", "to": "alice", "amount": 50}},
+			{"transition": "mint", "bindings": {"to": "bob", "amount": 1}},
+			{"transition": "mint", "bindings": {"to": "bob", "amount": 1}}
+		]`,
+	})
+	steps := simResult.Steps
+	if len(steps) != 5 {
+		t.Fatalf("Expected 5 steps, got %d", len(steps))
+	}
+
+	if changes := steps[0].DataChanges; len(changes) != 2 || changes[0].Path != "balances[alice]" || changes[1].Path != "last_mint" {
+		t.Errorf("Expected the mint to set alice's balance and last_mint, got %+v", changes)
+	}
+
+	changes := steps[1].DataChanges
+	if len(changes) != 2 || changes[0].Path != "balances[alice]" || changes[0].After != float64(70) ||
+		changes[1].Path != "balances[bob]" || changes[1].Before != nil || changes[1].After != float64(30) {
+		t.Errorf("Expected 30 to move from alice to bob, got %+v", changes)
+	}
+
+	// Bob only holds 30, so the guard rejects the transfer
+	if !strings.Contains(steps[2].Error, "guard not satisfied") || len(steps[2].DataChanges) != 0 {
+		t.Errorf("Expected the guard to reject bob's transfer, got %+v", steps[2])
+	}
+
+	// The third mint breaks the cap and is rolled back
+	if steps[3].Error != "" {
+		t.Errorf("Expected the second mint to succeed, got %s", steps[3].Error)
+	}
+	if len(steps[4].Violations) != 1 || steps[4].Violations[0] != "mint_cap" || steps[4].StateAfter["minted"] != 2 {
+		t.Errorf("Expected the third mint to violate mint_cap, got %+v", steps[4])
+	}
+
+	balances := simResult.FinalData["balances"].(map[string]any)
+	if balances["alice"] != float64(70) || balances["bob"] != float64(31) || simResult.Success {
+		t.Errorf("Expected alice=70 bob=31 and an unsuccessful run, got %v", simResult.FinalData)
+	}
+}
+
+func TestSimulateInitialData(t *testing.T) {
+	simResult := simulateData(t, map[string]any{
+		"model": tokenModel,
+		"data":  `{"balances": {"carol": 5}}`,
+		"steps": `[{"transition": "transfer", "bindings": {"from": "carol", "to": "dave", "amount": 5}}]`,
+	})
+	if !simResult.Success || simResult.InitialData["balances"].(map[string]any)["carol"] != float64(5) {
+		t.Fatalf("Expected carol's seeded balance to cover the transfer, got %+v", simResult)
+	}
+	if balances := simResult.FinalData["balances"].(map[string]any); balances["carol"] != float64(0) || balances["dave"] != float64(5) {
+		t.Errorf("Expected the balance to move to dave, got %v", balances)
+	}
+
+	result, _ := handleSimulateWithSteps(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{
+			"model":       tokenModel,
+			"data":        `{"minted": 1}`,
+			"transitions": `["mint"]`,
+		}},
+	})
+	if !result.IsError {
+		t.Error("Expected seeding a token place as data to fail")
+	}
+}
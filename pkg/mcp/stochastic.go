@@ -0,0 +1,113 @@
+package mcp
+
+// This file implements the petri_stochastic MCP tool, which runs a Gillespie
+// (stochastic simulation algorithm) over a model's token net. Unlike
+// petri_simulate, which fires a given sequence of transitions, it fires
+// transitions at random with exponential waiting times over many
+// replications and reports completion time, throughput and place occupancy,
+// which suits queueing-style workflows such as support tickets or orders.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/stochastic"
+)
+
+func stochasticTool() mcp.Tool {
+	return mcp.NewTool("petri_stochastic",
+		mcp.WithDescription("Run a stochastic (Gillespie) simulation of the model over many seeded replications. Reports mean and percentile completion time, per-transition throughput and time-averaged place occupancy."),
+		mcp.WithString("model",
+			mcp.Required(),
+			mcp.Description("The Petri net model as JSON or a workspace handle. Transition 'rate' fields and the simulation solver rates supply firing rates; transitions without one fire at rate 1."),
+		),
+		mcp.WithString("simulation",
+			mcp.Description("Optional JSON simulation block overriding the model's, e.g. {\"solver\":{\"rates\":{\"serve\":2}}}"),
+		),
+		mcp.WithString("rates",
+			mcp.Description("JSON object of firings per time unit by transition, overriding the model, e.g. {\"arrive\":0.5,\"serve\":1}"),
+		),
+		mcp.WithString("marking",
+			mcp.Description("JSON object of token counts replacing the initial marking, e.g. {\"waiting\":10}"),
+		),
+		mcp.WithString("kinetics",
+			mcp.Description("How rates scale with input tokens: mass-action (default, like the ODE) or single-server (one firing at a time, like a queue)"),
+		),
+		mcp.WithString("until",
+			mcp.Description("Completion condition over the marking, e.g. \"done >= 10\" (default: no transition enabled)"),
+		),
+		mcp.WithNumber("duration",
+			mcp.Description("Simulated time per replication; runs still going are cut off (default: run until completion)"),
+		),
+		mcp.WithNumber("replications",
+			mcp.Description(fmt.Sprintf("Independent runs (default %d, max %d)", stochastic.DefaultReplications, stochastic.MaxReplications)),
+		),
+		mcp.WithNumber("seed",
+			mcp.Description("Random seed; the same seed gives the same report (default 1)"),
+		),
+		mcp.WithNumber("max_events",
+			mcp.Description(fmt.Sprintf("Firings per replication before it is cut off (default %d)", stochastic.DefaultMaxEvents)),
+		),
+	)
+}
+
+// handleStochastic handles the petri_stochastic tool request.
+func handleStochastic(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	modelJSON, err := requireModel(ctx, request, "model")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	parsed, err := parseModelV2(modelJSON)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid model JSON: %v", err)), nil
+	}
+
+	simulation, err := modelSimulation(modelJSON, parsed, request.GetString("simulation", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var sim *metamodel.Simulation
+	if simulation != nil {
+		sim = &simulation.Simulation
+	}
+
+	opts := stochastic.Options{
+		Replications: request.GetInt("replications", 0),
+		Duration:     request.GetFloat("duration", 0),
+		Until:        request.GetString("until", ""),
+		Kinetics:     stochastic.Kinetics(request.GetString("kinetics", "")),
+		Seed:         int64(request.GetInt("seed", 1)),
+		MaxEvents:    request.GetInt("max_events", 0),
+		Rates:        stochastic.Rates(parsed.Model, sim),
+	}
+
+	if ratesJSON := request.GetString("rates", ""); ratesJSON != "" {
+		var rates map[string]float64
+		if err := json.Unmarshal([]byte(ratesJSON), &rates); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid rates JSON: %v", err)), nil
+		}
+		for id, rate := range rates {
+			opts.Rates[id] = rate
+		}
+	}
+	if markingJSON := request.GetString("marking", ""); markingJSON != "" {
+		if err := json.Unmarshal([]byte(markingJSON), &opts.Marking); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid marking JSON: %v", err)), nil
+		}
+	}
+
+	report, err := stochastic.Simulate(metamodel.FromModel(parsed.Model), opts)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal report: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(output)), nil
+}
@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/stochastic"
+)
+
+// stochasticModel is a support queue: five tickets are served one at a time at rate 2.
+const stochasticModel = `{
+	"name": "tickets",
+	"places": [
+		{"id": "waiting", "initial": 5},
+		{"id": "done", "initial": 0}
+	],
+	"transitions": [
+		{"id": "serve", "rate": 2}
+	],
+	"arcs": [
+		{"from": "waiting", "to": "serve"},
+		{"from": "serve", "to": "done"}
+	]
+}`
+
+func callStochastic(t *testing.T, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	result, err := handleStochastic(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: args},
+	})
+	if err != nil {
+		t.Fatalf("handleStochastic returned error: %v", err)
+	}
+	return result
+}
+
+func stochasticReport(t *testing.T, result *mcp.CallToolResult) stochastic.Report {
+	t.Helper()
+	if result.IsError {
+		t.Fatalf("expected success, got %v", result.Content[0])
+	}
+	var report stochastic.Report
+	text := result.Content[0].(mcp.TextContent).Text
+	if err := json.Unmarshal([]byte(text), &report); err != nil {
+		t.Fatalf("failed to parse report: %v\n%s", err, text)
+	}
+	return report
+}
+
+func TestStochastic(t *testing.T) {
+	report := stochasticReport(t, callStochastic(t, map[string]any{
+		"model":        stochasticModel,
+		"kinetics":     "single-server",
+		"replications": float64(2000),
+		"seed":         float64(7),
+	}))
+	if report.Replications != 2000 || report.Completed != 2000 || report.CompletionTime == nil {
+		t.Fatalf("expected every replication to complete, got %+v", report)
+	}
+	// Five services at rate 2 take 2.5 time units on average
+	if math.Abs(report.CompletionTime.Mean-2.5) > 0.15 {
+		t.Errorf("expected mean completion near 2.5, got %v", report.CompletionTime.Mean)
+	}
+
+	// The rates parameter overrides the model's rate
+	report = stochasticReport(t, callStochastic(t, map[string]any{
+		"model":        stochasticModel,
+		"kinetics":     "single-server",
+		"replications": float64(2000),
+		"rates":        `{"serve": 5}`,
+		"marking":      `{"waiting": 10}`,
+	}))
+	if report.Final["done"].Min != 10 || math.Abs(report.CompletionTime.Mean-2) > 0.15 {
+		t.Errorf("expected ten tickets served in about 2 time units, got %+v", report.CompletionTime)
+	}
+}
+
+func TestStochasticSeeded(t *testing.T) {
+	args := map[string]any{"model": stochasticModel, "replications": float64(10), "seed": float64(3)}
+	a := callStochastic(t, args).Content[0].(mcp.TextContent).Text
+	b := callStochastic(t, args).Content[0].(mcp.TextContent).Text
+	if a != b {
+		t.Error("expected the same seed to give the same report")
+	}
+}
+
+func TestStochasticErrors(t *testing.T) {
+	for name, args := range map[string]map[string]any{
+		"model":    {"model": "not json"},
+		"rates":    {"model": stochasticModel, "rates": "[1]"},
+		"unknown":  {"model": stochasticModel, "rates": `{"missing": 1}`},
+		"marking":  {"model": stochasticModel, "marking": `{"missing": 1}`},
+		"kinetics": {"model": stochasticModel, "kinetics": "fluid"},
+	} {
+		result := callStochastic(t, args)
+		if !result.IsError {
+			t.Errorf("%s: expected an error", name)
+			continue
+		}
+		if text := result.Content[0].(mcp.TextContent).Text; strings.TrimSpace(text) == "" {
+			t.Errorf("%s: expected an error message", name)
+		}
+	}
+}
@@ -0,0 +1,673 @@
+// Model workspace for MCP server.
+// Stores models under short handles with a revision history, so agents can
+// pass "orders" instead of re-sending the full model JSON on every call and
+// can undo petri_extend edits. Each handle is persisted as a JSON file under
+// the state directory, so the workspace survives server restarts.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// workspaceURIPrefix prefixes workspace resource URIs, e.g. petri://workspace/orders.
+const workspaceURIPrefix = "petri://workspace/"
+
+// maxRevisions bounds the history kept per handle; older revisions are dropped.
+const maxRevisions = 50
+
+// ErrUnknownHandle is returned when a handle names no stored model.
+var ErrUnknownHandle = errors.New("unknown workspace handle")
+
+// handlePattern matches valid handles. Handles double as file names.
+var handlePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// refPattern matches a handle reference with an optional revision: orders, orders@3.
+var refPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_.-]*)(?:@([0-9]+))?$`)
+
+// Revision is one stored version of a model.
+type Revision struct {
+	Number    int       `json:"number"`
+	Model     string    `json:"model"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkspaceModel is a model stored under a handle with its revision history, oldest first.
+type WorkspaceModel struct {
+	Handle    string     `json:"handle"`
+	Name      string     `json:"name,omitempty"`
+	Revisions []Revision `json:"revisions"`
+}
+
+// Head returns the latest revision.
+func (m *WorkspaceModel) Head() Revision {
+	return m.Revisions[len(m.Revisions)-1]
+}
+
+// Revision returns the numbered revision; zero selects the head.
+func (m *WorkspaceModel) Revision(number int) (Revision, error) {
+	if number == 0 {
+		return m.Head(), nil
+	}
+	for _, r := range m.Revisions {
+		if r.Number == number {
+			return r, nil
+		}
+	}
+	return Revision{}, fmt.Errorf("%s has no revision %d", m.Handle, number)
+}
+
+// WorkspaceSummary describes a handle without its model contents.
+type WorkspaceSummary struct {
+	Handle    string    `json:"handle"`
+	Name      string    `json:"name,omitempty"`
+	Revision  int       `json:"revision"`
+	Revisions int       `json:"revisions"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Workspace stores models as one JSON file per handle.
+type Workspace struct {
+	mu      sync.Mutex
+	dir     string
+	clients map[string]*Workspace
+}
+
+// newWorkspace creates a workspace persisted in dir.
+func newWorkspace(dir string) *Workspace {
+	return &Workspace{dir: dir}
+}
+
+// Global workspace singleton.
+var workspace = newWorkspace(filepath.Join(stateDir(), "workspace"))
+
+// Client returns the private workspace of an authenticated HTTP client,
+// stored in a clients/ subdirectory so handles never collide across clients.
+func (w *Workspace) Client(name string) *Workspace {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.clients == nil {
+		w.clients = make(map[string]*Workspace)
+	}
+	if c, ok := w.clients[name]; ok {
+		return c
+	}
+	c := newWorkspace(filepath.Join(w.dir, "clients", name))
+	w.clients[name] = c
+	return c
+}
+
+// workspaceFor returns the workspace serving a tool call: the caller's own
+// workspace over authenticated HTTP, or the shared one otherwise.
+func workspaceFor(ctx context.Context) *Workspace {
+	if client := ClientFromContext(ctx); client != "" {
+		return workspace.Client(client)
+	}
+	return workspace
+}
+
+func (w *Workspace) path(handle string) string {
+	return filepath.Join(w.dir, handle+".json")
+}
+
+// load reads a handle's file. Callers hold w.mu.
+func (w *Workspace) load(handle string) (*WorkspaceModel, error) {
+	if !handlePattern.MatchString(handle) {
+		return nil, fmt.Errorf("invalid handle %q", handle)
+	}
+	data, err := os.ReadFile(w.path(handle))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownHandle, handle)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m WorkspaceModel
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("corrupt workspace file for %s: %w", handle, err)
+	}
+	if len(m.Revisions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownHandle, handle)
+	}
+	return &m, nil
+}
+
+// store writes a handle's file atomically. Callers hold w.mu.
+func (w *Workspace) store(m *WorkspaceModel) error {
+	if err := os.MkdirAll(w.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := w.path(m.Handle) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, w.path(m.Handle))
+}
+
+// Get returns the model stored under handle.
+func (w *Workspace) Get(handle string) (*WorkspaceModel, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.load(handle)
+}
+
+// Save stores modelJSON as a new revision of handle, creating the handle if needed.
+// An empty handle is derived from name and made unique.
+func (w *Workspace) Save(handle, name, modelJSON, note string) (*WorkspaceModel, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if handle == "" {
+		handle = w.uniqueHandle(name)
+	}
+	m, err := w.load(handle)
+	if errors.Is(err, ErrUnknownHandle) {
+		m, err = &WorkspaceModel{Handle: handle}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	number := 1
+	if len(m.Revisions) > 0 {
+		number = m.Head().Number + 1
+	}
+	if name != "" {
+		m.Name = name
+	}
+	m.Revisions = append(m.Revisions, Revision{
+		Number:    number,
+		Model:     modelJSON,
+		Note:      note,
+		CreatedAt: time.Now().UTC(),
+	})
+	if len(m.Revisions) > maxRevisions {
+		m.Revisions = m.Revisions[len(m.Revisions)-maxRevisions:]
+	}
+	if err := w.store(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Undo drops the latest steps revisions of handle. The first revision is never dropped.
+func (w *Workspace) Undo(handle string, steps int) (*WorkspaceModel, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	m, err := w.load(handle)
+	if err != nil {
+		return nil, err
+	}
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be at least 1")
+	}
+	if steps >= len(m.Revisions) {
+		return nil, fmt.Errorf("%s has %d revision(s); cannot undo %d", handle, len(m.Revisions), steps)
+	}
+	m.Revisions = m.Revisions[:len(m.Revisions)-steps]
+	if err := w.store(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Delete removes handle and its history.
+func (w *Workspace) Delete(handle string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.load(handle); err != nil {
+		return err
+	}
+	return os.Remove(w.path(handle))
+}
+
+// List summarizes every stored handle, sorted by handle.
+func (w *Workspace) List() ([]WorkspaceSummary, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entries, err := os.ReadDir(w.dir)
+	if os.IsNotExist(err) {
+		return []WorkspaceSummary{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]WorkspaceSummary, 0, len(entries))
+	for _, e := range entries {
+		handle, ok := strings.CutSuffix(e.Name(), ".json")
+		if e.IsDir() || !ok {
+			continue
+		}
+		m, err := w.load(handle)
+		if err != nil {
+			continue
+		}
+		head := m.Head()
+		summaries = append(summaries, WorkspaceSummary{
+			Handle:    m.Handle,
+			Name:      m.Name,
+			Revision:  head.Number,
+			Revisions: len(m.Revisions),
+			UpdatedAt: head.CreatedAt,
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Handle < summaries[j].Handle })
+	return summaries, nil
+}
+
+// uniqueHandle derives an unused handle from a model name. Callers hold w.mu.
+func (w *Workspace) uniqueHandle(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	base := strings.TrimSuffix(b.String(), "-")
+	if base == "" {
+		base = "model"
+	}
+	handle := base
+	for i := 2; ; i++ {
+		if _, err := os.Stat(w.path(handle)); os.IsNotExist(err) {
+			return handle
+		}
+		handle = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// modelRef is a parsed workspace reference such as "orders@3".
+type modelRef struct {
+	Handle   string
+	Revision int // Zero selects the head
+}
+
+// parseModelRef reports whether a model parameter refers to the workspace
+// rather than carrying model JSON or DSL. References are a handle, optionally
+// followed by @revision, with or without the petri://workspace/ prefix.
+func parseModelRef(value string) (modelRef, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), workspaceURIPrefix)
+	match := refPattern.FindStringSubmatch(value)
+	if match == nil {
+		return modelRef{}, false
+	}
+	ref := modelRef{Handle: match[1]}
+	if match[2] != "" {
+		ref.Revision, _ = strconv.Atoi(match[2])
+	}
+	return ref, true
+}
+
+// resolveModel returns the model text a parameter value stands for:
+// the value itself, or the referenced workspace revision.
+func resolveModel(ctx context.Context, value string) (string, error) {
+	ref, ok := parseModelRef(value)
+	if !ok {
+		return value, nil
+	}
+	m, err := workspaceFor(ctx).Get(ref.Handle)
+	if err != nil {
+		return "", err
+	}
+	rev, err := m.Revision(ref.Revision)
+	if err != nil {
+		return "", err
+	}
+	return rev.Model, nil
+}
+
+// extendedModelJSON rewrites the original model document with an edited net,
+// keeping what petri_extend does not touch: the v2 wrapper and its extensions,
+// or the extension keys of a flat v1 model. before and after are the net as
+// JSON before and after editing. DSL models are replaced by the JSON net.
+func extendedModelJSON(original string, before, after []byte) (string, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(original), &doc); err != nil {
+		return string(after), nil
+	}
+	if _, ok := doc["net"]; ok {
+		doc["net"] = after
+	} else {
+		var old, edited map[string]json.RawMessage
+		if err := json.Unmarshal(before, &old); err != nil {
+			return "", err
+		}
+		if err := json.Unmarshal(after, &edited); err != nil {
+			return "", err
+		}
+		// Fields emptied by the edit are omitted from the net, so drop them too
+		for key := range old {
+			delete(doc, key)
+		}
+		for key, value := range edited {
+			doc[key] = value
+		}
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// requireModel reads a required model parameter, resolving workspace references.
+func requireModel(ctx context.Context, request mcp.CallToolRequest, key string) (string, error) {
+	value, err := request.RequireString(key)
+	if err != nil {
+		return "", fmt.Errorf("missing %s parameter: %v", key, err)
+	}
+	return resolveModel(ctx, value)
+}
+
+// --- Tool Definitions ---
+
+func workspaceSaveTool() mcp.Tool {
+	return mcp.NewTool("petri_workspace_save",
+		mcp.WithDescription("Store a model in the server-side workspace under a handle. Other tools accept the handle (e.g. 'orders', or 'orders@3' for a revision) in place of model JSON, and petri_extend on a handle records a new revision that petri_undo can revert."),
+		mcp.WithString("model",
+			mcp.Required(),
+			mcp.Description("The Petri net model as JSON or tokenmodel DSL, or a workspace handle to copy"),
+		),
+		mcp.WithString("handle",
+			mcp.Description("Handle to store under; saving to an existing handle adds a revision (default: derived from the model name)"),
+		),
+		mcp.WithString("note",
+			mcp.Description("Short description of this revision"),
+		),
+	)
+}
+
+func workspaceGetTool() mcp.Tool {
+	return mcp.NewTool("petri_workspace_get",
+		mcp.WithDescription("Return a model stored in the workspace, at its latest revision or at 'handle@revision'."),
+		mcp.WithString("handle",
+			mcp.Required(),
+			mcp.Description("Workspace handle, optionally with @revision"),
+		),
+	)
+}
+
+func workspaceListTool() mcp.Tool {
+	return mcp.NewTool("petri_workspace_list",
+		mcp.WithDescription("List the models stored in the workspace, or the revision history of one handle."),
+		mcp.WithString("handle",
+			mcp.Description("Show the revision history of this handle instead of listing handles"),
+		),
+	)
+}
+
+func workspaceDeleteTool() mcp.Tool {
+	return mcp.NewTool("petri_workspace_delete",
+		mcp.WithDescription("Delete a model and its revision history from the workspace."),
+		mcp.WithString("handle",
+			mcp.Required(),
+			mcp.Description("Workspace handle to delete"),
+		),
+	)
+}
+
+func undoTool() mcp.Tool {
+	return mcp.NewTool("petri_undo",
+		mcp.WithDescription("Revert a workspace model to an earlier revision by dropping its latest revisions."),
+		mcp.WithString("handle",
+			mcp.Required(),
+			mcp.Description("Workspace handle"),
+		),
+		mcp.WithNumber("steps",
+			mcp.Description("Number of revisions to undo (default 1)"),
+		),
+	)
+}
+
+func revisionDiffTool() mcp.Tool {
+	return mcp.NewTool("petri_revision_diff",
+		mcp.WithDescription("Compare two revisions of a workspace model and show structural differences, like petri_diff."),
+		mcp.WithString("handle",
+			mcp.Required(),
+			mcp.Description("Workspace handle"),
+		),
+		mcp.WithNumber("from",
+			mcp.Description("Base revision number (default: the revision before 'to')"),
+		),
+		mcp.WithNumber("to",
+			mcp.Description("Target revision number (default: the latest revision)"),
+		),
+	)
+}
+
+// --- Tool Handlers ---
+
+// workspaceResult is returned by tools that change or read a single handle.
+type workspaceResult struct {
+	Handle    string     `json:"handle"`
+	Name      string     `json:"name,omitempty"`
+	Revision  int        `json:"revision"`
+	Revisions int        `json:"revisions"`
+	Note      string     `json:"note,omitempty"`
+	URI       string     `json:"uri"`
+	Model     string     `json:"model,omitempty"`
+	History   []Revision `json:"history,omitempty"`
+}
+
+func newWorkspaceResult(m *WorkspaceModel, rev Revision) workspaceResult {
+	return workspaceResult{
+		Handle:    m.Handle,
+		Name:      m.Name,
+		Revision:  rev.Number,
+		Revisions: len(m.Revisions),
+		Note:      rev.Note,
+		URI:       workspaceURIPrefix + m.Handle,
+	}
+}
+
+func workspaceToolResult(v any) (*mcp.CallToolResult, error) {
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(output)), nil
+}
+
+func handleWorkspaceSave(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	modelJSON, err := requireModel(ctx, request, "model")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	parsed, err := parseModelV2(modelJSON)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid model JSON: %v", err)), nil
+	}
+
+	m, err := workspaceFor(ctx).Save(request.GetString("handle", ""), parsed.Model.Name, modelJSON, request.GetString("note", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return workspaceToolResult(newWorkspaceResult(m, m.Head()))
+}
+
+func handleWorkspaceGet(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	value, err := request.RequireString("handle")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("missing handle parameter: %v", err)), nil
+	}
+	ref, ok := parseModelRef(value)
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid handle %q", value)), nil
+	}
+
+	m, err := workspaceFor(ctx).Get(ref.Handle)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	rev, err := m.Revision(ref.Revision)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := newWorkspaceResult(m, rev)
+	result.Model = rev.Model
+	return workspaceToolResult(result)
+}
+
+func handleWorkspaceList(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if handle := request.GetString("handle", ""); handle != "" {
+		m, err := workspaceFor(ctx).Get(handle)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result := newWorkspaceResult(m, m.Head())
+		// History omits model contents; fetch them with petri_workspace_get
+		for _, r := range m.Revisions {
+			r.Model = ""
+			result.History = append(result.History, r)
+		}
+		return workspaceToolResult(result)
+	}
+
+	summaries, err := workspaceFor(ctx).List()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list workspace: %v", err)), nil
+	}
+	return workspaceToolResult(summaries)
+}
+
+func handleWorkspaceDelete(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	handle, err := request.RequireString("handle")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("missing handle parameter: %v", err)), nil
+	}
+	if err := workspaceFor(ctx).Delete(handle); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Deleted %s", handle)), nil
+}
+
+func handleUndo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	handle, err := request.RequireString("handle")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("missing handle parameter: %v", err)), nil
+	}
+
+	m, err := workspaceFor(ctx).Undo(handle, request.GetInt("steps", 1))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return workspaceToolResult(newWorkspaceResult(m, m.Head()))
+}
+
+func handleRevisionDiff(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	handle, err := request.RequireString("handle")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("missing handle parameter: %v", err)), nil
+	}
+
+	m, err := workspaceFor(ctx).Get(handle)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := m.Revision(request.GetInt("to", 0))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	from := request.GetInt("from", 0)
+	if from == 0 {
+		// Default to the revision just before 'to'
+		for _, r := range m.Revisions {
+			if r.Number < to.Number {
+				from = r.Number
+			}
+		}
+		if from == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("%s has no revision before %d", handle, to.Number)), nil
+		}
+	}
+	base, err := m.Revision(from)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	a, err := parseModelV2(base.Model)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("revision %d: %v", base.Number, err)), nil
+	}
+	b, err := parseModelV2(to.Model)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("revision %d: %v", to.Number, err)), nil
+	}
+
+	return workspaceToolResult(struct {
+		Handle string `json:"handle"`
+		From   int    `json:"from"`
+		To     int    `json:"to"`
+		ModelDiff
+	}{handle, base.Number, to.Number, compareModels(a.Model, b.Model)})
+}
+
+// --- Resources ---
+
+func handleWorkspaceIndexResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	summaries, err := workspaceFor(ctx).List()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(summaries, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      "petri://workspace",
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
+func handleWorkspaceModelResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	if _, ok := parseModelRef(request.Params.URI); !ok || !strings.HasPrefix(request.Params.URI, workspaceURIPrefix) {
+		return nil, fmt.Errorf("invalid workspace URI: %s", request.Params.URI)
+	}
+	model, err := resolveModel(ctx, request.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType := "application/json"
+	if strings.HasPrefix(strings.TrimSpace(model), "(") {
+		mimeType = "text/plain"
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: mimeType,
+			Text:     model,
+		},
+	}, nil
+}
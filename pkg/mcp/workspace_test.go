@@ -0,0 +1,182 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const workspaceModel = `{
+	"name": "Order Flow",
+	"places": [{"id": "pending", "initial": 1}, {"id": "shipped"}],
+	"transitions": [{"id": "ship"}],
+	"arcs": [{"from": "pending", "to": "ship"}, {"from": "ship", "to": "shipped"}],
+	"roles": [{"id": "clerk"}]
+}`
+
+// useTempWorkspace points the workspace at a fresh directory for one test.
+func useTempWorkspace(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	saved := workspace
+	workspace = newWorkspace(dir)
+	t.Cleanup(func() { workspace = saved })
+	return dir
+}
+
+func callTool(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) string {
+	t.Helper()
+	result, err := handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: args},
+	})
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("expected success, got %s", text)
+	}
+	return text
+}
+
+func saveWorkspaceModel(t *testing.T) workspaceResult {
+	t.Helper()
+	var saved workspaceResult
+	text := callTool(t, handleWorkspaceSave, map[string]any{"model": workspaceModel, "note": "initial"})
+	if err := json.Unmarshal([]byte(text), &saved); err != nil {
+		t.Fatalf("failed to parse result: %v\n%s", err, text)
+	}
+	return saved
+}
+
+func TestWorkspaceSaveAndGet(t *testing.T) {
+	useTempWorkspace(t)
+
+	saved := saveWorkspaceModel(t)
+	if saved.Handle != "order-flow" || saved.Revision != 1 || saved.URI != "petri://workspace/order-flow" {
+		t.Fatalf("unexpected save result: %+v", saved)
+	}
+
+	// Saving the same name again gets a fresh handle
+	if again := saveWorkspaceModel(t); again.Handle != "order-flow-2" {
+		t.Errorf("expected order-flow-2, got %s", again.Handle)
+	}
+
+	var got workspaceResult
+	text := callTool(t, handleWorkspaceGet, map[string]any{"handle": "order-flow@1"})
+	if err := json.Unmarshal([]byte(text), &got); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if got.Model != workspaceModel || got.Note != "initial" {
+		t.Errorf("expected the saved model back, got %+v", got)
+	}
+
+	// Other tools accept the handle in place of model JSON
+	text = callTool(t, handleValidate, map[string]any{"model": "order-flow"})
+	if !strings.Contains(text, `"valid"`) {
+		t.Errorf("expected a validation result, got %s", text)
+	}
+	result, _ := handleValidate(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"model": "missing"}},
+	})
+	if !result.IsError {
+		t.Error("expected an unknown handle to be an error")
+	}
+}
+
+func TestWorkspaceExtendUndo(t *testing.T) {
+	useTempWorkspace(t)
+	saveWorkspaceModel(t)
+
+	text := callTool(t, handleExtend, map[string]any{
+		"model":      "order-flow",
+		"operations": `[{"op":"add_place","id":"delivered"},{"op":"add_transition","id":"deliver"}]`,
+	})
+	var extended struct {
+		Handle   string `json:"handle"`
+		Revision int    `json:"revision"`
+	}
+	if err := json.Unmarshal([]byte(text), &extended); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if extended.Handle != "order-flow" || extended.Revision != 2 {
+		t.Fatalf("expected revision 2 of order-flow, got %+v", extended)
+	}
+
+	m, err := workspace.Get("order-flow")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	head := m.Head().Model
+	if !strings.Contains(head, "delivered") || !strings.Contains(head, "clerk") {
+		t.Errorf("expected the new place and the preserved roles, got %s", head)
+	}
+
+	text = callTool(t, handleRevisionDiff, map[string]any{"handle": "order-flow"})
+	if !strings.Contains(text, `"places_added"`) || !strings.Contains(text, "delivered") {
+		t.Errorf("expected the added place in the diff, got %s", text)
+	}
+
+	callTool(t, handleUndo, map[string]any{"handle": "order-flow"})
+	if m, _ = workspace.Get("order-flow"); m.Head().Number != 1 || m.Head().Model != workspaceModel {
+		t.Errorf("expected undo to restore revision 1, got %+v", m.Head())
+	}
+
+	// The first revision cannot be undone
+	result, _ := handleUndo(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"handle": "order-flow"}},
+	})
+	if !result.IsError {
+		t.Error("expected undo past the first revision to fail")
+	}
+}
+
+func TestWorkspacePersistence(t *testing.T) {
+	dir := useTempWorkspace(t)
+	saveWorkspaceModel(t)
+
+	// A new workspace over the same directory sees the saved model
+	workspace = newWorkspace(dir)
+	summaries, err := workspace.List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].Handle != "order-flow" || summaries[0].Name != "Order Flow" {
+		t.Errorf("expected the saved handle, got %+v", summaries)
+	}
+
+	contents, err := handleWorkspaceModelResource(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "petri://workspace/order-flow"},
+	})
+	if err != nil {
+		t.Fatalf("resource: %v", err)
+	}
+	if text := contents[0].(mcp.TextResourceContents).Text; text != workspaceModel {
+		t.Errorf("expected the model from the resource, got %s", text)
+	}
+
+	callTool(t, handleWorkspaceDelete, map[string]any{"handle": "order-flow"})
+	if summaries, _ = workspace.List(); len(summaries) != 0 {
+		t.Errorf("expected an empty workspace, got %+v", summaries)
+	}
+}
+
+func TestParseModelRef(t *testing.T) {
+	for value, want := range map[string]modelRef{
+		"orders":                      {Handle: "orders"},
+		"orders@3":                    {Handle: "orders", Revision: 3},
+		"petri://workspace/orders@12": {Handle: "orders", Revision: 12},
+	} {
+		if got, ok := parseModelRef(value); !ok || got != want {
+			t.Errorf("%s: expected %+v, got %+v", value, want, got)
+		}
+	}
+	for _, value := range []string{`{"places":[]}`, "(schema x)", "not json", "../etc"} {
+		if _, ok := parseModelRef(value); ok {
+			t.Errorf("%s: expected no reference", value)
+		}
+	}
+}
@@ -244,6 +244,8 @@ type WorkflowStep struct {
 	Input     map[string]string `json:"input,omitempty"` // Mapping from workflow context
 	OnSuccess string `json:"on_success,omitempty"` // Next step ID
 	OnFailure string `json:"on_failure,omitempty"` // Step ID on failure
+	Compensate string `json:"compensate,omitempty"` // Action on the same entity that undoes this step
+	Parallel  []string `json:"parallel,omitempty"` // Step IDs run together by a parallel step
 }
 
 // ToSchema converts an Entity to a metamodel Schema.
@@ -0,0 +1,682 @@
+package metamodel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ColoredKind is the place kind of colored places in go-pflow models. It
+// is an untyped constant so it compares with and assigns to Place.Kind.
+const ColoredKind = "colored"
+
+// Errors for colored states.
+var (
+	ErrInvalidColor    = errors.New("metamodel: invalid color")
+	ErrTokenType       = errors.New("metamodel: token does not match color")
+	ErrNoMatchingToken = errors.New("metamodel: no tokens match the arc inscriptions")
+)
+
+// Token is a colored token: a record whose fields are given by the color
+// of the state that holds it.
+type Token map[string]any
+
+// Clone returns a copy of the token.
+func (t Token) Clone() Token {
+	clone := make(Token, len(t))
+	for k, v := range t {
+		clone[k] = v
+	}
+	return clone
+}
+
+// Equal reports whether two tokens have the same fields and values.
+// Tokens should be normalized by Color.Check before they are compared.
+func (t Token) Equal(u Token) bool {
+	if len(t) != len(u) {
+		return false
+	}
+	for k, v := range t {
+		w, ok := u[k]
+		if !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
+
+// ColoredMarking holds the tokens of each colored state, in the order
+// they were produced.
+type ColoredMarking map[string][]Token
+
+// Clone returns a deep copy of the marking.
+func (m ColoredMarking) Clone() ColoredMarking {
+	clone := make(ColoredMarking, len(m))
+	for id, tokens := range m {
+		copied := make([]Token, len(tokens))
+		for i, tok := range tokens {
+			copied[i] = tok.Clone()
+		}
+		clone[id] = copied
+	}
+	return clone
+}
+
+// ColorField is a named, typed field of a color.
+type ColorField struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // string, int64, int, float64 or bool
+}
+
+// Color is the type of the tokens a colored state holds. It is written
+// like the body of a Go struct: "{id string, total int64}".
+type Color []ColorField
+
+// ParseColor parses a color such as "{id string, total int64}". The
+// braces are optional and "{}" is the color of plain, fieldless tokens.
+func ParseColor(typ string) (Color, error) {
+	body := strings.TrimSpace(typ)
+	body = strings.TrimPrefix(body, "{")
+	body = strings.TrimSuffix(body, "}")
+	if strings.TrimSpace(body) == "" {
+		return Color{}, nil
+	}
+
+	var color Color
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(body, ",") {
+		words := strings.Fields(part)
+		if len(words) != 2 {
+			return nil, fmt.Errorf("%w: %q: fields are written \"name type\"", ErrInvalidColor, typ)
+		}
+		name, ft := words[0], words[1]
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %q: duplicate field %s", ErrInvalidColor, typ, name)
+		}
+		switch ft {
+		case "string", "int64", "int", "float64", "bool":
+		default:
+			return nil, fmt.Errorf("%w: %q: field %s has unsupported type %s", ErrInvalidColor, typ, name, ft)
+		}
+		seen[name] = true
+		color = append(color, ColorField{Name: name, Type: ft})
+	}
+	return color, nil
+}
+
+// String formats the color the way ParseColor reads it.
+func (c Color) String() string {
+	parts := make([]string, len(c))
+	for i, f := range c {
+		parts[i] = f.Name + " " + f.Type
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// Field returns the field with a name.
+func (c Color) Field(name string) (ColorField, bool) {
+	for _, f := range c {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return ColorField{}, false
+}
+
+// Check returns the token with its values converted to the color's field
+// types, so a total decoded from JSON as float64 becomes an int64. It
+// fails if a field is missing, unknown or of the wrong type.
+func (c Color) Check(tok Token) (Token, error) {
+	for name := range tok {
+		if _, ok := c.Field(name); !ok {
+			return nil, fmt.Errorf("%w %s: unknown field %s", ErrTokenType, c, name)
+		}
+	}
+	out := make(Token, len(c))
+	for _, f := range c {
+		v, ok := tok[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w %s: missing field %s", ErrTokenType, c, f.Name)
+		}
+		converted, err := convertField(f, v)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %v", ErrTokenType, c, err)
+		}
+		out[f.Name] = converted
+	}
+	return out, nil
+}
+
+// project builds a token of color c from the fields of src, dropping the
+// fields c does not have.
+func (c Color) project(src map[string]any) (Token, error) {
+	tok := make(Token, len(c))
+	for _, f := range c {
+		if v, ok := src[f.Name]; ok {
+			tok[f.Name] = v
+		}
+	}
+	return c.Check(tok)
+}
+
+// convertField converts a value to a field's type. int fields hold
+// int64 values, like integer DataStates.
+func convertField(f ColorField, v any) (any, error) {
+	switch f.Type {
+	case "string":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "bool":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case "int", "int64":
+		switch n := v.(type) {
+		case int:
+			return int64(n), nil
+		case int64:
+			return n, nil
+		case float64:
+			if n == math.Trunc(n) {
+				return int64(n), nil
+			}
+		case json.Number:
+			if i, err := n.Int64(); err == nil {
+				return i, nil
+			}
+		}
+	case "float64":
+		switch n := v.(type) {
+		case int:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		case json.Number:
+			if x, err := n.Float64(); err == nil {
+				return x, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("field %s: %v is not a %s", f.Name, v, f.Type)
+}
+
+// tokenOf returns a binding's value as a token, if it is a record.
+func tokenOf(v any) (Token, bool) {
+	switch t := v.(type) {
+	case Token:
+		return t, true
+	case map[string]any:
+		return Token(t), true
+	}
+	return nil, false
+}
+
+// ColorOf returns the color of a colored state.
+func (s *Schema) ColorOf(stateID string) (Color, error) {
+	st := s.StateByID(stateID)
+	if st == nil || !st.IsColored() {
+		return nil, fmt.Errorf("%w: %s is not a colored state", ErrInvalidColor, stateID)
+	}
+	return ParseColor(st.Type)
+}
+
+// SetInitialTokens sets the initial tokens of colored states, by state ID.
+// go-pflow places only count their tokens, so models carry colored tokens
+// beside the net and callers seed the schema from FromModel with them.
+// Tokens are converted to the state's color; a token that does not match
+// it, or a state that is not colored, is an error and nothing is set.
+func (s *Schema) SetInitialTokens(tokens map[string][]Token) error {
+	checked := make(map[string][]Token, len(tokens))
+	for id, list := range tokens {
+		color, err := s.ColorOf(id)
+		if err != nil {
+			return err
+		}
+		checked[id] = make([]Token, len(list))
+		for i, tok := range list {
+			if checked[id][i], err = color.Check(tok); err != nil {
+				return fmt.Errorf("state %s: initial token: %w", id, err)
+			}
+		}
+	}
+	for id, list := range checked {
+		s.StateByID(id).Initial = list
+	}
+	return nil
+}
+
+// CheckColors checks the colored states of the schema and the arc
+// inscriptions that connect them:
+//   - every color parses and every initial token matches it;
+//   - an arc moves one token, so weights above 1 are rejected;
+//   - arc keys name fields of the color;
+//   - every field of a produced token is bound, either by a key or by the
+//     token variable of an input arc, with the same type.
+//
+// It returns every problem found.
+func (s *Schema) CheckColors() []error {
+	var errs []error
+	colors := make(map[string]Color)
+	for _, st := range s.ColoredStates() {
+		color, err := ParseColor(st.Type)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("state %s: %w", st.ID, err))
+			continue
+		}
+		colors[st.ID] = color
+		for _, tok := range initialColored(st) {
+			if _, err := color.Check(tok); err != nil {
+				errs = append(errs, fmt.Errorf("state %s: initial token: %w", st.ID, err))
+			}
+		}
+	}
+
+	for _, a := range s.Actions {
+		// Fields of the tokens bound by each input variable
+		vars := make(map[string]Color)
+		for _, arc := range s.InputArcs(a.ID) {
+			color, ok := colors[arc.Source]
+			if !ok {
+				continue
+			}
+			errs = append(errs, checkColoredArc(arc, arc.Source, color)...)
+			if arc.Value != "" && !arc.IsInhibitor() {
+				vars[arc.Value] = color
+			}
+		}
+		for _, arc := range s.OutputArcs(a.ID) {
+			color, ok := colors[arc.Target]
+			if !ok {
+				continue
+			}
+			errs = append(errs, checkColoredArc(arc, arc.Target, color)...)
+			src, bound := vars[arc.Value]
+			if arc.Value != "" && !bound {
+				// The caller supplies the token; only its keys can be checked
+				continue
+			}
+			for _, f := range color {
+				if containsString(arc.Keys, f.Name) {
+					continue
+				}
+				sf, ok := src.Field(f.Name)
+				switch {
+				case !ok:
+					errs = append(errs, fmt.Errorf("%w: arc %s -> %s: field %s is not bound", ErrInvalidColor, arc.Source, arc.Target, f.Name))
+				case !sameFieldType(sf.Type, f.Type):
+					errs = append(errs, fmt.Errorf("%w: arc %s -> %s: field %s is %s in %s but %s here", ErrInvalidColor, arc.Source, arc.Target, f.Name, sf.Type, arc.Value, f.Type))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// checkColoredArc checks the inscription of an arc to or from a colored
+// state.
+func checkColoredArc(arc Arc, stateID string, color Color) []error {
+	var errs []error
+	if arc.Weight > 1 {
+		errs = append(errs, fmt.Errorf("%w: arc %s -> %s: colored arcs move one token, not %d", ErrInvalidColor, arc.Source, arc.Target, arc.Weight))
+	}
+	for _, key := range arc.Keys {
+		if _, ok := color.Field(key); !ok {
+			errs = append(errs, fmt.Errorf("%w: arc %s -> %s: %s has no field %s", ErrInvalidColor, arc.Source, arc.Target, stateID, key))
+		}
+	}
+	return errs
+}
+
+func sameFieldType(a, b string) bool {
+	isInt := func(t string) bool { return t == "int" || t == "int64" }
+	return a == b || (isInt(a) && isInt(b))
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// initialColored returns the initial tokens of a colored state.
+func initialColored(st State) []Token {
+	switch v := st.Initial.(type) {
+	case []Token:
+		return v
+	case []map[string]any:
+		tokens := make([]Token, len(v))
+		for i, m := range v {
+			tokens[i] = Token(m)
+		}
+		return tokens
+	case []any:
+		var tokens []Token
+		for _, item := range v {
+			if tok, ok := tokenOf(item); ok {
+				tokens = append(tokens, tok)
+			}
+		}
+		return tokens
+	}
+	return nil
+}
+
+// Bind chooses the tokens an action consumes from its colored input
+// states, and returns the bindings extended with what the arc
+// inscriptions bind: each arc's Value variable is bound to its token, and
+// each of its Keys that is not bound yet is bound to the token's field.
+// Keys that are already bound must match the token's field, and a Value
+// variable that is already bound must equal the token, so callers pick
+// specific tokens by passing them in bindings.
+//
+// Tokens are tried in the order they were produced until the guard is
+// satisfied. Bind returns ErrNoMatchingToken if no tokens match the
+// inscriptions and ErrGuardNotSatisfied if the guard rejects every
+// choice. The output tokens are built too, so bindings that MoveTokens
+// would reject, such as a missing field of an output color, fail here
+// with ErrTokenType. It does not change the snapshot.
+func (r *Runtime) Bind(actionID string, bindings Bindings) (Bindings, error) {
+	if !r.Enabled(actionID) {
+		if r.Schema.ActionByID(actionID) == nil {
+			return nil, ErrActionNotFound
+		}
+		return nil, ErrActionNotEnabled
+	}
+	bound, err := r.bind(actionID, bindings, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.planMove(actionID, bound); err != nil {
+		return nil, err
+	}
+	return bound, nil
+}
+
+// MoveTokens moves the colored tokens of a firing whose bindings were
+// returned by Bind: it consumes the tokens the bindings select and
+// produces the output tokens. It neither checks the guard nor changes
+// TokenStates and DataStates, so generated aggregates use it to apply
+// events they have already validated.
+func (r *Runtime) MoveTokens(actionID string, bound Bindings) error {
+	if r.Schema.ActionByID(actionID) == nil {
+		return ErrActionNotFound
+	}
+	move, err := r.planMove(actionID, bound)
+	if err != nil {
+		return err
+	}
+	r.applyMove(move)
+	return nil
+}
+
+// hasColoredArcs reports whether an action is connected to a ColoredState.
+func (r *Runtime) hasColoredArcs(actionID string) bool {
+	for _, arc := range r.Schema.Arcs {
+		if arc.Target == actionID {
+			if st := r.Schema.StateByID(arc.Source); st != nil && st.IsColored() {
+				return true
+			}
+		}
+		if arc.Source == actionID {
+			if st := r.Schema.StateByID(arc.Target); st != nil && st.IsColored() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// coloredInputs returns the arcs that consume colored tokens for an
+// action. Arcs with inscriptions come first, so an arc without one takes
+// whichever token is left over and the choice is the same when the
+// bindings are replayed.
+func (r *Runtime) coloredInputs(actionID string) []Arc {
+	var inscribed, plain []Arc
+	for _, arc := range r.Schema.InputArcs(actionID) {
+		st := r.Schema.StateByID(arc.Source)
+		if st == nil || !st.IsColored() || arc.IsInhibitor() {
+			continue
+		}
+		if arc.Value != "" || len(arc.Keys) > 0 {
+			inscribed = append(inscribed, arc)
+		} else {
+			plain = append(plain, arc)
+		}
+	}
+	return append(inscribed, plain...)
+}
+
+// bind implements Bind. The guard is evaluated only if checkGuard is set.
+func (r *Runtime) bind(actionID string, bindings Bindings, funcs map[string]GuardFunc, checkGuard bool) (Bindings, error) {
+	a := r.Schema.ActionByID(actionID)
+	if a == nil {
+		return nil, ErrActionNotFound
+	}
+	if bindings == nil {
+		bindings = Bindings{}
+	}
+	inputs := r.coloredInputs(actionID)
+
+	rejected := false
+	accept := func(b Bindings) (bool, error) {
+		if !checkGuard || a.Guard == "" || r.GuardEvaluator == nil {
+			return true, nil
+		}
+		ok, err := r.GuardEvaluator.Evaluate(a.Guard, r.guardBindings(b), funcs)
+		if err != nil {
+			return false, fmt.Errorf("%w: %v", ErrGuardEvaluation, err)
+		}
+		if !ok {
+			rejected = true
+		}
+		return ok, nil
+	}
+
+	bound, err := r.search(inputs, bindings.Clone(), make(map[string]map[int]bool), accept)
+	if err != nil {
+		return nil, err
+	}
+	if bound == nil {
+		if rejected {
+			return nil, ErrGuardNotSatisfied
+		}
+		return nil, ErrNoMatchingToken
+	}
+	return bound, nil
+}
+
+// search binds the tokens of the remaining input arcs, backtracking until
+// accept approves the bindings. It returns nil if no choice is accepted.
+// taken marks the tokens already chosen, by state and index.
+func (r *Runtime) search(inputs []Arc, b Bindings, taken map[string]map[int]bool, accept func(Bindings) (bool, error)) (Bindings, error) {
+	if len(inputs) == 0 {
+		ok, err := accept(b)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return b, nil
+	}
+
+	arc := inputs[0]
+	color, err := r.Schema.ColorOf(arc.Source)
+	if err != nil {
+		return nil, err
+	}
+	if taken[arc.Source] == nil {
+		taken[arc.Source] = make(map[int]bool)
+	}
+	for i, tok := range r.Snapshot.Colored[arc.Source] {
+		if taken[arc.Source][i] {
+			continue
+		}
+		next, ok, err := matchToken(arc, color, tok, b)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		taken[arc.Source][i] = true
+		found, err := r.search(inputs[1:], next, taken, accept)
+		delete(taken[arc.Source], i)
+		if err != nil || found != nil {
+			return found, err
+		}
+	}
+	return nil, nil
+}
+
+// matchToken matches a token against an input arc's inscription and
+// returns the bindings extended with the variables it binds.
+func matchToken(arc Arc, color Color, tok Token, b Bindings) (Bindings, bool, error) {
+	if v, ok := b[arc.Value]; arc.Value != "" && ok && v != nil {
+		want, isToken := tokenOf(v)
+		if !isToken {
+			return nil, false, fmt.Errorf("%w: %s is a %T, not a token", ErrTokenType, arc.Value, v)
+		}
+		want, err := color.Check(want)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", arc.Value, err)
+		}
+		if !tok.Equal(want) {
+			return nil, false, nil
+		}
+	}
+	for _, key := range arc.Keys {
+		v, ok := b[key]
+		if !ok || v == nil {
+			continue
+		}
+		f, ok := color.Field(key)
+		if !ok {
+			return nil, false, fmt.Errorf("%w: %s has no field %s", ErrInvalidColor, arc.Source, key)
+		}
+		want, err := convertField(f, v)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: %v", ErrTokenType, err)
+		}
+		if tok[key] != want {
+			return nil, false, nil
+		}
+	}
+
+	next := b.Clone()
+	if arc.Value != "" {
+		// Guards read fields of plain maps: order.total
+		next[arc.Value] = map[string]any(tok.Clone())
+	}
+	for _, key := range arc.Keys {
+		if v, ok := next[key]; !ok || v == nil {
+			next[key] = tok[key]
+		}
+	}
+	return next, true, nil
+}
+
+// coloredMove is the change a firing makes to the ColoredStates.
+type coloredMove struct {
+	consume map[string]map[int]bool // state ID -> indices of consumed tokens
+	produce map[string][]Token      // state ID -> produced tokens
+}
+
+// planMove works out which tokens a firing with fully bound bindings
+// consumes and produces, without changing the snapshot.
+func (r *Runtime) planMove(actionID string, bound Bindings) (*coloredMove, error) {
+	move := &coloredMove{
+		consume: make(map[string]map[int]bool),
+		produce: make(map[string][]Token),
+	}
+
+	// Every variable is bound, so each arc takes the first token that
+	// matches, as the search in bind did
+	chosen := bound.Clone()
+	for _, arc := range r.coloredInputs(actionID) {
+		color, err := r.Schema.ColorOf(arc.Source)
+		if err != nil {
+			return nil, err
+		}
+		if move.consume[arc.Source] == nil {
+			move.consume[arc.Source] = make(map[int]bool)
+		}
+		found := false
+		for i, tok := range r.Snapshot.Colored[arc.Source] {
+			if move.consume[arc.Source][i] {
+				continue
+			}
+			next, ok, err := matchToken(arc, color, tok, chosen)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				move.consume[arc.Source][i] = true
+				chosen = next
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s -> %s", ErrNoMatchingToken, arc.Source, arc.Target)
+		}
+	}
+
+	for _, arc := range r.Schema.OutputArcs(actionID) {
+		st := r.Schema.StateByID(arc.Target)
+		if st == nil || !st.IsColored() {
+			continue
+		}
+		color, err := ParseColor(st.Type)
+		if err != nil {
+			return nil, fmt.Errorf("state %s: %w", st.ID, err)
+		}
+		src := make(map[string]any)
+		if tok, ok := tokenOf(chosen[arc.Value]); arc.Value != "" && ok {
+			for k, v := range tok {
+				src[k] = v
+			}
+		}
+		for _, key := range arc.Keys {
+			if v, ok := chosen[key]; ok {
+				src[key] = v
+			}
+		}
+		tok, err := color.project(src)
+		if err != nil {
+			return nil, fmt.Errorf("arc %s -> %s: %w", arc.Source, arc.Target, err)
+		}
+		move.produce[arc.Target] = append(move.produce[arc.Target], tok)
+	}
+	return move, nil
+}
+
+// applyMove consumes and produces the tokens of a planned move.
+func (r *Runtime) applyMove(move *coloredMove) {
+	if move == nil {
+		return
+	}
+	if r.Snapshot.Colored == nil {
+		r.Snapshot.Colored = make(ColoredMarking)
+	}
+	for id, consumed := range move.consume {
+		if len(consumed) == 0 {
+			continue
+		}
+		kept := make([]Token, 0, len(r.Snapshot.Colored[id])-len(consumed))
+		for i, tok := range r.Snapshot.Colored[id] {
+			if !consumed[i] {
+				kept = append(kept, tok)
+			}
+		}
+		r.Snapshot.Colored[id] = kept
+	}
+	for id, tokens := range move.produce {
+		r.Snapshot.Colored[id] = append(r.Snapshot.Colored[id], tokens...)
+	}
+}
@@ -0,0 +1,253 @@
+package metamodel_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/dsl"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+// fulfilment moves orders from pending to shipped. Shipping joins an
+// order with a courier for its region; large orders need express.
+func fulfilment() *metamodel.Schema {
+	s := metamodel.NewSchema("fulfilment")
+	s.AddColoredState("pending", "{id string, region string, total int64}")
+	s.AddColoredState("couriers", "{name string, region string, express bool}")
+	s.AddColoredState("shipped", "{id string, region string}")
+	s.AddTokenState("count", 0)
+	s.AddAction(metamodel.Action{ID: "place"})
+	s.AddAction(metamodel.Action{ID: "ship", Guard: "order.total < 100 || courier.express"})
+	s.AddArc(metamodel.Arc{Source: "place", Target: "pending", Keys: []string{"id", "region", "total"}})
+	s.AddArc(metamodel.Arc{Source: "pending", Target: "ship", Value: "order", Keys: []string{"id", "region"}})
+	s.AddArc(metamodel.Arc{Source: "couriers", Target: "ship", Value: "courier", Keys: []string{"region"}})
+	s.AddArc(metamodel.Arc{Source: "ship", Target: "couriers", Value: "courier"})
+	s.AddArc(metamodel.Arc{Source: "ship", Target: "shipped", Value: "order"})
+	s.AddArc(metamodel.Arc{Source: "ship", Target: "count"})
+	s.AddConstraint(metamodel.Constraint{ID: "one_count_per_shipment", Expr: "count == shipped"})
+	return s
+}
+
+func newFulfilment(t *testing.T) *metamodel.Runtime {
+	t.Helper()
+	s := fulfilment()
+	if err := s.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	rt := metamodel.NewRuntime(s)
+	rt.GuardEvaluator = dsl.NewEvaluator()
+	for _, c := range []metamodel.Token{
+		{"name": "ann", "region": "north", "express": false},
+		{"name": "bob", "region": "south", "express": false},
+		{"name": "cat", "region": "south", "express": true},
+	} {
+		if err := rt.AddToken("couriers", c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return rt
+}
+
+func TestColoredFiring(t *testing.T) {
+	rt := newFulfilment(t)
+
+	// Totals decoded from JSON are float64; the color makes them int64
+	for _, b := range []metamodel.Bindings{
+		{"id": "o1", "region": "south", "total": float64(250)},
+		{"id": "o2", "region": "north", "total": float64(40)},
+	} {
+		if err := rt.ExecuteWithBindings("place", b); err != nil {
+			t.Fatalf("place: %v", err)
+		}
+	}
+
+	// o1 is large, so bob is skipped for cat, the express courier
+	bound, err := rt.Bind("ship", metamodel.Bindings{"id": "o1"})
+	if err != nil {
+		t.Fatalf("bind: %v", err)
+	}
+	if c := bound["courier"].(map[string]any); c["name"] != "cat" {
+		t.Errorf("expected cat to be bound, got %v", c["name"])
+	}
+	if err := rt.ExecuteWithBindings("ship", metamodel.Bindings{"id": "o1"}); err != nil {
+		t.Fatalf("ship: %v", err)
+	}
+
+	// Shipped tokens keep the order's fields that their color has
+	want := metamodel.Token{"id": "o1", "region": "south"}
+	if got := rt.ColoredTokens("shipped"); len(got) != 1 || !got[0].Equal(want) {
+		t.Errorf("expected shipped %v, got %v", want, got)
+	}
+	if got := rt.ColoredTokens("pending"); len(got) != 1 || got[0]["id"] != "o2" || got[0]["total"] != int64(40) {
+		t.Errorf("expected o2 to be pending, got %v", got)
+	}
+	if rt.Tokens("couriers") != 3 || rt.Tokens("count") != 1 {
+		t.Errorf("expected 3 couriers and count 1, got %d and %d", rt.Tokens("couriers"), rt.Tokens("count"))
+	}
+
+	// A specific token is picked by passing it in
+	ann := metamodel.Token{"name": "ann", "region": "north", "express": false}
+	if err := rt.ExecuteWithBindings("ship", metamodel.Bindings{"courier": map[string]any(ann)}); err != nil {
+		t.Fatalf("ship o2: %v", err)
+	}
+	if rt.Enabled("ship") {
+		t.Error("expected ship to be disabled with nothing pending")
+	}
+}
+
+func TestColoredBindFailures(t *testing.T) {
+	rt := newFulfilment(t)
+	rt.ExecuteWithBindings("place", metamodel.Bindings{"id": "o1", "region": "west", "total": 10})
+	rt.ExecuteWithBindings("place", metamodel.Bindings{"id": "o2", "region": "north", "total": 500})
+
+	if _, err := rt.Bind("ship", metamodel.Bindings{"id": "o1"}); !errors.Is(err, metamodel.ErrNoMatchingToken) {
+		t.Errorf("expected no courier for the west, got %v", err)
+	}
+	if _, err := rt.Bind("ship", metamodel.Bindings{"id": "o2"}); !errors.Is(err, metamodel.ErrGuardNotSatisfied) {
+		t.Errorf("expected the guard to reject a large northern order, got %v", err)
+	}
+
+	// Output tokens are built while binding, so an event that could not
+	// be replayed is never recorded
+	if _, err := rt.Bind("place", metamodel.Bindings{"id": "o3"}); !errors.Is(err, metamodel.ErrTokenType) {
+		t.Errorf("expected an order without a region to be rejected, got %v", err)
+	}
+
+	before := rt.Snapshot.Clone()
+	err := rt.ExecuteWithBindings("place", metamodel.Bindings{"id": "o3", "region": "north", "total": "lots"})
+	if !errors.Is(err, metamodel.ErrTokenType) {
+		t.Errorf("expected a token type error, got %v", err)
+	}
+	if len(rt.ColoredTokens("pending")) != len(before.Colored["pending"]) {
+		t.Error("a failed firing changed the marking")
+	}
+}
+
+func TestMoveTokensReplaysBind(t *testing.T) {
+	rt := newFulfilment(t)
+	rt.ExecuteWithBindings("place", metamodel.Bindings{"id": "o1", "region": "south", "total": 20})
+	replica := rt.Clone()
+
+	bound, err := rt.Bind("ship", nil)
+	if err != nil {
+		t.Fatalf("bind: %v", err)
+	}
+	if err := rt.ExecuteWithBindings("ship", bound); err != nil {
+		t.Fatalf("ship: %v", err)
+	}
+	if err := replica.MoveTokens("ship", bound); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	for _, id := range []string{"pending", "couriers", "shipped"} {
+		got, want := replica.ColoredTokens(id), rt.ColoredTokens(id)
+		if len(got) != len(want) {
+			t.Fatalf("%s: replayed %v, fired %v", id, got, want)
+		}
+		for i := range got {
+			if !got[i].Equal(want[i]) {
+				t.Errorf("%s: replayed %v, fired %v", id, got, want)
+			}
+		}
+	}
+}
+
+func TestBindGuardReadsDataStates(t *testing.T) {
+	// Orders ship only to customers with credit, a data place the
+	// aggregate's typed state holds
+	s := metamodel.NewSchema("credit")
+	s.AddColoredState("pending", "{id string, customer string}")
+	s.AddColoredState("shipped", "{id string}")
+	s.AddDataState("credit", "map[string]int", nil, true)
+	s.AddAction(metamodel.Action{ID: "ship", Guard: "credit[order.customer] > 0"})
+	s.AddArc(metamodel.Arc{Source: "pending", Target: "ship", Value: "order", Keys: []string{"id"}})
+	s.AddArc(metamodel.Arc{Source: "ship", Target: "shipped", Value: "order"})
+	if err := s.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	rt := metamodel.NewRuntime(s)
+	rt.GuardEvaluator = dsl.NewEvaluator()
+	if err := rt.AddToken("pending", metamodel.Token{"id": "o1", "customer": "ann"}); err != nil {
+		t.Fatal(err)
+	}
+
+	rt.Snapshot.SetData("credit", map[string]int{"ann": 0})
+	if _, err := rt.Bind("ship", metamodel.Bindings{"id": "o1"}); !errors.Is(err, metamodel.ErrGuardNotSatisfied) {
+		t.Errorf("expected the guard to reject a customer without credit, got %v", err)
+	}
+	rt.Snapshot.SetData("credit", map[string]int{"ann": 5})
+	if _, err := rt.Bind("ship", metamodel.Bindings{"id": "o1"}); err != nil {
+		t.Errorf("expected the guard to pass with credit, got %v", err)
+	}
+}
+
+func TestCheckColors(t *testing.T) {
+	s := fulfilment()
+	s.AddColoredState("returns", "{id string, reason string}")
+	s.AddColoredState("broken", "{id uuid}")
+	s.AddAction(metamodel.Action{ID: "return"})
+	s.AddArc(metamodel.Arc{Source: "shipped", Target: "return", Value: "order", Weight: 2})
+	s.AddArc(metamodel.Arc{Source: "return", Target: "returns", Value: "order", Keys: []string{"why"}})
+
+	var msgs []string
+	for _, err := range s.CheckColors() {
+		msgs = append(msgs, err.Error())
+	}
+	got := strings.Join(msgs, "\n")
+	for _, want := range []string{
+		"state broken: metamodel: invalid color",
+		"colored arcs move one token, not 2",
+		"returns has no field why",
+		"field reason is not bound",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+
+	// Colored places survive the round trip through go-pflow models
+	back := metamodel.FromModel(fulfilment().ToModel())
+	if st := back.StateByID("pending"); st == nil || !st.IsColored() || st.Type != "{id string, region string, total int64}" {
+		t.Errorf("expected pending to stay colored, got %+v", st)
+	}
+}
+
+func TestSetInitialTokens(t *testing.T) {
+	s := metamodel.FromModel(fulfilment().ToModel())
+	couriers := map[string][]metamodel.Token{
+		"couriers": {{"name": "ann", "region": "north", "express": false}},
+	}
+	if err := s.SetInitialTokens(couriers); err != nil {
+		t.Fatalf("set initial tokens: %v", err)
+	}
+	rt := metamodel.NewRuntime(s)
+	if rt.Tokens("couriers") != 1 || rt.ColoredTokens("couriers")[0]["name"] != "ann" {
+		t.Errorf("expected ann to be the initial courier, got %v", rt.ColoredTokens("couriers"))
+	}
+
+	// JSON numbers are converted to the color's types
+	if err := s.SetInitialTokens(map[string][]metamodel.Token{
+		"pending": {{"id": "o1", "region": "north", "total": float64(5)}},
+	}); err != nil {
+		t.Fatalf("set pending: %v", err)
+	}
+	if got := s.StateByID("pending").Initial.([]metamodel.Token); got[0]["total"] != int64(5) {
+		t.Errorf("expected total to be an int64, got %T", got[0]["total"])
+	}
+
+	if err := s.SetInitialTokens(map[string][]metamodel.Token{"count": {{}}}); !errors.Is(err, metamodel.ErrInvalidColor) {
+		t.Errorf("expected tokens for a token state to be rejected, got %v", err)
+	}
+	err := s.SetInitialTokens(map[string][]metamodel.Token{"shipped": {{"id": "o2"}}})
+	if !errors.Is(err, metamodel.ErrTokenType) {
+		t.Errorf("expected a token without a region to be rejected, got %v", err)
+	}
+	if s.StateByID("shipped").Initial != nil {
+		t.Error("a rejected token was set")
+	}
+	for _, p := range s.ToModel().Places {
+		if p.ID == "couriers" && p.Initial != 1 {
+			t.Errorf("expected the couriers place to count 1 token, got %d", p.Initial)
+		}
+	}
+}
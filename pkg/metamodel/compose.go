@@ -0,0 +1,621 @@
+package metamodel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+)
+
+// Composition is a model document that imports other models as sub-nets.
+// Its own places, transitions and arcs form the root net. Compose
+// flattens it into a single model before validation and code generation.
+type Composition struct {
+	goflowmodel.Model
+
+	// Subnets are the imported models.
+	Subnets []Subnet `json:"subnets,omitempty"`
+}
+
+// Subnet imports a model into a composition.
+//
+// Every place, transition, event and constraint of the imported model is
+// copied with Prefix prepended to its ID, except the nodes named in Fuse.
+// Those are merged into a node of the composed net instead: arcs to and
+// from the sub-net node are connected to the target. Fusing places shares
+// their tokens between nets; fusing transitions makes them fire together.
+//
+// Prefix and Fuse targets may contain index expressions in braces, such
+// as "p{i}_" or "p{(i+1)%5}_turn", evaluated for each value of the
+// Replicate variable. Expressions support + - * / % and parentheses; %
+// never returns a negative number, so "{(i-1)%5}" wraps around.
+type Subnet struct {
+	// Model is the name the Loader reads, e.g. "player.json". Names in
+	// nested imports are relative to the importing model.
+	Model string `json:"model"`
+
+	// Prefix is prepended to the IDs of the imported nodes.
+	Prefix string `json:"prefix,omitempty"`
+
+	// Fuse maps IDs in the imported model to IDs in the composed net.
+	Fuse map[string]string `json:"fuse,omitempty"`
+
+	// Replicate imports the model once for each value of a variable.
+	Replicate *Replicate `json:"replicate,omitempty"`
+}
+
+// Replicate imports a sub-net once for each value of Var from From to To,
+// inclusive.
+type Replicate struct {
+	Var  string `json:"var,omitempty"` // default: "i"
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// Loader reads an imported model by name.
+type Loader func(name string) ([]byte, error)
+
+// ErrNoLoader is returned for a document with subnets when there is no
+// Loader, as for a model sent to a tool or service without a file of its own.
+var ErrNoLoader = errors.New("subnets are only supported in model files, where imported models can be loaded")
+
+// DirLoader loads imported models from files relative to dir.
+func DirLoader(dir string) Loader {
+	return func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	}
+}
+
+// RootedLoader loads imported models from files under dir, refusing names
+// and symlinks that lead outside it. Use it for documents that arrive over
+// the network, whose imports must not read other files.
+func RootedLoader(dir string) Loader {
+	return func(name string) ([]byte, error) {
+		root, err := os.OpenRoot(dir)
+		if err != nil {
+			return nil, err
+		}
+		defer root.Close()
+		return root.ReadFile(filepath.FromSlash(name))
+	}
+}
+
+// Source is where a node of a flattened model was defined.
+type Source struct {
+	// Model is the imported model. Nested imports are joined with " > ".
+	Model string `json:"model"`
+
+	// ID is the node's ID in that model.
+	ID string `json:"id"`
+
+	// Instance lists the replication variables, e.g. "i=2".
+	Instance string `json:"instance,omitempty"`
+}
+
+// String describes the source, e.g. "fold in player.json (i=2)".
+func (s Source) String() string {
+	out := s.ID + " in " + s.Model
+	if s.Instance != "" {
+		out += " (" + s.Instance + ")"
+	}
+	return out
+}
+
+// SourceMap maps IDs of a flattened model to where they were defined.
+// Nodes of the root net are not listed.
+type SourceMap map[string]Source
+
+// Explain appends the source of each imported ID that msg mentions, so
+// errors about a flattened model point at the sub-model to change.
+func (m SourceMap) Explain(msg string) string {
+	var notes []string
+	seen := make(map[string]bool)
+	for _, word := range identifiers(msg) {
+		src, ok := m[word]
+		if !ok || seen[word] {
+			continue
+		}
+		seen[word] = true
+		notes = append(notes, word+" is "+src.String())
+	}
+	if len(notes) == 0 {
+		return msg
+	}
+	return msg + " (" + strings.Join(notes, "; ") + ")"
+}
+
+// Flatten expands the sub-nets of a JSON model document and returns the
+// flattened document. Top-level fields other than the net, such as roles
+// and access rules, are kept from the root document: imported models
+// contribute only their places, transitions, arcs, events and
+// constraints. Documents without subnets are returned unchanged.
+func Flatten(data []byte, load Loader) ([]byte, SourceMap, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if _, ok := doc["subnets"]; !ok {
+		return data, nil, nil
+	}
+
+	var c Composition
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, nil, err
+	}
+	model, sources, err := Compose(&c, load)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := json.Marshal(model)
+	if err != nil {
+		return nil, nil, err
+	}
+	var net map[string]json.RawMessage
+	if err := json.Unmarshal(body, &net); err != nil {
+		return nil, nil, err
+	}
+	delete(doc, "subnets")
+	maps.Copy(doc, net)
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, sources, nil
+}
+
+// Compose flattens a composition into a single model, and reports where
+// each imported node was defined.
+func Compose(c *Composition, load Loader) (*goflowmodel.Model, SourceMap, error) {
+	return compose(c, load, ".", nil)
+}
+
+// composer accumulates the flattened net.
+type composer struct {
+	model   *goflowmodel.Model
+	sources SourceMap
+	kinds   map[string]string // node ID -> "place" or "transition"
+	owners  map[string]string // node ID -> description for duplicate errors
+	fusions []fusion
+}
+
+// fusion is a sub-net node merged into a node of the composed net.
+type fusion struct {
+	target string
+	kind   string
+	source Source
+}
+
+func compose(c *Composition, load Loader, dir string, chain []string) (*goflowmodel.Model, SourceMap, error) {
+	model := c.Model
+	model.Places = slices.Clone(c.Places)
+	model.Transitions = slices.Clone(c.Transitions)
+	model.Arcs = slices.Clone(c.Arcs)
+	model.Events = slices.Clone(c.Events)
+	model.Constraints = slices.Clone(c.Constraints)
+
+	m := &composer{
+		model:   &model,
+		sources: make(SourceMap),
+		kinds:   make(map[string]string),
+		owners:  make(map[string]string),
+	}
+	for _, p := range model.Places {
+		m.kinds[p.ID] = "place"
+		m.owners[p.ID] = p.ID + " in the root model"
+	}
+	for _, t := range model.Transitions {
+		m.kinds[t.ID] = "transition"
+		m.owners[t.ID] = t.ID + " in the root model"
+	}
+
+	for _, sub := range c.Subnets {
+		name := path.Join(dir, sub.Model)
+		if sub.Model == "" {
+			return nil, nil, fmt.Errorf("subnet has no model")
+		}
+		if slices.Contains(chain, name) {
+			return nil, nil, fmt.Errorf("subnet %s imports itself: %s", name, strings.Join(append(chain, name), " > "))
+		}
+		if load == nil {
+			return nil, nil, fmt.Errorf("subnet %s: %w", name, ErrNoLoader)
+		}
+		data, err := load(name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("subnet %s: %w", name, err)
+		}
+		var inner Composition
+		if err := json.Unmarshal(data, &inner); err != nil {
+			return nil, nil, fmt.Errorf("subnet %s: %w", name, err)
+		}
+		net, innerSources, err := compose(&inner, load, path.Dir(name), append(chain, name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("subnet %s: %w", name, err)
+		}
+
+		instances, err := sub.instances()
+		if err != nil {
+			return nil, nil, fmt.Errorf("subnet %s: %w", name, err)
+		}
+		for _, vars := range instances {
+			if err := m.add(name, sub, vars, net, innerSources); err != nil {
+				return nil, nil, fmt.Errorf("subnet %s%s: %w", name, instanceLabel(vars, " ("), err)
+			}
+		}
+	}
+
+	for _, f := range m.fusions {
+		kind, ok := m.kinds[f.target]
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: fuse target %s not found", f.source, f.target)
+		}
+		if kind != f.kind {
+			return nil, nil, fmt.Errorf("%s: cannot fuse a %s with %s %s", f.source, f.kind, kind, f.target)
+		}
+	}
+	return m.model, m.sources, nil
+}
+
+// add copies one instance of a sub-net into the composed net.
+func (m *composer) add(name string, sub Subnet, vars map[string]int, net *goflowmodel.Model, innerSources SourceMap) error {
+	prefix, err := expand(sub.Prefix, vars)
+	if err != nil {
+		return err
+	}
+
+	kinds := make(map[string]string)
+	for _, p := range net.Places {
+		kinds[p.ID] = "place"
+	}
+	for _, t := range net.Transitions {
+		kinds[t.ID] = "transition"
+	}
+
+	rename := make(map[string]string)
+	for id := range kinds {
+		rename[id] = prefix + id
+	}
+	fused := make(map[string]bool)
+	for _, id := range slices.Sorted(maps.Keys(sub.Fuse)) {
+		if _, ok := kinds[id]; !ok {
+			return fmt.Errorf("fuse: %s is not a place or transition of the sub-net", id)
+		}
+		target, err := expand(sub.Fuse[id], vars)
+		if err != nil {
+			return err
+		}
+		rename[id] = target
+		fused[id] = true
+	}
+
+	label := instanceLabel(vars, "")
+	source := func(id string) Source {
+		if inner, ok := innerSources[id]; ok {
+			instance := label
+			if inner.Instance != "" {
+				instance = strings.TrimPrefix(label+", "+inner.Instance, ", ")
+			}
+			return Source{Model: name + " > " + inner.Model, ID: inner.ID, Instance: instance}
+		}
+		return Source{Model: name, ID: id, Instance: label}
+	}
+	places := make(map[string]string)
+	for _, p := range net.Places {
+		places[p.ID] = rename[p.ID]
+	}
+
+	for _, p := range net.Places {
+		if fused[p.ID] {
+			m.fusions = append(m.fusions, fusion{target: rename[p.ID], kind: "place", source: source(p.ID)})
+			continue
+		}
+		src := source(p.ID)
+		p.ID = rename[p.ID]
+		if err := m.claim(p.ID, "place", src); err != nil {
+			return err
+		}
+		m.model.Places = append(m.model.Places, p)
+	}
+	for _, t := range net.Transitions {
+		if fused[t.ID] {
+			m.fusions = append(m.fusions, fusion{target: rename[t.ID], kind: "transition", source: source(t.ID)})
+			continue
+		}
+		src := source(t.ID)
+		t.ID = rename[t.ID]
+		t.Guard = renameIdents(t.Guard, places)
+		if t.Event != "" {
+			t.Event = prefix + t.Event
+		}
+		if t.EventType != "" {
+			t.EventType = prefix + t.EventType
+		}
+		if err := m.claim(t.ID, "transition", src); err != nil {
+			return err
+		}
+		m.model.Transitions = append(m.model.Transitions, t)
+	}
+	for _, a := range net.Arcs {
+		a.From = rename[a.From]
+		a.To = rename[a.To]
+		m.model.Arcs = append(m.model.Arcs, a)
+	}
+	for _, e := range net.Events {
+		e.ID = prefix + e.ID
+		m.model.Events = append(m.model.Events, e)
+	}
+	for _, c := range net.Constraints {
+		c.ID = prefix + c.ID
+		c.Expr = renameIdents(c.Expr, places)
+		m.model.Constraints = append(m.model.Constraints, c)
+	}
+	return nil
+}
+
+// claim records a new node of the composed net, rejecting duplicate IDs.
+func (m *composer) claim(id, kind string, src Source) error {
+	if owner, ok := m.owners[id]; ok {
+		return fmt.Errorf("%w: %s is both %s and %s", ErrDuplicateID, id, owner, src)
+	}
+	m.owners[id] = src.String()
+	m.kinds[id] = kind
+	m.sources[id] = src
+	return nil
+}
+
+// instances returns the replication variables of each copy of the
+// sub-net.
+func (s Subnet) instances() ([]map[string]int, error) {
+	r := s.Replicate
+	if r == nil {
+		return []map[string]int{nil}, nil
+	}
+	name := r.Var
+	if name == "" {
+		name = "i"
+	}
+	if r.To < r.From {
+		return nil, fmt.Errorf("replicate: %s runs from %d to %d", name, r.From, r.To)
+	}
+	var out []map[string]int
+	for i := r.From; i <= r.To; i++ {
+		out = append(out, map[string]int{name: i})
+	}
+	return out, nil
+}
+
+// instanceLabel formats replication variables as "i=2", preceded by open
+// and followed by a matching ")" when open is " (".
+func instanceLabel(vars map[string]int, open string) string {
+	if len(vars) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, name := range slices.Sorted(maps.Keys(vars)) {
+		parts = append(parts, fmt.Sprintf("%s=%d", name, vars[name]))
+	}
+	label := strings.Join(parts, ", ")
+	if open == "" {
+		return label
+	}
+	return open + label + ")"
+}
+
+// expand replaces each {expression} in s with its value for vars.
+func expand(s string, vars map[string]int) (string, error) {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(s, '{')
+		if start < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unclosed { in %q", s)
+		}
+		v, err := evalIndex(s[start+1:start+end], vars)
+		if err != nil {
+			return "", fmt.Errorf("%q: %w", s, err)
+		}
+		b.WriteString(s[:start])
+		b.WriteString(strconv.Itoa(v))
+		s = s[start+end+1:]
+	}
+}
+
+// indexParser evaluates integer index expressions.
+type indexParser struct {
+	src  string
+	pos  int
+	vars map[string]int
+}
+
+func evalIndex(expr string, vars map[string]int) (int, error) {
+	p := &indexParser{src: expr, vars: vars}
+	v, err := p.sum()
+	if err != nil {
+		return 0, err
+	}
+	if p.skipSpace(); p.pos < len(p.src) {
+		return 0, fmt.Errorf("unexpected %q in index expression", p.src[p.pos:])
+	}
+	return v, nil
+}
+
+func (p *indexParser) sum() (int, error) {
+	v, err := p.product()
+	for err == nil {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			break
+		}
+		p.pos++
+		var r int
+		if r, err = p.product(); op == '+' {
+			v += r
+		} else {
+			v -= r
+		}
+	}
+	return v, err
+}
+
+func (p *indexParser) product() (int, error) {
+	v, err := p.operand()
+	for err == nil {
+		op := p.peek()
+		if op != '*' && op != '/' && op != '%' {
+			break
+		}
+		p.pos++
+		var r int
+		if r, err = p.operand(); err != nil {
+			break
+		}
+		switch {
+		case op == '*':
+			v *= r
+		case r == 0:
+			return 0, fmt.Errorf("division by zero in index expression")
+		case op == '/':
+			v /= r
+		default:
+			v = ((v % r) + r) % r
+		}
+	}
+	return v, err
+}
+
+func (p *indexParser) operand() (int, error) {
+	switch c := p.peek(); {
+	case c == '(':
+		p.pos++
+		v, err := p.sum()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ')' {
+			return 0, fmt.Errorf("missing ) in index expression")
+		}
+		p.pos++
+		return v, nil
+	case c == '-':
+		p.pos++
+		v, err := p.operand()
+		return -v, err
+	case c >= '0' && c <= '9':
+		start := p.pos
+		for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+		return strconv.Atoi(p.src[start:p.pos])
+	case isIdentStart(c):
+		start := p.pos
+		for p.pos < len(p.src) && isIdentPart(p.src[p.pos]) {
+			p.pos++
+		}
+		name := p.src[start:p.pos]
+		v, ok := p.vars[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown variable %s in index expression", name)
+		}
+		return v, nil
+	case c == 0:
+		return 0, fmt.Errorf("missing operand in index expression")
+	default:
+		return 0, fmt.Errorf("unexpected %q in index expression", c)
+	}
+}
+
+// peek skips spaces and returns the next byte, or 0 at the end.
+func (p *indexParser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *indexParser) skipSpace() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// renameIdents replaces identifiers in an expression, leaving string
+// literals and field names after "." alone.
+func renameIdents(expr string, rename map[string]string) string {
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(expr) {
+				b.WriteString(expr[i : i+2])
+				i += 2
+				continue
+			}
+			if c == quote {
+				quote = 0
+			}
+			b.WriteByte(c)
+			i++
+		case c == '"' || c == '\'':
+			quote = c
+			b.WriteByte(c)
+			i++
+		case isIdentPart(c):
+			j := i
+			for j < len(expr) && isIdentPart(expr[j]) {
+				j++
+			}
+			word := expr[i:j]
+			if to, ok := rename[word]; ok && isIdentStart(c) && (i == 0 || expr[i-1] != '.') {
+				word = to
+			}
+			b.WriteString(word)
+			i = j
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// identifiers returns the identifier-like words of s.
+func identifiers(s string) []string {
+	var out []string
+	for i := 0; i < len(s); {
+		if !isIdentPart(s[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(s) && isIdentPart(s[j]) {
+			j++
+		}
+		out = append(out, s[i:j])
+		i = j
+	}
+	return out
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,210 @@
+package metamodel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mapLoader serves imported models from memory.
+func mapLoader(files map[string]string) Loader {
+	return func(name string) ([]byte, error) {
+		data, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("%s not found", name)
+		}
+		return []byte(data), nil
+	}
+}
+
+// player takes its turn, then hands the turn to the next player.
+const player = `{
+	"places": [{"id": "turn"}, {"id": "next"}, {"id": "chips", "initial": 10}],
+	"transitions": [{"id": "bet", "event_type": "bet_placed", "guard": "chips > 0 && amount <= chips"}],
+	"arcs": [{"from": "turn", "to": "bet"}, {"from": "chips", "to": "bet"}, {"from": "bet", "to": "next"}],
+	"constraints": [{"id": "chips_positive", "expr": "chips >= 0"}]
+}`
+
+const table = `{
+	"name": "table",
+	"roles": [{"id": "dealer"}],
+	"places": [{"id": "waiting", "initial": 1}],
+	"transitions": [{"id": "deal"}],
+	"arcs": [{"from": "waiting", "to": "deal"}, {"from": "deal", "to": "p0_turn"}],
+	"subnets": [{
+		"model": "player.json",
+		"prefix": "p{i}_",
+		"replicate": {"from": 0, "to": 2},
+		"fuse": {"next": "p{(i+1)%3}_turn"}
+	}]
+}`
+
+func TestCompose(t *testing.T) {
+	var c Composition
+	if err := json.Unmarshal([]byte(table), &c); err != nil {
+		t.Fatal(err)
+	}
+	model, sources, err := Compose(&c, mapLoader(map[string]string{"player.json": player}))
+	if err != nil {
+		t.Fatalf("compose: %v", err)
+	}
+
+	var places, transitions, arcs []string
+	for _, p := range model.Places {
+		places = append(places, p.ID)
+	}
+	for _, tr := range model.Transitions {
+		transitions = append(transitions, tr.ID+":"+tr.EventType+":"+tr.Guard)
+	}
+	for _, a := range model.Arcs {
+		arcs = append(arcs, a.From+">"+a.To)
+	}
+
+	if got, want := strings.Join(places, ","), "waiting,p0_turn,p0_chips,p1_turn,p1_chips,p2_turn,p2_chips"; got != want {
+		t.Errorf("places: got %s, want %s", got, want)
+	}
+	if got, want := transitions[3], "p2_bet:p2_bet_placed:p2_chips > 0 && amount <= p2_chips"; got != want {
+		t.Errorf("transition: got %s, want %s", got, want)
+	}
+	if got := strings.Join(arcs, ","); !strings.Contains(got, "p1_bet>p2_turn") || !strings.Contains(got, "p2_bet>p0_turn") {
+		t.Errorf("expected fused arcs to pass the turn around, got %s", got)
+	}
+	if got := model.Constraints[1]; got.ID != "p1_chips_positive" || got.Expr != "p1_chips >= 0" {
+		t.Errorf("unexpected constraint %+v", got)
+	}
+
+	if _, ok := sources["waiting"]; ok {
+		t.Error("root nodes should not be in the source map")
+	}
+	if got, want := sources.Explain("place p1_chips is unbounded"), "place p1_chips is unbounded (p1_chips is chips in player.json (i=1))"; got != want {
+		t.Errorf("explain: got %q, want %q", got, want)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	out, sources, err := Flatten([]byte(table), mapLoader(map[string]string{"player.json": player}))
+	if err != nil {
+		t.Fatalf("flatten: %v", err)
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["subnets"]; ok {
+		t.Error("flattened document still has subnets")
+	}
+	if _, ok := doc["roles"]; !ok {
+		t.Error("flattened document lost the root's roles")
+	}
+	if len(sources) != 9 {
+		t.Errorf("expected 9 imported nodes, got %d", len(sources))
+	}
+
+	plain := []byte(`{"places": [{"id": "a"}]}`)
+	if out, sources, err := Flatten(plain, nil); err != nil || string(out) != string(plain) || sources != nil {
+		t.Errorf("expected a model without subnets to be unchanged, got %s, %v, %v", out, sources, err)
+	}
+}
+
+func TestRootedLoader(t *testing.T) {
+	dir, outside := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "player.json"), []byte(player), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outside, "player.json"), []byte(player), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	load := RootedLoader(dir)
+	if _, _, err := Flatten([]byte(table), load); err != nil {
+		t.Fatalf("flatten: %v", err)
+	}
+	escape := `{"subnets": [{"model": "../` + filepath.Base(outside) + `/player.json"}]}`
+	if _, _, err := Flatten([]byte(escape), load); err == nil {
+		t.Error("expected an import outside the directory to be refused")
+	}
+
+	// Without a loader, subnets are an error rather than dropped
+	if _, _, err := Flatten([]byte(table), nil); !errors.Is(err, ErrNoLoader) {
+		t.Errorf("expected ErrNoLoader, got %v", err)
+	}
+}
+
+func TestComposeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		root  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name: "duplicate",
+			root: `{"places": [{"id": "p_turn"}], "subnets": [{"model": "player.json", "prefix": "p_"}]}`,
+			want: "p_turn is both p_turn in the root model and turn in player.json",
+		},
+		{
+			name: "missing fuse target",
+			root: `{"subnets": [{"model": "player.json", "prefix": "p{i}_", "replicate": {"from": 0, "to": 1}, "fuse": {"next": "p{i+1}_turn"}}]}`,
+			want: "next in player.json (i=1): fuse target p2_turn not found",
+		},
+		{
+			name: "fuse kind",
+			root: `{"transitions": [{"id": "go"}], "subnets": [{"model": "player.json", "fuse": {"turn": "go"}}]}`,
+			want: "cannot fuse a place with transition go",
+		},
+		{
+			name: "unknown variable",
+			root: `{"subnets": [{"model": "player.json", "prefix": "p{j}_"}]}`,
+			want: "unknown variable j",
+		},
+		{
+			name:  "cycle",
+			root:  `{"subnets": [{"model": "a.json"}]}`,
+			files: map[string]string{"a.json": `{"subnets": [{"model": "b/b.json"}]}`, "b/b.json": `{"subnets": [{"model": "../a.json"}]}`},
+			want:  "imports itself: a.json > b/b.json > a.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := tt.files
+			if files == nil {
+				files = map[string]string{"player.json": player}
+			}
+			var c Composition
+			if err := json.Unmarshal([]byte(tt.root), &c); err != nil {
+				t.Fatal(err)
+			}
+			_, _, err := Compose(&c, mapLoader(files))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	var c Composition
+	json.Unmarshal([]byte(`{"places": [{"id": "p_turn"}], "subnets": [{"model": "player.json", "prefix": "p_"}]}`), &c)
+	if _, _, err := Compose(&c, mapLoader(map[string]string{"player.json": player})); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("expected ErrDuplicateID, got %v", err)
+	}
+}
+
+func TestNestedSources(t *testing.T) {
+	files := map[string]string{
+		"seat.json":           `{"subnets": [{"model": "players/player.json", "prefix": "s{k}_", "replicate": {"var": "k", "from": 1, "to": 2}}]}`,
+		"players/player.json": player,
+	}
+	var c Composition
+	json.Unmarshal([]byte(`{"subnets": [{"model": "seat.json", "prefix": "t{i}_", "replicate": {"from": 0, "to": 1}}]}`), &c)
+	_, sources, err := Compose(&c, mapLoader(files))
+	if err != nil {
+		t.Fatalf("compose: %v", err)
+	}
+	src := sources["t1_s2_bet"]
+	if got, want := src.String(), "bet in seat.json > players/player.json (i=1, k=2)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
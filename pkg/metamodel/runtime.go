@@ -45,13 +45,19 @@ type Snapshot struct {
 	// Key: state ID, Value: typed data (maps, structs, etc.)
 	// Firing semantics: arcs specify keys for map transformation.
 	Data map[string]any `json:"data,omitempty"`
+
+	// Colored holds the tokens of ColoredState places.
+	// Key: state ID, Value: tokens in the order they were produced
+	// Firing semantics: each arc moves one token chosen by its inscription.
+	Colored ColoredMarking `json:"colored,omitempty"`
 }
 
 // NewSnapshot creates an empty snapshot.
 func NewSnapshot() *Snapshot {
 	return &Snapshot{
-		Tokens: make(map[string]int),
-		Data:   make(map[string]any),
+		Tokens:  make(map[string]int),
+		Data:    make(map[string]any),
+		Colored: make(ColoredMarking),
 	}
 }
 
@@ -61,6 +67,18 @@ func NewSnapshotFromSchema(s *Schema) *Snapshot {
 	for _, st := range s.States {
 		if st.IsToken() {
 			snap.Tokens[st.ID] = st.InitialTokens()
+		} else if st.IsColored() {
+			// Initial tokens are normalized when they match the color;
+			// Schema.Validate reports those that do not
+			color, _ := ParseColor(st.Type)
+			tokens := make([]Token, 0)
+			for _, tok := range initialColored(st) {
+				if checked, err := color.Check(tok); err == nil {
+					tok = checked
+				}
+				tokens = append(tokens, tok.Clone())
+			}
+			snap.Colored[st.ID] = tokens
 		} else {
 			if st.Initial != nil {
 				snap.Data[st.ID] = st.Initial
@@ -138,11 +156,19 @@ func (s *Snapshot) Clone() *Snapshot {
 		}
 	}
 
+	if s.Colored != nil {
+		clone.Colored = s.Colored.Clone()
+	}
+
 	return clone
 }
 
-// GetTokens returns the token count for a TokenState.
+// GetTokens returns the token count for a TokenState, or the number of
+// tokens in a ColoredState.
 func (s *Snapshot) GetTokens(stateID string) int {
+	if tokens, ok := s.Colored[stateID]; ok {
+		return len(tokens)
+	}
 	return s.Tokens[stateID]
 }
 
@@ -336,9 +362,34 @@ func (r *Runtime) DataMap(stateID string) map[string]any {
 	return r.Snapshot.GetDataMap(stateID)
 }
 
+// ColoredTokens returns the tokens at a ColoredState.
+func (r *Runtime) ColoredTokens(stateID string) []Token {
+	return r.Snapshot.Colored[stateID]
+}
+
+// AddToken adds a token to a ColoredState after checking it against the
+// state's color.
+func (r *Runtime) AddToken(stateID string, tok Token) error {
+	color, err := r.Schema.ColorOf(stateID)
+	if err != nil {
+		return err
+	}
+	checked, err := color.Check(tok)
+	if err != nil {
+		return fmt.Errorf("%s: %w", stateID, err)
+	}
+	if r.Snapshot.Colored == nil {
+		r.Snapshot.Colored = make(ColoredMarking)
+	}
+	r.Snapshot.Colored[stateID] = append(r.Snapshot.Colored[stateID], checked)
+	return nil
+}
+
 // Enabled returns true if an action can execute.
 // For TokenState inputs: checks token count >= weight (default 1)
 // For DataState inputs: always enabled (data transformation doesn't consume)
+// For ColoredState inputs: checks there is a token for each arc; whether
+// the tokens match the inscriptions and guard is decided by Bind
 // For inhibitor arcs: blocks if source has any tokens (inhibitor semantics)
 func (r *Runtime) Enabled(actionID string) bool {
 	a := r.Schema.ActionByID(actionID)
@@ -346,9 +397,22 @@ func (r *Runtime) Enabled(actionID string) bool {
 		return false
 	}
 
+	// Tokens needed from each ColoredState
+	need := make(map[string]int)
+
 	// Check all input arcs from TokenStates
 	for _, arc := range r.Schema.InputArcs(actionID) {
 		st := r.Schema.StateByID(arc.Source)
+		if st != nil && st.IsColored() {
+			if arc.IsInhibitor() {
+				if len(r.Snapshot.Colored[arc.Source]) > 0 {
+					return false
+				}
+			} else {
+				need[arc.Source]++
+			}
+			continue
+		}
 		if st != nil && st.IsToken() {
 			if arc.IsInhibitor() {
 				// Inhibitor arc: blocked if source has ANY tokens
@@ -368,6 +432,12 @@ func (r *Runtime) Enabled(actionID string) bool {
 		}
 	}
 
+	for id, n := range need {
+		if len(r.Snapshot.Colored[id]) < n {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -385,11 +455,24 @@ func (r *Runtime) EnabledActions() []string {
 // Execute runs an action.
 // For TokenStates: consumes/produces tokens (Petri net semantics)
 // For DataStates: no automatic transformation (use ExecuteWithBindings for data)
+// For ColoredStates: moves the first tokens that match the arc inscriptions,
+// without evaluating the guard
 func (r *Runtime) Execute(actionID string) error {
 	if !r.Enabled(actionID) {
 		return ErrActionNotEnabled
 	}
 
+	var move *coloredMove
+	if r.hasColoredArcs(actionID) {
+		bound, err := r.bind(actionID, Bindings{}, nil, false)
+		if err != nil {
+			return err
+		}
+		if move, err = r.planMove(actionID, bound); err != nil {
+			return err
+		}
+	}
+
 	// Process input arcs
 	for _, arc := range r.Schema.InputArcs(actionID) {
 		// Skip inhibitor arcs - they are read-only
@@ -418,67 +501,105 @@ func (r *Runtime) Execute(actionID string) error {
 		}
 	}
 
+	r.applyMove(move)
 	r.Sequence++
 
-	// Check constraints if enabled
-	if r.CheckConstraints {
-		if violations := r.Constraints(); len(violations) > 0 {
-			v := violations[0]
-			if v.Err != nil {
-				return fmt.Errorf("%w: %s: %v", ErrConstraintEvaluation, v.Constraint.ID, v.Err)
-			}
-			return fmt.Errorf("%w: %s", ErrConstraintViolated, v.Constraint.ID)
-		}
-	}
-
-	return nil
+	return r.checkConstraints()
 }
 
 // ExecuteWithBindings runs an action with variable bindings.
 // This applies data transformations based on arc Keys and Value specifications.
+// For actions with colored arcs, the guard also sees the token variables
+// bound by the input arcs; see Bind.
 func (r *Runtime) ExecuteWithBindings(actionID string, bindings Bindings) error {
+	return r.execute(actionID, bindings, nil)
+}
+
+// execute runs an action with bindings and guard functions.
+func (r *Runtime) execute(actionID string, bindings Bindings, funcs map[string]GuardFunc) error {
 	a := r.Schema.ActionByID(actionID)
 	if a == nil {
 		return ErrActionNotFound
 	}
 
-	// Evaluate guard if present and evaluator is set
-	if a.Guard != "" && r.GuardEvaluator != nil {
-		ok, err := r.GuardEvaluator.Evaluate(a.Guard, bindings, nil)
-		if err != nil {
-			return fmt.Errorf("%w: %v", ErrGuardEvaluation, err)
+	if r.hasColoredArcs(actionID) {
+		// Tokens are chosen together with the guard, which may read them
+		if !r.Enabled(actionID) {
+			return ErrActionNotEnabled
 		}
-		if !ok {
-			return ErrGuardNotSatisfied
+		bound, err := r.bind(actionID, bindings, funcs, true)
+		if err != nil {
+			return err
+		}
+		bindings = bound
+	} else {
+		// Evaluate guard if present and evaluator is set
+		if a.Guard != "" && r.GuardEvaluator != nil {
+			ok, err := r.GuardEvaluator.Evaluate(a.Guard, r.guardBindings(bindings), funcs)
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrGuardEvaluation, err)
+			}
+			if !ok {
+				return ErrGuardNotSatisfied
+			}
 		}
-	}
 
-	// Check enablement
-	if !r.Enabled(actionID) {
-		return ErrActionNotEnabled
+		// Check enablement
+		if !r.Enabled(actionID) {
+			return ErrActionNotEnabled
+		}
 	}
 
 	// Apply arc transformations
-	r.applyArcs(actionID, bindings)
+	if err := r.applyArcs(actionID, bindings); err != nil {
+		return err
+	}
 
 	r.Sequence++
 
-	// Check constraints if enabled
-	if r.CheckConstraints {
-		if violations := r.Constraints(); len(violations) > 0 {
-			v := violations[0]
-			if v.Err != nil {
-				return fmt.Errorf("%w: %s: %v", ErrConstraintEvaluation, v.Constraint.ID, v.Err)
-			}
-			return fmt.Errorf("%w: %s", ErrConstraintViolated, v.Constraint.ID)
+	return r.checkConstraints()
+}
+
+// checkConstraints returns the first constraint violation, if constraint
+// checking is enabled.
+func (r *Runtime) checkConstraints() error {
+	if !r.CheckConstraints {
+		return nil
+	}
+	if violations := r.Constraints(); len(violations) > 0 {
+		v := violations[0]
+		if v.Err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrConstraintEvaluation, v.Constraint.ID, v.Err)
 		}
+		return fmt.Errorf("%w: %s", ErrConstraintViolated, v.Constraint.ID)
 	}
-
 	return nil
 }
 
-// applyArcs processes input and output arcs for an action.
-func (r *Runtime) applyArcs(actionID string, bindings Bindings) {
+// guardBindings returns the variables a guard sees: the firing's bindings
+// plus the current value of every DataState, so guards such as
+// "balances[from] >= amount" read state the way generated aggregates do.
+// A DataState shadows a binding of the same name.
+func (r *Runtime) guardBindings(bindings Bindings) Bindings {
+	merged := bindings.Clone()
+	for _, st := range r.Schema.DataStates() {
+		merged[st.ID] = r.Snapshot.GetData(st.ID)
+	}
+	return merged
+}
+
+// applyArcs processes input and output arcs for an action. Colored
+// tokens are chosen before anything changes, so a firing that cannot
+// produce its tokens leaves the snapshot as it was.
+func (r *Runtime) applyArcs(actionID string, bindings Bindings) error {
+	var move *coloredMove
+	if r.hasColoredArcs(actionID) {
+		var err error
+		if move, err = r.planMove(actionID, bindings); err != nil {
+			return err
+		}
+	}
+
 	// Process input arcs (consume from source states)
 	for _, arc := range r.Schema.InputArcs(actionID) {
 		// Skip inhibitor arcs - they are read-only and don't consume tokens
@@ -487,7 +608,7 @@ func (r *Runtime) applyArcs(actionID string, bindings Bindings) {
 		}
 
 		st := r.Schema.StateByID(arc.Source)
-		if st == nil {
+		if st == nil || st.IsColored() {
 			continue
 		}
 
@@ -506,7 +627,7 @@ func (r *Runtime) applyArcs(actionID string, bindings Bindings) {
 	// Process output arcs (produce at target states)
 	for _, arc := range r.Schema.OutputArcs(actionID) {
 		st := r.Schema.StateByID(arc.Target)
-		if st == nil {
+		if st == nil || st.IsColored() {
 			continue
 		}
 
@@ -521,6 +642,9 @@ func (r *Runtime) applyArcs(actionID string, bindings Bindings) {
 			r.applyDataArc(arc.Target, arc, bindings, true)
 		}
 	}
+
+	r.applyMove(move)
+	return nil
 }
 
 // applyDataArc applies a data transformation to a DataState.
@@ -631,47 +755,11 @@ func getMapInt64(m map[string]any, key string) int64 {
 
 // ExecuteWithGuardFuncs runs an action with bindings and custom guard functions.
 func (r *Runtime) ExecuteWithGuardFuncs(actionID string, bindings Bindings, funcs map[string]GuardFunc) error {
-	a := r.Schema.ActionByID(actionID)
-	if a == nil {
-		return ErrActionNotFound
-	}
-
-	// Evaluate guard if present and evaluator is set
-	if a.Guard != "" && r.GuardEvaluator != nil {
-		ok, err := r.GuardEvaluator.Evaluate(a.Guard, bindings, funcs)
-		if err != nil {
-			return fmt.Errorf("%w: %v", ErrGuardEvaluation, err)
-		}
-		if !ok {
-			return ErrGuardNotSatisfied
-		}
-	}
-
-	// Check enablement
-	if !r.Enabled(actionID) {
-		return ErrActionNotEnabled
-	}
-
-	// Apply arc transformations
-	r.applyArcs(actionID, bindings)
-
-	r.Sequence++
-
-	// Check constraints if enabled
-	if r.CheckConstraints {
-		if violations := r.Constraints(); len(violations) > 0 {
-			v := violations[0]
-			if v.Err != nil {
-				return fmt.Errorf("%w: %s: %v", ErrConstraintEvaluation, v.Constraint.ID, v.Err)
-			}
-			return fmt.Errorf("%w: %s", ErrConstraintViolated, v.Constraint.ID)
-		}
-	}
-
-	return nil
+	return r.execute(actionID, bindings, funcs)
 }
 
 // Constraints checks all schema constraints against the current snapshot.
+// ColoredStates count as their number of tokens.
 // Returns a slice of violations (empty if all constraints hold).
 func (r *Runtime) Constraints() []ConstraintViolation {
 	var violations []ConstraintViolation
@@ -681,7 +769,7 @@ func (r *Runtime) Constraints() []ConstraintViolation {
 	}
 
 	for _, c := range r.Schema.Constraints {
-		ok, err := r.GuardEvaluator.EvaluateConstraint(c.Expr, r.Snapshot.Tokens)
+		ok, err := r.GuardEvaluator.EvaluateConstraint(c.Expr, r.marking())
 		if err != nil {
 			violations = append(violations, ConstraintViolation{
 				Constraint: c,
@@ -731,17 +819,35 @@ func (r *Runtime) CanReach(targetTokens map[string]int, maxSteps int) bool {
 	return false
 }
 
+// marking returns the token counts constraints are evaluated against.
+func (r *Runtime) marking() map[string]int {
+	if len(r.Snapshot.Colored) == 0 {
+		return r.Snapshot.Tokens
+	}
+	m := make(map[string]int, len(r.Snapshot.Tokens)+len(r.Snapshot.Colored))
+	for id, n := range r.Snapshot.Tokens {
+		m[id] = n
+	}
+	for id, tokens := range r.Snapshot.Colored {
+		m[id] = len(tokens)
+	}
+	return m
+}
+
 func (r *Runtime) tokenKey() string {
 	result := ""
 	for _, st := range r.Schema.TokenStates() {
 		result += fmt.Sprintf("%s:%d;", st.ID, r.Snapshot.Tokens[st.ID])
 	}
+	for _, st := range r.Schema.ColoredStates() {
+		result += fmt.Sprintf("%s:%v;", st.ID, r.Snapshot.Colored[st.ID])
+	}
 	return result
 }
 
 func (r *Runtime) matchesTokens(target map[string]int) bool {
 	for k, v := range target {
-		if r.Snapshot.Tokens[k] != v {
+		if r.Snapshot.GetTokens(k) != v {
 			return false
 		}
 	}
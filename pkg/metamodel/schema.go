@@ -2,7 +2,11 @@
 // This is a local fork of go-pflow/metamodel, extended for petri-pilot's needs.
 package metamodel
 
-import goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+import (
+	"fmt"
+
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+)
 
 // Kind discriminates between token-counting and data-holding states.
 type Kind string
@@ -17,6 +21,12 @@ const (
 	// Firing semantics: arcs specify keys for map access and transformation.
 	// Used for state: balances, owners, allowances, approvals.
 	DataState Kind = "data"
+
+	// ColoredState holds a multiset of typed tokens ("tokens-as-records").
+	// Firing semantics: each arc moves one token; arc inscriptions bind
+	// the token to a variable and match or set its fields.
+	// Used for items that flow through stages: orders, tickets, jobs.
+	ColoredState Kind = ColoredKind
 )
 
 // State represents a named container in a goflowmodel.
@@ -24,15 +34,17 @@ const (
 // or a structured data state.
 type State struct {
 	ID   string `json:"id"`
-	Kind Kind   `json:"kind,omitempty"` // "token" or "data" (default: "data")
+	Kind Kind   `json:"kind,omitempty"` // "token", "data" or "colored" (default: "data")
 
 	// For TokenState: Initial is an int (token count)
 	// For DataState: Initial can be any value (map, struct, etc.)
+	// For ColoredState: Initial is a []Token
 	Initial any `json:"initial,omitempty"`
 
 	// Type describes the state's type goflowmodel.
 	// For TokenState: typically empty or "int"
 	// For DataState: e.g., "map[string]int64", "string", "int64"
+	// For ColoredState: the token color, e.g., "{id string, total int64}"
 	Type string `json:"type,omitempty"`
 
 	// Exported states are externally visible.
@@ -52,6 +64,11 @@ func (s *State) IsData() bool {
 	return s.Kind == DataState || s.Kind == "" // default to data
 }
 
+// IsColored returns true if this state holds colored tokens.
+func (s *State) IsColored() bool {
+	return s.Kind == ColoredState
+}
+
 // InitialTokens returns the initial token count (for TokenState).
 // Returns 0 if not a token state or if Initial is not numeric.
 func (s *State) InitialTokens() int {
@@ -121,12 +138,14 @@ const (
 // Semantics depend on the connected state's Kind:
 //   - TokenState: arc weight is 1, decrement on input, increment on output
 //   - DataState: Keys specify map access path, Value specifies the binding name
+//   - ColoredState: Value names the token variable, Keys name token fields
+//     that are matched against (input) or set from (output) bindings
 //   - InhibitorArc: prevents firing if source has tokens (read-only)
 type Arc struct {
 	Source string   `json:"source"`           // state or action ID
 	Target string   `json:"target"`           // state or action ID
-	Keys   []string `json:"keys,omitempty"`   // for DataState: binding names for map keys
-	Value  string   `json:"value,omitempty"`  // for DataState: binding name for value (default: "amount")
+	Keys   []string `json:"keys,omitempty"`   // for DataState: binding names for map keys; for ColoredState: token fields
+	Value  string   `json:"value,omitempty"`  // for DataState: binding name for value (default: "amount"); for ColoredState: token variable
 	Weight int      `json:"weight,omitempty"` // for TokenState: arc weight (default: 1)
 	Type   ArcType  `json:"type,omitempty"`   // arc type: "" (normal) or "inhibitor"
 }
@@ -242,6 +261,16 @@ func (s *Schema) AddDataState(id string, typ string, initial any, exported bool)
 	})
 }
 
+// AddColoredState adds a colored state whose tokens have the given color,
+// e.g. "{id string, total int64}".
+func (s *Schema) AddColoredState(id string, color string) *Schema {
+	return s.AddState(State{
+		ID:   id,
+		Kind: ColoredState,
+		Type: color,
+	})
+}
+
 // AddAction adds an action to the goflowmodel.
 func (s *Schema) AddAction(a Action) *Schema {
 	s.Actions = append(s.Actions, a)
@@ -300,6 +329,17 @@ func (s *Schema) DataStates() []State {
 	return result
 }
 
+// ColoredStates returns all colored states.
+func (s *Schema) ColoredStates() []State {
+	var result []State
+	for _, st := range s.States {
+		if st.IsColored() {
+			result = append(result, st)
+		}
+	}
+	return result
+}
+
 // ActionByID returns an action by its ID, or nil if not found.
 func (s *Schema) ActionByID(id string) *Action {
 	for i := range s.Actions {
@@ -332,6 +372,49 @@ func (s *Schema) OutputArcs(actionID string) []Arc {
 	return result
 }
 
+// Validate checks the schema's structure: every state and action has a
+// unique non-empty ID, every arc connects a state and an action, and
+// colored states pass CheckColors.
+func (s *Schema) Validate() error {
+	seen := make(map[string]bool)
+	for _, st := range s.States {
+		if st.ID == "" {
+			return fmt.Errorf("%w: state", ErrEmptyID)
+		}
+		if seen[st.ID] {
+			return fmt.Errorf("%w: %s", ErrDuplicateID, st.ID)
+		}
+		seen[st.ID] = true
+	}
+	for _, a := range s.Actions {
+		if a.ID == "" {
+			return fmt.Errorf("%w: action", ErrEmptyID)
+		}
+		if seen[a.ID] {
+			return fmt.Errorf("%w: %s", ErrDuplicateID, a.ID)
+		}
+		seen[a.ID] = true
+	}
+
+	for _, arc := range s.Arcs {
+		if !seen[arc.Source] {
+			return fmt.Errorf("%w: %s", ErrInvalidArcSource, arc.Source)
+		}
+		if !seen[arc.Target] {
+			return fmt.Errorf("%w: %s", ErrInvalidArcTarget, arc.Target)
+		}
+		fromState := s.StateByID(arc.Source) != nil
+		toState := s.StateByID(arc.Target) != nil
+		if fromState == toState {
+			return fmt.Errorf("%w: %s -> %s", ErrInvalidArcConnection, arc.Source, arc.Target)
+		}
+	}
+	if errs := s.CheckColors(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
 // ToModel converts the local metamodel.Schema to goflowmodel.Model for code generation.
 func (s *Schema) ToModel() *goflowmodel.Model {
 	model := &goflowmodel.Model{
@@ -352,6 +435,9 @@ func (s *Schema) ToModel() *goflowmodel.Model {
 		if state.IsToken() {
 			place.Kind = goflowmodel.TokenKind
 			place.Initial = state.InitialTokens()
+		} else if state.IsColored() {
+			place.Kind = ColoredKind
+			place.Initial = len(initialColored(state))
 		} else {
 			place.Kind = goflowmodel.DataKind
 			place.Initial = 0
@@ -398,6 +484,80 @@ func (s *Schema) ToModel() *goflowmodel.Model {
 	return model
 }
 
+// FromModel converts a go-pflow model into a schema. Places without an
+// explicit data or colored kind become token states; data and colored
+// states start empty. go-pflow places only count colored tokens, so
+// callers seed them with SetInitialTokens.
+func FromModel(model *goflowmodel.Model) *Schema {
+	s := NewSchema(model.Name)
+	if model.Version != "" {
+		s.Version = model.Version
+	}
+	s.Description = model.Description
+
+	for _, place := range model.Places {
+		if place.Kind == goflowmodel.DataKind {
+			s.AddState(State{
+				ID:          place.ID,
+				Kind:        DataState,
+				Type:        place.Type,
+				Exported:    place.Exported,
+				Description: place.Description,
+			})
+			continue
+		}
+		if place.Kind == ColoredKind {
+			s.AddState(State{
+				ID:          place.ID,
+				Kind:        ColoredState,
+				Type:        place.Type,
+				Exported:    place.Exported,
+				Description: place.Description,
+			})
+			continue
+		}
+		s.AddState(State{
+			ID:          place.ID,
+			Kind:        TokenState,
+			Initial:     place.Initial,
+			Exported:    place.Exported,
+			Description: place.Description,
+		})
+	}
+
+	for _, t := range model.Transitions {
+		action := Action{
+			ID:          t.ID,
+			Guard:       t.Guard,
+			Description: t.Description,
+		}
+		if len(t.Bindings) > 0 {
+			action.Bindings = make(map[string]string, len(t.Bindings))
+			for _, b := range t.Bindings {
+				action.Bindings[b.Name] = b.Type
+			}
+		}
+		s.AddAction(action)
+	}
+
+	for _, arc := range model.Arcs {
+		s.AddArc(Arc{
+			Source: arc.From,
+			Target: arc.To,
+			Keys:   arc.Keys,
+			Value:  arc.Value,
+			Weight: arc.Weight,
+			Type:   ArcType(arc.Type),
+		})
+	}
+
+	for _, c := range model.Constraints {
+		s.AddConstraint(Constraint{ID: c.ID, Expr: c.Expr})
+	}
+
+	return s
+}
+
 // mapToBindings converts map[string]string to []goflowmodel.Binding.
 func mapToBindings(m map[string]string) []goflowmodel.Binding {
 	if len(m) == 0 {
@@ -0,0 +1,460 @@
+// Package advisor scores the actions available to the current player of a
+// model that declares a metamodel.Simulation.
+//
+// Moves are ranked against the simulation objective, either by bounded
+// minimax search with alpha-beta pruning, by ODE lookahead over the
+// continuous relaxation of the net, or by minimax with ODE-scored leaves.
+package advisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/dsl"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+// Method selects how candidate moves are scored.
+type Method string
+
+const (
+	// MethodMinimax searches Depth plies ahead and scores leaves with the objective.
+	MethodMinimax Method = "minimax"
+
+	// MethodODE plays each move once and scores the ODE steady state.
+	MethodODE Method = "ode"
+
+	// MethodHybrid searches like minimax but scores non-terminal leaves with the ODE.
+	MethodHybrid Method = "hybrid"
+)
+
+const (
+	// DefaultDepth is the search depth used when Options.Depth is zero.
+	DefaultDepth = 4
+
+	// MaxDepth bounds the search depth callers may request.
+	MaxDepth = 10
+
+	// MaxHybridDepth bounds the depth of hybrid search, which integrates
+	// the ODE at every leaf.
+	MaxHybridDepth = 3
+
+	// DefaultMaxNodes is the node budget used when Options.MaxNodes is zero.
+	DefaultMaxNodes = 200000
+)
+
+var (
+	// ErrNoObjective is returned when the schema declares no simulation objective.
+	ErrNoObjective = errors.New("advisor: schema has no simulation objective")
+
+	// ErrUnknownPlayer is returned when Options.Player names no declared player.
+	ErrUnknownPlayer = errors.New("advisor: unknown player")
+
+	// ErrInvalidDepth is returned when Options.Depth is out of range for the method.
+	ErrInvalidDepth = errors.New("advisor: invalid depth")
+
+	// ErrBudgetExceeded is returned when a search visits more than Options.MaxNodes positions.
+	ErrBudgetExceeded = errors.New("advisor: search budget exceeded")
+)
+
+// Options tunes a single Advise call.
+type Options struct {
+	Method Method // Scoring method (default: minimax)
+	Depth  int    // Plies searched by minimax and hybrid, including the advised move (default: DefaultDepth)
+	Player string // Advise this player instead of the one whose turn it is
+
+	// MaxNodes bounds the positions a search may visit (default: DefaultMaxNodes)
+	MaxNodes int
+}
+
+// Move is a scored candidate action.
+type Move struct {
+	Action string  `json:"action"`
+	Score  float64 `json:"score"`
+}
+
+// Advice ranks the enabled actions of one player, best first.
+type Advice struct {
+	Player    string  `json:"player,omitempty"`
+	Maximizes bool    `json:"maximizes"`
+	Method    Method  `json:"method"`
+	Depth     int     `json:"depth,omitempty"`
+	Objective float64 `json:"objective"` // Objective value of the current marking
+	Best      string  `json:"best,omitempty"`
+	Moves     []Move  `json:"moves"`
+}
+
+// ParseMethod validates a method name; the empty string selects minimax.
+func ParseMethod(name string) (Method, error) {
+	switch Method(name) {
+	case "", MethodMinimax:
+		return MethodMinimax, nil
+	case MethodODE, MethodHybrid:
+		return Method(name), nil
+	default:
+		return "", fmt.Errorf("advisor: unknown method %q (want minimax, ode or hybrid)", name)
+	}
+}
+
+// Advise scores every enabled action of the current player in rt.
+// The runtime is not modified. When no player can move the advice has no moves.
+// The search stops with ctx's error once ctx is done, and with
+// ErrBudgetExceeded once it has visited Options.MaxNodes positions.
+func Advise(ctx context.Context, rt *metamodel.Runtime, opts Options) (*Advice, error) {
+	method, err := ParseMethod(string(opts.Method))
+	if err != nil {
+		return nil, err
+	}
+	depth := opts.Depth
+	if depth == 0 {
+		depth = DefaultDepth
+		if method == MethodHybrid {
+			depth = min(depth, MaxHybridDepth)
+		}
+	}
+	if err := CheckDepth(method, depth); err != nil {
+		return nil, err
+	}
+
+	s, err := newSearch(rt.Schema, method)
+	if err != nil {
+		return nil, err
+	}
+	s.ctx = ctx
+	s.budget = opts.MaxNodes
+	if s.budget == 0 {
+		s.budget = DefaultMaxNodes
+	}
+
+	var p *player
+	if opts.Player != "" {
+		if p = s.player(opts.Player); p == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPlayer, opts.Player)
+		}
+	} else {
+		p = s.current(rt)
+	}
+
+	current, err := s.evaluate(rt)
+	if err != nil {
+		return nil, err
+	}
+	advice := &Advice{
+		Method:    method,
+		Objective: current,
+		Moves:     make([]Move, 0),
+	}
+	if method != MethodODE {
+		advice.Depth = depth
+	}
+	if p == nil {
+		return advice, nil
+	}
+	advice.Player = p.name
+	advice.Maximizes = p.Maximizes
+
+	for _, action := range s.moves(rt, p) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		next, ok := s.play(rt, action)
+		if !ok {
+			continue
+		}
+		var score float64
+		if method == MethodODE {
+			score, err = s.ode.score(next)
+		} else {
+			score, err = s.value(next, depth-1, math.Inf(-1), math.Inf(1))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("advisor: scoring %s: %w", action, err)
+		}
+		advice.Moves = append(advice.Moves, Move{Action: action, Score: score})
+	}
+
+	sort.SliceStable(advice.Moves, func(i, j int) bool {
+		if p.Maximizes {
+			return advice.Moves[i].Score > advice.Moves[j].Score
+		}
+		return advice.Moves[i].Score < advice.Moves[j].Score
+	})
+	if len(advice.Moves) > 0 {
+		advice.Best = advice.Moves[0].Action
+	}
+	return advice, nil
+}
+
+// CheckDepth reports ErrInvalidDepth unless depth is between 1 and the
+// method's maximum: MaxHybridDepth for hybrid, MaxDepth otherwise.
+func CheckDepth(method Method, depth int) error {
+	limit := MaxDepth
+	if method == MethodHybrid {
+		limit = MaxHybridDepth
+	}
+	if depth < 1 || depth > limit {
+		return fmt.Errorf("%w: %s depth must be between 1 and %d, got %d", ErrInvalidDepth, method, limit, depth)
+	}
+	return nil
+}
+
+// player is a simulation player with its resolved action set.
+type player struct {
+	metamodel.Player
+	name    string
+	actions []string
+}
+
+// search holds the per-call state shared by every node of the game tree.
+type search struct {
+	schema    *metamodel.Schema
+	objective *dsl.Compiled
+	players   []*player
+	automatic []string // Unowned actions that change the marking, fired after every move
+	leafODE   bool
+	ode       *odeModel
+
+	ctx    context.Context
+	budget int // Positions left to visit
+}
+
+func newSearch(schema *metamodel.Schema, method Method) (*search, error) {
+	sim := schema.Simulation
+	if sim == nil || sim.Objective == "" {
+		return nil, ErrNoObjective
+	}
+	objective, err := dsl.Compile(sim.Objective)
+	if err != nil {
+		return nil, fmt.Errorf("advisor: objective: %w", err)
+	}
+
+	s := &search{
+		schema:    schema,
+		objective: objective,
+		leafODE:   method == MethodHybrid,
+	}
+	if method != MethodMinimax {
+		s.ode = newODEModel(schema, sim)
+	}
+
+	names := make([]string, 0, len(sim.Players))
+	for name := range sim.Players {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	owned := make(map[string]bool)
+	for _, name := range names {
+		p := &player{Player: sim.Players[name], name: name, actions: sim.Players[name].Transitions}
+		if len(p.actions) == 0 && p.TurnPlace != "" {
+			// Infer the player's actions from the arcs leaving its turn place
+			for _, arc := range schema.Arcs {
+				if arc.Source == p.TurnPlace && !arc.IsInhibitor() && schema.ActionByID(arc.Target) != nil {
+					p.actions = append(p.actions, arc.Target)
+				}
+			}
+		}
+		if len(p.actions) == 0 && p.TurnPlace == "" && len(names) == 1 {
+			// A lone player with neither a turn place nor transitions plays every action
+			for _, a := range schema.Actions {
+				p.actions = append(p.actions, a.ID)
+			}
+		}
+		for _, a := range p.actions {
+			owned[a] = true
+		}
+		s.players = append(s.players, p)
+	}
+
+	// Without players the model is a single-agent puzzle over every action
+	if len(s.players) == 0 {
+		p := &player{Player: metamodel.Player{Maximizes: true}}
+		for _, a := range schema.Actions {
+			p.actions = append(p.actions, a.ID)
+		}
+		s.players = append(s.players, p)
+		return s, nil
+	}
+
+	for _, a := range schema.Actions {
+		if !owned[a.ID] && changesMarking(schema, a.ID) {
+			s.automatic = append(s.automatic, a.ID)
+		}
+	}
+	return s, nil
+}
+
+// player returns the named player, or nil.
+func (s *search) player(name string) *player {
+	for _, p := range s.players {
+		if p.name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// current returns the player whose turn place holds a token. A lone player
+// without a turn place is always to move. Nil means the game is over.
+func (s *search) current(rt *metamodel.Runtime) *player {
+	for _, p := range s.players {
+		if p.TurnPlace != "" && rt.Tokens(p.TurnPlace) > 0 {
+			return p
+		}
+	}
+	if len(s.players) == 1 && s.players[0].TurnPlace == "" {
+		return s.players[0]
+	}
+	return nil
+}
+
+// moves returns the player's enabled actions in declaration order.
+func (s *search) moves(rt *metamodel.Runtime, p *player) []string {
+	var enabled []string
+	for _, a := range p.actions {
+		if rt.Enabled(a) {
+			enabled = append(enabled, a)
+		}
+	}
+	return enabled
+}
+
+// play returns a copy of rt after firing action and settling automatic
+// actions. It reports false when the action violates a constraint.
+func (s *search) play(rt *metamodel.Runtime, action string) (*metamodel.Runtime, bool) {
+	next := rt.Clone()
+	if err := next.Execute(action); err != nil {
+		return nil, false
+	}
+	return s.settle(next), true
+}
+
+// settle fires enabled automatic actions, such as win or draw detection,
+// until none remain. Each pass fires at most one action, and the number of
+// passes is bounded by the number of actions so cycles cannot run away.
+func (s *search) settle(rt *metamodel.Runtime) *metamodel.Runtime {
+	for range s.schema.Actions {
+		fired := false
+		for _, a := range s.automatic {
+			if !rt.Enabled(a) {
+				continue
+			}
+			next := rt.Clone()
+			if next.Execute(a) == nil {
+				rt, fired = next, true
+				break
+			}
+		}
+		if !fired {
+			break
+		}
+	}
+	return rt
+}
+
+// visit charges one position against the budget, and checks the context
+// every so often.
+func (s *search) visit() error {
+	if s.budget <= 0 {
+		return ErrBudgetExceeded
+	}
+	s.budget--
+	if s.budget%256 == 0 {
+		return s.ctx.Err()
+	}
+	return nil
+}
+
+// value is the minimax value of rt searched depth plies ahead.
+func (s *search) value(rt *metamodel.Runtime, depth int, alpha, beta float64) (float64, error) {
+	if err := s.visit(); err != nil {
+		return 0, err
+	}
+	p := s.current(rt)
+	var moves []string
+	if p != nil {
+		moves = s.moves(rt, p)
+	}
+	if len(moves) == 0 {
+		return s.evaluate(rt)
+	}
+	if depth == 0 {
+		if s.leafODE {
+			return s.ode.score(rt)
+		}
+		return s.evaluate(rt)
+	}
+
+	best := math.Inf(1)
+	if p.Maximizes {
+		best = math.Inf(-1)
+	}
+	searched := false
+	for _, action := range moves {
+		next, ok := s.play(rt, action)
+		if !ok {
+			continue
+		}
+		v, err := s.value(next, depth-1, alpha, beta)
+		if err != nil {
+			return 0, err
+		}
+		searched = true
+		if p.Maximizes {
+			best = math.Max(best, v)
+			alpha = math.Max(alpha, best)
+		} else {
+			best = math.Min(best, v)
+			beta = math.Min(beta, best)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	if !searched {
+		return s.evaluate(rt)
+	}
+	return best, nil
+}
+
+// evaluate computes the objective over the runtime's token marking.
+func (s *search) evaluate(rt *metamodel.Runtime) (float64, error) {
+	marking := dsl.Marking(rt.Snapshot.Tokens)
+	bindings := make(map[string]any, len(marking))
+	for placeID, count := range marking {
+		bindings[placeID] = int64(count)
+	}
+	return dsl.EvalNumericCompiled(s.objective, bindings, dsl.MakeAggregates(marking))
+}
+
+// changesMarking reports whether firing the action changes any token count.
+// Self-loops such as a reset guard would otherwise fire forever while settling.
+func changesMarking(schema *metamodel.Schema, actionID string) bool {
+	delta := make(map[string]int)
+	for _, arc := range schema.InputArcs(actionID) {
+		if st := schema.StateByID(arc.Source); st != nil && st.IsToken() && !arc.IsInhibitor() {
+			delta[arc.Source] -= arcWeight(arc)
+		}
+	}
+	for _, arc := range schema.OutputArcs(actionID) {
+		if st := schema.StateByID(arc.Target); st != nil && st.IsToken() {
+			delta[arc.Target] += arcWeight(arc)
+		}
+	}
+	for _, d := range delta {
+		if d != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func arcWeight(arc metamodel.Arc) int {
+	if arc.Weight == 0 {
+		return 1
+	}
+	return arc.Weight
+}
@@ -0,0 +1,258 @@
+package advisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+// ticTacToe builds the same net as services/tic-tac-toe.json: players take
+// turns claiming cells, and unowned win transitions consume the opponent's
+// turn and the game_active token.
+func ticTacToe() *metamodel.Schema {
+	s := metamodel.NewSchema("tic-tac-toe")
+	s.AddTokenState("x_turn", 1)
+	s.AddTokenState("o_turn", 0)
+	s.AddTokenState("game_active", 1)
+	s.AddTokenState("win_x", 0)
+	s.AddTokenState("win_o", 0)
+	s.AddTokenState("can_reset", 1)
+	s.AddAction(metamodel.Action{ID: "reset"})
+	s.AddArc(metamodel.Arc{Source: "can_reset", Target: "reset"})
+	s.AddArc(metamodel.Arc{Source: "reset", Target: "can_reset"})
+
+	sim := &metamodel.Simulation{
+		Objective: "win_x - win_o",
+		Players: map[string]metamodel.Player{
+			"x": {Maximizes: true, TurnPlace: "x_turn"},
+			"o": {Maximizes: false, TurnPlace: "o_turn"},
+		},
+	}
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			cell := fmt.Sprintf("%d%d", r, c)
+			s.AddTokenState("p"+cell, 1)
+			for _, pl := range []struct{ me, next string }{{"x", "o"}, {"o", "x"}} {
+				s.AddTokenState(pl.me+cell, 0)
+				play := pl.me + "_play_" + cell
+				s.AddAction(metamodel.Action{ID: play})
+				s.AddArc(metamodel.Arc{Source: "p" + cell, Target: play})
+				s.AddArc(metamodel.Arc{Source: pl.me + "_turn", Target: play})
+				s.AddArc(metamodel.Arc{Source: play, Target: pl.me + cell})
+				s.AddArc(metamodel.Arc{Source: play, Target: pl.next + "_turn"})
+				p := sim.Players[pl.me]
+				p.Transitions = append(p.Transitions, play)
+				sim.Players[pl.me] = p
+			}
+		}
+	}
+
+	lines := map[string][3]string{
+		"row0": {"00", "01", "02"}, "row1": {"10", "11", "12"}, "row2": {"20", "21", "22"},
+		"col0": {"00", "10", "20"}, "col1": {"01", "11", "21"}, "col2": {"02", "12", "22"},
+		"diag": {"00", "11", "22"}, "anti": {"02", "11", "20"},
+	}
+	for name, cells := range lines {
+		for _, pl := range []struct{ me, next string }{{"x", "o"}, {"o", "x"}} {
+			win := pl.me + "_win_" + name
+			s.AddAction(metamodel.Action{ID: win})
+			for _, cell := range cells {
+				s.AddArc(metamodel.Arc{Source: pl.me + cell, Target: win})
+				s.AddArc(metamodel.Arc{Source: win, Target: pl.me + cell})
+			}
+			s.AddArc(metamodel.Arc{Source: "game_active", Target: win})
+			s.AddArc(metamodel.Arc{Source: pl.next + "_turn", Target: win})
+			s.AddArc(metamodel.Arc{Source: win, Target: "win_" + pl.me})
+		}
+	}
+	s.Simulation = sim
+	return s
+}
+
+func play(t *testing.T, rt *metamodel.Runtime, actions ...string) {
+	t.Helper()
+	for _, a := range actions {
+		if err := rt.Execute(a); err != nil {
+			t.Fatalf("execute %s: %v", a, err)
+		}
+	}
+}
+
+func TestAdviseBlocksThreat(t *testing.T) {
+	ctx := context.Background()
+	rt := metamodel.NewRuntime(ticTacToe())
+	play(t, rt, "x_play_11", "o_play_10", "x_play_21")
+
+	advice, err := Advise(ctx, rt, Options{Depth: 2})
+	if err != nil {
+		t.Fatalf("advise: %v", err)
+	}
+	if advice.Player != "o" || advice.Maximizes {
+		t.Errorf("expected advice for the minimizing player o, got %q", advice.Player)
+	}
+	if advice.Best != "o_play_01" {
+		t.Errorf("expected o to block at 01, got %+v", advice.Moves)
+	}
+	if len(advice.Moves) != 6 {
+		t.Errorf("expected one move per empty cell, got %d", len(advice.Moves))
+	}
+	if last := advice.Moves[len(advice.Moves)-1]; last.Score != 1 {
+		t.Errorf("expected unblocked moves to lose, got %+v", last)
+	}
+	if rt.Tokens("o_turn") != 1 || rt.Tokens("o01") != 0 {
+		t.Error("advise must not modify the runtime")
+	}
+}
+
+func TestAdviseTakesWin(t *testing.T) {
+	ctx := context.Background()
+	rt := metamodel.NewRuntime(ticTacToe())
+	play(t, rt, "x_play_00", "o_play_10", "x_play_01", "o_play_11")
+
+	advice, err := Advise(ctx, rt, Options{Depth: 1})
+	if err != nil {
+		t.Fatalf("advise: %v", err)
+	}
+	if advice.Best != "x_play_02" || advice.Moves[0].Score != 1 {
+		t.Errorf("expected x to complete the top row, got %+v", advice.Moves)
+	}
+}
+
+func TestAdviseGameOver(t *testing.T) {
+	ctx := context.Background()
+	rt := metamodel.NewRuntime(ticTacToe())
+	play(t, rt, "x_play_00", "o_play_10", "x_play_01", "o_play_11", "x_play_02", "x_win_row0")
+
+	advice, err := Advise(ctx, rt, Options{})
+	if err != nil {
+		t.Fatalf("advise: %v", err)
+	}
+	if advice.Player != "" || len(advice.Moves) != 0 || advice.Objective != 1 {
+		t.Errorf("expected no player to move after x wins, got %+v", advice)
+	}
+}
+
+func TestAdviseSinglePlayer(t *testing.T) {
+	ctx := context.Background()
+	// A knapsack with capacity 3: the heavy item is worth more alone,
+	// but two light items fit together and are worth more still.
+	s := metamodel.NewSchema("knapsack")
+	s.AddTokenState("capacity", 3)
+	s.AddTokenState("value", 0)
+	for _, item := range []struct {
+		id            string
+		weight, value int
+	}{{"heavy", 3, 5}, {"light_a", 1, 3}, {"light_b", 1, 3}} {
+		s.AddTokenState(item.id, 1)
+		s.AddAction(metamodel.Action{ID: "take_" + item.id})
+		s.AddArc(metamodel.Arc{Source: item.id, Target: "take_" + item.id})
+		s.AddArc(metamodel.Arc{Source: "capacity", Target: "take_" + item.id, Weight: item.weight})
+		s.AddArc(metamodel.Arc{Source: "take_" + item.id, Target: "value", Weight: item.value})
+	}
+	s.Simulation = &metamodel.Simulation{Objective: "value"}
+
+	rt := metamodel.NewRuntime(s)
+	greedy, err := Advise(ctx, rt, Options{Depth: 1})
+	if err != nil {
+		t.Fatalf("advise: %v", err)
+	}
+	if greedy.Best != "take_heavy" {
+		t.Errorf("expected a one-ply search to be greedy, got %+v", greedy.Moves)
+	}
+
+	deep, err := Advise(ctx, rt, Options{Depth: 3})
+	if err != nil {
+		t.Fatalf("advise: %v", err)
+	}
+	if deep.Best != "take_light_a" || deep.Moves[0].Score != 6 {
+		t.Errorf("expected a deeper search to take both light items, got %+v", deep.Moves)
+	}
+
+	// A lone player without a turn place or transitions plays every action
+	s.Simulation.Players = map[string]metamodel.Player{"packer": {Maximizes: true}}
+	named, err := Advise(ctx, rt, Options{Depth: 3})
+	if err != nil {
+		t.Fatalf("advise: %v", err)
+	}
+	if named.Player != "packer" || named.Best != "take_light_a" || len(named.Moves) != 3 {
+		t.Errorf("expected packer to play every action, got %+v", named)
+	}
+}
+
+func TestAdviseODE(t *testing.T) {
+	ctx := context.Background()
+	rt := metamodel.NewRuntime(ticTacToe())
+	play(t, rt, "x_play_11", "o_play_10", "x_play_21")
+
+	for _, method := range []Method{MethodODE, MethodHybrid} {
+		advice, err := Advise(ctx, rt, Options{Method: method, Depth: 2})
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		if len(advice.Moves) != 6 {
+			t.Errorf("%s: expected 6 moves, got %d", method, len(advice.Moves))
+		}
+		for _, m := range advice.Moves {
+			if math.IsNaN(m.Score) || math.IsInf(m.Score, 0) {
+				t.Errorf("%s: non-finite score for %s", method, m.Action)
+			}
+		}
+	}
+}
+
+func TestAdviseErrors(t *testing.T) {
+	ctx := context.Background()
+	rt := metamodel.NewRuntime(metamodel.NewSchema("empty"))
+	if _, err := Advise(ctx, rt, Options{}); !errors.Is(err, ErrNoObjective) {
+		t.Errorf("expected ErrNoObjective, got %v", err)
+	}
+
+	rt = metamodel.NewRuntime(ticTacToe())
+	if _, err := Advise(ctx, rt, Options{Player: "z"}); !errors.Is(err, ErrUnknownPlayer) {
+		t.Errorf("expected ErrUnknownPlayer, got %v", err)
+	}
+	if _, err := Advise(ctx, rt, Options{Method: "random"}); err == nil {
+		t.Error("expected an error for an unknown method")
+	}
+	if _, err := Advise(ctx, rt, Options{Depth: MaxDepth + 1}); !errors.Is(err, ErrInvalidDepth) {
+		t.Errorf("expected ErrInvalidDepth beyond MaxDepth, got %v", err)
+	}
+	if _, err := Advise(ctx, rt, Options{Method: MethodHybrid, Depth: MaxHybridDepth + 1}); !errors.Is(err, ErrInvalidDepth) {
+		t.Errorf("expected ErrInvalidDepth beyond MaxHybridDepth, got %v", err)
+	}
+
+	advice, err := Advise(ctx, rt, Options{Player: "o"})
+	if err != nil {
+		t.Fatalf("advise: %v", err)
+	}
+	if advice.Player != "o" || len(advice.Moves) != 0 {
+		t.Errorf("expected no moves for o on x's turn, got %+v", advice)
+	}
+}
+
+func TestAdviseLimits(t *testing.T) {
+	rt := metamodel.NewRuntime(ticTacToe())
+
+	if _, err := Advise(context.Background(), rt, Options{Depth: MaxDepth, MaxNodes: 100}); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Advise(ctx, rt, Options{Depth: MaxDepth}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled context's error, got %v", err)
+	}
+
+	// Hybrid search defaults to its own, lower depth
+	advice, err := Advise(context.Background(), rt, Options{Method: MethodHybrid})
+	if err != nil {
+		t.Fatalf("hybrid: %v", err)
+	}
+	if advice.Depth != MaxHybridDepth {
+		t.Errorf("expected hybrid depth %d, got %d", MaxHybridDepth, advice.Depth)
+	}
+}
@@ -0,0 +1,78 @@
+package advisor
+
+import (
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+	"github.com/pflow-xyz/petri-pilot/pkg/dsl"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+// odeModel is the continuous relaxation of a schema's token states.
+type odeModel struct {
+	net       *petri.PetriNet
+	places    []string
+	rates     map[string]float64
+	tspan     [2]float64
+	dt        float64
+	objective string
+}
+
+// newODEModel builds the Petri net and solver settings for the schema.
+// Data states and their arcs are left out; rates default to 1.0 and the
+// time span to [0, 10].
+func newODEModel(schema *metamodel.Schema, sim *metamodel.Simulation) *odeModel {
+	m := &odeModel{
+		net:       petri.NewPetriNet(),
+		rates:     make(map[string]float64),
+		tspan:     [2]float64{0, 10},
+		objective: sim.Objective,
+	}
+	if sim.Solver != nil {
+		if sim.Solver.Tspan[1] > sim.Solver.Tspan[0] {
+			m.tspan = sim.Solver.Tspan
+		}
+		m.dt = sim.Solver.Dt
+	}
+
+	for _, st := range schema.TokenStates() {
+		m.net.AddPlace(st.ID, float64(st.InitialTokens()), nil, 0, 0, nil)
+		m.places = append(m.places, st.ID)
+	}
+	for _, a := range schema.Actions {
+		m.net.AddTransition(a.ID, "default", 0, 0, nil)
+		m.rates[a.ID] = 1.0
+		if sim.Solver != nil {
+			if rate, ok := sim.Solver.Rates[a.ID]; ok {
+				m.rates[a.ID] = rate
+			}
+		}
+	}
+	for _, arc := range schema.Arcs {
+		place := arc.Source
+		if schema.ActionByID(arc.Source) != nil {
+			place = arc.Target
+		}
+		if st := schema.StateByID(place); st == nil || !st.IsToken() {
+			continue
+		}
+		m.net.AddArc(arc.Source, arc.Target, float64(arcWeight(arc)), arc.IsInhibitor())
+	}
+	return m
+}
+
+// score solves the ODE from the runtime's marking and evaluates the
+// objective on the final continuous state.
+func (m *odeModel) score(rt *metamodel.Runtime) (float64, error) {
+	state := make(map[string]float64, len(m.places))
+	for _, id := range m.places {
+		state[id] = float64(rt.Tokens(id))
+	}
+
+	opts := solver.DefaultOptions()
+	if m.dt > 0 {
+		opts.Dt = m.dt
+	}
+	prob := solver.NewProblem(m.net, state, m.tspan, m.rates)
+	sol := solver.Solve(prob, solver.Tsit5(), opts)
+	return dsl.EvaluateObjectiveContinuous(m.objective, sol.GetFinalState())
+}
@@ -43,6 +43,46 @@ type TransitionResult struct {
 	Error string `json:"error,omitempty"`
 }
 
+// MaxSequenceSteps is the most transitions a sequence may fire.
+const MaxSequenceSteps = 100
+
+// SequenceRequest represents a request to fire transitions on an aggregate
+// in order, all of them or none.
+type SequenceRequest struct {
+	// Steps are the transitions to fire, in order, at most MaxSequenceSteps.
+	Steps []SequenceStep `json:"steps"`
+}
+
+// SequenceStep is one transition of a sequence.
+type SequenceStep struct {
+	// TransitionID is the transition to fire.
+	TransitionID string `json:"transition_id"`
+
+	// Data is the transition payload.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// SequenceResult represents the result of firing a sequence.
+type SequenceResult struct {
+	// Success indicates if every transition fired.
+	Success bool `json:"success"`
+
+	// AggregateID is the affected eventsource.
+	AggregateID string `json:"aggregate_id"`
+
+	// Version is the aggregate version after the last transition.
+	Version int `json:"version"`
+
+	// Markings are the place markings after each transition, in order.
+	Markings []map[string]int `json:"markings"`
+
+	// State is the final aggregate state (optional).
+	State any `json:"state,omitempty"`
+
+	// EnabledTransitions lists transitions that can now fire.
+	EnabledTransitions []string `json:"enabled,omitempty"`
+}
+
 // StateResponse represents aggregate state.
 type StateResponse struct {
 	// AggregateID is the aggregate identifier.
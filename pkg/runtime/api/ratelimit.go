@@ -0,0 +1,284 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitRule configures a token bucket.
+// Limit tokens are refilled evenly over each Window, and the bucket holds
+// at most Burst tokens (Limit when Burst is zero).
+type RateLimitRule struct {
+	// Name identifies the rule in bucket keys and the RateLimit-Policy header.
+	Name string
+
+	// Limit is the number of requests allowed per Window.
+	Limit int
+
+	// Window is the refill period.
+	Window time.Duration
+
+	// Burst is the bucket capacity.
+	Burst int
+}
+
+// capacity returns the bucket size.
+func (r RateLimitRule) capacity() float64 {
+	if r.Burst > 0 {
+		return float64(r.Burst)
+	}
+	return float64(r.Limit)
+}
+
+// refillRate returns tokens added per second.
+func (r RateLimitRule) refillRate() float64 {
+	if r.Window <= 0 {
+		return 0
+	}
+	return float64(r.Limit) / r.Window.Seconds()
+}
+
+// RateLimitDecision is the outcome of taking a token from a bucket.
+type RateLimitDecision struct {
+	// Allowed is false when the bucket was empty.
+	Allowed bool
+
+	// Rule is the rule that produced this decision.
+	Rule RateLimitRule
+
+	// Remaining is the number of whole tokens left in the bucket.
+	Remaining int
+
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+
+	// RetryAfter is the time until the next token is available (zero if allowed).
+	RetryAfter time.Duration
+}
+
+// RateLimitStore holds token buckets keyed by caller and rule.
+type RateLimitStore interface {
+	// Take removes one token from the bucket for key, refilling it first.
+	Take(ctx context.Context, key string, rule RateLimitRule, now time.Time) (RateLimitDecision, error)
+
+	// Refund returns a token taken from the bucket for key, up to its
+	// capacity. Callers refund the tokens of a request that another
+	// bucket rejected, so rejected requests do not use up quota.
+	Refund(ctx context.Context, key string, rule RateLimitRule) error
+}
+
+// takeToken refills a bucket that held tokens at last and tries to take one.
+// It returns the new token count and the decision.
+func takeToken(tokens float64, last, now time.Time, rule RateLimitRule) (float64, RateLimitDecision) {
+	if elapsed := now.Sub(last).Seconds(); elapsed > 0 {
+		tokens = math.Min(rule.capacity(), tokens+elapsed*rule.refillRate())
+	}
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+	return tokens, bucketDecision(tokens, allowed, rule)
+}
+
+// bucketDecision describes a bucket left holding tokens after a take.
+func bucketDecision(tokens float64, allowed bool, rule RateLimitRule) RateLimitDecision {
+	rate := rule.refillRate()
+
+	decision := RateLimitDecision{Rule: rule, Allowed: allowed}
+	if !allowed && rate > 0 {
+		decision.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+
+	decision.Remaining = int(math.Floor(tokens))
+	if rate > 0 {
+		decision.Reset = secondsToDuration((rule.capacity() - tokens) / rate)
+	}
+	return decision
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
+
+// memoryBucketSweep is how often MemoryRateLimitStore drops idle buckets.
+const memoryBucketSweep = time.Minute
+
+// MemoryRateLimitStore keeps token buckets in process memory. Buckets that
+// have refilled to capacity are dropped, since a missing bucket starts
+// full, so memory stays bounded by the callers active within a window.
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	swept   time.Time
+}
+
+type memoryBucket struct {
+	tokens float64
+	last   time.Time
+	rule   RateLimitRule
+}
+
+// NewMemoryRateLimitStore creates an in-memory rate limit store.
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*memoryBucket),
+	}
+}
+
+// Take implements RateLimitStore.
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, rule RateLimitRule, now time.Time) (RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.swept) >= memoryBucketSweep {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: rule.capacity(), last: now}
+		s.buckets[key] = b
+	}
+
+	var decision RateLimitDecision
+	b.tokens, decision = takeToken(b.tokens, b.last, now, rule)
+	b.last = now
+	b.rule = rule
+	return decision, nil
+}
+
+// sweep drops the buckets that have refilled to capacity by now.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		refilled := b.tokens + now.Sub(b.last).Seconds()*b.rule.refillRate()
+		if refilled >= b.rule.capacity() {
+			delete(s.buckets, key)
+		}
+	}
+	s.swept = now
+}
+
+// Refund implements RateLimitStore.
+func (s *MemoryRateLimitStore) Refund(ctx context.Context, key string, rule RateLimitRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, ok := s.buckets[key]; ok {
+		b.tokens = math.Min(rule.capacity(), b.tokens+1)
+	}
+	return nil
+}
+
+// SQLRateLimitStore keeps token buckets in a SQL table so limits are shared
+// across restarts and replicas. Queries use "$n" placeholders and RETURNING,
+// which SQLite and Postgres both accept.
+type SQLRateLimitStore struct {
+	db *sql.DB
+}
+
+// NewSQLRateLimitStore creates a SQL-backed rate limit store.
+func NewSQLRateLimitStore(db *sql.DB) *SQLRateLimitStore {
+	return &SQLRateLimitStore{db: db}
+}
+
+// InitSchema creates the rate limit table.
+func (s *SQLRateLimitStore) InitSchema() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+			bucket_key TEXT PRIMARY KEY,
+			tokens DOUBLE PRECISION NOT NULL,
+			last_allowed BOOLEAN NOT NULL DEFAULT FALSE,
+			updated_at BIGINT NOT NULL
+		)
+	`)
+	return err
+}
+
+// refilledTokens is the bucket level after refilling up to $2 (now, in Unix
+// nanoseconds) at $4 tokens per nanosecond, capped at $3.
+const refilledTokens = `CASE
+	WHEN tokens + CAST(CASE WHEN $2 > updated_at THEN $2 - updated_at ELSE 0 END AS DOUBLE PRECISION) * CAST($4 AS DOUBLE PRECISION) > CAST($3 AS DOUBLE PRECISION)
+	THEN CAST($3 AS DOUBLE PRECISION)
+	ELSE tokens + CAST(CASE WHEN $2 > updated_at THEN $2 - updated_at ELSE 0 END AS DOUBLE PRECISION) * CAST($4 AS DOUBLE PRECISION)
+END`
+
+// takeTokenSQL refills and decrements a bucket in one statement, so
+// concurrent takes from different replicas cannot both spend the same token.
+var takeTokenSQL = `
+	UPDATE rate_limit_buckets SET
+		tokens = (` + refilledTokens + `) - CASE WHEN (` + refilledTokens + `) >= 1 THEN 1 ELSE 0 END,
+		last_allowed = (` + refilledTokens + `) >= 1,
+		updated_at = CASE WHEN $2 > updated_at THEN $2 ELSE updated_at END
+	WHERE bucket_key = $1
+	RETURNING tokens, last_allowed
+`
+
+// Take implements RateLimitStore.
+func (s *SQLRateLimitStore) Take(ctx context.Context, key string, rule RateLimitRule, now time.Time) (RateLimitDecision, error) {
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO rate_limit_buckets (bucket_key, tokens, updated_at) VALUES ($1, CAST($2 AS DOUBLE PRECISION), $3)
+		ON CONFLICT (bucket_key) DO NOTHING
+	`, key, rule.capacity(), now.UnixNano()); err != nil {
+		return RateLimitDecision{}, err
+	}
+
+	var tokens float64
+	var allowed bool
+	if err := s.db.QueryRowContext(ctx, takeTokenSQL,
+		key, now.UnixNano(), rule.capacity(), rule.refillRate()/float64(time.Second),
+	).Scan(&tokens, &allowed); err != nil {
+		return RateLimitDecision{}, err
+	}
+	return bucketDecision(tokens, allowed, rule), nil
+}
+
+// Refund implements RateLimitStore.
+func (s *SQLRateLimitStore) Refund(ctx context.Context, key string, rule RateLimitRule) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE rate_limit_buckets SET tokens = CASE
+			WHEN tokens + 1 > CAST($2 AS DOUBLE PRECISION) THEN CAST($2 AS DOUBLE PRECISION)
+			ELSE tokens + 1
+		END
+		WHERE bucket_key = $1
+	`, key, rule.capacity())
+	return err
+}
+
+// WriteRateLimitHeaders sets the RateLimit-* response headers for a decision,
+// and Retry-After when the request was rejected.
+func WriteRateLimitHeaders(w http.ResponseWriter, d RateLimitDecision) {
+	h := w.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(d.Rule.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(d.Reset)))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", d.Rule.Limit, ceilSeconds(d.Rule.Window)))
+	if !d.Allowed {
+		h.Set("Retry-After", strconv.Itoa(ceilSeconds(d.RetryAfter)))
+	}
+}
+
+// RateLimited writes a 429 Too Many Requests error for a rejected decision.
+func RateLimited(w http.ResponseWriter, d RateLimitDecision) {
+	WriteRateLimitHeaders(w, d)
+	JSON(w, http.StatusTooManyRequests, ErrorResponse{
+		Code:    "RATE_LIMITED",
+		Message: fmt.Sprintf("rate limit exceeded, retry in %ds", ceilSeconds(d.RetryAfter)),
+		Details: map[string]any{
+			"rule":        d.Rule.Name,
+			"limit":       d.Rule.Limit,
+			"window":      ceilSeconds(d.Rule.Window),
+			"retry_after": ceilSeconds(d.RetryAfter),
+		},
+	})
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRateLimitStore()
+	rule := RateLimitRule{Name: "create", Limit: 2, Window: time.Minute}
+	now := time.Unix(1700000000, 0)
+
+	t.Run("burst then reject", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			d, err := store.Take(ctx, "alice", rule, now)
+			if err != nil {
+				t.Fatalf("take: %v", err)
+			}
+			if !d.Allowed {
+				t.Fatalf("request %d: expected allowed", i)
+			}
+		}
+
+		d, _ := store.Take(ctx, "alice", rule, now)
+		if d.Allowed {
+			t.Fatal("expected third request to be rejected")
+		}
+		if d.Remaining != 0 {
+			t.Errorf("expected 0 remaining, got %d", d.Remaining)
+		}
+		if d.RetryAfter != 30*time.Second {
+			t.Errorf("expected retry after 30s, got %v", d.RetryAfter)
+		}
+	})
+
+	t.Run("keys are independent", func(t *testing.T) {
+		d, _ := store.Take(ctx, "bob", rule, now)
+		if !d.Allowed {
+			t.Error("expected bob to have his own bucket")
+		}
+	})
+
+	t.Run("refill", func(t *testing.T) {
+		d, _ := store.Take(ctx, "alice", rule, now.Add(30*time.Second))
+		if !d.Allowed {
+			t.Error("expected a token after half a window")
+		}
+	})
+
+	t.Run("refund", func(t *testing.T) {
+		later := now.Add(30 * time.Second)
+		if err := store.Refund(ctx, "alice", rule); err != nil {
+			t.Fatalf("refund: %v", err)
+		}
+		if d, _ := store.Take(ctx, "alice", rule, later); !d.Allowed {
+			t.Error("expected the refunded token to be available")
+		}
+		for i := 0; i < 3; i++ {
+			store.Refund(ctx, "bob", rule)
+		}
+		if d, _ := store.Take(ctx, "bob", rule, now); d.Remaining != 1 {
+			t.Errorf("expected refunds to stop at the capacity, got %d remaining", d.Remaining)
+		}
+	})
+}
+
+func TestMemoryRateLimitStoreEvictsIdleBuckets(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRateLimitStore()
+	fast := RateLimitRule{Name: "fast", Limit: 2, Window: time.Second}
+	slow := RateLimitRule{Name: "slow", Limit: 2, Window: time.Hour}
+	now := time.Unix(1700000000, 0)
+
+	store.Take(ctx, "alice", fast, now)
+	store.Take(ctx, "bob", slow, now)
+
+	// By the next sweep alice's bucket has refilled and bob's has not
+	later := now.Add(memoryBucketSweep)
+	store.Take(ctx, "carol", slow, later)
+	if _, ok := store.buckets["alice"]; ok {
+		t.Error("expected alice's idle full bucket to be dropped")
+	}
+	if _, ok := store.buckets["bob"]; !ok {
+		t.Error("expected bob's bucket to be kept until it refills")
+	}
+	if d, _ := store.Take(ctx, "alice", fast, later); !d.Allowed || d.Remaining != 1 {
+		t.Errorf("expected alice to start from a full bucket, got %+v", d)
+	}
+}
+
+func TestSQLRateLimitStore(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "limits.db")+"?_pragma=busy_timeout(10000)")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	store := NewSQLRateLimitStore(db)
+	if err := store.InitSchema(); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+	rule := RateLimitRule{Name: "create", Limit: 2, Window: time.Minute}
+	now := time.Unix(1700000000, 0)
+
+	t.Run("exhaustion", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			d, err := store.Take(ctx, "alice", rule, now)
+			if err != nil {
+				t.Fatalf("take: %v", err)
+			}
+			if !d.Allowed || d.Remaining != 1-i {
+				t.Fatalf("request %d: expected allowed with %d remaining, got %+v", i, 1-i, d)
+			}
+		}
+
+		d, err := store.Take(ctx, "alice", rule, now)
+		if err != nil {
+			t.Fatalf("take: %v", err)
+		}
+		if d.Allowed {
+			t.Fatal("expected third request to be rejected")
+		}
+		if d.RetryAfter != 30*time.Second {
+			t.Errorf("expected retry after 30s, got %v", d.RetryAfter)
+		}
+	})
+
+	t.Run("refill", func(t *testing.T) {
+		if d, _ := store.Take(ctx, "alice", rule, now.Add(15*time.Second)); d.Allowed {
+			t.Error("expected no token after a quarter window")
+		}
+		if d, _ := store.Take(ctx, "alice", rule, now.Add(30*time.Second)); !d.Allowed {
+			t.Error("expected a token after half a window")
+		}
+		// A request stamped before the last update does not refill
+		if d, _ := store.Take(ctx, "alice", rule, now); d.Allowed {
+			t.Error("expected a stale clock not to refill the bucket")
+		}
+		d, _ := store.Take(ctx, "alice", rule, now.Add(10*time.Minute))
+		if !d.Allowed || d.Remaining != 1 {
+			t.Errorf("expected a full bucket after a long pause, got %+v", d)
+		}
+	})
+
+	t.Run("refund", func(t *testing.T) {
+		store.Take(ctx, "carol", rule, now)
+		store.Take(ctx, "carol", rule, now)
+		if err := store.Refund(ctx, "carol", rule); err != nil {
+			t.Fatalf("refund: %v", err)
+		}
+		if d, _ := store.Take(ctx, "carol", rule, now); !d.Allowed {
+			t.Error("expected the refunded token to be available")
+		}
+	})
+
+	t.Run("concurrent takes", func(t *testing.T) {
+		burst := RateLimitRule{Name: "burst", Limit: 10, Window: time.Hour}
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		allowed := 0
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				d, err := store.Take(ctx, "dave", burst, now)
+				if err != nil {
+					t.Errorf("take: %v", err)
+					return
+				}
+				if d.Allowed {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		if allowed != 10 {
+			t.Errorf("expected 10 of 50 concurrent takes to be allowed, got %d", allowed)
+		}
+	})
+}
+
+func TestRateLimited(t *testing.T) {
+	rule := RateLimitRule{Name: "create", Limit: 10, Window: time.Minute}
+	_, d := takeToken(0, time.Unix(0, 0), time.Unix(0, 0), rule)
+
+	w := httptest.NewRecorder()
+	RateLimited(w, d)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "6" {
+		t.Errorf("expected Retry-After 6, got %q", got)
+	}
+	if got := w.Header().Get("RateLimit-Policy"); got != "10;w=60" {
+		t.Errorf("expected RateLimit-Policy 10;w=60, got %q", got)
+	}
+
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Code != "RATE_LIMITED" {
+		t.Errorf("expected RATE_LIMITED, got %s", resp.Code)
+	}
+}
@@ -5,69 +5,108 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
-	"sync"
 
 	"github.com/pflow-xyz/go-pflow/eventsource"
 	"github.com/pflow-xyz/petri-pilot/pkg/dsl"
 	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
 )
 
+// ErrStoreFailed wraps the event store errors ExecuteSequence returns, so
+// callers can tell a failed read or append from a step that cannot fire.
+var ErrStoreFailed = errors.New("event store failed")
+
 // Engine wraps metamodel.Runtime with event sourcing.
 // It provides the execution layer for generated applications.
 type Engine struct {
 	schema *metamodel.Schema
 	store  eventsource.Store
 
-	// runtimes holds per-aggregate runtime instances
-	runtimes map[string]*metamodel.Runtime
-	mu       sync.RWMutex
+	// actions maps event types back to the actions that emit them,
+	// and events maps actions to the event types they emit
+	actions map[string]string
+	events  map[string]string
 }
 
 // NewEngine creates a new engine from a metamodel schema and event store.
 func NewEngine(schema *metamodel.Schema, store eventsource.Store) *Engine {
-	return &Engine{
-		schema:   schema,
-		store:    store,
-		runtimes: make(map[string]*metamodel.Runtime),
+	e := &Engine{
+		schema:  schema,
+		store:   store,
+		actions: make(map[string]string, len(schema.Actions)),
+		events:  make(map[string]string, len(schema.Actions)),
+	}
+	for _, a := range schema.Actions {
+		e.SetEventType(a.ID, actionIDToEventType(a.ID))
 	}
+	return e
 }
 
-// Schema returns the underlying metamodel schema.
-func (e *Engine) Schema() *metamodel.Schema {
-	return e.schema
+// SetEventType names the events an action emits, for models that give a
+// transition an explicit event type instead of its past-tense ID.
+func (e *Engine) SetEventType(actionID, eventType string) {
+	if previous, ok := e.events[actionID]; ok {
+		delete(e.actions, previous)
+	}
+	e.events[actionID] = eventType
+	e.actions[eventType] = actionID
 }
 
-// getOrCreateRuntime gets an existing runtime for an aggregate or creates a new one.
-func (e *Engine) getOrCreateRuntime(aggregateID string) *metamodel.Runtime {
-	e.mu.RLock()
-	rt, exists := e.runtimes[aggregateID]
-	e.mu.RUnlock()
-
-	if exists {
-		return rt
+// EventType returns the event type an action emits.
+func (e *Engine) EventType(actionID string) string {
+	if eventType, ok := e.events[actionID]; ok {
+		return eventType
 	}
+	return actionIDToEventType(actionID)
+}
 
-	e.mu.Lock()
-	defer e.mu.Unlock()
+// DefaultEventType returns the event type an action emits unless the
+// model names one: its ID in PascalCase and past tense.
+// e.g., "submit" -> "Submitted", "approve" -> "Approved"
+func DefaultEventType(actionID string) string {
+	return actionIDToEventType(actionID)
+}
 
-	// Double-check after acquiring write lock
-	if rt, exists = e.runtimes[aggregateID]; exists {
-		return rt
-	}
+// Schema returns the underlying metamodel schema.
+func (e *Engine) Schema() *metamodel.Schema {
+	return e.schema
+}
 
-	// Create new runtime with guard evaluator
-	rt = metamodel.NewRuntime(e.schema)
+// newRuntime creates a runtime in the schema's initial state.
+func (e *Engine) newRuntime() *metamodel.Runtime {
+	rt := metamodel.NewRuntime(e.schema)
 	rt.GuardEvaluator = dsl.NewEvaluator()
-	e.runtimes[aggregateID] = rt
 	return rt
 }
 
 // LoadState loads the runtime state for an aggregate by replaying events.
+// Every load replays onto a fresh runtime, so repeated loads of the same
+// aggregate do not apply its events twice.
 func (e *Engine) LoadState(ctx context.Context, aggregateID string) (*metamodel.Runtime, error) {
-	// Get or create runtime
-	rt := e.getOrCreateRuntime(aggregateID)
+	return e.replay(ctx, aggregateID, false)
+}
+
+// Verify replays an aggregate's events strictly, returning an error for
+// the first event the schema has no action for or cannot apply. Use it to
+// check that a history written under one schema can be read under another.
+func (e *Engine) Verify(ctx context.Context, aggregateID string) error {
+	_, err := e.replay(ctx, aggregateID, true)
+	return err
+}
+
+// ActionForEvent returns the schema action that emits an event type.
+func (e *Engine) ActionForEvent(eventType string) (string, bool) {
+	actionID, ok := e.actions[eventType]
+	if !ok {
+		actionID = eventTypeToActionID(eventType)
+	}
+	return actionID, e.schema.ActionByID(actionID) != nil
+}
+
+func (e *Engine) replay(ctx context.Context, aggregateID string, strict bool) (*metamodel.Runtime, error) {
+	rt := e.newRuntime()
 
 	// Read events from store
 	events, err := e.store.Read(ctx, aggregateID, 0)
@@ -83,19 +122,25 @@ func (e *Engine) LoadState(ctx context.Context, aggregateID string) (*metamodel.
 		}
 
 		// Extract action ID from event type
-		actionID := eventTypeToActionID(event.Type)
-		if actionID == "" {
+		actionID, ok := e.ActionForEvent(event.Type)
+		if !ok {
+			if strict {
+				return nil, fmt.Errorf("event %d (%s): no action emits it", event.Version, event.Type)
+			}
 			continue // Skip events that don't map to actions
 		}
 
 		// Apply the action (without re-checking guards for replay)
 		rt.CheckConstraints = false
-		if err := rt.ExecuteWithBindings(actionID, bindings); err != nil {
-			// Log but don't fail on replay errors - event already occurred
-			rt.CheckConstraints = true
+		err = rt.ExecuteWithBindings(actionID, bindings)
+		rt.CheckConstraints = true
+		if err != nil {
+			if strict {
+				return nil, fmt.Errorf("event %d (%s): %w", event.Version, event.Type, err)
+			}
+			// Don't fail on replay errors - event already occurred
 			continue
 		}
-		rt.CheckConstraints = true
 	}
 
 	return rt, nil
@@ -120,7 +165,7 @@ func (e *Engine) Execute(ctx context.Context, aggregateID, actionID string, bind
 	}
 
 	// Create and persist event
-	eventType := actionIDToEventType(actionID)
+	eventType := e.EventType(actionID)
 	event, err := eventsource.NewEvent(aggregateID, eventType, bindings)
 	if err != nil {
 		return fmt.Errorf("creating event: %w", err)
@@ -141,6 +186,65 @@ func (e *Engine) Execute(ctx context.Context, aggregateID, actionID string, bind
 	return nil
 }
 
+// Step is one action of a sequence.
+type Step struct {
+	ActionID string
+	Bindings metamodel.Bindings
+}
+
+// ExecuteSequence fires actions on an aggregate in order and persists all
+// of their events in a single append, or none of them. The actions run on
+// a clone of the aggregate's runtime, so a step that is not enabled or
+// fails its guard leaves the stored history untouched. If check is not
+// nil, it is called before each step with the runtime in the state that
+// step fires from, and an error from it fails the sequence.
+//
+// It returns the token counts after each step.
+func (e *Engine) ExecuteSequence(ctx context.Context, aggregateID string, steps []Step, check func(i int, rt *metamodel.Runtime) error) ([]map[string]int, error) {
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("sequence has no steps")
+	}
+
+	// Read the version first, so events appended while the sequence is
+	// validated fail the append instead of being overlooked
+	version, err := e.store.StreamVersion(ctx, aggregateID)
+	if err != nil && err != eventsource.ErrStreamNotFound {
+		return nil, fmt.Errorf("%w: getting stream version: %w", ErrStoreFailed, err)
+	}
+	loaded, err := e.LoadState(ctx, aggregateID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStoreFailed, err)
+	}
+
+	rt := loaded.Clone()
+	events := make([]*eventsource.Event, 0, len(steps))
+	markings := make([]map[string]int, 0, len(steps))
+	for i, step := range steps {
+		if check != nil {
+			if err := check(i, rt); err != nil {
+				return nil, fmt.Errorf("step %d (%s): %w", i, step.ActionID, err)
+			}
+		}
+		if !rt.Enabled(step.ActionID) {
+			return nil, fmt.Errorf("step %d: action %s is not enabled", i, step.ActionID)
+		}
+		if err := rt.ExecuteWithBindings(step.ActionID, step.Bindings); err != nil {
+			return nil, fmt.Errorf("step %d: executing action %s: %w", i, step.ActionID, err)
+		}
+		event, err := eventsource.NewEvent(aggregateID, e.EventType(step.ActionID), step.Bindings)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: creating event: %w", i, err)
+		}
+		events = append(events, event)
+		markings = append(markings, maps.Clone(rt.Snapshot.Tokens))
+	}
+
+	if _, err := e.store.Append(ctx, aggregateID, version, events); err != nil {
+		return nil, fmt.Errorf("%w: appending events: %w", ErrStoreFailed, err)
+	}
+	return markings, nil
+}
+
 // Enabled returns all enabled actions for an aggregate.
 func (e *Engine) Enabled(ctx context.Context, aggregateID string) ([]string, error) {
 	rt, err := e.LoadState(ctx, aggregateID)
@@ -0,0 +1,663 @@
+// Package eventstore provides event store implementations for generated
+// services beyond the in-memory and SQLite stores that ship with go-pflow.
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+// DefaultNotifyChannel is the LISTEN/NOTIFY channel appends are announced on.
+const DefaultNotifyChannel = "petri_events"
+
+// PostgresSchema creates the event store tables. It is the Postgres dialect
+// of the events and snapshots tables in the generated migrations.
+const PostgresSchema = `
+CREATE TABLE IF NOT EXISTS events (
+    id TEXT PRIMARY KEY,
+    position BIGSERIAL UNIQUE NOT NULL,
+    stream_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    data JSONB NOT NULL,
+    metadata JSONB,
+    timestamp TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (stream_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_stream_version ON events (stream_id, version);
+CREATE INDEX IF NOT EXISTS idx_events_type ON events (type);
+CREATE INDEX IF NOT EXISTS idx_events_timestamp ON events (timestamp);
+
+CREATE TABLE IF NOT EXISTS snapshots (
+    stream_id TEXT PRIMARY KEY,
+    version INTEGER NOT NULL,
+    state JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+`
+
+// uniqueViolation is the SQLSTATE for a unique constraint violation.
+const uniqueViolation = "23505"
+
+// appendLockKey is the transaction-scoped advisory lock every append takes,
+// paired with a hash of the schema so stores in other schemas of the same
+// database do not wait on it.
+const appendLockKey = "petri_pilot_events_append"
+
+// PostgresStore is an eventsource.Store backed by Postgres.
+//
+// Appends to a schema's events table are serialized by an advisory lock held
+// until commit, so version checks see every earlier append and positions are
+// assigned in commit order: a reader polling ReadAll never skips an event
+// that commits late. The unique
+// (stream_id, version) constraint still backs the version check. Every append
+// announces the new versions with NOTIFY in the same transaction, so
+// subscribers on any replica see events only once they are committed.
+type PostgresStore struct {
+	pool     *pgxpool.Pool
+	ownsPool bool
+	channel  string
+
+	mu     sync.Mutex
+	subs   map[string]map[chan *eventsource.Event]struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+	closed bool
+}
+
+// StreamAppend is one stream's part of a batched append.
+type StreamAppend struct {
+	StreamID        string
+	ExpectedVersion int // -1 to skip the version check
+	Events          []*eventsource.Event
+}
+
+// notification is the NOTIFY payload: the versions appended to a stream.
+type notification struct {
+	StreamID string `json:"stream_id"`
+	From     int    `json:"from"`
+	To       int    `json:"to"`
+}
+
+// NewPostgresStore connects to the database at url, creates the schema if
+// needed and returns a store that closes the connection pool on Close.
+func NewPostgresStore(ctx context.Context, url string) (*PostgresStore, error) {
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to postgres: %w", err)
+	}
+	s := NewPostgresStoreFromPool(pool)
+	s.ownsPool = true
+	if err := s.InitSchema(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewPostgresStoreFromPool creates a store on an existing pool.
+// The caller owns the pool and must create the schema, e.g. with InitSchema
+// or the generated migrations.
+func NewPostgresStoreFromPool(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{
+		pool:    pool,
+		channel: DefaultNotifyChannel,
+		subs:    make(map[string]map[chan *eventsource.Event]struct{}),
+	}
+}
+
+// WithNotifyChannel sets the LISTEN/NOTIFY channel. Services sharing one
+// database should use distinct channels. It must be called before Subscribe.
+func (s *PostgresStore) WithNotifyChannel(channel string) *PostgresStore {
+	s.channel = channel
+	return s
+}
+
+// InitSchema creates the event store tables.
+func (s *PostgresStore) InitSchema(ctx context.Context) error {
+	if _, err := s.pool.Exec(ctx, PostgresSchema); err != nil {
+		return fmt.Errorf("creating event store schema: %w", err)
+	}
+	return nil
+}
+
+// Append adds events to a stream and returns the new stream version.
+// All events are written in one round trip and one transaction.
+func (s *PostgresStore) Append(ctx context.Context, streamID string, expectedVersion int, events []*eventsource.Event) (int, error) {
+	versions, err := s.AppendBatch(ctx, []StreamAppend{{
+		StreamID:        streamID,
+		ExpectedVersion: expectedVersion,
+		Events:          events,
+	}})
+	if err != nil {
+		return 0, err
+	}
+	return versions[0], nil
+}
+
+// AppendBatch appends to several streams atomically: either every append
+// succeeds or none is written. It returns the new version of each stream.
+// StreamID, Version and Timestamp are set on the events only once the
+// append has committed.
+func (s *PostgresStore) AppendBatch(ctx context.Context, appends []StreamAppend) ([]int, error) {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1), hashtext(current_schema()))`, appendLockKey); err != nil {
+		return nil, err
+	}
+
+	versions := make([]int, len(appends))
+	timestamps := make([][]time.Time, len(appends))
+	batch := &pgx.Batch{}
+	for i, a := range appends {
+		var current int
+		if err := tx.QueryRow(ctx,
+			`SELECT COALESCE(MAX(version), 0) FROM events WHERE stream_id = $1`, a.StreamID,
+		).Scan(&current); err != nil {
+			return nil, err
+		}
+		if a.ExpectedVersion >= 0 && a.ExpectedVersion != current {
+			return nil, eventsource.ErrConcurrency
+		}
+
+		version := current
+		timestamps[i] = make([]time.Time, len(a.Events))
+		for j, evt := range a.Events {
+			version++
+			timestamp := evt.Timestamp
+			if timestamp.IsZero() {
+				timestamp = time.Now().UTC()
+			}
+			timestamps[i][j] = timestamp
+			metadata, err := marshalMetadata(evt.Metadata)
+			if err != nil {
+				return nil, err
+			}
+			batch.Queue(`
+				INSERT INTO events (id, stream_id, type, version, data, metadata, timestamp)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+			`, evt.ID, a.StreamID, evt.Type, version, []byte(evt.Data), metadata, timestamp)
+		}
+		versions[i] = version
+
+		if len(a.Events) > 0 {
+			payload, err := json.Marshal(notification{StreamID: a.StreamID, From: current + 1, To: version})
+			if err != nil {
+				return nil, err
+			}
+			batch.Queue(`SELECT pg_notify($1, $2)`, s.channel, string(payload))
+		}
+	}
+
+	if batch.Len() > 0 {
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			return nil, translateError(err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, translateError(err)
+	}
+
+	for i, a := range appends {
+		first := versions[i] - len(a.Events)
+		for j, evt := range a.Events {
+			evt.StreamID = a.StreamID
+			evt.Version = first + j + 1
+			evt.Timestamp = timestamps[i][j]
+		}
+	}
+	return versions, nil
+}
+
+// Read returns the events of a stream after fromVersion, in order.
+// It returns eventsource.ErrStreamNotFound if the stream has no events.
+func (s *PostgresStore) Read(ctx context.Context, streamID string, fromVersion int) ([]*eventsource.Event, error) {
+	events, err := s.queryEvents(ctx, `
+		SELECT id, stream_id, type, version, data, metadata, timestamp
+		FROM events WHERE stream_id = $1 AND version > $2
+		ORDER BY version
+	`, streamID, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		version, err := s.StreamVersion(ctx, streamID)
+		if err != nil {
+			return nil, err
+		}
+		if version == 0 {
+			return nil, eventsource.ErrStreamNotFound
+		}
+	}
+	return events, nil
+}
+
+// ReadAll returns events from all streams after fromPosition in append
+// order. A limit of zero or less returns every remaining event. Appends
+// commit in position order, so polling from the last position seen never
+// skips an event.
+func (s *PostgresStore) ReadAll(ctx context.Context, fromPosition int64, limit int) ([]*eventsource.Event, error) {
+	if limit <= 0 {
+		return s.queryEvents(ctx, `
+			SELECT id, stream_id, type, version, data, metadata, timestamp
+			FROM events WHERE position > $1
+			ORDER BY position
+		`, fromPosition)
+	}
+	return s.queryEvents(ctx, `
+		SELECT id, stream_id, type, version, data, metadata, timestamp
+		FROM events WHERE position > $1
+		ORDER BY position LIMIT $2
+	`, fromPosition, limit)
+}
+
+// PositionedEvent is an event with its position in the global log.
+type PositionedEvent struct {
+	Position int64
+	Event    *eventsource.Event
+}
+
+// ReadAllPositioned is ReadAll with each event's log position, so callers
+// can checkpoint the last position they processed. Positions are unique and
+// increasing but not contiguous.
+func (s *PostgresStore) ReadAllPositioned(ctx context.Context, fromPosition int64, limit int) ([]PositionedEvent, error) {
+	query := `
+		SELECT position, id, stream_id, type, version, data, metadata, timestamp
+		FROM events WHERE position > $1
+		ORDER BY position`
+	args := []any{fromPosition}
+	if limit > 0 {
+		query += ` LIMIT $2`
+		args = append(args, limit)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []PositionedEvent
+	for rows.Next() {
+		var position int64
+		evt, err := scanEvent(rows, &position)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, PositionedEvent{Position: position, Event: evt})
+	}
+	return events, rows.Err()
+}
+
+// StreamVersion returns the current version of a stream, or 0 if it is empty.
+func (s *PostgresStore) StreamVersion(ctx context.Context, streamID string) (int, error) {
+	var version int
+	err := s.pool.QueryRow(ctx,
+		`SELECT COALESCE(MAX(version), 0) FROM events WHERE stream_id = $1`, streamID,
+	).Scan(&version)
+	return version, err
+}
+
+// DeleteStream removes a stream's events and snapshot.
+func (s *PostgresStore) DeleteStream(ctx context.Context, streamID string) error {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM events WHERE stream_id = $1`, streamID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM snapshots WHERE stream_id = $1`, streamID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Subscribe delivers events appended to streamID, by this or any other
+// process, until ctx is done. An empty streamID subscribes to every stream.
+// Slow subscribers miss events rather than block delivery, and events
+// appended while the listener is reconnecting are not replayed.
+func (s *PostgresStore) Subscribe(ctx context.Context, streamID string) (<-chan *eventsource.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, errors.New("event store is closed")
+	}
+	if s.cancel == nil {
+		listenCtx, cancel := context.WithCancel(context.Background())
+		s.cancel = cancel
+		s.done = make(chan struct{})
+		go s.listen(listenCtx)
+	}
+
+	ch := make(chan *eventsource.Event, 64)
+	if s.subs[streamID] == nil {
+		s.subs[streamID] = make(map[chan *eventsource.Event]struct{})
+	}
+	s.subs[streamID][ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		s.unsubscribe(streamID, ch)
+	}()
+	return ch, nil
+}
+
+// unsubscribe removes and closes a subscriber channel.
+func (s *PostgresStore) unsubscribe(streamID string, ch chan *eventsource.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if subs, ok := s.subs[streamID]; ok {
+		if _, ok := subs[ch]; ok {
+			delete(subs, ch)
+			close(ch)
+		}
+		if len(subs) == 0 {
+			delete(s.subs, streamID)
+		}
+	}
+}
+
+// listen holds a dedicated connection on the notify channel, reconnecting
+// after errors until ctx is cancelled.
+func (s *PostgresStore) listen(ctx context.Context) {
+	defer close(s.done)
+	for {
+		err := s.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
+
+// listenOnce listens on one pooled connection until it fails.
+func (s *PostgresStore) listenOnce(ctx context.Context) error {
+	conn, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	channel := pgx.Identifier{s.channel}.Sanitize()
+	if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+		return err
+	}
+	defer func() {
+		// Return the connection to the pool without the subscription
+		if !conn.Conn().IsClosed() {
+			unlistenCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			conn.Exec(unlistenCtx, "UNLISTEN "+channel)
+		}
+	}()
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		s.dispatch(ctx, n.Payload)
+	}
+}
+
+// dispatch reads the events announced by a notification and fans them out.
+func (s *PostgresStore) dispatch(ctx context.Context, payload string) {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return
+	}
+	if !s.hasSubscribers(n.StreamID) {
+		return
+	}
+
+	events, err := s.queryEvents(ctx, `
+		SELECT id, stream_id, type, version, data, metadata, timestamp
+		FROM events WHERE stream_id = $1 AND version BETWEEN $2 AND $3
+		ORDER BY version
+	`, n.StreamID, n.From, n.To)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, evt := range events {
+		for _, key := range []string{n.StreamID, ""} {
+			for ch := range s.subs[key] {
+				select {
+				case ch <- evt:
+				default:
+					// Skip slow subscribers
+				}
+			}
+		}
+	}
+}
+
+// hasSubscribers reports whether anyone is subscribed to streamID or to all streams.
+func (s *PostgresStore) hasSubscribers(streamID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subs[streamID]) > 0 || len(s.subs[""]) > 0
+}
+
+// Close stops the listener, closes subscriber channels and, if the store
+// opened the pool, closes it.
+func (s *PostgresStore) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+
+	s.mu.Lock()
+	for streamID, subs := range s.subs {
+		for ch := range subs {
+			close(ch)
+		}
+		delete(s.subs, streamID)
+	}
+	s.mu.Unlock()
+
+	if s.ownsPool {
+		s.pool.Close()
+	}
+	return nil
+}
+
+// ListInstances lists streams with their version and first and last event
+// times, most recently updated first, leaving out internal streams whose
+// IDs start with "__". from and to bound the last update
+// (RFC 3339 or YYYY-MM-DD). Token state is not stored with events, so place
+// filtering is left to the caller and place is ignored here.
+func (s *PostgresStore) ListInstances(ctx context.Context, place, from, to string, page, perPage int) ([]eventsource.Instance, int, error) {
+	fromTime, err := parseTimeFilter(from)
+	if err != nil {
+		return nil, 0, err
+	}
+	toTime, err := parseTimeFilter(to)
+	if err != nil {
+		return nil, 0, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 50
+	}
+
+	const grouped = `
+		SELECT stream_id, MAX(version) AS version, MIN(timestamp) AS created_at, MAX(timestamp) AS updated_at
+		FROM events WHERE left(stream_id, 2) <> '__' GROUP BY stream_id
+		HAVING ($1::timestamptz IS NULL OR MAX(timestamp) >= $1)
+		   AND ($2::timestamptz IS NULL OR MAX(timestamp) <= $2)
+	`
+
+	var total int
+	if err := s.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM (`+grouped+`) AS instances`, fromTime, toTime,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.pool.Query(ctx,
+		grouped+` ORDER BY updated_at DESC, stream_id LIMIT $3 OFFSET $4`,
+		fromTime, toTime, perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var instances []eventsource.Instance
+	for rows.Next() {
+		inst := eventsource.Instance{State: make(map[string]int)}
+		if err := rows.Scan(&inst.ID, &inst.Version, &inst.CreatedAt, &inst.UpdatedAt); err != nil {
+			return nil, 0, err
+		}
+		instances = append(instances, inst)
+	}
+	return instances, total, rows.Err()
+}
+
+// GetStats returns instance and event counts. Like ListInstances, it
+// leaves out internal streams, whose IDs start with "__".
+func (s *PostgresStore) GetStats(ctx context.Context) (*eventsource.Stats, error) {
+	stats := &eventsource.Stats{ByPlace: make(map[string]int)}
+	err := s.pool.QueryRow(ctx,
+		`SELECT COUNT(DISTINCT stream_id), COUNT(*) FROM events WHERE left(stream_id, 2) <> '__'`,
+	).Scan(&stats.TotalInstances, &stats.TotalEvents)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// Save stores a snapshot, replacing any earlier one for the stream.
+func (s *PostgresStore) Save(ctx context.Context, snapshot *eventsource.Snapshot) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO snapshots (stream_id, version, state, created_at) VALUES ($1, $2, $3, now())
+		ON CONFLICT (stream_id) DO UPDATE SET version = excluded.version, state = excluded.state, created_at = excluded.created_at
+	`, snapshot.StreamID, snapshot.Version, []byte(snapshot.State))
+	return err
+}
+
+// Load returns the latest snapshot for a stream, or nil if there is none.
+func (s *PostgresStore) Load(ctx context.Context, streamID string) (*eventsource.Snapshot, error) {
+	snapshot := &eventsource.Snapshot{StreamID: streamID}
+	var state []byte
+	err := s.pool.QueryRow(ctx,
+		`SELECT version, state FROM snapshots WHERE stream_id = $1`, streamID,
+	).Scan(&snapshot.Version, &state)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot.State = state
+	return snapshot, nil
+}
+
+// queryEvents runs a query selecting event columns and scans the rows.
+func (s *PostgresStore) queryEvents(ctx context.Context, query string, args ...any) ([]*eventsource.Event, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*eventsource.Event
+	for rows.Next() {
+		evt, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, evt)
+	}
+	return events, rows.Err()
+}
+
+// scanEvent scans a row of event columns, preceded by any extra columns
+// given as dest.
+func scanEvent(rows pgx.Rows, dest ...any) (*eventsource.Event, error) {
+	evt := &eventsource.Event{}
+	var data, metadata []byte
+	dest = append(dest, &evt.ID, &evt.StreamID, &evt.Type, &evt.Version, &data, &metadata, &evt.Timestamp)
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	evt.Data = data
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &evt.Metadata); err != nil {
+			return nil, fmt.Errorf("decoding metadata of event %s: %w", evt.ID, err)
+		}
+	}
+	return evt, nil
+}
+
+// marshalMetadata encodes event metadata, storing NULL when there is none.
+func marshalMetadata(metadata any) ([]byte, error) {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" || string(data) == "{}" {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// translateError maps a (stream_id, version) conflict to ErrConcurrency.
+func translateError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == "events_stream_id_version_key" {
+		return eventsource.ErrConcurrency
+	}
+	return err
+}
+
+// parseTimeFilter parses an optional RFC 3339 or YYYY-MM-DD time.
+func parseTimeFilter(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid time filter: %q", value)
+}
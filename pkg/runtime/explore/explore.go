@@ -0,0 +1,569 @@
+// Package explore fuzzes a model: it fires transitions with generated
+// bindings, by seeded random walks or a bounded breadth-first search, and
+// reports the problems it runs into.
+//
+// Firing uses metamodel.Runtime with the DSL guard evaluator, the same
+// semantics as petri_simulate and generated services. Findings are guard
+// evaluation errors, constraint violations and deadlocks; each comes with
+// the shortest trace delta debugging could find that still reproduces it,
+// as a step list that petri_simulate accepts unchanged.
+//
+// Suite reuses the same search to derive regression cases for generated
+// services: simple paths, deadlock witnesses and user scenarios, each step
+// paired with the marking, enabled transitions and data expected after it.
+package explore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/dsl"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+// Mode selects how the state space is explored.
+type Mode string
+
+const (
+	// Random runs independent random walks from the initial state.
+	Random Mode = "random"
+
+	// Exhaustive searches breadth-first, firing every enabled transition
+	// with every combination of candidate binding values.
+	Exhaustive Mode = "exhaustive"
+)
+
+const (
+	// DefaultWalks is used when Options.Walks is zero.
+	DefaultWalks = 100
+
+	// MaxWalks bounds the random walks callers may request.
+	MaxWalks = 100000
+
+	// DefaultDepth bounds a random walk when Options.Depth is zero.
+	DefaultDepth = 20
+
+	// DefaultSearchDepth bounds exhaustive search when Options.Depth is zero.
+	DefaultSearchDepth = 6
+
+	// MaxDepth bounds the depth callers may request.
+	MaxDepth = 1000
+
+	// DefaultMaxStates bounds the distinct states exhaustive search
+	// visits when Options.MaxStates is zero.
+	DefaultMaxStates = 10000
+
+	// bindingAttempts is how many binding sets a random walk tries for a
+	// transition before moving on to another.
+	bindingAttempts = 5
+)
+
+// Options configures an exploration.
+type Options struct {
+	Mode      Mode             // Random (default) or Exhaustive
+	Walks     int              // Random walks (default 100)
+	Depth     int              // Steps per walk (default 20) or search depth (default 6)
+	Seed      int64            // Seed for the random source
+	MaxStates int              // Distinct states exhaustive search visits (default 10000)
+	Data      map[string]any   // Initial values by data state ID
+	Values    map[string][]any // Candidate values by binding name, replacing generated ones
+}
+
+// Step fires one transition. It has the same JSON shape as a petri_simulate step.
+type Step struct {
+	Transition string         `json:"transition"`
+	Bindings   map[string]any `json:"bindings,omitempty"`
+}
+
+// Kind classifies a finding.
+type Kind string
+
+const (
+	// GuardError is a guard expression that failed to evaluate.
+	GuardError Kind = "guard_error"
+
+	// ConstraintViolation is a firing that would break a constraint.
+	ConstraintViolation Kind = "constraint_violation"
+
+	// Deadlock is a reachable state in which no transition is enabled.
+	Deadlock Kind = "deadlock"
+)
+
+// Finding is a problem found during exploration.
+type Finding struct {
+	Kind       Kind   `json:"kind"`
+	Transition string `json:"transition,omitempty"`
+	Message    string `json:"message"`
+
+	// Trace reproduces the finding from the initial state. For guard errors
+	// and constraint violations its last step is the failing firing; for
+	// deadlocks every step fires and the final state is dead.
+	Trace []Step `json:"trace"`
+
+	// FoundLength is the trace length before minimization.
+	FoundLength int `json:"foundLength"`
+}
+
+// Report is the result of an exploration.
+type Report struct {
+	Mode      Mode  `json:"mode"`
+	Seed      int64 `json:"seed"`
+	Depth     int   `json:"depth"`
+	Walks     int   `json:"walks,omitempty"`
+	States    int   `json:"states"`              // Distinct states reached
+	Fired     int   `json:"fired"`               // Successful firings
+	Truncated bool  `json:"truncated,omitempty"` // Exhaustive search stopped at MaxStates
+
+	FireCounts map[string]int `json:"fireCounts"`
+	NeverFired []string       `json:"neverFired"`
+	Findings   []Finding      `json:"findings"`
+}
+
+// Explore explores the schema's behaviour and reports what it finds.
+func Explore(schema *metamodel.Schema, opts Options) (*Report, error) {
+	switch opts.Mode {
+	case "":
+		opts.Mode = Random
+	case Random, Exhaustive:
+	default:
+		return nil, fmt.Errorf("explore: unknown mode %q (use %s or %s)", opts.Mode, Random, Exhaustive)
+	}
+	if opts.Walks == 0 {
+		opts.Walks = DefaultWalks
+	}
+	if opts.Walks < 0 || opts.Walks > MaxWalks {
+		return nil, fmt.Errorf("explore: walks must be between 1 and %d", MaxWalks)
+	}
+	if opts.Depth == 0 {
+		opts.Depth = DefaultDepth
+		if opts.Mode == Exhaustive {
+			opts.Depth = DefaultSearchDepth
+		}
+	}
+	if opts.Depth < 0 || opts.Depth > MaxDepth {
+		return nil, fmt.Errorf("explore: depth must be between 1 and %d", MaxDepth)
+	}
+	if opts.MaxStates == 0 {
+		opts.MaxStates = DefaultMaxStates
+	}
+	if opts.MaxStates < 0 {
+		return nil, fmt.Errorf("explore: max states must not be negative")
+	}
+	for id := range opts.Data {
+		if st := schema.StateByID(id); st == nil || !st.IsData() {
+			return nil, fmt.Errorf("explore: unknown data state: %s", id)
+		}
+	}
+
+	e := newExplorer(schema, opts)
+	if opts.Mode == Random {
+		e.report.Walks = opts.Walks
+		rng := rand.New(rand.NewSource(opts.Seed))
+		for i := 0; i < opts.Walks; i++ {
+			e.walk(rng)
+		}
+	} else {
+		e.search()
+	}
+
+	e.report.States = len(e.seen)
+	for _, a := range schema.Actions {
+		if e.report.FireCounts[a.ID] == 0 {
+			e.report.NeverFired = append(e.report.NeverFired, a.ID)
+		}
+	}
+	for i := range e.report.Findings {
+		e.minimize(&e.report.Findings[i])
+	}
+	return e.report, nil
+}
+
+// param is a binding a transition takes and its candidate values.
+type param struct {
+	name   string
+	values []any
+}
+
+// parameters lists the bindings an action takes: its declared bindings,
+// plus the key and value names its data arcs read. Names are sorted so
+// exploration is deterministic.
+func parameters(schema *metamodel.Schema, a metamodel.Action, values map[string][]any) []param {
+	types := make(map[string]string)
+	for name, typ := range a.Bindings {
+		types[name] = typ
+	}
+	infer := func(stateID string, arc metamodel.Arc) {
+		st := schema.StateByID(stateID)
+		if st == nil || !st.IsData() || (!st.IsSimpleType() && len(arc.Keys) == 0) {
+			return // Keyless arcs on maps change nothing
+		}
+		for _, key := range arc.Keys {
+			if _, ok := types[key]; !ok {
+				types[key] = "string"
+			}
+		}
+		value := arc.Value
+		if value == "" {
+			value = "amount"
+		}
+		if _, ok := types[value]; !ok {
+			if st.IsSimpleType() {
+				types[value] = st.Type
+			} else {
+				types[value] = "int64"
+			}
+		}
+	}
+	for _, arc := range schema.InputArcs(a.ID) {
+		infer(arc.Source, arc)
+	}
+	for _, arc := range schema.OutputArcs(a.ID) {
+		infer(arc.Target, arc)
+	}
+
+	params := make([]param, 0, len(types))
+	for name, typ := range types {
+		p := param{name: name, values: values[name]}
+		if len(p.values) == 0 {
+			p.values = candidates(typ)
+		}
+		params = append(params, p)
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].name < params[j].name })
+	return params
+}
+
+// candidates returns the values tried for a binding type: a few shared
+// identities for strings, so map keys collide, and boundary amounts for numbers.
+func candidates(typ string) []any {
+	switch strings.ToLower(typ) {
+	case "int", "int64", "uint", "uint64", "uint256", "number", "amount":
+		return []any{int64(0), int64(1), int64(10), int64(100)}
+	case "float", "float64":
+		return []any{0.0, 0.5, 1.0, 100.0}
+	case "bool", "boolean":
+		return []any{false, true}
+	case "time.time", "time", "datetime":
+		return []any{"2025-01-01T00:00:00Z", "2030-01-01T00:00:00Z"}
+	default:
+		return []any{"alice", "bob", "carol"}
+	}
+}
+
+type explorer struct {
+	schema *metamodel.Schema
+	opts   Options
+	params map[string][]param
+	seen   map[string]bool // State fingerprints reached
+	found  map[string]bool // Finding keys already reported
+	report *Report
+}
+
+// newExplorer prepares an exploration with validated options.
+func newExplorer(schema *metamodel.Schema, opts Options) *explorer {
+	e := &explorer{
+		schema: schema,
+		opts:   opts,
+		params: make(map[string][]param),
+		seen:   make(map[string]bool),
+		found:  make(map[string]bool),
+		report: &Report{
+			Mode:       opts.Mode,
+			Seed:       opts.Seed,
+			Depth:      opts.Depth,
+			FireCounts: make(map[string]int),
+			NeverFired: []string{},
+			Findings:   []Finding{},
+		},
+	}
+	for _, a := range schema.Actions {
+		e.params[a.ID] = parameters(schema, a, opts.Values)
+		e.report.FireCounts[a.ID] = 0
+	}
+	return e
+}
+
+// initial returns a runtime in the initial state.
+func (e *explorer) initial() *metamodel.Runtime {
+	rt := metamodel.NewRuntime(e.schema)
+	rt.GuardEvaluator = dsl.NewEvaluator()
+	for id, value := range e.opts.Data {
+		rt.SetData(id, value)
+	}
+	return rt.Clone() // Deep copy, so firings never write to opts.Data
+}
+
+// outcome is the result of trying one step.
+type outcome struct {
+	next    *metamodel.Runtime // Runtime after the step; nil if it did not fire
+	kind    Kind               // Set when the step is a finding
+	message string
+}
+
+// fire tries a step on a copy of rt.
+func (e *explorer) fire(rt *metamodel.Runtime, step Step) outcome {
+	next := rt.Clone()
+	err := next.ExecuteWithBindings(step.Transition, metamodel.Bindings(step.Bindings))
+	switch {
+	case err == nil:
+		return outcome{next: next}
+	case errors.Is(err, metamodel.ErrGuardEvaluation):
+		return outcome{kind: GuardError, message: err.Error()}
+	case errors.Is(err, metamodel.ErrConstraintViolated), errors.Is(err, metamodel.ErrConstraintEvaluation):
+		var ids []string
+		for _, v := range next.Constraints() {
+			ids = append(ids, v.Constraint.ID)
+		}
+		return outcome{kind: ConstraintViolation, message: "constraint violated: " + strings.Join(ids, ", ")}
+	default:
+		// Guard not satisfied or not enabled: an ordinary refusal
+		return outcome{}
+	}
+}
+
+// visit records a reached state and reports whether it is new.
+func (e *explorer) visit(rt *metamodel.Runtime) bool {
+	key := fingerprint(rt)
+	if e.seen[key] {
+		return false
+	}
+	e.seen[key] = true
+	return true
+}
+
+// record adds a finding unless an equivalent one was already reported.
+func (e *explorer) record(kind Kind, transition, message string, trace []Step) {
+	key := string(kind) + "\x00" + transition + "\x00" + message
+	if e.found[key] {
+		return
+	}
+	e.found[key] = true
+	e.report.Findings = append(e.report.Findings, Finding{
+		Kind:        kind,
+		Transition:  transition,
+		Message:     message,
+		Trace:       append([]Step(nil), trace...),
+		FoundLength: len(trace),
+	})
+}
+
+// try fires a step from rt with trace leading to it, recording any
+// finding. It returns the next runtime, or nil if the step did not fire.
+func (e *explorer) try(rt *metamodel.Runtime, trace []Step, step Step) *metamodel.Runtime {
+	out := e.fire(rt, step)
+	if out.kind != "" {
+		e.record(out.kind, step.Transition, out.message, append(trace, step))
+	}
+	if out.next != nil {
+		e.report.Fired++
+		e.report.FireCounts[step.Transition]++
+	}
+	return out.next
+}
+
+// deadlocked records a deadlock if no transition is enabled in rt.
+func (e *explorer) deadlocked(rt *metamodel.Runtime, trace []Step) bool {
+	if len(rt.EnabledActions()) > 0 {
+		return false
+	}
+	e.record(Deadlock, "", "no transition enabled in "+markingString(rt), trace)
+	return true
+}
+
+// walk runs one random walk from the initial state.
+func (e *explorer) walk(rng *rand.Rand) {
+	rt := e.initial()
+	e.visit(rt)
+	var trace []Step
+
+	for !e.deadlocked(rt, trace) && len(trace) < e.opts.Depth {
+		enabled := rt.EnabledActions()
+		rng.Shuffle(len(enabled), func(i, j int) { enabled[i], enabled[j] = enabled[j], enabled[i] })
+
+		var next *metamodel.Runtime
+		var step Step
+	search:
+		for _, id := range enabled {
+			for attempt := 0; attempt < bindingAttempts; attempt++ {
+				step = Step{Transition: id, Bindings: e.randomBindings(rng, id)}
+				if next = e.try(rt, trace, step); next != nil {
+					break search
+				}
+				if len(e.params[id]) == 0 {
+					break // Same step every attempt
+				}
+			}
+		}
+		if next == nil {
+			return // Every enabled transition refused the bindings tried
+		}
+		rt = next
+		trace = append(trace, step)
+		e.visit(rt)
+	}
+}
+
+// randomBindings picks a value for each of a transition's bindings.
+func (e *explorer) randomBindings(rng *rand.Rand, id string) map[string]any {
+	params := e.params[id]
+	if len(params) == 0 {
+		return nil
+	}
+	bindings := make(map[string]any, len(params))
+	for _, p := range params {
+		bindings[p.name] = p.values[rng.Intn(len(p.values))]
+	}
+	return bindings
+}
+
+// node is a state on the exhaustive search frontier.
+type node struct {
+	rt    *metamodel.Runtime
+	trace []Step
+}
+
+// search explores breadth-first up to the configured depth, so the first
+// trace reaching each finding is also a shortest one.
+func (e *explorer) search() {
+	start := e.initial()
+	e.visit(start)
+	frontier := []node{{rt: start}}
+
+	for depth := 0; depth <= e.opts.Depth && len(frontier) > 0; depth++ {
+		var next []node
+		for _, n := range frontier {
+			if e.deadlocked(n.rt, n.trace) || depth == e.opts.Depth {
+				continue
+			}
+			for _, id := range n.rt.EnabledActions() {
+				for _, bindings := range combinations(e.params[id]) {
+					step := Step{Transition: id, Bindings: bindings}
+					rt := e.try(n.rt, n.trace, step)
+					if rt == nil || !e.visit(rt) {
+						continue
+					}
+					if len(e.seen) >= e.opts.MaxStates {
+						e.report.Truncated = true
+						return
+					}
+					trace := append(append([]Step(nil), n.trace...), step)
+					next = append(next, node{rt: rt, trace: trace})
+				}
+			}
+		}
+		frontier = next
+	}
+}
+
+// combinations returns every assignment of candidate values to params.
+func combinations(params []param) []map[string]any {
+	if len(params) == 0 {
+		return []map[string]any{nil}
+	}
+	var out []map[string]any
+	var build func(i int, current map[string]any)
+	build = func(i int, current map[string]any) {
+		if i == len(params) {
+			bindings := make(map[string]any, len(current))
+			for k, v := range current {
+				bindings[k] = v
+			}
+			out = append(out, bindings)
+			return
+		}
+		for _, v := range params[i].values {
+			current[params[i].name] = v
+			build(i+1, current)
+		}
+	}
+	build(0, make(map[string]any, len(params)))
+	return out
+}
+
+// reproduces replays steps from the initial state and reports whether they
+// still produce the finding: the same kind on the same transition, and for
+// constraint violations and deadlocks the same message.
+func (e *explorer) reproduces(f *Finding, steps []Step) bool {
+	rt := e.initial()
+	for i, step := range steps {
+		out := e.fire(rt, step)
+		if i == len(steps)-1 && f.Kind != Deadlock {
+			return out.kind == f.Kind && step.Transition == f.Transition &&
+				(f.Kind == GuardError || out.message == f.Message)
+		}
+		if out.next == nil {
+			return false
+		}
+		rt = out.next
+	}
+	return f.Kind == Deadlock && len(rt.EnabledActions()) == 0 &&
+		"no transition enabled in "+markingString(rt) == f.Message
+}
+
+// minimize shrinks a finding's trace with delta debugging (ddmin) over
+// the steps leading up to the failure, keeping the failing step itself.
+func (e *explorer) minimize(f *Finding) {
+	prefix, last := f.Trace, []Step(nil)
+	if f.Kind != Deadlock {
+		prefix, last = f.Trace[:len(f.Trace)-1], f.Trace[len(f.Trace)-1:]
+	}
+	test := func(candidate []Step) bool {
+		return e.reproduces(f, append(append([]Step(nil), candidate...), last...))
+	}
+
+	prefix = ddmin(prefix, test)
+	f.Trace = append(append([]Step(nil), prefix...), last...)
+}
+
+// ddmin returns a 1-minimal subsequence of steps for which test holds:
+// removing any single remaining step makes it fail.
+func ddmin(steps []Step, test func([]Step) bool) []Step {
+	if len(steps) == 0 || test(nil) {
+		return nil
+	}
+	n := 2
+	for len(steps) >= 2 {
+		reduced := false
+		for i := 0; i < n; i++ {
+			start, end := i*len(steps)/n, (i+1)*len(steps)/n
+			complement := append(append([]Step(nil), steps[:start]...), steps[end:]...)
+			if test(complement) {
+				steps = complement
+				if n > 2 {
+					n--
+				}
+				reduced = true
+				break
+			}
+		}
+		if !reduced {
+			if n >= len(steps) {
+				break
+			}
+			n = min(n*2, len(steps))
+		}
+	}
+	return steps
+}
+
+// fingerprint identifies a state by its tokens and data. JSON sorts map
+// keys, so equal states give equal fingerprints.
+func fingerprint(rt *metamodel.Runtime) string {
+	data, _ := json.Marshal(rt.Snapshot)
+	return string(data)
+}
+
+// markingString formats the non-empty token places, e.g. "{done: 1}".
+func markingString(rt *metamodel.Runtime) string {
+	var parts []string
+	for _, st := range rt.Schema.TokenStates() {
+		if n := rt.Tokens(st.ID); n > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", st.ID, n))
+		}
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,178 @@
+package explore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+// ledger builds a token ledger: mints are capped at two by a constraint,
+// transfers are guarded by the sender's balance, and ping changes nothing.
+func ledger() *metamodel.Schema {
+	s := metamodel.NewSchema("ledger")
+	s.AddDataState("balances", "map[string]int64", nil, true)
+	s.AddTokenState("minted", 0)
+	s.AddTokenState("idle", 1)
+	s.AddAction(metamodel.Action{ID: "mint", Bindings: map[string]string{"to": "string", "amount": "int64"}})
+	s.AddAction(metamodel.Action{ID: "transfer", Guard: "balances[from] >= amount"})
+	s.AddAction(metamodel.Action{ID: "ping"})
+	s.AddArc(metamodel.Arc{Source: "mint", Target: "balances", Keys: []string{"to"}, Value: "amount"})
+	s.AddArc(metamodel.Arc{Source: "mint", Target: "minted"})
+	s.AddArc(metamodel.Arc{Source: "balances", Target: "transfer", Keys: []string{"from"}, Value: "amount"})
+	s.AddArc(metamodel.Arc{Source: "transfer", Target: "balances", Keys: []string{"to"}, Value: "amount"})
+	s.AddArc(metamodel.Arc{Source: "idle", Target: "ping"})
+	s.AddArc(metamodel.Arc{Source: "ping", Target: "idle"})
+	s.AddConstraint(metamodel.Constraint{ID: "mint_cap", Expr: "minted <= 2"})
+	return s
+}
+
+// pipeline builds start -> finish -> done, plus a transition that can never fire.
+func pipeline() *metamodel.Schema {
+	s := metamodel.NewSchema("pipeline")
+	s.AddTokenState("start", 1)
+	s.AddTokenState("done", 0)
+	s.AddTokenState("never", 0)
+	s.AddAction(metamodel.Action{ID: "finish"})
+	s.AddAction(metamodel.Action{ID: "unreachable"})
+	s.AddArc(metamodel.Arc{Source: "start", Target: "finish"})
+	s.AddArc(metamodel.Arc{Source: "finish", Target: "done"})
+	s.AddArc(metamodel.Arc{Source: "never", Target: "unreachable"})
+	return s
+}
+
+func findings(rep *Report, kind Kind) []Finding {
+	var out []Finding
+	for _, f := range rep.Findings {
+		if f.Kind == kind {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func TestExploreRandomMinimizes(t *testing.T) {
+	rep, err := Explore(ledger(), Options{Walks: 50, Depth: 12, Seed: 3})
+	if err != nil {
+		t.Fatalf("explore: %v", err)
+	}
+
+	violations := findings(rep, ConstraintViolation)
+	if len(violations) != 1 {
+		t.Fatalf("expected one constraint violation, got %+v", rep.Findings)
+	}
+	v := violations[0]
+	if v.Transition != "mint" || v.Message != "constraint violated: mint_cap" {
+		t.Errorf("unexpected violation: %+v", v)
+	}
+	// Only the three mints matter; pings and transfers are removed
+	if len(v.Trace) != 3 || v.FoundLength < 3 {
+		t.Fatalf("expected a minimized trace of three mints, got %+v", v.Trace)
+	}
+	for _, step := range v.Trace {
+		if step.Transition != "mint" {
+			t.Errorf("expected only mints in the trace, got %+v", v.Trace)
+		}
+	}
+
+	if rep.FireCounts["transfer"] == 0 {
+		t.Error("expected transfers to fire once balances exist")
+	}
+	if len(rep.NeverFired) != 0 {
+		t.Errorf("expected every transition to fire, got %v", rep.NeverFired)
+	}
+}
+
+func TestExploreSeeded(t *testing.T) {
+	a, _ := Explore(ledger(), Options{Walks: 10, Seed: 9})
+	b, _ := Explore(ledger(), Options{Walks: 10, Seed: 9})
+	if !reflect.DeepEqual(a, b) {
+		t.Error("expected the same seed to give the same report")
+	}
+}
+
+func TestExploreExhaustive(t *testing.T) {
+	rep, err := Explore(pipeline(), Options{Mode: Exhaustive})
+	if err != nil {
+		t.Fatalf("explore: %v", err)
+	}
+	if rep.States != 2 || rep.Fired != 1 {
+		t.Errorf("expected two states and one firing, got %d and %d", rep.States, rep.Fired)
+	}
+	deadlocks := findings(rep, Deadlock)
+	if len(deadlocks) != 1 || deadlocks[0].Message != "no transition enabled in {done: 1}" ||
+		!reflect.DeepEqual(deadlocks[0].Trace, []Step{{Transition: "finish"}}) {
+		t.Errorf("expected a deadlock after finish, got %+v", deadlocks)
+	}
+	if !reflect.DeepEqual(rep.NeverFired, []string{"unreachable"}) {
+		t.Errorf("expected unreachable never to fire, got %v", rep.NeverFired)
+	}
+
+	// Search stops at the state bound
+	rep, err = Explore(ledger(), Options{Mode: Exhaustive, Depth: 4, MaxStates: 20})
+	if err != nil {
+		t.Fatalf("explore: %v", err)
+	}
+	if !rep.Truncated || rep.States != 20 {
+		t.Errorf("expected search truncated at 20 states, got %d (truncated=%v)", rep.States, rep.Truncated)
+	}
+}
+
+func TestExploreGuardErrors(t *testing.T) {
+	s := pipeline()
+	s.Actions[0].Guard = "nosuch(1) > 0"
+	rep, err := Explore(s, Options{Mode: Exhaustive})
+	if err != nil {
+		t.Fatalf("explore: %v", err)
+	}
+	errs := findings(rep, GuardError)
+	if len(errs) != 1 || errs[0].Transition != "finish" || len(errs[0].Trace) != 1 {
+		t.Errorf("expected a guard error on finish, got %+v", rep.Findings)
+	}
+}
+
+func TestExploreValuesAndData(t *testing.T) {
+	// With only zero amounts, no balance ever changes
+	rep, err := Explore(ledger(), Options{
+		Mode:   Exhaustive,
+		Depth:  2,
+		Values: map[string][]any{"amount": {int64(0)}, "to": {"alice"}, "from": {"alice"}},
+		Data:   map[string]any{"balances": map[string]any{"alice": int64(5)}},
+	})
+	if err != nil {
+		t.Fatalf("explore: %v", err)
+	}
+	// Only minted and idle vary: minted 0..2 with idle fixed
+	if rep.States != 3 {
+		t.Errorf("expected three states, got %d", rep.States)
+	}
+}
+
+func TestExploreOptions(t *testing.T) {
+	for name, opts := range map[string]Options{
+		"mode":  {Mode: "bfs"},
+		"walks": {Walks: -1},
+		"depth": {Depth: MaxDepth + 1},
+		"data":  {Data: map[string]any{"minted": 1}},
+	} {
+		if _, err := Explore(ledger(), opts); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestDDMin(t *testing.T) {
+	steps := []Step{{Transition: "a"}, {Transition: "b"}, {Transition: "c"}, {Transition: "d"}, {Transition: "e"}}
+	needs := func(candidate []Step) bool {
+		var a, c bool
+		for _, s := range candidate {
+			a = a || s.Transition == "a"
+			c = c || s.Transition == "c"
+		}
+		return a && c
+	}
+	got := ddmin(steps, needs)
+	if !reflect.DeepEqual(got, []Step{{Transition: "a"}, {Transition: "c"}}) {
+		t.Errorf("expected [a c], got %+v", got)
+	}
+}
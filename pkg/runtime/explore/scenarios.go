@@ -0,0 +1,259 @@
+package explore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+// DefaultMaxPaths bounds the simple paths a suite includes when
+// SuiteOptions.MaxPaths is zero.
+const DefaultMaxPaths = 50
+
+// Scenario is a named list of steps, as written in a scenario file.
+type Scenario struct {
+	Name  string `json:"name"`
+	Steps []Step `json:"steps"`
+}
+
+// Source says where a test case's steps came from.
+type Source string
+
+const (
+	// FromFile is a user-supplied scenario, replayed as written.
+	FromFile Source = "file"
+
+	// FromDeadlock is a minimized trace reaching a dead state.
+	FromDeadlock Source = "deadlock"
+
+	// FromPath is a simple path: a firing sequence that never revisits a state.
+	FromPath Source = "path"
+)
+
+// SuiteOptions configures Suite.
+type SuiteOptions struct {
+	Depth     int              // Longest generated path and deadlock search depth (default 6)
+	MaxPaths  int              // Simple paths included (default 50)
+	Values    map[string][]any // Candidate values by binding name, replacing generated ones
+	Scenarios []Scenario       // User-supplied scenarios
+}
+
+// Case is a firing sequence with the state expected after each step.
+type Case struct {
+	Name   string    `json:"name"`
+	Source Source    `json:"source"`
+	Steps  []Outcome `json:"steps"`
+}
+
+// Outcome is a step and the state the model is in after it.
+type Outcome struct {
+	Step
+
+	// Refused explains why the step did not fire; the state is then unchanged.
+	Refused string `json:"refused,omitempty"`
+
+	Tokens  map[string]int `json:"tokens"`
+	Enabled []string       `json:"enabled"`
+	Data    map[string]any `json:"data,omitempty"`
+}
+
+// Suite derives regression test cases from the schema: the user's
+// scenarios, a witness for each reachable deadlock, and every simple path
+// up to the configured depth, each step paired with its expected state.
+//
+// Steps refused because the transition is disabled or its guard fails are
+// kept, with the state left unchanged. A scenario step that would break a
+// constraint is an error: generated aggregates do not check constraints,
+// so no expectation about it would hold. Generated paths stop short of such
+// steps instead.
+func Suite(schema *metamodel.Schema, opts SuiteOptions) ([]Case, error) {
+	if opts.Depth == 0 {
+		opts.Depth = DefaultSearchDepth
+	}
+	if opts.Depth < 0 || opts.Depth > MaxDepth {
+		return nil, fmt.Errorf("explore: depth must be between 1 and %d", MaxDepth)
+	}
+	if opts.MaxPaths == 0 {
+		opts.MaxPaths = DefaultMaxPaths
+	}
+	if opts.MaxPaths < 0 {
+		return nil, fmt.Errorf("explore: max paths must not be negative")
+	}
+
+	e := newExplorer(schema, Options{Mode: Exhaustive, Depth: opts.Depth, MaxStates: DefaultMaxStates, Values: opts.Values})
+	var cases []Case
+	seen := make(map[string]bool)
+	add := func(name string, source Source, steps []Step) error {
+		key, _ := json.Marshal(steps)
+		if len(steps) == 0 || seen[string(key)] {
+			return nil
+		}
+		seen[string(key)] = true
+		outcomes, err := e.replay(steps)
+		if err != nil {
+			return fmt.Errorf("explore: %s %q: %w", source, name, err)
+		}
+		cases = append(cases, Case{Name: name, Source: source, Steps: outcomes})
+		return nil
+	}
+
+	for i, sc := range opts.Scenarios {
+		name := sc.Name
+		if name == "" {
+			name = fmt.Sprintf("scenario %d", i+1)
+		}
+		if err := add(name, FromFile, sc.Steps); err != nil {
+			return nil, err
+		}
+	}
+
+	report, err := Explore(schema, Options{Mode: Exhaustive, Depth: opts.Depth, Values: opts.Values})
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range report.Findings {
+		if f.Kind != Deadlock {
+			continue
+		}
+		if err := add("deadlock after "+traceString(f.Trace), FromDeadlock, f.Trace); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, steps := range e.simplePaths(opts.MaxPaths) {
+		if err := add(traceString(steps), FromPath, steps); err != nil {
+			return nil, err
+		}
+	}
+	return cases, nil
+}
+
+// replay fires steps from the initial state, recording the state after
+// each. Refused steps leave the state unchanged.
+func (e *explorer) replay(steps []Step) ([]Outcome, error) {
+	rt := e.initial()
+	outcomes := make([]Outcome, 0, len(steps))
+	for i, step := range steps {
+		if e.schema.ActionByID(step.Transition) == nil {
+			return nil, fmt.Errorf("step %d: unknown transition %q", i+1, step.Transition)
+		}
+
+		out := Outcome{Step: step}
+		next := rt.Clone()
+		err := next.ExecuteWithBindings(step.Transition, metamodel.Bindings(step.Bindings))
+		switch {
+		case err == nil:
+			rt = next
+		case errors.Is(err, metamodel.ErrConstraintViolated), errors.Is(err, metamodel.ErrConstraintEvaluation):
+			return nil, fmt.Errorf("step %d (%s): %w", i+1, step.Transition, err)
+		case errors.Is(err, metamodel.ErrActionNotEnabled):
+			out.Refused = "not enabled"
+		case errors.Is(err, metamodel.ErrGuardNotSatisfied):
+			out.Refused = "guard not satisfied"
+		default:
+			out.Refused = err.Error()
+		}
+
+		snap := rt.Snapshot.Clone()
+		out.Tokens = snap.Tokens
+		out.Enabled = rt.EnabledActions()
+		if len(snap.Data) > 0 {
+			out.Data = snap.Data
+		}
+		outcomes = append(outcomes, out)
+	}
+	return outcomes, nil
+}
+
+// simplePaths returns firing sequences from the initial state that never
+// revisit a state, extended until nothing new is reachable or the depth
+// bound is hit. Each transition fires with the first candidate bindings
+// that lead somewhere new, so paths branch on transitions, not values.
+func (e *explorer) simplePaths(limit int) [][]Step {
+	var paths [][]Step
+	start := e.initial()
+	onPath := map[string]bool{fingerprint(start): true}
+
+	var extend func(rt *metamodel.Runtime, trace []Step)
+	extend = func(rt *metamodel.Runtime, trace []Step) {
+		extended := false
+		for _, id := range rt.EnabledActions() {
+			if len(trace) == e.opts.Depth || len(paths) >= limit {
+				break
+			}
+			for _, bindings := range combinations(e.params[id]) {
+				step := Step{Transition: id, Bindings: bindings}
+				next := e.fire(rt, step).next
+				if next == nil {
+					continue
+				}
+				key := fingerprint(next)
+				if onPath[key] {
+					continue
+				}
+				onPath[key] = true
+				extend(next, append(trace[:len(trace):len(trace)], step))
+				delete(onPath, key)
+				extended = true
+				break
+			}
+		}
+		if !extended && len(trace) > 0 && len(paths) < limit {
+			paths = append(paths, trace)
+		}
+	}
+	extend(start, nil)
+	return paths
+}
+
+// traceString names a trace by its transitions, e.g. "ship > deliver".
+func traceString(steps []Step) string {
+	ids := make([]string, len(steps))
+	for i, s := range steps {
+		ids[i] = s.Transition
+	}
+	return strings.Join(ids, " > ")
+}
+
+// ParseScenarios reads a scenario file. It holds a scenario object
+// ({"name": ..., "steps": [...]}), a list of them, or a bare step list
+// such as an explore trace, which becomes one unnamed scenario.
+func ParseScenarios(data []byte) ([]Scenario, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var sc Scenario
+		if err := json.Unmarshal(data, &sc); err != nil {
+			return nil, fmt.Errorf("explore: invalid scenario: %w", err)
+		}
+		return []Scenario{sc}, nil
+	}
+
+	var entries []struct {
+		Scenario
+		Transition string         `json:"transition"`
+		Bindings   map[string]any `json:"bindings"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("explore: invalid scenarios: %w", err)
+	}
+	var scenarios []Scenario
+	var steps []Step
+	for _, entry := range entries {
+		if entry.Transition != "" {
+			steps = append(steps, Step{Transition: entry.Transition, Bindings: entry.Bindings})
+		} else {
+			scenarios = append(scenarios, entry.Scenario)
+		}
+	}
+	if len(steps) > 0 {
+		if len(scenarios) > 0 {
+			return nil, fmt.Errorf("explore: scenario file mixes steps and scenarios")
+		}
+		return []Scenario{{Steps: steps}}, nil
+	}
+	return scenarios, nil
+}
@@ -0,0 +1,131 @@
+package explore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+// choice builds start -> (left | right) -> end, plus a reset back to start.
+func choice() *metamodel.Schema {
+	s := metamodel.NewSchema("choice")
+	s.AddTokenState("start", 1)
+	s.AddTokenState("end", 0)
+	for _, id := range []string{"left", "right"} {
+		s.AddAction(metamodel.Action{ID: id})
+		s.AddArc(metamodel.Arc{Source: "start", Target: id})
+		s.AddArc(metamodel.Arc{Source: id, Target: "end"})
+	}
+	s.AddAction(metamodel.Action{ID: "reset"})
+	s.AddArc(metamodel.Arc{Source: "end", Target: "reset"})
+	s.AddArc(metamodel.Arc{Source: "reset", Target: "start"})
+	return s
+}
+
+func TestSuitePaths(t *testing.T) {
+	cases, err := Suite(choice(), SuiteOptions{})
+	if err != nil {
+		t.Fatalf("suite: %v", err)
+	}
+	// Reset returns to the initial state, so each path ends after one choice
+	var names []string
+	for _, c := range cases {
+		names = append(names, c.Name)
+		if c.Source != FromPath {
+			t.Errorf("%s: expected a path, got %s", c.Name, c.Source)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"left", "right"}) {
+		t.Fatalf("expected paths left and right, got %v", names)
+	}
+	step := cases[0].Steps[0]
+	if !reflect.DeepEqual(step.Tokens, map[string]int{"start": 0, "end": 1}) ||
+		!reflect.DeepEqual(step.Enabled, []string{"reset"}) {
+		t.Errorf("unexpected state after left: %+v", step)
+	}
+
+	cases, _ = Suite(choice(), SuiteOptions{MaxPaths: 1})
+	if len(cases) != 1 {
+		t.Errorf("expected one path, got %d", len(cases))
+	}
+}
+
+func TestSuiteDeadlocks(t *testing.T) {
+	cases, err := Suite(pipeline(), SuiteOptions{})
+	if err != nil {
+		t.Fatalf("suite: %v", err)
+	}
+	// The only path is also the deadlock witness, which comes first
+	if len(cases) != 1 || cases[0].Source != FromDeadlock || cases[0].Name != "deadlock after finish" {
+		t.Fatalf("expected one deadlock case, got %+v", cases)
+	}
+	if got := cases[0].Steps[0]; len(got.Enabled) != 0 || got.Tokens["done"] != 1 {
+		t.Errorf("expected a dead state after finish, got %+v", got)
+	}
+}
+
+func TestSuiteScenarios(t *testing.T) {
+	scenario := Scenario{Name: "overdraft", Steps: []Step{
+		{Transition: "mint", Bindings: map[string]any{"to": "alice", "amount": 10.0}},
+		{Transition: "transfer", Bindings: map[string]any{"from": "alice", "to": "bob", "amount": 20.0}},
+		{Transition: "transfer", Bindings: map[string]any{"from": "alice", "to": "bob", "amount": 4.0}},
+	}}
+	cases, err := Suite(ledger(), SuiteOptions{Depth: 2, MaxPaths: 1, Scenarios: []Scenario{scenario}})
+	if err != nil {
+		t.Fatalf("suite: %v", err)
+	}
+	c := cases[0]
+	if c.Name != "overdraft" || c.Source != FromFile || len(c.Steps) != 3 {
+		t.Fatalf("expected the scenario first, got %+v", c)
+	}
+	if c.Steps[0].Refused != "" || c.Steps[1].Refused != "guard not satisfied" || c.Steps[2].Refused != "" {
+		t.Errorf("expected only the overdraft refused, got %+v", c.Steps)
+	}
+	want := map[string]any{"alice": int64(6), "bob": int64(4)}
+	if got := c.Steps[2].Data["balances"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected balances %v, got %v", want, got)
+	}
+	if c.Steps[1].Data["balances"].(map[string]any)["alice"] != int64(10) {
+		t.Errorf("expected the refused step to leave balances unchanged, got %v", c.Steps[1].Data)
+	}
+
+	// Mints are capped by a constraint the generated aggregate cannot check
+	mint := Step{Transition: "mint", Bindings: map[string]any{"to": "alice", "amount": 1}}
+	_, err = Suite(ledger(), SuiteOptions{Scenarios: []Scenario{{Steps: []Step{mint, mint, mint}}}})
+	if err == nil || !strings.Contains(err.Error(), `file "scenario 1": step 3 (mint)`) {
+		t.Errorf("expected a constraint error on the third mint, got %v", err)
+	}
+
+	_, err = Suite(ledger(), SuiteOptions{Scenarios: []Scenario{{Steps: []Step{{Transition: "burn"}}}}})
+	if err == nil || !strings.Contains(err.Error(), "unknown transition") {
+		t.Errorf("expected an unknown transition error, got %v", err)
+	}
+}
+
+func TestParseScenarios(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input string
+		want  []Scenario
+	}{
+		"object": {`{"name": "a", "steps": [{"transition": "finish"}]}`, []Scenario{{Name: "a", Steps: []Step{{Transition: "finish"}}}}},
+		"list":   {`[{"name": "a", "steps": []}, {"name": "b", "steps": []}]`, []Scenario{{Name: "a", Steps: []Step{}}, {Name: "b", Steps: []Step{}}}},
+		"steps":  {`[{"transition": "mint", "bindings": {"to": "alice"}}]`, []Scenario{{Steps: []Step{{Transition: "mint", Bindings: map[string]any{"to": "alice"}}}}}},
+	} {
+		got, err := ParseScenarios([]byte(tc.input))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected %+v, got %+v", name, tc.want, got)
+		}
+	}
+
+	for _, input := range []string{`[{"transition": "a"}, {"name": "b"}]`, `"steps"`} {
+		if _, err := ParseScenarios([]byte(input)); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Input values are coerced to the shapes encoding/json decodes: float64
+// for Int and Float, string for ID and enums, map[string]any for input
+// objects and []any for lists. Custom scalars pass through unchanged.
+
+// coerceVariables coerces the request's variables against the
+// operation's variable definitions.
+func coerceVariables(s *Schema, op *Operation, values map[string]any) (map[string]any, []*Error) {
+	coerced := make(map[string]any)
+	var errs []*Error
+	for _, def := range op.Variables {
+		value, provided := values[def.Name]
+		switch {
+		case !provided && def.Default != nil:
+			v, _, err := coerceLiteral(s, def.Default, def.Type, nil)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			coerced[def.Name] = v
+		case !provided && def.Type.NonNull:
+			errs = append(errs, errorf([]Location{def.Loc},
+				"Variable \"$%s\" of required type %q was not provided.", def.Name, def.Type))
+		case !provided:
+		default:
+			v, err := coerceInput(s, value, def.Type)
+			if err != nil {
+				errs = append(errs, errorf([]Location{def.Loc},
+					"Variable \"$%s\" got invalid value %s; %s", def.Name, jsonString(value), err))
+				continue
+			}
+			coerced[def.Name] = v
+		}
+	}
+	return coerced, errs
+}
+
+// coerceInput coerces an input value supplied as JSON.
+func coerceInput(s *Schema, value any, t *TypeRef) (any, error) {
+	if value == nil {
+		if t.NonNull {
+			return nil, fmt.Errorf("Expected non-nullable type %q not to be null.", t)
+		}
+		return nil, nil
+	}
+	if t.Elem != nil {
+		items, ok := value.([]any)
+		if !ok {
+			item, err := coerceInput(s, value, t.Elem)
+			if err != nil {
+				return nil, err
+			}
+			return []any{item}, nil
+		}
+		list := make([]any, len(items))
+		for i, item := range items {
+			v, err := coerceInput(s, item, t.Elem)
+			if err != nil {
+				return nil, fmt.Errorf("%s at index %d", strings.TrimSuffix(err.Error(), "."), i)
+			}
+			list[i] = v
+		}
+		return list, nil
+	}
+
+	named := s.Type(t.Name)
+	switch named.Kind {
+	case ScalarKind:
+		return coerceScalarInput(named, value)
+	case EnumKind:
+		name, ok := value.(string)
+		if !ok || named.EnumValue(name) == nil {
+			return nil, fmt.Errorf("Value %s does not exist in %q enum.", jsonString(value), named.Name)
+		}
+		return name, nil
+	case InputObjectKind:
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("Expected type %q to be an object.", named.Name)
+		}
+		for name := range fields {
+			if named.InputField(name) == nil {
+				return nil, fmt.Errorf("Field %q is not defined by type %q.", name, named.Name)
+			}
+		}
+		object := make(map[string]any)
+		for _, f := range named.InputFields {
+			fv, provided := fields[f.Name]
+			switch {
+			case !provided && f.Default != nil:
+				v, _, err := coerceLiteral(s, f.Default, f.Type, nil)
+				if err != nil {
+					return nil, err
+				}
+				object[f.Name] = v
+			case !provided && f.Type.NonNull:
+				return nil, fmt.Errorf("Field %q of required type %q was not provided.", f.Name, f.Type)
+			case !provided:
+			default:
+				v, err := coerceInput(s, fv, f.Type)
+				if err != nil {
+					return nil, fmt.Errorf("%s at %q", strings.TrimSuffix(err.Error(), "."), f.Name)
+				}
+				object[f.Name] = v
+			}
+		}
+		return object, nil
+	}
+	return nil, fmt.Errorf("Type %q is not an input type.", t.Name)
+}
+
+func coerceScalarInput(t *Type, value any) (any, error) {
+	switch t.Name {
+	case "Int":
+		if f, ok := toFloat(value); ok && f == math.Trunc(f) && f >= math.MinInt32 && f <= math.MaxInt32 {
+			return f, nil
+		}
+		return nil, fmt.Errorf("Int cannot represent non-integer value: %s", jsonString(value))
+	case "Float":
+		if f, ok := toFloat(value); ok {
+			return f, nil
+		}
+		return nil, fmt.Errorf("Float cannot represent non numeric value: %s", jsonString(value))
+	case "String":
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		return nil, fmt.Errorf("String cannot represent a non string value: %s", jsonString(value))
+	case "Boolean":
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("Boolean cannot represent a non boolean value: %s", jsonString(value))
+	case "ID":
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		if f, ok := toFloat(value); ok && f == math.Trunc(f) {
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+		return nil, fmt.Errorf("ID cannot represent value: %s", jsonString(value))
+	}
+	return value, nil
+}
+
+// coerceLiteral coerces a literal from the document. Variables are read
+// from vars; provided reports false for a variable that was not supplied,
+// so that the argument or field holding it counts as absent. With nil
+// vars, as during validation, variables are assumed valid.
+func coerceLiteral(s *Schema, v *Value, t *TypeRef, vars map[string]any) (value any, provided bool, err *Error) {
+	invalid := func() (any, bool, *Error) {
+		return nil, false, errorf([]Location{v.Loc}, "Expected value of type %q, found %s.", t, v)
+	}
+
+	if v.Kind == VariableValue {
+		if vars == nil {
+			return nil, true, nil
+		}
+		value, provided := vars[v.Raw]
+		if provided && value == nil && t.NonNull {
+			return nil, false, errorf([]Location{v.Loc},
+				"Variable \"$%s\" must not be null: it is used where %q is expected.", v.Raw, t)
+		}
+		return value, provided, nil
+	}
+	if v.Kind == NullValue {
+		if t.NonNull {
+			return invalid()
+		}
+		return nil, true, nil
+	}
+
+	if t.Elem != nil {
+		if v.Kind != ListValue {
+			item, provided, err := coerceLiteral(s, v, t.Elem, vars)
+			if err != nil || !provided {
+				return nil, provided, err
+			}
+			return []any{item}, true, nil
+		}
+		list := make([]any, len(v.List))
+		for i, item := range v.List {
+			iv, provided, err := coerceLiteral(s, item, t.Elem, vars)
+			if err != nil {
+				return nil, false, err
+			}
+			if !provided && t.Elem.NonNull {
+				return invalid()
+			}
+			list[i] = iv
+		}
+		return list, true, nil
+	}
+
+	named := s.Type(t.Name)
+	switch named.Kind {
+	case ScalarKind:
+		value, ok := scalarLiteral(named, v)
+		if !ok {
+			return invalid()
+		}
+		return value, true, nil
+	case EnumKind:
+		if v.Kind != EnumValue || named.EnumValue(v.Raw) == nil {
+			return invalid()
+		}
+		return v.Raw, true, nil
+	case InputObjectKind:
+		if v.Kind != ObjectValue {
+			return invalid()
+		}
+		given := make(map[string]*ObjectField)
+		for _, f := range v.Fields {
+			if named.InputField(f.Name) == nil {
+				return nil, false, errorf([]Location{f.Loc}, "Field %q is not defined by type %q.", f.Name, named.Name)
+			}
+			if given[f.Name] != nil {
+				return nil, false, errorf([]Location{given[f.Name].Loc, f.Loc}, "There can be only one input field named %q.", f.Name)
+			}
+			given[f.Name] = f
+		}
+		object := make(map[string]any)
+		for _, def := range named.InputFields {
+			if f := given[def.Name]; f != nil {
+				fv, provided, err := coerceLiteral(s, f.Value, def.Type, vars)
+				if err != nil {
+					return nil, false, err
+				}
+				if provided {
+					object[def.Name] = fv
+					continue
+				}
+			}
+			switch {
+			case def.Default != nil:
+				fv, _, err := coerceLiteral(s, def.Default, def.Type, nil)
+				if err != nil {
+					return nil, false, err
+				}
+				object[def.Name] = fv
+			case def.Type.NonNull:
+				return nil, false, errorf([]Location{v.Loc},
+					"Field \"%s.%s\" of required type %q was not provided.", named.Name, def.Name, def.Type)
+			}
+		}
+		return object, true, nil
+	}
+	return invalid()
+}
+
+func scalarLiteral(t *Type, v *Value) (any, bool) {
+	switch t.Name {
+	case "Int":
+		if v.Kind != IntValue {
+			return nil, false
+		}
+		n, err := strconv.ParseInt(v.Raw, 10, 32)
+		return float64(n), err == nil
+	case "Float":
+		if v.Kind != IntValue && v.Kind != FloatValue {
+			return nil, false
+		}
+		f, err := strconv.ParseFloat(v.Raw, 64)
+		return f, err == nil && !math.IsInf(f, 0)
+	case "String":
+		return v.Raw, v.Kind == StringValue
+	case "Boolean":
+		return v.Raw == "true", v.Kind == BooleanValue
+	case "ID":
+		return v.Raw, v.Kind == StringValue || v.Kind == IntValue
+	}
+	// Custom scalars take any literal, as the JSON it reads as.
+	return literalJSON(v), true
+}
+
+// literalJSON converts a constant literal to its JSON-decoded form.
+func literalJSON(v *Value) any {
+	switch v.Kind {
+	case IntValue, FloatValue:
+		f, _ := strconv.ParseFloat(v.Raw, 64)
+		return f
+	case StringValue, EnumValue:
+		return v.Raw
+	case BooleanValue:
+		return v.Raw == "true"
+	case ListValue:
+		list := make([]any, len(v.List))
+		for i, item := range v.List {
+			list[i] = literalJSON(item)
+		}
+		return list
+	case ObjectValue:
+		object := make(map[string]any, len(v.Fields))
+		for _, f := range v.Fields {
+			object[f.Name] = literalJSON(f.Value)
+		}
+		return object
+	}
+	return nil
+}
+
+// String prints the value as it would be written in a document.
+func (v *Value) String() string {
+	switch v.Kind {
+	case VariableValue:
+		return "$" + v.Raw
+	case StringValue:
+		return strconv.Quote(v.Raw)
+	case NullValue:
+		return "null"
+	case ListValue:
+		items := make([]string, len(v.List))
+		for i, item := range v.List {
+			items[i] = item.String()
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case ObjectValue:
+		fields := make([]string, len(v.Fields))
+		for i, f := range v.Fields {
+			fields[i] = f.Name + ": " + f.Value.String()
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	}
+	return v.Raw
+}
+
+// toFloat reads a number of any Go numeric type.
+func toFloat(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func jsonString(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
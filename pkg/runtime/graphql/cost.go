@@ -0,0 +1,285 @@
+package graphql
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Limits bound the size of the operations a request may run, so a single
+// query cannot make a server do unbounded work. Zero fields are unlimited.
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields allowed; root fields are
+	// at depth 1.
+	MaxDepth int
+	// MaxComplexity is the highest complexity allowed, as Measure scores it.
+	MaxComplexity int
+	// FieldCosts overrides the cost of fields, keyed "Type.field". Other
+	// fields cost 1.
+	FieldCosts map[string]int
+	// ListSize is the number of items assumed for a list field whose size
+	// no argument gives. Zero means DefaultListSize.
+	ListSize int
+}
+
+// DefaultListSize is the list size assumed when Limits.ListSize is zero.
+const DefaultListSize = 10
+
+// sizeArguments are the arguments that give the number of items a list
+// field returns. On a field that is not a list, such as a page of
+// results, they size the lists directly below it.
+var sizeArguments = []string{"first", "last", "limit", "perPage"}
+
+// Cost is the measured size of an operation.
+type Cost struct {
+	Operation OperationType
+	// Fields are the names of the root fields selected, in order.
+	Fields     []string
+	Depth      int
+	Complexity int
+}
+
+// Measure scores the selected operation of a validated document and
+// checks it against limits.
+//
+// A field's complexity is its cost plus that of its selection set, which
+// a list field multiplies by its size: the value of a first, last, limit
+// or perPage argument, or limits.ListSize. Every selection counts,
+// whatever its @skip and @include directives, and fragments count where
+// they are spread. Introspection fields are free and add no depth.
+//
+// The cost is returned along with any errors, unless the operation
+// could not be selected.
+func Measure(s *Schema, doc *Document, operationName string, variables map[string]any, limits Limits) (*Cost, []*Error) {
+	op, err := selectOperation(doc, operationName)
+	if err != nil {
+		return nil, []*Error{err}
+	}
+	root := s.RootType(op.Type)
+	if root == nil {
+		return nil, []*Error{errorf([]Location{op.Loc}, "Schema is not configured for %ss.", op.Type)}
+	}
+	if limits.ListSize == 0 {
+		limits.ListSize = DefaultListSize
+	}
+
+	m := &measurer{
+		schema:    s,
+		doc:       doc,
+		vars:      variables,
+		limits:    limits,
+		fragments: make(map[fragmentSize]measure),
+		visiting:  make(map[string]bool),
+	}
+	total := m.selectionSet(root, op.SelectionSet, -1)
+	cost := &Cost{Operation: op.Type, Depth: total.depth, Complexity: total.complexity}
+	seen := make(map[string]bool)
+	m.rootFields(op.SelectionSet, func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			cost.Fields = append(cost.Fields, name)
+		}
+	})
+
+	var errs []*Error
+	if limits.MaxDepth > 0 && cost.Depth > limits.MaxDepth {
+		errs = append(errs, errorf([]Location{op.Loc}, "Operation %s is %d levels deep, more than the maximum of %d.", operationLabel(op), cost.Depth, limits.MaxDepth))
+	}
+	if limits.MaxComplexity > 0 && cost.Complexity > limits.MaxComplexity {
+		errs = append(errs, errorf([]Location{op.Loc}, "Operation %s has complexity %d, more than the maximum of %d.", operationLabel(op), cost.Complexity, limits.MaxComplexity))
+	}
+	return cost, errs
+}
+
+type measurer struct {
+	schema *Schema
+	doc    *Document
+	vars   map[string]any
+	limits Limits
+
+	// fragments memoizes the measure of each fragment, so a fragment
+	// spread many times is walked once.
+	fragments map[fragmentSize]measure
+	visiting  map[string]bool
+}
+
+type fragmentSize struct {
+	name string
+	size int
+}
+
+// measure is the depth and complexity of a selection set.
+type measure struct {
+	depth      int
+	complexity int
+}
+
+// selectionSet measures selections on t. size is the list size a parent
+// field's size argument gives the lists directly below it, or -1.
+func (m *measurer) selectionSet(t *Type, set []Selection, size int) measure {
+	var total measure
+	for _, sel := range set {
+		var part measure
+		switch sel := sel.(type) {
+		case *Field:
+			part = m.field(t, sel, size)
+		case *InlineFragment:
+			ft := t
+			if sel.TypeCondition != "" {
+				if ct := m.schema.Type(sel.TypeCondition); ct != nil {
+					ft = ct
+				}
+			}
+			part = m.selectionSet(ft, sel.SelectionSet, size)
+		case *FragmentSpread:
+			part = m.fragment(sel.Name, size)
+		}
+		total.depth = max(total.depth, part.depth)
+		total.complexity = saturatingAdd(total.complexity, part.complexity)
+	}
+	return total
+}
+
+func (m *measurer) fragment(name string, size int) measure {
+	key := fragmentSize{name, size}
+	if cached, ok := m.fragments[key]; ok {
+		return cached
+	}
+	var t *Type
+	f := m.doc.Fragment(name)
+	if f != nil {
+		t = m.schema.Type(f.TypeCondition)
+	}
+	// Validation rejects cycles; guard anyway rather than recurse forever
+	if t == nil || m.visiting[name] {
+		return measure{}
+	}
+	m.visiting[name] = true
+	result := m.selectionSet(t, f.SelectionSet, size)
+	delete(m.visiting, name)
+	m.fragments[key] = result
+	return result
+}
+
+func (m *measurer) field(t *Type, f *Field, size int) measure {
+	if strings.HasPrefix(f.Name, "__") {
+		return measure{}
+	}
+	cost := 1
+	if c, ok := m.limits.FieldCosts[t.Name+"."+f.Name]; ok {
+		cost = max(c, 0)
+	}
+	def := m.schema.fieldDefinition(t, f.Name)
+	if def == nil {
+		return measure{depth: 1, complexity: cost}
+	}
+
+	// A list's size applies to the list; a page's to the lists below it
+	own := m.size(def, f)
+	inherited := own
+	if def.Type.Elem != nil {
+		inherited = -1
+	}
+	var below measure
+	if child := m.schema.Type(def.Type.NamedType()); child != nil && child.isComposite() {
+		below = m.selectionSet(child, f.SelectionSet, inherited)
+	}
+
+	// Each level of list multiplies the selection set; the outermost by
+	// the field's own size, or the one its parent gave
+	multiplier := 1
+	first := true
+	for ref := def.Type; ref.Elem != nil; ref = ref.Elem {
+		n := m.limits.ListSize
+		if first && own >= 0 {
+			n = own
+		} else if first && size >= 0 {
+			n = size
+		}
+		multiplier = saturatingMul(multiplier, n)
+		first = false
+	}
+	return measure{
+		depth:      1 + below.depth,
+		complexity: saturatingAdd(cost, saturatingMul(multiplier, below.complexity)),
+	}
+}
+
+// size returns the value of a field's size argument, or -1 if it has none.
+func (m *measurer) size(def *FieldDefinition, f *Field) int {
+	for _, name := range sizeArguments {
+		var value *Value
+		if a := argument(f.Arguments, name); a != nil {
+			value = a.Value
+		} else if d := def.Arg(name); d != nil && d.Default != nil {
+			value = d.Default
+		}
+		if value == nil {
+			continue
+		}
+		if n, ok := m.intValue(value); ok {
+			return max(n, 0)
+		}
+	}
+	return -1
+}
+
+func (m *measurer) intValue(v *Value) (int, bool) {
+	switch v.Kind {
+	case IntValue:
+		n, err := strconv.Atoi(v.Raw)
+		if err != nil {
+			return math.MaxInt32, true
+		}
+		return n, true
+	case VariableValue:
+		f, ok := toFloat(m.vars[v.Raw])
+		if !ok {
+			return 0, false
+		}
+		return int(min(f, math.MaxInt32)), true
+	}
+	return 0, false
+}
+
+// rootFields calls fn with the name of each field an operation selects at
+// its root.
+func (m *measurer) rootFields(set []Selection, fn func(name string)) {
+	visited := make(map[string]bool)
+	var walk func(set []Selection)
+	walk = func(set []Selection) {
+		for _, sel := range set {
+			switch sel := sel.(type) {
+			case *Field:
+				fn(sel.Name)
+			case *InlineFragment:
+				walk(sel.SelectionSet)
+			case *FragmentSpread:
+				if f := m.doc.Fragment(sel.Name); f != nil && !visited[sel.Name] {
+					visited[sel.Name] = true
+					walk(f.SelectionSet)
+				}
+			}
+		}
+	}
+	walk(set)
+}
+
+// saturatingAdd and saturatingMul keep scores of pathological queries
+// from overflowing into small or negative numbers.
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+func saturatingMul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	if a > math.MaxInt/b {
+		return math.MaxInt
+	}
+	return a * b
+}
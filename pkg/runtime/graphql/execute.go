@@ -0,0 +1,457 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// ExecuteParams is a parsed and validated request to execute.
+type ExecuteParams struct {
+	Schema        *Schema
+	Document      *Document
+	OperationName string
+	Variables     map[string]any
+	Resolvers     map[string]ResolverFunc
+}
+
+// Execute runs the selected operation of a validated document. Root
+// fields are resolved one after another, in document order.
+func Execute(ctx context.Context, p ExecuteParams) *Result {
+	op, err := selectOperation(p.Document, p.OperationName)
+	if err != nil {
+		return &Result{Errors: []*Error{err}}
+	}
+	root := p.Schema.RootType(op.Type)
+	if root == nil {
+		return &Result{Errors: []*Error{errorf([]Location{op.Loc}, "Schema is not configured for %ss.", op.Type)}}
+	}
+	if op.Type == Subscription {
+		return &Result{Errors: []*Error{errorf([]Location{op.Loc}, "Subscriptions cannot be executed as a single request.")}}
+	}
+	vars, errs := coerceVariables(p.Schema, op, p.Variables)
+	if len(errs) > 0 {
+		return &Result{Errors: errs}
+	}
+
+	e := &executor{schema: p.Schema, doc: p.Document, vars: vars, resolvers: p.Resolvers}
+	return e.execute(ctx, root, op)
+}
+
+// execute runs the selection set of an operation from its root type.
+func (e *executor) execute(ctx context.Context, root *Type, op *Operation) *Result {
+	data, ok := e.executeSelectionSet(ctx, root, op.SelectionSet, nil, nil)
+	result := &Result{Errors: e.errors, executed: true}
+	if ok {
+		result.Data = data
+	}
+	return result
+}
+
+func selectOperation(doc *Document, name string) (*Operation, *Error) {
+	if name == "" {
+		if len(doc.Operations) != 1 {
+			return nil, errorf(nil, "Must provide operation name if query contains multiple operations.")
+		}
+		return doc.Operations[0], nil
+	}
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, errorf(nil, "Unknown operation named %q.", name)
+}
+
+type executor struct {
+	schema    *Schema
+	doc       *Document
+	vars      map[string]any
+	resolvers map[string]ResolverFunc
+	errors    []*Error
+}
+
+func (e *executor) fieldError(err error, fields []*Field, path []any) {
+	gqlErr := &Error{Message: err.Error(), Path: append([]any(nil), path...)}
+	if inner, ok := err.(*Error); ok {
+		gqlErr.Message = inner.Message
+		gqlErr.Locations = inner.Locations
+	}
+	if len(gqlErr.Locations) == 0 {
+		gqlErr.Locations = []Location{fields[0].Loc}
+	}
+	e.errors = append(e.errors, gqlErr)
+}
+
+// orderedMap is a response object, which marshals its fields in the order
+// they were selected.
+type orderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func (m *orderedMap) set(key string, value any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON writes the fields in selection order.
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// collectFields groups the selected fields of an object type by response
+// key, applying @skip, @include and fragment type conditions.
+func (e *executor) collectFields(t *Type, set []Selection, keys *[]string, fields map[string][]*Field, visited map[string]bool) {
+	for _, sel := range set {
+		if !e.included(sel.directives()) {
+			continue
+		}
+		switch sel := sel.(type) {
+		case *Field:
+			key := sel.ResponseKey()
+			if _, ok := fields[key]; !ok {
+				*keys = append(*keys, key)
+			}
+			fields[key] = append(fields[key], sel)
+		case *FragmentSpread:
+			if visited[sel.Name] {
+				continue
+			}
+			visited[sel.Name] = true
+			f := e.doc.Fragment(sel.Name)
+			if f == nil || !e.applies(t, f.TypeCondition) {
+				continue
+			}
+			e.collectFields(t, f.SelectionSet, keys, fields, visited)
+		case *InlineFragment:
+			if sel.TypeCondition != "" && !e.applies(t, sel.TypeCondition) {
+				continue
+			}
+			e.collectFields(t, sel.SelectionSet, keys, fields, visited)
+		}
+	}
+}
+
+func (e *executor) applies(t *Type, condition string) bool {
+	ct := e.schema.Type(condition)
+	return ct != nil && e.schema.isPossibleType(ct, t.Name)
+}
+
+func (e *executor) included(dirs []*Directive) bool {
+	for _, d := range dirs {
+		if d.Name != "skip" && d.Name != "include" {
+			continue
+		}
+		args, err := coerceArguments(e.schema, directiveNamed(d.Name).Args, d.Arguments, e.vars)
+		if err != nil {
+			continue
+		}
+		if cond, _ := args["if"].(bool); cond == (d.Name == "skip") {
+			return false
+		}
+	}
+	return true
+}
+
+// executeSelectionSet resolves the fields selected on an object. It
+// reports false when a non-null field failed, nulling the object.
+func (e *executor) executeSelectionSet(ctx context.Context, t *Type, set []Selection, parent any, path []any) (any, bool) {
+	var keys []string
+	fields := make(map[string][]*Field)
+	e.collectFields(t, set, &keys, fields, make(map[string]bool))
+
+	result := &orderedMap{values: make(map[string]any, len(keys))}
+	for _, key := range keys {
+		value, ok := e.executeField(ctx, t, fields[key], parent, append(path, key))
+		if !ok {
+			return nil, false
+		}
+		result.set(key, value)
+	}
+	return result, true
+}
+
+func (e *executor) executeField(ctx context.Context, t *Type, fields []*Field, parent any, path []any) (any, bool) {
+	field := fields[0]
+	def := e.schema.fieldDefinition(t, field.Name)
+	if def == nil {
+		// Validation rejects unknown fields; an abstract result may still
+		// be missing one, which reads as null.
+		return nil, true
+	}
+	if def == typenameMetaField {
+		return t.Name, true
+	}
+
+	args, err := coerceArguments(e.schema, def.Args, field.Arguments, e.vars)
+	if err != nil {
+		e.fieldError(err, fields, path)
+		return nil, !def.Type.NonNull
+	}
+	value, err := e.resolve(ctx, t, def, parent, args)
+	if err != nil {
+		e.fieldError(err, fields, path)
+		return nil, !def.Type.NonNull
+	}
+	return e.completeValue(ctx, def.Type, fields, value, path)
+}
+
+func (e *executor) resolve(ctx context.Context, t *Type, def *FieldDefinition, parent any, args map[string]any) (any, error) {
+	switch def {
+	case schemaMetaField:
+		return schemaIntrospection{s: e.schema}, nil
+	case typeMetaField:
+		name, _ := args["name"].(string)
+		return e.schema.namedIntrospection(name), nil
+	}
+
+	if parent == nil {
+		resolver := e.resolvers[def.Name]
+		if resolver == nil {
+			return nil, fmt.Errorf("No resolver for field %s.%s.", t.Name, def.Name)
+		}
+		if args == nil {
+			args = map[string]any{}
+		}
+		return resolver(ctx, args)
+	}
+	switch parent := parent.(type) {
+	case fieldResolver:
+		return parent.resolveField(ctx, def.Name, args)
+	case map[string]any:
+		return parent[def.Name], nil
+	}
+	return nil, nil
+}
+
+// completeValue shapes a resolved value to the field's type. It reports
+// false when the value is null where the type forbids it; the error has
+// been recorded and the null propagates to the nearest nullable parent.
+func (e *executor) completeValue(ctx context.Context, t *TypeRef, fields []*Field, value any, path []any) (any, bool) {
+	if !t.NonNull {
+		v, ok := e.completeNullable(ctx, t, fields, value, path)
+		if !ok {
+			return nil, true
+		}
+		return v, true
+	}
+	inner := *t
+	inner.NonNull = false
+	v, ok := e.completeNullable(ctx, &inner, fields, value, path)
+	if ok && v == nil {
+		e.fieldError(fmt.Errorf("Cannot return null for non-nullable field %s.", fields[0].Name), fields, path)
+	}
+	return v, ok && v != nil
+}
+
+// completeNullable completes a value of a nullable type. It reports false
+// when the value is null because of an error that has been recorded.
+func (e *executor) completeNullable(ctx context.Context, t *TypeRef, fields []*Field, value any, path []any) (any, bool) {
+	if isNull(value) {
+		return nil, true
+	}
+
+	if t.Elem != nil {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.fieldError(fmt.Errorf("Expected a list for field %s, got %T.", fields[0].Name, value), fields, path)
+			return nil, false
+		}
+		list := make([]any, rv.Len())
+		for i := range list {
+			item, ok := e.completeValue(ctx, t.Elem, fields, rv.Index(i).Interface(), append(path, i))
+			if !ok {
+				return nil, false
+			}
+			list[i] = item
+		}
+		return list, true
+	}
+
+	named := e.schema.Type(t.Name)
+	switch named.Kind {
+	case ScalarKind, EnumKind:
+		v, err := serialize(named, value)
+		if err != nil {
+			e.fieldError(err, fields, path)
+			return nil, false
+		}
+		return v, true
+	}
+
+	object, err := toObject(value)
+	if err != nil {
+		e.fieldError(fmt.Errorf("Expected an object for field %s: %v", fields[0].Name, err), fields, path)
+		return nil, false
+	}
+	runtime := named
+	if named.Kind != ObjectKind {
+		if runtime, err = e.resolveType(named, object); err != nil {
+			e.fieldError(err, fields, path)
+			return nil, false
+		}
+	}
+
+	var set []Selection
+	for _, f := range fields {
+		set = append(set, f.SelectionSet...)
+	}
+	return e.executeSelectionSet(ctx, runtime, set, object, path)
+}
+
+// resolveType picks the object type of an interface or union value: the
+// one named by its __typename, or the only possible one.
+func (e *executor) resolveType(abstract *Type, object any) (*Type, error) {
+	name := ""
+	if m, ok := object.(map[string]any); ok {
+		name, _ = m["__typename"].(string)
+	}
+	if name == "" && len(abstract.PossibleTypes) == 1 {
+		name = abstract.PossibleTypes[0]
+	}
+	t := e.schema.Type(name)
+	if t == nil || t.Kind != ObjectKind || !e.schema.isPossibleType(abstract, name) {
+		return nil, fmt.Errorf("Abstract type %q must resolve to an object type at runtime, got %q.", abstract.Name, name)
+	}
+	return t, nil
+}
+
+func coerceArguments(s *Schema, defs []*InputValue, args []*Argument, vars map[string]any) (map[string]any, error) {
+	coerced := make(map[string]any, len(defs))
+	for _, def := range defs {
+		if arg := argument(args, def.Name); arg != nil {
+			v, provided, err := coerceLiteral(s, arg.Value, def.Type, vars)
+			if err != nil {
+				return nil, err
+			}
+			if provided {
+				coerced[def.Name] = v
+				continue
+			}
+		}
+		switch {
+		case def.Default != nil:
+			v, _, err := coerceLiteral(s, def.Default, def.Type, nil)
+			if err != nil {
+				return nil, err
+			}
+			coerced[def.Name] = v
+		case def.Type.NonNull:
+			return nil, fmt.Errorf("Argument %q of required type %q was not provided.", def.Name, def.Type)
+		}
+	}
+	return coerced, nil
+}
+
+// toObject reads a resolved value as an object: introspection objects
+// and maps are used as they are, anything else through encoding/json.
+func toObject(value any) (any, error) {
+	switch value.(type) {
+	case fieldResolver, map[string]any:
+		return value, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var object map[string]any
+	if err := dec.Decode(&object); err != nil {
+		return nil, fmt.Errorf("%s is not an object", data)
+	}
+	return object, nil
+}
+
+func isNull(value any) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// serialize converts a leaf value to the type's result representation.
+func serialize(t *Type, value any) (any, error) {
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer {
+		value = rv.Elem().Interface()
+	}
+	switch t.Kind {
+	case EnumKind:
+		name, ok := value.(string)
+		if !ok {
+			name = fmt.Sprint(value)
+		}
+		if t.EnumValue(name) == nil {
+			return nil, fmt.Errorf("Enum %q cannot represent value: %s", t.Name, jsonString(value))
+		}
+		return name, nil
+	}
+
+	switch t.Name {
+	case "Int":
+		if f, ok := toFloat(value); ok && f == math.Trunc(f) && math.Abs(f) <= 1<<53 {
+			return int64(f), nil
+		}
+		return nil, fmt.Errorf("Int cannot represent non-integer value: %s", jsonString(value))
+	case "Float":
+		if f, ok := toFloat(value); ok && !math.IsInf(f, 0) && !math.IsNaN(f) {
+			return f, nil
+		}
+		return nil, fmt.Errorf("Float cannot represent non numeric value: %s", jsonString(value))
+	case "String":
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		case fmt.Stringer:
+			return v.String(), nil
+		}
+		if f, ok := toFloat(value); ok {
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+		return nil, fmt.Errorf("String cannot represent value: %s", jsonString(value))
+	case "Boolean":
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("Boolean cannot represent a non boolean value: %s", jsonString(value))
+	case "ID":
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		if f, ok := toFloat(value); ok && f == math.Trunc(f) {
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+		return nil, fmt.Errorf("ID cannot represent value: %s", jsonString(value))
+	}
+	return value, nil
+}
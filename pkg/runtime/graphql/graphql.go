@@ -0,0 +1,138 @@
+// Package graphql executes GraphQL requests against a schema written in
+// SDL, with root fields served by resolver functions.
+//
+// Requests are parsed, validated against the schema and executed as the
+// GraphQL specification describes: arguments come from literals and
+// variables, selection sets pick and alias fields of the resolved values,
+// fragments and the @include and @skip directives apply, and errors carry
+// the location and response path they arose at. Introspection (__schema,
+// __type and __typename) is answered from the schema itself.
+// Subscriptions run through Subscribe, which executes the selection set
+// once for every event of the root field's stream.
+//
+// Resolvers receive their arguments the way encoding/json decodes them:
+// numbers as float64, input objects as map[string]any and lists as
+// []any. Their results may be maps, slices, or any value encoding/json can
+// marshal; fields below the root are read from those results by name.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ResolverFunc resolves a root field from its coerced arguments.
+type ResolverFunc func(ctx context.Context, args map[string]any) (any, error)
+
+// Params is a request to execute.
+type Params struct {
+	Schema        *Schema
+	Query         string
+	OperationName string
+	Variables     map[string]any
+
+	// Resolvers serve the root fields of queries and mutations, by name.
+	Resolvers map[string]ResolverFunc
+	// Subscriptions open the event streams of subscription root fields,
+	// by name. Only Subscribe uses them.
+	Subscriptions map[string]SubscriptionFunc
+
+	// Limits reject operations too deep or complex to run.
+	Limits Limits
+	// ReadOnly rejects operations other than queries, for requests that
+	// must not change state. Only Do uses it.
+	ReadOnly bool
+}
+
+// Result is a response: data for the fields that resolved, and errors.
+// Data is absent when the request failed before execution began.
+type Result struct {
+	Data   any      `json:"data"`
+	Errors []*Error `json:"errors,omitempty"`
+
+	// Cost is the measured size of the operation, once it has passed
+	// validation.
+	Cost *Cost `json:"-"`
+
+	executed bool
+}
+
+// MarshalJSON omits data when execution never started, as the
+// specification requires for parse and validation errors.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	if !r.executed {
+		return json.Marshal(struct {
+			Errors []*Error `json:"errors"`
+		}{r.Errors})
+	}
+	type result Result
+	return json.Marshal((*result)(r))
+}
+
+// Location is a 1-based line and column in a document.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error is a GraphQL error with the locations and path it refers to.
+// Extensions carry machine-readable details, such as an error code.
+type Error struct {
+	Message    string         `json:"message"`
+	Locations  []Location     `json:"locations,omitempty"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Message)
+	if len(e.Locations) > 0 {
+		fmt.Fprintf(&sb, " (%d:%d)", e.Locations[0].Line, e.Locations[0].Column)
+	}
+	return sb.String()
+}
+
+func errorf(locs []Location, format string, args ...any) *Error {
+	return &Error{Message: fmt.Sprintf(format, args...), Locations: locs}
+}
+
+func syntaxError(loc Location, format string, args ...any) *Error {
+	return errorf([]Location{loc}, "Syntax Error: "+format, args...)
+}
+
+// Do parses, validates, measures and executes a request.
+func Do(ctx context.Context, p Params) *Result {
+	doc, err := Parse(p.Query)
+	if err != nil {
+		return &Result{Errors: []*Error{asError(err)}}
+	}
+	if errs := Validate(p.Schema, doc); len(errs) > 0 {
+		return &Result{Errors: errs}
+	}
+	cost, errs := Measure(p.Schema, doc, p.OperationName, p.Variables, p.Limits)
+	if len(errs) > 0 {
+		return &Result{Errors: errs, Cost: cost}
+	}
+	if p.ReadOnly && cost.Operation != Query {
+		return &Result{Errors: []*Error{errorf(nil, "Only queries are allowed here, not a %s.", cost.Operation)}, Cost: cost}
+	}
+	result := Execute(ctx, ExecuteParams{
+		Schema:        p.Schema,
+		Document:      doc,
+		OperationName: p.OperationName,
+		Variables:     p.Variables,
+		Resolvers:     p.Resolvers,
+	})
+	result.Cost = cost
+	return result
+}
+
+func asError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return &Error{Message: err.Error()}
+}
@@ -0,0 +1,409 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const orderSchema = `
+scalar Time
+
+type Query {
+  # Get an order by ID
+  order(id: ID!): Order
+  orders(status: Status = OPEN, first: Int): [Order!]!
+  search(text: String!): [Result!]!
+}
+
+type Mutation {
+  order_submit(input: SubmitInput!): Order!
+}
+
+"""
+An order and its lines.
+"""
+type Order implements Node {
+  id: ID!
+  status: Status!
+  total: Float!
+  lines: [Line!]!
+  placedAt: Time
+  note: String @deprecated(reason: "Use lines")
+}
+
+type Line implements Node {
+  id: ID!
+  sku: String!
+  quantity: Int!
+}
+
+interface Node {
+  id: ID!
+}
+
+union Result = Order | Line
+
+enum Status { OPEN CLOSED }
+
+input SubmitInput {
+  aggregateId: ID!
+  priority: Int = 1
+  tags: [String!]
+}
+`
+
+type line struct {
+	ID       string `json:"id"`
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+type order struct {
+	ID     string  `json:"id"`
+	Status string  `json:"status"`
+	Total  float64 `json:"total"`
+	Lines  []line  `json:"lines"`
+}
+
+func testResolvers(calls *[]map[string]any) map[string]ResolverFunc {
+	orders := map[string]order{
+		"o1": {ID: "o1", Status: "OPEN", Total: 12.5, Lines: []line{{"l1", "apple", 2}, {"l2", "pear", 1}}},
+		"o2": {ID: "o2", Status: "CLOSED", Total: 3, Lines: []line{{"l3", "fig", 3}}},
+	}
+	return map[string]ResolverFunc{
+		"order": func(_ context.Context, args map[string]any) (any, error) {
+			*calls = append(*calls, args)
+			if o, ok := orders[args["id"].(string)]; ok {
+				return o, nil
+			}
+			return nil, errors.New("order not found")
+		},
+		"orders": func(_ context.Context, args map[string]any) (any, error) {
+			*calls = append(*calls, args)
+			var list []order
+			for _, id := range []string{"o1", "o2"} {
+				if orders[id].Status == args["status"] {
+					list = append(list, orders[id])
+				}
+			}
+			return list, nil
+		},
+		"search": func(context.Context, map[string]any) (any, error) {
+			return []any{
+				map[string]any{"__typename": "Order", "id": "o1", "status": "OPEN", "total": 12.5},
+				map[string]any{"__typename": "Line", "id": "l1", "sku": "apple", "quantity": 2},
+			}, nil
+		},
+		"order_submit": func(_ context.Context, args map[string]any) (any, error) {
+			*calls = append(*calls, args)
+			// Breaks the schema: status is non-null.
+			return map[string]any{"id": "o3", "total": 1}, nil
+		},
+	}
+}
+
+func run(t *testing.T, query string, vars map[string]any) (string, []map[string]any) {
+	t.Helper()
+	schema, err := ParseSchema(orderSchema)
+	if err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+	var calls []map[string]any
+	result := Do(context.Background(), Params{
+		Schema:    schema,
+		Query:     query,
+		Variables: vars,
+		Resolvers: testResolvers(&calls),
+	})
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(data), calls
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		vars  map[string]any
+		want  string
+	}{
+		{
+			name:  "selection and aliases",
+			query: `{ first: order(id: "o1") { id total } second: order(id: "o2") { status lines { sku } } }`,
+			want:  `{"data":{"first":{"id":"o1","total":12.5},"second":{"status":"CLOSED","lines":[{"sku":"fig"}]}}}`,
+		},
+		{
+			name: "fragments and variables",
+			query: `query Q($id: ID!, $withLines: Boolean!) {
+				order(id: $id) { ...summary lines @include(if: $withLines) { quantity } }
+			}
+			fragment summary on Order { id ... on Order { status } }`,
+			vars: map[string]any{"id": "o1", "withLines": false},
+			want: `{"data":{"order":{"id":"o1","status":"OPEN"}}}`,
+		},
+		{
+			name:  "skip and default arguments",
+			query: `{ orders { id note @skip(if: true) } closed: orders(status: CLOSED) { id } }`,
+			want:  `{"data":{"orders":[{"id":"o1"}],"closed":[{"id":"o2"}]}}`,
+		},
+		{
+			name:  "abstract types",
+			query: `{ search(text: "a") { __typename ... on Node { id } ... on Line { sku } } }`,
+			want:  `{"data":{"search":[{"__typename":"Order","id":"o1"},{"__typename":"Line","id":"l1","sku":"apple"}]}}`,
+		},
+		{
+			name:  "resolver error on a nullable field",
+			query: `{ order(id: "o9") { id } ok: order(id: "o2") { id } }`,
+			want:  `{"data":{"order":null,"ok":{"id":"o2"}},"errors":[{"message":"order not found","locations":[{"line":1,"column":3}],"path":["order"]}]}`,
+		},
+		{
+			name:  "null propagates to the nearest nullable field",
+			query: `mutation { order_submit(input: {aggregateId: "o3"}) { id status } }`,
+			want:  `{"data":null,"errors":[{"message":"Cannot return null for non-nullable field status.","locations":[{"line":1,"column":58}],"path":["order_submit","status"]}]}`,
+		},
+		{
+			name:  "parse error",
+			query: `{ order(id: "o1") { id }`,
+			want:  `{"errors":[{"message":"Syntax Error: Expected Name, found \u003cEOF\u003e.","locations":[{"line":1,"column":25}]}]}`,
+		},
+		{
+			name:  "validation errors",
+			query: `query { order(id: 1.5) { id price } orders }`,
+			want: `{"errors":[` +
+				`{"message":"Expected value of type \"ID!\", found 1.5.","locations":[{"line":1,"column":19}]},` +
+				`{"message":"Cannot query field \"price\" on type \"Order\".","locations":[{"line":1,"column":29}]},` +
+				`{"message":"Field \"orders\" of type \"[Order!]!\" must have a selection of subfields. Did you mean \"orders { ... }\"?","locations":[{"line":1,"column":37}]}]}`,
+		},
+		{
+			name:  "missing variable",
+			query: `query ($id: ID!) { order(id: $id) { id } }`,
+			want:  `{"errors":[{"message":"Variable \"$id\" of required type \"ID!\" was not provided.","locations":[{"line":1,"column":8}]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := run(t, tt.query, tt.vars)
+			if got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteArguments(t *testing.T) {
+	query := `mutation Submit($tags: [String!]) { order_submit(input: {aggregateId: 7, tags: $tags}) { id } }`
+	_, calls := run(t, query, map[string]any{"tags": "rush"})
+	if len(calls) != 1 {
+		t.Fatalf("calls = %d, want 1", len(calls))
+	}
+	got, _ := json.Marshal(calls[0])
+	want := `{"input":{"aggregateId":"7","priority":1,"tags":["rush"]}}`
+	if string(got) != want {
+		t.Errorf("args = %s, want %s", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema, err := ParseSchema(orderSchema)
+	if err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`{ order(id: "1") { id } } query Q { orders { id } }`, "This anonymous operation must be the only defined operation."},
+		{`{ order(id: "1") { ...a } } fragment a on Order { ...b } fragment b on Order { ...a }`, `Cannot spread fragment "a" within itself.`},
+		{`{ order(id: "1") { id } } fragment unused on Order { id }`, `Fragment "unused" is never used.`},
+		{`{ order(id: "1") { ... on Line { sku } } }`, `Fragment cannot be spread here as objects of type "Order" can never be of type "Line".`},
+		{`query ($s: String) { orders(status: $s) { id } }`, `Variable "$s" of type "String" used in position expecting type "Status".`},
+		{`query ($unused: Int) { orders { id } }`, `Variable "$unused" is never used in operation anonymous.`},
+		{`{ orders(first: $n) { id } }`, `Variable "$n" is not defined by operation anonymous.`},
+		{`{ order { id } }`, `Field "order" argument "id" of type "ID!" is required, but it was not provided.`},
+		{`{ order(id: "1") { id @skip } }`, `Directive "@skip" argument "if" of type "Boolean!" is required, but it was not provided.`},
+		{`{ order(id: "1") { id @live } }`, `Unknown directive "@live".`},
+		{`{ order(id: "1") { id: total id } }`, `Fields "id" conflict because "total" and "id" are different fields. Use different aliases on the fields to fetch both if this was intentional.`},
+		{`{ order(id: "1") { id(x: 1) } }`, `Unknown argument "x" on field "Order.id".`},
+		{`{ orders(status: PENDING) { id } }`, `Expected value of type "Status", found PENDING.`},
+		{`query ($a: ID) { nope(x: $a) }`, `Cannot query field "nope" on type "Query".`},
+		{`query ($a: ID) { order(id: "1") { id(x: $a) } }`, `Unknown argument "x" on field "Order.id".`},
+	}
+	for _, tt := range tests {
+		doc, err := Parse(tt.query)
+		if err != nil {
+			t.Fatalf("parse %s: %v", tt.query, err)
+		}
+		errs := Validate(schema, doc)
+		var messages []string
+		for _, e := range errs {
+			messages = append(messages, e.Message)
+		}
+		if len(errs) != 1 || errs[0].Message != tt.want {
+			t.Errorf("%s:\n got  %q\n want %q", tt.query, messages, tt.want)
+		}
+	}
+}
+
+func TestIntrospection(t *testing.T) {
+	got, _ := run(t, `{
+		__schema { queryType { name } mutationType { name } subscriptionType { name } }
+		status: __type(name: "Status") { kind enumValues { name } }
+		order: __type(name: "Order") {
+			fields(includeDeprecated: true) { name isDeprecated type { kind ofType { name kind } } }
+			interfaces { name }
+		}
+		input: __type(name: "SubmitInput") { inputFields { name defaultValue } }
+	}`, nil)
+	want := `{"data":{` +
+		`"__schema":{"queryType":{"name":"Query"},"mutationType":{"name":"Mutation"},"subscriptionType":null},` +
+		`"status":{"kind":"ENUM","enumValues":[{"name":"OPEN"},{"name":"CLOSED"}]},` +
+		`"order":{"fields":[` +
+		`{"name":"id","isDeprecated":false,"type":{"kind":"NON_NULL","ofType":{"name":"ID","kind":"SCALAR"}}},` +
+		`{"name":"status","isDeprecated":false,"type":{"kind":"NON_NULL","ofType":{"name":"Status","kind":"ENUM"}}},` +
+		`{"name":"total","isDeprecated":false,"type":{"kind":"NON_NULL","ofType":{"name":"Float","kind":"SCALAR"}}},` +
+		`{"name":"lines","isDeprecated":false,"type":{"kind":"NON_NULL","ofType":{"name":null,"kind":"LIST"}}},` +
+		`{"name":"placedAt","isDeprecated":false,"type":{"kind":"SCALAR","ofType":null}},` +
+		`{"name":"note","isDeprecated":true,"type":{"kind":"SCALAR","ofType":null}}],` +
+		`"interfaces":[{"name":"Node"}]},` +
+		`"input":{"inputFields":[{"name":"aggregateId","defaultValue":null},{"name":"priority","defaultValue":"1"},{"name":"tags","defaultValue":null}]}}}`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestParseSchemaLenient(t *testing.T) {
+	// Combined schemas can reference types that were never defined.
+	schema, err := ParseSchema("type Query { item: Item\n  kind: Kind! }\ntype Item { kind: Kind }")
+	if err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+	if k := schema.Type("Kind"); k == nil || k.Kind != ScalarKind {
+		t.Fatalf("Kind = %+v, want a custom scalar", k)
+	}
+
+	if _, err := ParseSchema("type Query { a: Int }\ntype Query { b: Int }"); err == nil || !strings.Contains(err.Error(), "only one type") {
+		t.Errorf("duplicate type err = %v", err)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	schema, err := ParseSchema(orderSchema + "type Subscription { orderUpdated(id: ID!): Order! }")
+	if err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan any, 3)
+	var gotArgs map[string]any
+	params := Params{
+		Schema:    schema,
+		Query:     `subscription ($id: ID!) { updated: orderUpdated(id: $id) { id status } }`,
+		Variables: map[string]any{"id": "o1"},
+		Subscriptions: map[string]SubscriptionFunc{
+			"orderUpdated": func(_ context.Context, args map[string]any) (<-chan any, error) {
+				gotArgs = args
+				return events, nil
+			},
+		},
+	}
+	results, failed := Subscribe(ctx, params)
+	if failed != nil {
+		t.Fatalf("subscribe: %+v", failed.Errors)
+	}
+	if gotArgs["id"] != "o1" {
+		t.Errorf("args = %v", gotArgs)
+	}
+	events <- order{ID: "o1", Status: "OPEN"}
+	events <- map[string]any{"id": "o1"}
+	events <- errors.New("stream failed")
+	close(events)
+
+	want := []string{
+		`{"data":{"updated":{"id":"o1","status":"OPEN"}}}`,
+		`{"data":null,"errors":[{"message":"Cannot return null for non-nullable field status.","locations":[{"line":1,"column":63}],"path":["updated","status"]}]}`,
+		`{"data":null,"errors":[{"message":"stream failed","locations":[{"line":1,"column":27}],"path":["updated"]}]}`,
+	}
+	var got []string
+	for result := range results {
+		data, _ := json.Marshal(result)
+		got = append(got, string(data))
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	params.Query = `subscription { orderUpdated(id: "o1") { id } __typename }`
+	if _, failed := Subscribe(ctx, params); failed == nil || len(failed.Errors) != 2 {
+		t.Errorf("expected two validation errors, got %+v", failed)
+	}
+	params.Query = `{ order(id: "o2") { id } }`
+	params.Resolvers = testResolvers(new([]map[string]any))
+	results, _ = Subscribe(ctx, params)
+	if data, _ := json.Marshal(<-results); string(data) != `{"data":{"order":{"id":"o2"}}}` {
+		t.Errorf("query over subscribe = %s", data)
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	schema, err := ParseSchema(orderSchema)
+	if err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+	limits := Limits{FieldCosts: map[string]int{"Query.search": 50}}
+	tests := []struct {
+		query      string
+		vars       map[string]any
+		depth      int
+		complexity int
+	}{
+		// order 1 + id 1 + lines (1 + 10 × sku 1)
+		{`{ order(id: "1") { id lines { sku } } }`, nil, 3, 13},
+		// orders (1 + 2 × (id 1 + lines (1 + 10 × 2)))
+		{`{ orders(first: 2) { id lines { sku quantity } } }`, nil, 3, 45},
+		{`query ($n: Int) { orders(first: $n) { id } }`, map[string]any{"n": float64(5)}, 2, 6},
+		{`{ order(id: "1") { ...f } } fragment f on Order { id total }`, nil, 2, 3},
+		{`{ __typename order(id: "1") { __typename id } }`, nil, 2, 2},
+		{`{ search(text: "a") { ... on Line { sku } } }`, nil, 2, 60},
+	}
+	for _, tt := range tests {
+		doc, err := Parse(tt.query)
+		if err != nil {
+			t.Fatalf("parse %s: %v", tt.query, err)
+		}
+		cost, errs := Measure(schema, doc, "", tt.vars, limits)
+		if len(errs) > 0 {
+			t.Fatalf("%s: %v", tt.query, errs)
+		}
+		if cost.Depth != tt.depth || cost.Complexity != tt.complexity {
+			t.Errorf("%s: depth %d complexity %d, want %d and %d", tt.query, cost.Depth, cost.Complexity, tt.depth, tt.complexity)
+		}
+	}
+
+	// Over the limits, Do reports both and runs nothing
+	var calls []map[string]any
+	result := Do(context.Background(), Params{
+		Schema:    schema,
+		Query:     `query Deep { order(id: "1") { lines { sku } } }`,
+		Resolvers: testResolvers(&calls),
+		Limits:    Limits{MaxDepth: 2, MaxComplexity: 10},
+	})
+	data, _ := json.Marshal(result)
+	want := `{"errors":[` +
+		`{"message":"Operation \"Deep\" is 3 levels deep, more than the maximum of 2.","locations":[{"line":1,"column":1}]},` +
+		`{"message":"Operation \"Deep\" has complexity 12, more than the maximum of 10.","locations":[{"line":1,"column":1}]}]}`
+	if string(data) != want {
+		t.Errorf("got  %s\nwant %s", data, want)
+	}
+	if len(calls) != 0 {
+		t.Errorf("resolvers called %d times", len(calls))
+	}
+	if result.Cost == nil || result.Cost.Operation != Query || strings.Join(result.Cost.Fields, ",") != "order" {
+		t.Errorf("cost = %+v", result.Cost)
+	}
+}
@@ -0,0 +1,360 @@
+package graphql
+
+import "context"
+
+// introspectionSDL defines the types introspection queries select from.
+const introspectionSDL = `
+type __Schema {
+  description: String
+  types: [__Type!]!
+  queryType: __Type!
+  mutationType: __Type
+  subscriptionType: __Type
+  directives: [__Directive!]!
+}
+
+type __Type {
+  kind: __TypeKind!
+  name: String
+  description: String
+  specifiedByURL: String
+  fields(includeDeprecated: Boolean = false): [__Field!]
+  interfaces: [__Type!]
+  possibleTypes: [__Type!]
+  enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
+  inputFields(includeDeprecated: Boolean = false): [__InputValue!]
+  ofType: __Type
+}
+
+type __Field {
+  name: String!
+  description: String
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
+  type: __Type!
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+
+type __InputValue {
+  name: String!
+  description: String
+  type: __Type!
+  defaultValue: String
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+
+type __EnumValue {
+  name: String!
+  description: String
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+
+type __Directive {
+  name: String!
+  description: String
+  locations: [__DirectiveLocation!]!
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
+  isRepeatable: Boolean!
+}
+
+enum __TypeKind {
+  SCALAR
+  OBJECT
+  INTERFACE
+  UNION
+  ENUM
+  INPUT_OBJECT
+  LIST
+  NON_NULL
+}
+
+enum __DirectiveLocation {
+  QUERY
+  MUTATION
+  SUBSCRIPTION
+  FIELD
+  FRAGMENT_DEFINITION
+  FRAGMENT_SPREAD
+  INLINE_FRAGMENT
+  VARIABLE_DEFINITION
+  SCHEMA
+  SCALAR
+  OBJECT
+  FIELD_DEFINITION
+  ARGUMENT_DEFINITION
+  INTERFACE
+  UNION
+  ENUM
+  ENUM_VALUE
+  INPUT_OBJECT
+  INPUT_FIELD_DEFINITION
+}
+`
+
+var introspectionSchema = mustParseSDL(introspectionSDL)
+
+func mustParseSDL(sdl string) *Schema {
+	s, err := parseSDL(sdl)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Meta fields every schema answers without resolvers.
+var (
+	schemaMetaField = &FieldDefinition{
+		Name:        "__schema",
+		Description: "Access the current type schema of this server.",
+		Type:        &TypeRef{Name: "__Schema", NonNull: true},
+	}
+	typeMetaField = &FieldDefinition{
+		Name:        "__type",
+		Description: "Request the type information of a single type.",
+		Args:        []*InputValue{{Name: "name", Type: &TypeRef{Name: "String", NonNull: true}}},
+		Type:        &TypeRef{Name: "__Type"},
+	}
+	typenameMetaField = &FieldDefinition{
+		Name:        "__typename",
+		Description: "The name of the current Object type at runtime.",
+		Type:        &TypeRef{Name: "String", NonNull: true},
+	}
+)
+
+// fieldDefinition looks up a field of a composite type, including the
+// meta fields available on it.
+func (s *Schema) fieldDefinition(t *Type, name string) *FieldDefinition {
+	switch {
+	case name == typenameMetaField.Name:
+		return typenameMetaField
+	case name == schemaMetaField.Name && t.Name == s.QueryType:
+		return schemaMetaField
+	case name == typeMetaField.Name && t.Name == s.QueryType:
+		return typeMetaField
+	}
+	return t.Field(name)
+}
+
+// fieldResolver is implemented by values whose fields are computed, such
+// as the introspection objects, rather than read from a map or struct.
+type fieldResolver interface {
+	resolveField(ctx context.Context, name string, args map[string]any) (any, error)
+}
+
+type schemaIntrospection struct{ s *Schema }
+
+func (i schemaIntrospection) resolveField(_ context.Context, name string, _ map[string]any) (any, error) {
+	s := i.s
+	switch name {
+	case "types":
+		types := make([]any, 0, len(s.Types))
+		for _, name := range s.TypeNames() {
+			types = append(types, typeIntrospection{s: s, named: s.Types[name]})
+		}
+		return types, nil
+	case "queryType":
+		return s.namedIntrospection(s.QueryType), nil
+	case "mutationType":
+		return s.namedIntrospection(s.MutationType), nil
+	case "subscriptionType":
+		return s.namedIntrospection(s.SubscriptionType), nil
+	case "directives":
+		list := make([]any, len(directives))
+		for i, d := range directives {
+			list[i] = directiveIntrospection{s: s, d: d}
+		}
+		return list, nil
+	}
+	return nil, nil
+}
+
+func (s *Schema) namedIntrospection(name string) any {
+	if t := s.Types[name]; t != nil {
+		return typeIntrospection{s: s, named: t}
+	}
+	return nil
+}
+
+// typeIntrospection describes a named type, or a list or non-null
+// wrapper when ref is set.
+type typeIntrospection struct {
+	s     *Schema
+	named *Type
+	ref   *TypeRef
+}
+
+func (s *Schema) refIntrospection(ref *TypeRef) typeIntrospection {
+	if ref.Elem == nil && !ref.NonNull {
+		return typeIntrospection{s: s, named: s.Types[ref.Name]}
+	}
+	return typeIntrospection{s: s, ref: ref}
+}
+
+func (i typeIntrospection) resolveField(_ context.Context, name string, args map[string]any) (any, error) {
+	s := i.s
+	if ref := i.ref; ref != nil {
+		switch name {
+		case "kind":
+			if ref.NonNull {
+				return "NON_NULL", nil
+			}
+			return "LIST", nil
+		case "ofType":
+			if ref.NonNull {
+				inner := *ref
+				inner.NonNull = false
+				return s.refIntrospection(&inner), nil
+			}
+			return s.refIntrospection(ref.Elem), nil
+		}
+		return nil, nil
+	}
+
+	t := i.named
+	includeDeprecated, _ := args["includeDeprecated"].(bool)
+	switch name {
+	case "kind":
+		return string(t.Kind), nil
+	case "name":
+		return t.Name, nil
+	case "description":
+		return optional(t.Description), nil
+	case "fields":
+		if t.Kind != ObjectKind && t.Kind != InterfaceKind {
+			return nil, nil
+		}
+		fields := []any{}
+		for _, f := range t.Fields {
+			if includeDeprecated || !f.Deprecated {
+				fields = append(fields, fieldIntrospection{s: s, f: f})
+			}
+		}
+		return fields, nil
+	case "interfaces":
+		if t.Kind != ObjectKind && t.Kind != InterfaceKind {
+			return nil, nil
+		}
+		return s.namedList(t.Interfaces), nil
+	case "possibleTypes":
+		if t.Kind != InterfaceKind && t.Kind != UnionKind {
+			return nil, nil
+		}
+		return s.namedList(t.PossibleTypes), nil
+	case "enumValues":
+		if t.Kind != EnumKind {
+			return nil, nil
+		}
+		values := []any{}
+		for _, v := range t.EnumValues {
+			if includeDeprecated || !v.Deprecated {
+				values = append(values, map[string]any{
+					"name":              v.Name,
+					"description":       optional(v.Description),
+					"isDeprecated":      v.Deprecated,
+					"deprecationReason": optional(v.DeprecationReason),
+				})
+			}
+		}
+		return values, nil
+	case "inputFields":
+		if t.Kind != InputObjectKind {
+			return nil, nil
+		}
+		return s.inputValueList(t.InputFields), nil
+	}
+	return nil, nil
+}
+
+func (s *Schema) namedList(names []string) []any {
+	list := make([]any, 0, len(names))
+	for _, name := range names {
+		list = append(list, s.namedIntrospection(name))
+	}
+	return list
+}
+
+func (s *Schema) inputValueList(values []*InputValue) []any {
+	list := make([]any, len(values))
+	for i, v := range values {
+		list[i] = inputValueIntrospection{s: s, v: v}
+	}
+	return list
+}
+
+type fieldIntrospection struct {
+	s *Schema
+	f *FieldDefinition
+}
+
+func (i fieldIntrospection) resolveField(_ context.Context, name string, _ map[string]any) (any, error) {
+	switch name {
+	case "name":
+		return i.f.Name, nil
+	case "description":
+		return optional(i.f.Description), nil
+	case "args":
+		return i.s.inputValueList(i.f.Args), nil
+	case "type":
+		return i.s.refIntrospection(i.f.Type), nil
+	case "isDeprecated":
+		return i.f.Deprecated, nil
+	case "deprecationReason":
+		return optional(i.f.DeprecationReason), nil
+	}
+	return nil, nil
+}
+
+type inputValueIntrospection struct {
+	s *Schema
+	v *InputValue
+}
+
+func (i inputValueIntrospection) resolveField(_ context.Context, name string, _ map[string]any) (any, error) {
+	switch name {
+	case "name":
+		return i.v.Name, nil
+	case "description":
+		return optional(i.v.Description), nil
+	case "type":
+		return i.s.refIntrospection(i.v.Type), nil
+	case "defaultValue":
+		if i.v.Default == nil {
+			return nil, nil
+		}
+		return i.v.Default.String(), nil
+	case "isDeprecated":
+		return false, nil
+	}
+	return nil, nil
+}
+
+type directiveIntrospection struct {
+	s *Schema
+	d *directiveDefinition
+}
+
+func (i directiveIntrospection) resolveField(_ context.Context, name string, _ map[string]any) (any, error) {
+	switch name {
+	case "name":
+		return i.d.Name, nil
+	case "description":
+		return optional(i.d.Description), nil
+	case "locations":
+		return i.d.Locations, nil
+	case "args":
+		return i.s.inputValueList(i.d.Args), nil
+	case "isRepeatable":
+		return false, nil
+	}
+	return nil, nil
+}
+
+// optional maps an empty string to null.
+func optional(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
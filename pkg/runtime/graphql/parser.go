@@ -0,0 +1,422 @@
+package graphql
+
+import "fmt"
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func newParser(src string) (*parser, error) {
+	p := &parser{lex: newLexer(src)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) peek(punct string) bool {
+	return p.tok.kind == tokPunct && p.tok.value == punct
+}
+
+func (p *parser) peekName(name string) bool {
+	return p.tok.kind == tokName && p.tok.value == name
+}
+
+// skip consumes the punctuator if it is next.
+func (p *parser) skip(punct string) (bool, error) {
+	if !p.peek(punct) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.peek(punct) {
+		return p.unexpected(fmt.Sprintf("Expected %q", punct))
+	}
+	return p.advance()
+}
+
+func (p *parser) expectKeyword(name string) error {
+	if !p.peekName(name) {
+		return p.unexpected(fmt.Sprintf("Expected %q", name))
+	}
+	return p.advance()
+}
+
+func (p *parser) name() (string, Location, error) {
+	if p.tok.kind != tokName {
+		return "", p.tok.loc, p.unexpected("Expected Name")
+	}
+	tok := p.tok
+	return tok.value, tok.loc, p.advance()
+}
+
+func (p *parser) unexpected(expected string) error {
+	return syntaxError(p.tok.loc, "%s, found %s.", expected, p.tok)
+}
+
+// Parse parses an executable document: operations and fragments.
+func Parse(src string) (*Document, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	doc := &Document{}
+	if p.tok.kind == tokEOF {
+		return nil, p.unexpected("Expected an operation or fragment")
+	}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.peek("{"):
+			loc := p.tok.loc
+			set, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: Query, SelectionSet: set, Loc: loc})
+		case p.peekName("query"), p.peekName("mutation"), p.peekName("subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.peekName("fragment"):
+			f, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			doc.Fragments = append(doc.Fragments, f)
+		default:
+			return nil, p.unexpected("Expected an operation or fragment")
+		}
+	}
+	return doc, nil
+}
+
+func (p *parser) operation() (*Operation, error) {
+	op := &Operation{Type: OperationType(p.tok.value), Loc: p.tok.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var err error
+	if p.tok.kind == tokName {
+		if op.Name, _, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if op.Variables, err = p.variableDefinitions(); err != nil {
+		return nil, err
+	}
+	if op.Directives, err = p.directives(false); err != nil {
+		return nil, err
+	}
+	if op.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) variableDefinitions() ([]*VariableDefinition, error) {
+	if ok, err := p.skip("("); !ok || err != nil {
+		return nil, err
+	}
+	var defs []*VariableDefinition
+	for {
+		loc := p.tok.loc
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, _, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		typ, err := p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		def := &VariableDefinition{Name: name, Type: typ, Loc: loc}
+		if ok, err := p.skip("="); err != nil {
+			return nil, err
+		} else if ok {
+			if def.Default, err = p.value(true); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.directives(true); err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+		if ok, err := p.skip(")"); ok || err != nil {
+			return defs, err
+		}
+	}
+}
+
+func (p *parser) fragment() (*Fragment, error) {
+	f := &Fragment{Loc: p.tok.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var err error
+	if p.peekName("on") {
+		return nil, p.unexpected("Expected a fragment name")
+	}
+	if f.Name, _, err = p.name(); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("on"); err != nil {
+		return nil, err
+	}
+	if f.TypeCondition, _, err = p.name(); err != nil {
+		return nil, err
+	}
+	if f.Directives, err = p.directives(false); err != nil {
+		return nil, err
+	}
+	if f.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (p *parser) selectionSet() ([]Selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var set []Selection
+	for {
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, sel)
+		if ok, err := p.skip("}"); ok || err != nil {
+			return set, err
+		}
+	}
+}
+
+func (p *parser) selection() (Selection, error) {
+	if p.peek("...") {
+		loc := p.tok.loc
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokName && !p.peekName("on") {
+			spread := &FragmentSpread{Loc: loc}
+			var err error
+			if spread.Name, _, err = p.name(); err != nil {
+				return nil, err
+			}
+			if spread.Directives, err = p.directives(false); err != nil {
+				return nil, err
+			}
+			return spread, nil
+		}
+		inline := &InlineFragment{Loc: loc}
+		var err error
+		if p.peekName("on") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if inline.TypeCondition, _, err = p.name(); err != nil {
+				return nil, err
+			}
+		}
+		if inline.Directives, err = p.directives(false); err != nil {
+			return nil, err
+		}
+		if inline.SelectionSet, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+		return inline, nil
+	}
+
+	field := &Field{}
+	var err error
+	if field.Name, field.Loc, err = p.name(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		field.Alias = field.Name
+		if field.Name, _, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if field.Arguments, err = p.arguments(false); err != nil {
+		return nil, err
+	}
+	if field.Directives, err = p.directives(false); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		if field.SelectionSet, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) arguments(constant bool) ([]*Argument, error) {
+	if ok, err := p.skip("("); !ok || err != nil {
+		return nil, err
+	}
+	var args []*Argument
+	for {
+		name, loc, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.value(constant)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, &Argument{Name: name, Value: value, Loc: loc})
+		if ok, err := p.skip(")"); ok || err != nil {
+			return args, err
+		}
+	}
+}
+
+func (p *parser) directives(constant bool) ([]*Directive, error) {
+	var dirs []*Directive
+	for p.peek("@") {
+		loc := p.tok.loc
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, _, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.arguments(constant)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, &Directive{Name: name, Arguments: args, Loc: loc})
+	}
+	return dirs, nil
+}
+
+func (p *parser) value(constant bool) (*Value, error) {
+	tok := p.tok
+	v := &Value{Loc: tok.loc, Raw: tok.value}
+	switch tok.kind {
+	case tokInt:
+		v.Kind = IntValue
+	case tokFloat:
+		v.Kind = FloatValue
+	case tokString, tokBlockString:
+		v.Kind = StringValue
+	case tokName:
+		switch tok.value {
+		case "true", "false":
+			v.Kind = BooleanValue
+		case "null":
+			v.Kind = NullValue
+		default:
+			v.Kind = EnumValue
+		}
+	case tokPunct:
+		switch tok.value {
+		case "$":
+			if constant {
+				return nil, p.unexpected("Unexpected variable in a constant value")
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, _, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			return &Value{Kind: VariableValue, Raw: name, Loc: tok.loc}, nil
+		case "[":
+			v.Kind = ListValue
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			for {
+				if ok, err := p.skip("]"); ok || err != nil {
+					return v, err
+				}
+				item, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				v.List = append(v.List, item)
+			}
+		case "{":
+			v.Kind = ObjectValue
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			for {
+				if ok, err := p.skip("}"); ok || err != nil {
+					return v, err
+				}
+				name, loc, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				fv, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				v.Fields = append(v.Fields, &ObjectField{Name: name, Value: fv, Loc: loc})
+			}
+		default:
+			return nil, p.unexpected("Expected a value")
+		}
+	default:
+		return nil, p.unexpected("Expected a value")
+	}
+	return v, p.advance()
+}
+
+func (p *parser) typeRef() (*TypeRef, error) {
+	var t *TypeRef
+	if ok, err := p.skip("["); err != nil {
+		return nil, err
+	} else if ok {
+		elem, err := p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		t = &TypeRef{Elem: elem}
+	} else {
+		name, _, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		t = &TypeRef{Name: name}
+	}
+	nonNull, err := p.skip("!")
+	t.NonNull = nonNull
+	return t, err
+}
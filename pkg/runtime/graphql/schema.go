@@ -0,0 +1,319 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TypeKind is the kind of a named type, as introspection reports it.
+type TypeKind string
+
+const (
+	ScalarKind      TypeKind = "SCALAR"
+	ObjectKind      TypeKind = "OBJECT"
+	InterfaceKind   TypeKind = "INTERFACE"
+	UnionKind       TypeKind = "UNION"
+	EnumKind        TypeKind = "ENUM"
+	InputObjectKind TypeKind = "INPUT_OBJECT"
+)
+
+// Type is a named type of a schema.
+type Type struct {
+	Kind        TypeKind
+	Name        string
+	Description string
+
+	// Fields of an object or interface, in definition order.
+	Fields []*FieldDefinition
+	// Interfaces an object or interface implements.
+	Interfaces []string
+	// PossibleTypes are a union's members, or the objects implementing an
+	// interface.
+	PossibleTypes []string
+	EnumValues    []*EnumValueDefinition
+	InputFields   []*InputValue
+}
+
+// Field returns the field with the given name, or nil.
+func (t *Type) Field(name string) *FieldDefinition {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// InputField returns the input field with the given name, or nil.
+func (t *Type) InputField(name string) *InputValue {
+	for _, f := range t.InputFields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// EnumValue returns the enum value with the given name, or nil.
+func (t *Type) EnumValue(name string) *EnumValueDefinition {
+	for _, v := range t.EnumValues {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// isComposite reports whether selections can be made on the type.
+func (t *Type) isComposite() bool {
+	return t.Kind == ObjectKind || t.Kind == InterfaceKind || t.Kind == UnionKind
+}
+
+func (t *Type) isInput() bool {
+	return t.Kind == ScalarKind || t.Kind == EnumKind || t.Kind == InputObjectKind
+}
+
+func (t *Type) isOutput() bool {
+	return t.Kind != InputObjectKind
+}
+
+// FieldDefinition is a field of an object or interface type.
+type FieldDefinition struct {
+	Name              string
+	Description       string
+	Args              []*InputValue
+	Type              *TypeRef
+	Deprecated        bool
+	DeprecationReason string
+}
+
+// Arg returns the argument with the given name, or nil.
+func (f *FieldDefinition) Arg(name string) *InputValue {
+	for _, a := range f.Args {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// InputValue is an argument or an input object field.
+type InputValue struct {
+	Name        string
+	Description string
+	Type        *TypeRef
+	Default     *Value
+}
+
+// EnumValueDefinition is a value of an enum type.
+type EnumValueDefinition struct {
+	Name              string
+	Description       string
+	Deprecated        bool
+	DeprecationReason string
+}
+
+// Schema is a set of named types and the root operation types.
+type Schema struct {
+	Types map[string]*Type
+
+	QueryType        string
+	MutationType     string
+	SubscriptionType string
+}
+
+// Type returns the named type, or nil.
+func (s *Schema) Type(name string) *Type {
+	return s.Types[name]
+}
+
+// RootType returns the root type of an operation type, or nil if the
+// schema does not support it.
+func (s *Schema) RootType(op OperationType) *Type {
+	var name string
+	switch op {
+	case Query:
+		name = s.QueryType
+	case Mutation:
+		name = s.MutationType
+	case Subscription:
+		name = s.SubscriptionType
+	}
+	if name == "" {
+		return nil
+	}
+	return s.Types[name]
+}
+
+// TypeNames returns the names of all types, sorted.
+func (s *Schema) TypeNames() []string {
+	names := make([]string, 0, len(s.Types))
+	for name := range s.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isPossibleType reports whether the object type can be returned where
+// the given type is expected.
+func (s *Schema) isPossibleType(t *Type, object string) bool {
+	if t.Kind == ObjectKind {
+		return t.Name == object
+	}
+	for _, name := range t.PossibleTypes {
+		if name == object {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps reports whether two composite types share a possible object.
+func (s *Schema) overlaps(a, b *Type) bool {
+	for _, name := range s.possibleTypes(a) {
+		if s.isPossibleType(b, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) possibleTypes(t *Type) []string {
+	if t.Kind == ObjectKind {
+		return []string{t.Name}
+	}
+	return t.PossibleTypes
+}
+
+var builtinScalars = []string{"Int", "Float", "String", "Boolean", "ID"}
+
+// finish completes a parsed schema: it adds the built-in scalars and the
+// introspection types, declares types that are referenced but never
+// defined as custom scalars, resolves the root types and the possible
+// types of interfaces, and checks that the types fit together.
+func (s *Schema) finish() error {
+	for _, name := range builtinScalars {
+		if s.Types[name] == nil {
+			s.Types[name] = &Type{Kind: ScalarKind, Name: name}
+		}
+	}
+	for name, t := range introspectionSchema.Types {
+		if s.Types[name] == nil {
+			s.Types[name] = t
+		}
+	}
+
+	// Combined schemas may reference types a service never defines, such
+	// as enums dropped when the schemas were merged; treat them as custom
+	// scalars rather than rejecting the schema.
+	declare := func(ref *TypeRef) {
+		if name := ref.NamedType(); s.Types[name] == nil {
+			s.Types[name] = &Type{Kind: ScalarKind, Name: name}
+		}
+	}
+	for _, t := range s.Types {
+		for _, f := range t.Fields {
+			declare(f.Type)
+			for _, a := range f.Args {
+				declare(a.Type)
+			}
+		}
+		for _, f := range t.InputFields {
+			declare(f.Type)
+		}
+	}
+
+	if s.QueryType == "" && s.Types["Query"] != nil {
+		s.QueryType = "Query"
+	}
+	if s.MutationType == "" && s.Types["Mutation"] != nil {
+		s.MutationType = "Mutation"
+	}
+	if s.SubscriptionType == "" && s.Types["Subscription"] != nil {
+		s.SubscriptionType = "Subscription"
+	}
+	if s.QueryType == "" {
+		return fmt.Errorf("schema has no query type")
+	}
+	for _, name := range []string{s.QueryType, s.MutationType, s.SubscriptionType} {
+		if name == "" {
+			continue
+		}
+		if t := s.Types[name]; t == nil || t.Kind != ObjectKind {
+			return fmt.Errorf("root type %s must be a defined object type", name)
+		}
+	}
+
+	for _, name := range s.TypeNames() {
+		t := s.Types[name]
+		for _, iface := range t.Interfaces {
+			it := s.Types[iface]
+			if it == nil || it.Kind != InterfaceKind {
+				return fmt.Errorf("type %s implements %s, which is not an interface", name, iface)
+			}
+			if t.Kind == ObjectKind {
+				it.PossibleTypes = append(it.PossibleTypes, name)
+			}
+		}
+		for _, member := range t.PossibleTypes {
+			if t.Kind != UnionKind {
+				break
+			}
+			if mt := s.Types[member]; mt == nil || mt.Kind != ObjectKind {
+				return fmt.Errorf("union %s member %s must be an object type", name, member)
+			}
+		}
+		for _, f := range t.Fields {
+			if !s.Types[f.Type.NamedType()].isOutput() {
+				return fmt.Errorf("field %s.%s has input type %s", name, f.Name, f.Type)
+			}
+			for _, a := range f.Args {
+				if !s.Types[a.Type.NamedType()].isInput() {
+					return fmt.Errorf("argument %s.%s(%s) has output type %s", name, f.Name, a.Name, a.Type)
+				}
+			}
+		}
+		for _, f := range t.InputFields {
+			if !s.Types[f.Type.NamedType()].isInput() {
+				return fmt.Errorf("input field %s.%s has output type %s", name, f.Name, f.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// directiveDefinition is a directive executable documents may use.
+type directiveDefinition struct {
+	Name        string
+	Description string
+	Locations   []string
+	Args        []*InputValue
+}
+
+var booleanNonNull = &TypeRef{Name: "Boolean", NonNull: true}
+
+// directives are the directives the executor understands.
+var directives = []*directiveDefinition{
+	{
+		Name:        "include",
+		Description: "Directs the executor to include this field or fragment only when the `if` argument is true.",
+		Locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		Args:        []*InputValue{{Name: "if", Description: "Included when true.", Type: booleanNonNull}},
+	},
+	{
+		Name:        "skip",
+		Description: "Directs the executor to skip this field or fragment when the `if` argument is true.",
+		Locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		Args:        []*InputValue{{Name: "if", Description: "Skipped when true.", Type: booleanNonNull}},
+	},
+}
+
+func directiveNamed(name string) *directiveDefinition {
+	for _, d := range directives {
+		if d.Name == name {
+			return d
+		}
+	}
+	return nil
+}
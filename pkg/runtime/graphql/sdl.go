@@ -0,0 +1,376 @@
+package graphql
+
+// ParseSchema parses a schema written in the GraphQL schema definition
+// language. Directive definitions are accepted and ignored; @deprecated
+// is honored on fields and enum values. Types that are referenced but not
+// defined become custom scalars, whose values pass through unchanged.
+func ParseSchema(sdl string) (*Schema, error) {
+	s, err := parseSDL(sdl)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.finish(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func parseSDL(sdl string) (*Schema, error) {
+	p, err := newParser(sdl)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{Types: make(map[string]*Type)}
+	for p.tok.kind != tokEOF {
+		desc, err := p.description()
+		if err != nil {
+			return nil, err
+		}
+		extend := false
+		if p.peekName("extend") {
+			extend = true
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		keyword, loc, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		var t *Type
+		switch keyword {
+		case "schema":
+			if err := p.schemaDefinition(s); err != nil {
+				return nil, err
+			}
+			continue
+		case "directive":
+			if err := p.directiveDefinition(); err != nil {
+				return nil, err
+			}
+			continue
+		case "scalar":
+			t, err = p.typeHeader(ScalarKind)
+		case "type":
+			t, err = p.objectDefinition(ObjectKind)
+		case "interface":
+			t, err = p.objectDefinition(InterfaceKind)
+		case "union":
+			t, err = p.unionDefinition()
+		case "enum":
+			t, err = p.enumDefinition()
+		case "input":
+			t, err = p.inputDefinition()
+		default:
+			return nil, syntaxError(loc, "Unexpected %q.", keyword)
+		}
+		if err != nil {
+			return nil, err
+		}
+		t.Description = desc
+
+		existing := s.Types[t.Name]
+		switch {
+		case extend && existing == nil:
+			return nil, errorf([]Location{loc}, "Cannot extend undefined type %q.", t.Name)
+		case extend && existing.Kind != t.Kind:
+			return nil, errorf([]Location{loc}, "Cannot extend %s %q as %s.", existing.Kind, t.Name, t.Kind)
+		case extend:
+			existing.Fields = append(existing.Fields, t.Fields...)
+			existing.Interfaces = append(existing.Interfaces, t.Interfaces...)
+			existing.PossibleTypes = append(existing.PossibleTypes, t.PossibleTypes...)
+			existing.EnumValues = append(existing.EnumValues, t.EnumValues...)
+			existing.InputFields = append(existing.InputFields, t.InputFields...)
+		case existing != nil:
+			return nil, errorf([]Location{loc}, "There can be only one type named %q.", t.Name)
+		default:
+			s.Types[t.Name] = t
+		}
+	}
+	return s, nil
+}
+
+// description consumes an optional description string.
+func (p *parser) description() (string, error) {
+	if p.tok.kind != tokString && p.tok.kind != tokBlockString {
+		return "", nil
+	}
+	desc := p.tok.value
+	return desc, p.advance()
+}
+
+func (p *parser) schemaDefinition(s *Schema) error {
+	if _, err := p.directives(true); err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		if ok, err := p.skip("}"); ok || err != nil {
+			return err
+		}
+		op, loc, err := p.name()
+		if err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		name, _, err := p.name()
+		if err != nil {
+			return err
+		}
+		switch OperationType(op) {
+		case Query:
+			s.QueryType = name
+		case Mutation:
+			s.MutationType = name
+		case Subscription:
+			s.SubscriptionType = name
+		default:
+			return syntaxError(loc, "Unexpected operation type %q.", op)
+		}
+	}
+}
+
+// directiveDefinition skips a directive definition: only the built-in
+// directives are executed.
+func (p *parser) directiveDefinition() error {
+	if err := p.expect("@"); err != nil {
+		return err
+	}
+	if _, _, err := p.name(); err != nil {
+		return err
+	}
+	if _, err := p.argumentDefinitions(); err != nil {
+		return err
+	}
+	if p.peekName("repeatable") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	if err := p.expectKeyword("on"); err != nil {
+		return err
+	}
+	if _, err := p.skip("|"); err != nil {
+		return err
+	}
+	for {
+		if _, _, err := p.name(); err != nil {
+			return err
+		}
+		if ok, err := p.skip("|"); !ok || err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) typeHeader(kind TypeKind) (*Type, error) {
+	name, _, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(true); err != nil {
+		return nil, err
+	}
+	return &Type{Kind: kind, Name: name}, nil
+}
+
+func (p *parser) objectDefinition(kind TypeKind) (*Type, error) {
+	name, _, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	t := &Type{Kind: kind, Name: name}
+	if p.peekName("implements") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if _, err := p.skip("&"); err != nil {
+			return nil, err
+		}
+		for {
+			iface, _, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			t.Interfaces = append(t.Interfaces, iface)
+			if ok, err := p.skip("&"); err != nil {
+				return nil, err
+			} else if !ok {
+				break
+			}
+		}
+	}
+	if _, err := p.directives(true); err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip("{"); !ok || err != nil {
+		return t, err
+	}
+	for {
+		if ok, err := p.skip("}"); ok || err != nil {
+			return t, err
+		}
+		f := &FieldDefinition{}
+		if f.Description, err = p.description(); err != nil {
+			return nil, err
+		}
+		if f.Name, _, err = p.name(); err != nil {
+			return nil, err
+		}
+		if f.Args, err = p.argumentDefinitions(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if f.Type, err = p.typeRef(); err != nil {
+			return nil, err
+		}
+		dirs, err := p.directives(true)
+		if err != nil {
+			return nil, err
+		}
+		f.Deprecated, f.DeprecationReason = deprecation(dirs)
+		t.Fields = append(t.Fields, f)
+	}
+}
+
+func (p *parser) argumentDefinitions() ([]*InputValue, error) {
+	if ok, err := p.skip("("); !ok || err != nil {
+		return nil, err
+	}
+	var args []*InputValue
+	for {
+		if ok, err := p.skip(")"); ok || err != nil {
+			return args, err
+		}
+		arg, err := p.inputValueDefinition()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+}
+
+func (p *parser) inputValueDefinition() (*InputValue, error) {
+	v := &InputValue{}
+	var err error
+	if v.Description, err = p.description(); err != nil {
+		return nil, err
+	}
+	if v.Name, _, err = p.name(); err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	if v.Type, err = p.typeRef(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip("="); err != nil {
+		return nil, err
+	} else if ok {
+		if v.Default, err = p.value(true); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.directives(true); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (p *parser) unionDefinition() (*Type, error) {
+	t, err := p.typeHeader(UnionKind)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip("="); !ok || err != nil {
+		return t, err
+	}
+	if _, err := p.skip("|"); err != nil {
+		return nil, err
+	}
+	for {
+		member, _, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		t.PossibleTypes = append(t.PossibleTypes, member)
+		if ok, err := p.skip("|"); !ok || err != nil {
+			return t, err
+		}
+	}
+}
+
+func (p *parser) enumDefinition() (*Type, error) {
+	t, err := p.typeHeader(EnumKind)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip("{"); !ok || err != nil {
+		return t, err
+	}
+	for {
+		if ok, err := p.skip("}"); ok || err != nil {
+			return t, err
+		}
+		v := &EnumValueDefinition{}
+		if v.Description, err = p.description(); err != nil {
+			return nil, err
+		}
+		var loc Location
+		if v.Name, loc, err = p.name(); err != nil {
+			return nil, err
+		}
+		if v.Name == "true" || v.Name == "false" || v.Name == "null" {
+			return nil, syntaxError(loc, "Enum value cannot be %q.", v.Name)
+		}
+		dirs, err := p.directives(true)
+		if err != nil {
+			return nil, err
+		}
+		v.Deprecated, v.DeprecationReason = deprecation(dirs)
+		t.EnumValues = append(t.EnumValues, v)
+	}
+}
+
+func (p *parser) inputDefinition() (*Type, error) {
+	t, err := p.typeHeader(InputObjectKind)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip("{"); !ok || err != nil {
+		return t, err
+	}
+	for {
+		if ok, err := p.skip("}"); ok || err != nil {
+			return t, err
+		}
+		f, err := p.inputValueDefinition()
+		if err != nil {
+			return nil, err
+		}
+		t.InputFields = append(t.InputFields, f)
+	}
+}
+
+const defaultDeprecationReason = "No longer supported"
+
+func deprecation(dirs []*Directive) (bool, string) {
+	for _, d := range dirs {
+		if d.Name != "deprecated" {
+			continue
+		}
+		if reason := argument(d.Arguments, "reason"); reason != nil && reason.Value.Kind == StringValue {
+			return true, reason.Value.Raw
+		}
+		return true, defaultDeprecationReason
+	}
+	return false, ""
+}
@@ -0,0 +1,126 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+)
+
+// SubscriptionFunc opens the event stream of a subscription root field
+// from its coerced arguments. The stream ends when the channel is closed;
+// the function should stop sending once ctx is done.
+type SubscriptionFunc func(ctx context.Context, args map[string]any) (<-chan any, error)
+
+// Subscribe parses and validates a request and starts it. A subscription
+// yields one result per event of its root field's stream, each the
+// selection set executed against that event; queries and mutations yield
+// their single result. The channel is closed when the stream ends or ctx
+// is done.
+//
+// When the request cannot start, Subscribe returns a nil channel and a
+// result holding the errors.
+func Subscribe(ctx context.Context, p Params) (<-chan *Result, *Result) {
+	doc, err := Parse(p.Query)
+	if err != nil {
+		return nil, &Result{Errors: []*Error{asError(err)}}
+	}
+	if errs := Validate(p.Schema, doc); len(errs) > 0 {
+		return nil, &Result{Errors: errs}
+	}
+	cost, errs := Measure(p.Schema, doc, p.OperationName, p.Variables, p.Limits)
+	if len(errs) > 0 {
+		return nil, &Result{Errors: errs, Cost: cost}
+	}
+	op, gqlErr := selectOperation(doc, p.OperationName)
+	if gqlErr != nil {
+		return nil, &Result{Errors: []*Error{gqlErr}}
+	}
+	if op.Type != Subscription {
+		results := make(chan *Result, 1)
+		result := Execute(ctx, ExecuteParams{
+			Schema:        p.Schema,
+			Document:      doc,
+			OperationName: p.OperationName,
+			Variables:     p.Variables,
+			Resolvers:     p.Resolvers,
+		})
+		result.Cost = cost
+		results <- result
+		close(results)
+		return results, nil
+	}
+
+	root := p.Schema.RootType(Subscription)
+	if root == nil {
+		return nil, &Result{Errors: []*Error{errorf([]Location{op.Loc}, "Schema is not configured for subscriptions.")}}
+	}
+	vars, errs := coerceVariables(p.Schema, op, p.Variables)
+	if len(errs) > 0 {
+		return nil, &Result{Errors: errs}
+	}
+
+	e := &executor{schema: p.Schema, doc: doc, vars: vars}
+	var keys []string
+	fields := make(map[string][]*Field)
+	e.collectFields(root, op.SelectionSet, &keys, fields, make(map[string]bool))
+	if len(keys) != 1 {
+		return nil, &Result{Errors: []*Error{errorf([]Location{op.Loc}, "Subscription must select exactly one top level field.")}}
+	}
+	field := fields[keys[0]]
+	path := []any{keys[0]}
+	def := root.Field(field[0].Name)
+	subscribe := p.Subscriptions[field[0].Name]
+	if def == nil || subscribe == nil {
+		e.fieldError(fmt.Errorf("No subscription for field %s.%s.", root.Name, field[0].Name), field, path)
+		return nil, &Result{Errors: e.errors}
+	}
+	args, err := coerceArguments(p.Schema, def.Args, field[0].Arguments, vars)
+	if err == nil {
+		if args == nil {
+			args = map[string]any{}
+		}
+		var events <-chan any
+		if events, err = subscribe(ctx, args); err == nil {
+			return e.stream(ctx, root, op, def.Name, events, cost), nil
+		}
+	}
+	e.fieldError(err, field, path)
+	return nil, &Result{Errors: e.errors}
+}
+
+// stream maps each event to the result of executing the operation with
+// the event as the value of its root field. Each result carries the
+// operation's cost.
+func (e *executor) stream(ctx context.Context, root *Type, op *Operation, name string, events <-chan any, cost *Cost) <-chan *Result {
+	results := make(chan *Result)
+	go func() {
+		defer close(results)
+		for {
+			var event any
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				event = ev
+			}
+			each := &executor{schema: e.schema, doc: e.doc, vars: e.vars, resolvers: map[string]ResolverFunc{
+				name: func(context.Context, map[string]any) (any, error) {
+					if err, ok := event.(error); ok {
+						return nil, err
+					}
+					return event, nil
+				},
+			}}
+			result := each.execute(ctx, root, op)
+			result.Cost = cost
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results
+}
@@ -0,0 +1,605 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Validate checks a document against a schema with the validation rules
+// of the specification, returning every error found.
+func Validate(s *Schema, doc *Document) []*Error {
+	v := &validator{schema: s, doc: doc, seen: make(map[string]bool)}
+	v.operations()
+	v.fragments()
+	for _, op := range doc.Operations {
+		if root := s.RootType(op.Type); root != nil {
+			v.directives(op.Directives, opLocation(op.Type))
+			v.selections(root, op.SelectionSet)
+			v.variables(op)
+		}
+	}
+	for _, f := range doc.Fragments {
+		if t := s.Type(f.TypeCondition); t != nil && t.isComposite() {
+			v.directives(f.Directives, "FRAGMENT_DEFINITION")
+			v.selections(t, f.SelectionSet)
+		}
+	}
+	return v.errs
+}
+
+type validator struct {
+	schema *Schema
+	doc    *Document
+	errs   []*Error
+	seen   map[string]bool
+}
+
+// report records an error once, however many times the selection it
+// concerns is reached through fragments.
+func (v *validator) report(locs []Location, format string, args ...any) {
+	v.add(errorf(locs, format, args...))
+}
+
+func (v *validator) add(err *Error) {
+	key := fmt.Sprint(err.Message, err.Locations)
+	if v.seen[key] {
+		return
+	}
+	v.seen[key] = true
+	v.errs = append(v.errs, err)
+}
+
+func opLocation(t OperationType) string {
+	switch t {
+	case Mutation:
+		return "MUTATION"
+	case Subscription:
+		return "SUBSCRIPTION"
+	}
+	return "QUERY"
+}
+
+func (v *validator) operations() {
+	names := make(map[string]*Operation)
+	for _, op := range v.doc.Operations {
+		if op.Name == "" && len(v.doc.Operations) > 1 {
+			v.report([]Location{op.Loc}, "This anonymous operation must be the only defined operation.")
+		}
+		if op.Name != "" {
+			if prev := names[op.Name]; prev != nil {
+				v.report([]Location{prev.Loc, op.Loc}, "There can be only one operation named %q.", op.Name)
+			}
+			names[op.Name] = op
+		}
+		if v.schema.RootType(op.Type) == nil {
+			v.report([]Location{op.Loc}, "Schema is not configured for %ss.", op.Type)
+		}
+		if op.Type == Subscription {
+			fields := v.rootFields(op)
+			if len(fields) != 1 {
+				v.report([]Location{op.Loc}, "Subscription %s must select only one top level field.", operationLabel(op))
+			}
+			for _, f := range fields {
+				if strings.HasPrefix(f.Name, "__") {
+					v.report([]Location{f.Loc}, "Subscription %s must not select an introspection top level field.", operationLabel(op))
+				}
+			}
+		}
+	}
+}
+
+func operationLabel(op *Operation) string {
+	if op.Name == "" {
+		return "anonymous"
+	}
+	return fmt.Sprintf("%q", op.Name)
+}
+
+// rootFields returns the first field of each response key an operation
+// selects at its root, ignoring directives.
+func (v *validator) rootFields(op *Operation) []*Field {
+	seen := make(map[string]bool)
+	var fields []*Field
+	var walk func(set []Selection, visited map[string]bool)
+	walk = func(set []Selection, visited map[string]bool) {
+		for _, sel := range set {
+			switch sel := sel.(type) {
+			case *Field:
+				if !seen[sel.ResponseKey()] {
+					seen[sel.ResponseKey()] = true
+					fields = append(fields, sel)
+				}
+			case *InlineFragment:
+				walk(sel.SelectionSet, visited)
+			case *FragmentSpread:
+				if f := v.doc.Fragment(sel.Name); f != nil && !visited[sel.Name] {
+					visited[sel.Name] = true
+					walk(f.SelectionSet, visited)
+				}
+			}
+		}
+	}
+	walk(op.SelectionSet, make(map[string]bool))
+	return fields
+}
+
+func (v *validator) fragments() {
+	names := make(map[string]*Fragment)
+	for _, f := range v.doc.Fragments {
+		if prev := names[f.Name]; prev != nil {
+			v.report([]Location{prev.Loc, f.Loc}, "There can be only one fragment named %q.", f.Name)
+		}
+		names[f.Name] = f
+		if t := v.schema.Type(f.TypeCondition); t == nil {
+			v.report([]Location{f.Loc}, "Unknown type %q.", f.TypeCondition)
+		} else if !t.isComposite() {
+			v.report([]Location{f.Loc}, "Fragment %q cannot condition on non composite type %q.", f.Name, f.TypeCondition)
+		}
+	}
+
+	// Every fragment must be used, and none may spread itself.
+	used := make(map[string]bool)
+	var use func(set []Selection)
+	use = func(set []Selection) {
+		forEachSpread(set, func(s *FragmentSpread) {
+			if f := v.doc.Fragment(s.Name); f != nil && !used[s.Name] {
+				used[s.Name] = true
+				use(f.SelectionSet)
+			}
+		})
+	}
+	for _, op := range v.doc.Operations {
+		use(op.SelectionSet)
+	}
+	for _, f := range v.doc.Fragments {
+		if !used[f.Name] {
+			v.report([]Location{f.Loc}, "Fragment %q is never used.", f.Name)
+		}
+	}
+
+	cyclic := make(map[string]bool)
+	for _, f := range v.doc.Fragments {
+		if cyclic[f.Name] {
+			continue
+		}
+		if path := v.cycle(f.Name, f, nil, make(map[string]bool)); path != nil {
+			locs := make([]Location, len(path))
+			for i, s := range path {
+				locs[i] = s.Loc
+				cyclic[s.Name] = true
+			}
+			v.report(locs, "Cannot spread fragment %q within itself.", f.Name)
+		}
+	}
+}
+
+// cycle returns the spreads that lead from a fragment back to the target
+// fragment, or nil.
+func (v *validator) cycle(target string, f *Fragment, path []*FragmentSpread, visited map[string]bool) []*FragmentSpread {
+	var found []*FragmentSpread
+	forEachSpread(f.SelectionSet, func(s *FragmentSpread) {
+		if found != nil {
+			return
+		}
+		next := append(path[:len(path):len(path)], s)
+		if s.Name == target {
+			found = next
+			return
+		}
+		if visited[s.Name] {
+			return
+		}
+		visited[s.Name] = true
+		if spread := v.doc.Fragment(s.Name); spread != nil {
+			found = v.cycle(target, spread, next, visited)
+		}
+	})
+	return found
+}
+
+// forEachSpread calls fn for the fragment spreads of a selection set,
+// including those inside fields and inline fragments.
+func forEachSpread(set []Selection, fn func(*FragmentSpread)) {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *Field:
+			forEachSpread(sel.SelectionSet, fn)
+		case *InlineFragment:
+			forEachSpread(sel.SelectionSet, fn)
+		case *FragmentSpread:
+			fn(sel)
+		}
+	}
+}
+
+// selectionSet checks the selections made on a composite type.
+func (v *validator) selectionSet(t *Type, set []Selection) {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *Field:
+			v.directives(sel.Directives, "FIELD")
+			v.field(t, sel)
+		case *FragmentSpread:
+			v.directives(sel.Directives, "FRAGMENT_SPREAD")
+			f := v.doc.Fragment(sel.Name)
+			if f == nil {
+				v.report([]Location{sel.Loc}, "Unknown fragment %q.", sel.Name)
+				continue
+			}
+			if ft := v.schema.Type(f.TypeCondition); ft != nil && ft.isComposite() && !v.schema.overlaps(t, ft) {
+				v.report([]Location{sel.Loc},
+					"Fragment %q cannot be spread here as objects of type %q can never be of type %q.", sel.Name, t.Name, ft.Name)
+			}
+		case *InlineFragment:
+			v.directives(sel.Directives, "INLINE_FRAGMENT")
+			ft := t
+			if sel.TypeCondition != "" {
+				ft = v.schema.Type(sel.TypeCondition)
+				switch {
+				case ft == nil:
+					v.report([]Location{sel.Loc}, "Unknown type %q.", sel.TypeCondition)
+					continue
+				case !ft.isComposite():
+					v.report([]Location{sel.Loc}, "Fragment cannot condition on non composite type %q.", sel.TypeCondition)
+					continue
+				case !v.schema.overlaps(t, ft):
+					v.report([]Location{sel.Loc},
+						"Fragment cannot be spread here as objects of type %q can never be of type %q.", t.Name, ft.Name)
+				}
+			}
+			v.selectionSet(ft, sel.SelectionSet)
+		}
+	}
+}
+
+// selections checks a field's, operation's or fragment's selection set,
+// including whether its fields can be merged.
+func (v *validator) selections(t *Type, set []Selection) {
+	v.selectionSet(t, set)
+	v.overlaps(t, set)
+}
+
+func (v *validator) field(t *Type, f *Field) {
+	def := v.schema.fieldDefinition(t, f.Name)
+	if def == nil {
+		v.report([]Location{f.Loc}, "Cannot query field %q on type %q.", f.Name, t.Name)
+		return
+	}
+	v.arguments(f.Arguments, def.Args, fmt.Sprintf("field \"%s.%s\"", t.Name, f.Name), fmt.Sprintf("Field %q", f.Name), f.Loc)
+
+	ft := v.schema.Type(def.Type.NamedType())
+	switch {
+	case ft.isComposite() && len(f.SelectionSet) == 0:
+		v.report([]Location{f.Loc},
+			"Field %q of type %q must have a selection of subfields. Did you mean \"%s { ... }\"?", f.Name, def.Type, f.Name)
+	case !ft.isComposite() && len(f.SelectionSet) > 0:
+		v.report([]Location{f.Loc},
+			"Field %q must not have a selection since type %q has no subfields.", f.Name, def.Type)
+	case ft.isComposite():
+		v.selections(ft, f.SelectionSet)
+	}
+}
+
+// arguments checks arguments against their definitions: each must be
+// known, given once, of the right type, and present if required. Owner
+// and required name the field or directive in the two kinds of message.
+func (v *validator) arguments(args []*Argument, defs []*InputValue, owner, required string, loc Location) {
+	seen := make(map[string]*Argument)
+	for _, arg := range args {
+		if prev := seen[arg.Name]; prev != nil {
+			v.report([]Location{prev.Loc, arg.Loc}, "There can be only one argument named %q.", arg.Name)
+			continue
+		}
+		seen[arg.Name] = arg
+		var def *InputValue
+		for _, d := range defs {
+			if d.Name == arg.Name {
+				def = d
+			}
+		}
+		if def == nil {
+			v.report([]Location{arg.Loc}, "Unknown argument %q on %s.", arg.Name, owner)
+			continue
+		}
+		if _, _, err := coerceLiteral(v.schema, arg.Value, def.Type, nil); err != nil {
+			v.add(err)
+		}
+	}
+	for _, def := range defs {
+		if def.Type.NonNull && def.Default == nil && seen[def.Name] == nil {
+			v.report([]Location{loc}, "%s argument %q of type %q is required, but it was not provided.",
+				required, def.Name, def.Type)
+		}
+	}
+}
+
+func (v *validator) directives(dirs []*Directive, location string) {
+	seen := make(map[string]bool)
+	for _, d := range dirs {
+		def := directiveNamed(d.Name)
+		if def == nil {
+			v.report([]Location{d.Loc}, "Unknown directive \"@%s\".", d.Name)
+			continue
+		}
+		allowed := false
+		for _, l := range def.Locations {
+			allowed = allowed || l == location
+		}
+		if !allowed {
+			v.report([]Location{d.Loc}, "Directive \"@%s\" may not be used on %s.", d.Name, location)
+		}
+		if seen[d.Name] {
+			v.report([]Location{d.Loc}, "The directive \"@%s\" can only be used once at this location.", d.Name)
+		}
+		seen[d.Name] = true
+		v.arguments(d.Arguments, def.Args, fmt.Sprintf("directive \"@%s\"", d.Name), fmt.Sprintf("Directive \"@%s\"", d.Name), d.Loc)
+	}
+}
+
+// overlaps checks that fields selected under the same response key can be
+// merged: they must name the same field with the same arguments, unless
+// they apply to different object types.
+func (v *validator) overlaps(t *Type, set []Selection) {
+	type selected struct {
+		parent *Type
+		field  *Field
+	}
+	byKey := make(map[string][]selected)
+	var keys []string
+	var collect func(parent *Type, set []Selection, visited map[string]bool)
+	collect = func(parent *Type, set []Selection, visited map[string]bool) {
+		for _, sel := range set {
+			switch sel := sel.(type) {
+			case *Field:
+				key := sel.ResponseKey()
+				if byKey[key] == nil {
+					keys = append(keys, key)
+				}
+				byKey[key] = append(byKey[key], selected{parent, sel})
+			case *InlineFragment:
+				ft := parent
+				if sel.TypeCondition != "" {
+					ft = v.schema.Type(sel.TypeCondition)
+				}
+				if ft != nil {
+					collect(ft, sel.SelectionSet, visited)
+				}
+			case *FragmentSpread:
+				f := v.doc.Fragment(sel.Name)
+				if f == nil || visited[sel.Name] {
+					continue
+				}
+				visited[sel.Name] = true
+				if ft := v.schema.Type(f.TypeCondition); ft != nil {
+					collect(ft, f.SelectionSet, visited)
+				}
+			}
+		}
+	}
+	collect(t, set, make(map[string]bool))
+
+	for _, key := range keys {
+		fields := byKey[key]
+		for i := 1; i < len(fields); i++ {
+			a, b := fields[0], fields[i]
+			if a.parent != b.parent && a.parent.Kind == ObjectKind && b.parent.Kind == ObjectKind {
+				continue
+			}
+			switch {
+			case a.field.Name != b.field.Name:
+				v.report([]Location{a.field.Loc, b.field.Loc},
+					"Fields %q conflict because %q and %q are different fields. Use different aliases on the fields to fetch both if this was intentional.",
+					key, a.field.Name, b.field.Name)
+			case !sameArguments(a.field.Arguments, b.field.Arguments):
+				v.report([]Location{a.field.Loc, b.field.Loc},
+					"Fields %q conflict because they have differing arguments. Use different aliases on the fields to fetch both if this was intentional.", key)
+			default:
+				continue
+			}
+			break
+		}
+	}
+}
+
+func sameArguments(a, b []*Argument) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, arg := range a {
+		other := argument(b, arg.Name)
+		if other == nil || other.Value.String() != arg.Value.String() {
+			return false
+		}
+	}
+	return true
+}
+
+// variables checks an operation's variable definitions against their
+// uses in it and in the fragments it spreads.
+func (v *validator) variables(op *Operation) {
+	defined := make(map[string]*VariableDefinition)
+	for _, def := range op.Variables {
+		if defined[def.Name] != nil {
+			v.report([]Location{defined[def.Name].Loc, def.Loc}, "There can be only one variable named \"$%s\".", def.Name)
+			continue
+		}
+		defined[def.Name] = def
+		if t := v.schema.Type(def.Type.NamedType()); t == nil {
+			v.report([]Location{def.Loc}, "Unknown type %q.", def.Type.NamedType())
+			continue
+		} else if !t.isInput() {
+			v.report([]Location{def.Loc}, "Variable \"$%s\" cannot be non-input type %q.", def.Name, def.Type)
+			continue
+		}
+		if def.Default != nil {
+			if _, _, err := coerceLiteral(v.schema, def.Default, def.Type, nil); err != nil {
+				v.add(err)
+			}
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, u := range v.variableUsages(op) {
+		used[u.value.Raw] = true
+		def := defined[u.value.Raw]
+		if def == nil {
+			v.report([]Location{u.value.Loc, op.Loc}, "Variable \"$%s\" is not defined by operation %s.", u.value.Raw, operationLabel(op))
+			continue
+		}
+		if v.schema.Type(def.Type.NamedType()) == nil || u.typ == nil {
+			// An unknown type, field or argument is reported elsewhere.
+			continue
+		}
+		varType := def.Type
+		if u.typ.NonNull && !varType.NonNull && (def.Default != nil || u.hasDefault) {
+			nonNull := *varType
+			nonNull.NonNull = true
+			varType = &nonNull
+		}
+		if !isSubType(varType, u.typ) {
+			v.report([]Location{def.Loc, u.value.Loc},
+				"Variable \"$%s\" of type %q used in position expecting type %q.", def.Name, def.Type, u.typ)
+		}
+	}
+	names := make([]string, 0, len(defined))
+	for name := range defined {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !used[name] {
+			v.report([]Location{defined[name].Loc}, "Variable \"$%s\" is never used in operation %s.", name, operationLabel(op))
+		}
+	}
+}
+
+type variableUsage struct {
+	value      *Value
+	typ        *TypeRef
+	hasDefault bool
+}
+
+// variableUsages finds the variables an operation uses, with the types
+// expected where they appear.
+func (v *validator) variableUsages(op *Operation) []variableUsage {
+	var usages []variableUsage
+	var inValue func(val *Value, t *TypeRef, hasDefault bool)
+	inValue = func(val *Value, t *TypeRef, hasDefault bool) {
+		switch val.Kind {
+		case VariableValue:
+			usages = append(usages, variableUsage{val, t, hasDefault})
+		case ListValue:
+			for _, item := range val.List {
+				elem := t
+				if t != nil && t.Elem != nil {
+					elem = t.Elem
+				}
+				inValue(item, elem, false)
+			}
+		case ObjectValue:
+			var named *Type
+			if t != nil {
+				named = v.schema.Type(t.NamedType())
+			}
+			for _, f := range val.Fields {
+				var ft *TypeRef
+				var def *InputValue
+				if named != nil {
+					def = named.InputField(f.Name)
+				}
+				if def != nil {
+					ft = def.Type
+				}
+				inValue(f.Value, ft, def != nil && def.Default != nil)
+			}
+		}
+	}
+	inArgs := func(args []*Argument, defs []*InputValue) {
+		for _, arg := range args {
+			var def *InputValue
+			for _, d := range defs {
+				if d.Name == arg.Name {
+					def = d
+				}
+			}
+			var t *TypeRef
+			if def != nil {
+				t = def.Type
+			}
+			inValue(arg.Value, t, def != nil && def.Default != nil)
+		}
+	}
+	inDirectives := func(dirs []*Directive) {
+		for _, d := range dirs {
+			if def := directiveNamed(d.Name); def != nil {
+				inArgs(d.Arguments, def.Args)
+			}
+		}
+	}
+
+	visited := make(map[string]bool)
+	var walk func(t *Type, set []Selection)
+	walk = func(t *Type, set []Selection) {
+		for _, sel := range set {
+			inDirectives(sel.directives())
+			switch sel := sel.(type) {
+			case *Field:
+				var def *FieldDefinition
+				if t != nil {
+					def = v.schema.fieldDefinition(t, sel.Name)
+				}
+				var defs []*InputValue
+				var ft *Type
+				if def != nil {
+					defs = def.Args
+					ft = v.schema.Type(def.Type.NamedType())
+				}
+				inArgs(sel.Arguments, defs)
+				walk(ft, sel.SelectionSet)
+			case *InlineFragment:
+				ft := t
+				if sel.TypeCondition != "" {
+					ft = v.schema.Type(sel.TypeCondition)
+				}
+				walk(ft, sel.SelectionSet)
+			case *FragmentSpread:
+				f := v.doc.Fragment(sel.Name)
+				if f == nil || visited[sel.Name] {
+					continue
+				}
+				visited[sel.Name] = true
+				inDirectives(f.Directives)
+				walk(v.schema.Type(f.TypeCondition), f.SelectionSet)
+			}
+		}
+	}
+	inDirectives(op.Directives)
+	walk(v.schema.RootType(op.Type), op.SelectionSet)
+	return usages
+}
+
+// isSubType reports whether a variable of type sub can be used where
+// super is expected.
+func isSubType(sub, super *TypeRef) bool {
+	if super == nil {
+		return true
+	}
+	if super.NonNull {
+		if !sub.NonNull {
+			return false
+		}
+		s1, s2 := *sub, *super
+		s1.NonNull, s2.NonNull = false, false
+		return isSubType(&s1, &s2)
+	}
+	if sub.NonNull {
+		s1 := *sub
+		s1.NonNull = false
+		return isSubType(&s1, super)
+	}
+	if super.Elem != nil {
+		return sub.Elem != nil && isSubType(sub.Elem, super.Elem)
+	}
+	return sub.Elem == nil && sub.Name == super.Name
+}
@@ -0,0 +1,153 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/engine"
+)
+
+// Report summarizes a dry run of every stream in a store.
+type Report struct {
+	Streams  int       `json:"streams"`
+	Events   int       `json:"events"`
+	Upcast   int       `json:"upcast"`
+	Failures []Failure `json:"failures,omitempty"`
+}
+
+// Failure is a stream that does not replay under the new model.
+type Failure struct {
+	StreamID string `json:"stream_id"`
+	Error    string `json:"error"`
+}
+
+// OK reports whether every stream replayed.
+func (r *Report) OK() bool {
+	return len(r.Failures) == 0
+}
+
+// Check replays every stream in store under model, with plan's upcasters
+// applied on read, and reports the streams that fail. It changes nothing.
+func Check(ctx context.Context, store eventsource.Store, model *goflowmodel.Model, plan *Plan) (*Report, error) {
+	streams, err := readStreams(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+
+	eng := NewEngine(model, NewUpcastingStore(store, plan))
+	report := &Report{}
+	for _, s := range streams {
+		report.Streams++
+		report.Events += len(s.events)
+		for _, evt := range s.events {
+			_, changed, err := plan.upcast(evt)
+			if err != nil {
+				return nil, err
+			}
+			if changed {
+				report.Upcast++
+			}
+		}
+		if err := eng.Verify(ctx, s.id); err != nil {
+			report.Failures = append(report.Failures, Failure{StreamID: s.id, Error: err.Error()})
+		}
+	}
+	return report, nil
+}
+
+// Rewrite copies every event in src into dst, an empty store, with plan's
+// upcasters applied, and returns how many streams an upcaster changed.
+// Events keep src's log order. src is only read, so a failed or interrupted
+// rewrite loses nothing: swap dst in for src only once Rewrite returns, and
+// run it only after Check reports no failures.
+func Rewrite(ctx context.Context, src, dst eventsource.Store, plan *Plan) (int, error) {
+	events, err := src.ReadAll(ctx, 0, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	versions := make(map[string]int)
+	changed := make(map[string]bool)
+	var batch []*eventsource.Event
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		id := batch[0].StreamID
+		version, err := dst.Append(ctx, id, versions[id], batch)
+		if err != nil {
+			return fmt.Errorf("stream %s: %w", id, err)
+		}
+		versions[id] = version
+		batch = nil
+		return nil
+	}
+
+	// Append runs of consecutive events of a stream together
+	for _, evt := range events {
+		if len(batch) > 0 && batch[0].StreamID != evt.StreamID {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+		upcast, c, err := plan.upcast(evt)
+		if err != nil {
+			return 0, err
+		}
+		if c {
+			changed[evt.StreamID] = true
+		}
+		e := *upcast
+		batch = append(batch, &e)
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+	return len(changed), nil
+}
+
+// NewEngine creates an engine for a model that reads the event types the
+// model's transitions declare, as generated services write them.
+func NewEngine(model *goflowmodel.Model, store eventsource.Store) *engine.Engine {
+	eng := engine.NewEngine(metamodel.FromModel(model), store)
+	for _, t := range model.Transitions {
+		if t.EventType != "" {
+			eng.SetEventType(t.ID, t.EventType)
+		}
+	}
+	return eng
+}
+
+type stream struct {
+	id     string
+	events []*eventsource.Event
+}
+
+// readStreams groups every event in store by stream, in append order.
+// Internal streams, such as role assignments, are not aggregates and are
+// left out.
+func readStreams(ctx context.Context, store eventsource.Store) ([]stream, error) {
+	events, err := store.ReadAll(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	var streams []stream
+	index := make(map[string]int)
+	for _, evt := range events {
+		if strings.HasPrefix(evt.StreamID, "__") {
+			continue
+		}
+		i, ok := index[evt.StreamID]
+		if !ok {
+			i = len(streams)
+			index[evt.StreamID] = i
+			streams = append(streams, stream{id: evt.StreamID})
+		}
+		streams[i].events = append(streams[i].events, evt)
+	}
+	return streams, nil
+}
@@ -0,0 +1,268 @@
+package migrate
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/engine"
+)
+
+// ModelDiff represents the differences between two models.
+type ModelDiff struct {
+	PlacesAdded        []string `json:"places_added,omitempty"`
+	PlacesRemoved      []string `json:"places_removed,omitempty"`
+	TransitionsAdded   []string `json:"transitions_added,omitempty"`
+	TransitionsRemoved []string `json:"transitions_removed,omitempty"`
+	ArcsAdded          []string `json:"arcs_added,omitempty"`
+	ArcsRemoved        []string `json:"arcs_removed,omitempty"`
+	BindingsAdded      []string `json:"bindings_added,omitempty"`
+	BindingsRemoved    []string `json:"bindings_removed,omitempty"`
+	RolesAdded         []string `json:"roles_added,omitempty"`
+	RolesRemoved       []string `json:"roles_removed,omitempty"`
+	AccessAdded        []string `json:"access_added,omitempty"`
+	AccessRemoved      []string `json:"access_removed,omitempty"`
+	HasChanges         bool     `json:"has_changes"`
+}
+
+// Compare reports the places, transitions, arcs and bindings added and
+// removed between two models. Arcs are keyed "from->to" and bindings
+// "transition.binding", and every list is sorted.
+func Compare(a, b *goflowmodel.Model) ModelDiff {
+	diff := ModelDiff{}
+
+	// Compare places
+	placesA := make(map[string]bool)
+	for _, p := range a.Places {
+		placesA[p.ID] = true
+	}
+	placesB := make(map[string]bool)
+	for _, p := range b.Places {
+		placesB[p.ID] = true
+	}
+	diff.PlacesAdded, diff.PlacesRemoved = setDiff(placesA, placesB)
+
+	// Compare transitions
+	transA := make(map[string]bool)
+	for _, t := range a.Transitions {
+		transA[t.ID] = true
+	}
+	transB := make(map[string]bool)
+	for _, t := range b.Transitions {
+		transB[t.ID] = true
+	}
+	diff.TransitionsAdded, diff.TransitionsRemoved = setDiff(transA, transB)
+
+	// Compare arcs
+	arcKey := func(arc goflowmodel.Arc) string {
+		return fmt.Sprintf("%s->%s", arc.From, arc.To)
+	}
+	arcsA := make(map[string]bool)
+	for _, arc := range a.Arcs {
+		arcsA[arcKey(arc)] = true
+	}
+	arcsB := make(map[string]bool)
+	for _, arc := range b.Arcs {
+		arcsB[arcKey(arc)] = true
+	}
+	diff.ArcsAdded, diff.ArcsRemoved = setDiff(arcsA, arcsB)
+
+	// Compare bindings of transitions both models have
+	bindingsA := make(map[string]bool)
+	for _, t := range a.Transitions {
+		for _, binding := range t.Bindings {
+			if transB[t.ID] {
+				bindingsA[t.ID+"."+binding.Name] = true
+			}
+		}
+	}
+	bindingsB := make(map[string]bool)
+	for _, t := range b.Transitions {
+		for _, binding := range t.Bindings {
+			if transA[t.ID] {
+				bindingsB[t.ID+"."+binding.Name] = true
+			}
+		}
+	}
+	diff.BindingsAdded, diff.BindingsRemoved = setDiff(bindingsA, bindingsB)
+
+	// Note: Roles and Access are now stored in extensions, not in the core Model.
+	// Diff for those constructs would require extension-aware comparison.
+
+	// Check if there are any changes
+	diff.HasChanges = len(diff.PlacesAdded) > 0 || len(diff.PlacesRemoved) > 0 ||
+		len(diff.TransitionsAdded) > 0 || len(diff.TransitionsRemoved) > 0 ||
+		len(diff.ArcsAdded) > 0 || len(diff.ArcsRemoved) > 0 ||
+		len(diff.BindingsAdded) > 0 || len(diff.BindingsRemoved) > 0
+
+	return diff
+}
+
+// setDiff returns the sorted keys only in b and only in a.
+func setDiff(a, b map[string]bool) (added, removed []string) {
+	for key := range b {
+		if !a[key] {
+			added = append(added, key)
+		}
+	}
+	for key := range a {
+		if !b[key] {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// Suggest drafts a plan that upcasts events written under prev to the
+// next schema version, from the diff between prev and next:
+//
+//   - A removed transition whose arcs all reappear on an added transition
+//     is a rename, and its events are renamed to the new event type.
+//   - Bindings added to a transition get a zero-value default, and removed
+//     bindings are dropped from its events.
+//
+// Changes a plan cannot express, such as removed transitions and places,
+// are left as notes for the dry run to confirm.
+func Suggest(diff ModelDiff, prev, next *goflowmodel.Model) *Plan {
+	plan := &Plan{Version: 2}
+	upcasters := make(map[string]*Upcaster)
+	upcaster := func(transitionID string) *Upcaster {
+		eventType := eventType(prev, transitionID)
+		if u, ok := upcasters[eventType]; ok {
+			return u
+		}
+		u := &Upcaster{EventType: eventType, Version: 1}
+		upcasters[eventType] = u
+		return u
+	}
+
+	renamed := make(map[string]bool)
+	for _, removed := range diff.TransitionsRemoved {
+		to := renameTarget(diff, removed, renamed)
+		if to == "" {
+			plan.Notes = append(plan.Notes, fmt.Sprintf(
+				"transition %s was removed: streams with %s events will not replay", removed, eventType(prev, removed)))
+			continue
+		}
+		renamed[to] = true
+		if from, to := eventType(prev, removed), eventType(next, to); from != to {
+			upcaster(removed).RenameTo = to
+		}
+	}
+
+	for _, key := range diff.BindingsAdded {
+		transitionID, name, _ := strings.Cut(key, ".")
+		u := upcaster(transitionID)
+		if u.Defaults == nil {
+			u.Defaults = make(map[string]any)
+		}
+		u.Defaults[name] = zeroValue(bindingType(next, transitionID, name))
+		plan.Notes = append(plan.Notes, fmt.Sprintf("review the default for %s", key))
+	}
+	for _, key := range diff.BindingsRemoved {
+		transitionID, name, _ := strings.Cut(key, ".")
+		u := upcaster(transitionID)
+		u.DropFields = append(u.DropFields, name)
+	}
+
+	for _, place := range diff.PlacesRemoved {
+		plan.Notes = append(plan.Notes, fmt.Sprintf(
+			"place %s was removed: replay recomputes markings from the new arcs; check the dry run", place))
+	}
+
+	eventTypes := make([]string, 0, len(upcasters))
+	for eventType := range upcasters {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+	for _, eventType := range eventTypes {
+		plan.Upcasters = append(plan.Upcasters, *upcasters[eventType])
+	}
+	return plan
+}
+
+// renameTarget returns the added transition whose arcs are exactly the
+// removed transition's arcs with the transition renamed, if there is
+// exactly one that has not been claimed already.
+func renameTarget(diff ModelDiff, removed string, claimed map[string]bool) string {
+	arcs := arcsOf(diff.ArcsRemoved, removed)
+	if len(arcs) == 0 {
+		return ""
+	}
+	target := ""
+	for _, added := range diff.TransitionsAdded {
+		if claimed[added] {
+			continue
+		}
+		if !slices.Equal(arcsOf(diff.ArcsAdded, added), arcs) {
+			continue
+		}
+		if target != "" {
+			return ""
+		}
+		target = added
+	}
+	return target
+}
+
+// arcsOf returns a transition's arcs with its ID replaced by "*", sorted.
+func arcsOf(arcs []string, transitionID string) []string {
+	var out []string
+	for _, arc := range arcs {
+		from, to, _ := strings.Cut(arc, "->")
+		switch transitionID {
+		case from:
+			out = append(out, "*->"+to)
+		case to:
+			out = append(out, from+"->*")
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// eventType returns the event type a transition emits, as NewEngine reads it.
+func eventType(model *goflowmodel.Model, transitionID string) string {
+	for _, t := range model.Transitions {
+		if t.ID == transitionID && t.EventType != "" {
+			return t.EventType
+		}
+	}
+	return engine.DefaultEventType(transitionID)
+}
+
+func bindingType(model *goflowmodel.Model, transitionID, name string) string {
+	for _, t := range model.Transitions {
+		if t.ID != transitionID {
+			continue
+		}
+		for _, b := range t.Bindings {
+			if b.Name == name {
+				return b.Type
+			}
+		}
+	}
+	return ""
+}
+
+// zeroValue returns the JSON zero value for a binding type.
+func zeroValue(typ string) any {
+	switch {
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "float"), typ == "number":
+		return 0
+	case typ == "bool", typ == "boolean":
+		return false
+	case typ == "string", typ == "address", typ == "":
+		return ""
+	case strings.HasPrefix(typ, "map"):
+		return map[string]any{}
+	case strings.HasPrefix(typ, "[]"):
+		return []any{}
+	default:
+		return nil
+	}
+}
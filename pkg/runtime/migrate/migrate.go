@@ -0,0 +1,177 @@
+// Package migrate carries event streams across model versions.
+//
+// A Plan holds declarative upcasters keyed by event type and the schema
+// version the event was written under. Upcasters rename events, rename
+// and drop payload fields, and fill in defaults for fields a new version
+// requires. Events are upcast on read by wrapping a store in
+// UpcastingStore, or rewritten in place with Rewrite once Check has
+// replayed every stream under the new model without failures.
+//
+// Events record their schema version in metadata. Events without one were
+// written before any migration and are read as version 1.
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"strconv"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+// SchemaVersionKey is the event metadata key holding the schema version
+// an event was written or last upcast under.
+const SchemaVersionKey = "schema_version"
+
+// Upcaster rewrites one event type written under one schema version into
+// the shape of the next version. Field renames apply before defaults and
+// drops, so defaults and drops use the new field names.
+type Upcaster struct {
+	EventType string `json:"event_type"`
+	Version   int    `json:"version"`
+
+	RenameTo     string            `json:"rename_to,omitempty"`
+	RenameFields map[string]string `json:"rename_fields,omitempty"`
+	Defaults     map[string]any    `json:"defaults,omitempty"`
+	DropFields   []string          `json:"drop_fields,omitempty"`
+}
+
+// Plan upcasts events to a schema version. Notes record changes a plan
+// suggested from a model diff could not express as upcasters.
+type Plan struct {
+	Version   int        `json:"version"`
+	Upcasters []Upcaster `json:"upcasters"`
+	Notes     []string   `json:"notes,omitempty"`
+}
+
+type upcasterKey struct {
+	eventType string
+	version   int
+}
+
+// LoadPlan reads and validates a plan file.
+func LoadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var plan Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("parsing plan: %w", err)
+	}
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// Validate checks that every upcaster names an event type and a version
+// below the plan's, and that no two upcasters share a key.
+func (p *Plan) Validate() error {
+	if p.Version < 1 {
+		return fmt.Errorf("plan version must be at least 1, got %d", p.Version)
+	}
+	seen := make(map[upcasterKey]bool)
+	for i, u := range p.Upcasters {
+		if u.EventType == "" {
+			return fmt.Errorf("upcaster %d: event_type is required", i)
+		}
+		if u.Version < 1 || u.Version >= p.Version {
+			return fmt.Errorf("upcaster %d (%s): version must be between 1 and %d, got %d", i, u.EventType, p.Version-1, u.Version)
+		}
+		key := upcasterKey{u.EventType, u.Version}
+		if seen[key] {
+			return fmt.Errorf("upcaster %d: duplicate upcaster for %s at version %d", i, u.EventType, u.Version)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// SchemaVersion returns the schema version an event was written under.
+func SchemaVersion(evt *eventsource.Event) int {
+	if v, err := strconv.Atoi(evt.Metadata[SchemaVersionKey]); err == nil {
+		return v
+	}
+	return 1
+}
+
+// Upcast returns a copy of an event brought up to the plan's version by
+// applying the upcasters for each version it passes through. Events at or
+// above the plan's version are returned unchanged. A nil plan upcasts
+// nothing.
+func (p *Plan) Upcast(evt *eventsource.Event) (*eventsource.Event, error) {
+	out, _, err := p.upcast(evt)
+	return out, err
+}
+
+// upcast also reports whether an upcaster changed the event's type or
+// payload, as opposed to only its schema version.
+func (p *Plan) upcast(evt *eventsource.Event) (*eventsource.Event, bool, error) {
+	version := SchemaVersion(evt)
+	if p == nil || version >= p.Version {
+		return evt, false, nil
+	}
+
+	upcasters := make(map[upcasterKey]Upcaster, len(p.Upcasters))
+	for _, u := range p.Upcasters {
+		upcasters[upcasterKey{u.EventType, u.Version}] = u
+	}
+
+	out := *evt
+	out.Metadata = maps.Clone(evt.Metadata)
+	if out.Metadata == nil {
+		out.Metadata = make(map[string]string)
+	}
+
+	changed := false
+	var data map[string]any
+	for ; version < p.Version; version++ {
+		u, ok := upcasters[upcasterKey{out.Type, version}]
+		if !ok {
+			continue
+		}
+		if data == nil {
+			data = make(map[string]any)
+			if len(out.Data) > 0 {
+				if err := json.Unmarshal(out.Data, &data); err != nil {
+					return nil, false, fmt.Errorf("event %s: decoding payload: %w", evt.ID, err)
+				}
+			}
+		}
+		u.apply(&out, data)
+		changed = true
+	}
+
+	if changed {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return nil, false, fmt.Errorf("event %s: encoding payload: %w", evt.ID, err)
+		}
+		out.Data = encoded
+	}
+	out.Metadata[SchemaVersionKey] = strconv.Itoa(p.Version)
+	return &out, changed, nil
+}
+
+func (u Upcaster) apply(evt *eventsource.Event, data map[string]any) {
+	if u.RenameTo != "" {
+		evt.Type = u.RenameTo
+	}
+	for from, to := range u.RenameFields {
+		if v, ok := data[from]; ok {
+			delete(data, from)
+			data[to] = v
+		}
+	}
+	for field, v := range u.Defaults {
+		if _, ok := data[field]; !ok {
+			data[field] = v
+		}
+	}
+	for _, field := range u.DropFields {
+		delete(data, field)
+	}
+}
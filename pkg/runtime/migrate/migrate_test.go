@@ -0,0 +1,265 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/eventstore"
+)
+
+// expenseV1 files expenses with submit, and managers approve or reject them.
+const expenseV1 = `{
+	"name": "expense",
+	"places": [{"id": "draft", "initial": 1}, {"id": "submitted"}, {"id": "approved"}],
+	"transitions": [
+		{"id": "submit", "bindings": [{"name": "amount", "type": "int64"}]},
+		{"id": "approve", "bindings": [{"name": "note", "type": "string"}]},
+		{"id": "reject"}
+	],
+	"arcs": [
+		{"from": "draft", "to": "submit"}, {"from": "submit", "to": "submitted"},
+		{"from": "submitted", "to": "approve"}, {"from": "approve", "to": "approved"},
+		{"from": "submitted", "to": "reject"}, {"from": "reject", "to": "draft"}
+	]
+}`
+
+// expenseV2 renames submit to file, makes approve record an approver
+// instead of a note, and drops reject.
+const expenseV2 = `{
+	"name": "expense",
+	"places": [{"id": "draft", "initial": 1}, {"id": "submitted"}, {"id": "approved"}],
+	"transitions": [
+		{"id": "file", "bindings": [{"name": "amount", "type": "int64"}]},
+		{"id": "approve", "bindings": [{"name": "approver", "type": "string"}]}
+	],
+	"arcs": [
+		{"from": "draft", "to": "file"}, {"from": "file", "to": "submitted"},
+		{"from": "submitted", "to": "approve"}, {"from": "approve", "to": "approved"}
+	]
+}`
+
+func parseModel(t *testing.T, data string) *goflowmodel.Model {
+	t.Helper()
+	var model goflowmodel.Model
+	if err := json.Unmarshal([]byte(data), &model); err != nil {
+		t.Fatalf("parse model: %v", err)
+	}
+	return &model
+}
+
+func TestUpcast(t *testing.T) {
+	plan := &Plan{Version: 3, Upcasters: []Upcaster{
+		{EventType: "Submitted", Version: 1, RenameTo: "Filed", RenameFields: map[string]string{"total": "amount"}},
+		{EventType: "Filed", Version: 2, Defaults: map[string]any{"currency": "EUR", "amount": 0}, DropFields: []string{"legacy"}},
+	}}
+	if err := plan.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	evt := &eventsource.Event{ID: "e1", Type: "Submitted", Data: json.RawMessage(`{"total": 12, "legacy": true}`)}
+	got, err := plan.Upcast(evt)
+	if err != nil {
+		t.Fatalf("upcast: %v", err)
+	}
+	var data map[string]any
+	if err := json.Unmarshal(got.Data, &data); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	want := map[string]any{"amount": float64(12), "currency": "EUR"}
+	if got.Type != "Filed" || !reflect.DeepEqual(data, want) || SchemaVersion(got) != 3 {
+		t.Errorf("expected a version 3 Filed event with %v, got %s %s v%d", want, got.Type, got.Data, SchemaVersion(got))
+	}
+	if evt.Type != "Submitted" || evt.Metadata != nil {
+		t.Errorf("expected the original event to be untouched, got %+v", evt)
+	}
+
+	// Events already at the plan's version are left alone
+	current := &eventsource.Event{Type: "Submitted", Metadata: map[string]string{SchemaVersionKey: "3"}}
+	if got, _ := plan.Upcast(current); got != current {
+		t.Errorf("expected a current event to pass through, got %+v", got)
+	}
+
+	for _, bad := range []*Plan{
+		{Version: 0},
+		{Version: 2, Upcasters: []Upcaster{{EventType: "Submitted", Version: 2}}},
+		{Version: 2, Upcasters: []Upcaster{{EventType: "Submitted", Version: 1}, {EventType: "Submitted", Version: 1}}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", bad)
+		}
+	}
+}
+
+// positionedMemoryStore reports sparse log positions, as the Postgres store does.
+type positionedMemoryStore struct {
+	eventsource.Store
+}
+
+func (s positionedMemoryStore) ReadAllPositioned(ctx context.Context, fromPosition int64, limit int) ([]eventstore.PositionedEvent, error) {
+	events, err := s.ReadAll(ctx, fromPosition/10, limit)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]eventstore.PositionedEvent, len(events))
+	for i, evt := range events {
+		entries[i] = eventstore.PositionedEvent{Position: (fromPosition/10 + int64(i) + 1) * 10, Event: evt}
+	}
+	return entries, nil
+}
+
+func TestUpcastKeepsLogPositions(t *testing.T) {
+	ctx := context.Background()
+	plan := &Plan{Version: 2, Upcasters: []Upcaster{{EventType: "Submitted", Version: 1, RenameTo: "Filed"}}}
+
+	if _, ok := Upcast(eventsource.NewMemoryStore(), plan).(positionedStore); ok {
+		t.Error("expected a store without positions to stay without them")
+	}
+
+	mem := eventsource.NewMemoryStore()
+	if _, err := mem.Append(ctx, "a1", 0, []*eventsource.Event{{ID: "e1", Type: "Submitted", Data: json.RawMessage(`{}`)}}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	positioned, ok := Upcast(positionedMemoryStore{mem}, plan).(positionedStore)
+	if !ok {
+		t.Fatal("expected the upcasting store to report log positions")
+	}
+	entries, err := positioned.ReadAllPositioned(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Position != 10 || entries[0].Event.Type != "Filed" {
+		t.Errorf("expected the upcast event at position 10, got %+v", entries)
+	}
+}
+
+func TestUpcastingStoreAppend(t *testing.T) {
+	ctx := context.Background()
+	store := NewUpcastingStore(eventsource.NewMemoryStore(), &Plan{Version: 2})
+
+	events := []*eventsource.Event{
+		{ID: "e1", Type: "Filed", Data: json.RawMessage(`{}`)},
+		{ID: "e2", Type: "Filed", Data: json.RawMessage(`{}`), Metadata: map[string]string{SchemaVersionKey: "2"}},
+	}
+	version, err := store.Append(ctx, "a1", 0, events)
+	if err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if version != 2 {
+		t.Errorf("expected stream version 2, got %d", version)
+	}
+	for i, evt := range events {
+		if evt.Version != i+1 || evt.StreamID != "a1" {
+			t.Errorf("expected event %d to carry its stream position, got %+v", i, evt)
+		}
+	}
+	if events[0].Metadata != nil {
+		t.Errorf("expected the caller's metadata untouched, got %v", events[0].Metadata)
+	}
+
+	stored, _ := store.Read(ctx, "a1", 0)
+	if len(stored) != 2 || SchemaVersion(stored[0]) != 2 {
+		t.Errorf("expected stored events stamped with version 2, got %+v", stored)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	v1, v2 := parseModel(t, expenseV1), parseModel(t, expenseV2)
+	diff := Compare(v1, v2)
+	if !reflect.DeepEqual(diff.BindingsAdded, []string{"approve.approver"}) || !reflect.DeepEqual(diff.BindingsRemoved, []string{"approve.note"}) {
+		t.Errorf("expected approve's bindings to change, got %+v", diff)
+	}
+
+	plan := Suggest(diff, v1, v2)
+	want := []Upcaster{
+		{EventType: "Approved", Version: 1, Defaults: map[string]any{"approver": ""}, DropFields: []string{"note"}},
+		{EventType: "Submited", Version: 1, RenameTo: "Filed"},
+	}
+	if plan.Version != 2 || !reflect.DeepEqual(plan.Upcasters, want) {
+		t.Errorf("expected upcasters %+v, got %+v", want, plan.Upcasters)
+	}
+	if len(plan.Notes) != 2 || !strings.Contains(plan.Notes[0], "transition reject was removed") {
+		t.Errorf("expected notes on reject and the approver default, got %q", plan.Notes)
+	}
+	if err := plan.Validate(); err != nil {
+		t.Errorf("expected a valid plan, got %v", err)
+	}
+}
+
+func TestCheckAndRewrite(t *testing.T) {
+	ctx := context.Background()
+	v1, v2 := parseModel(t, expenseV1), parseModel(t, expenseV2)
+	store := eventsource.NewMemoryStore()
+	eng := NewEngine(v1, store)
+	for _, step := range []struct{ id, action string }{
+		{"approved", "submit"}, {"approved", "approve"},
+		{"rejected", "submit"}, {"rejected", "reject"},
+		{"pending", "submit"},
+	} {
+		if err := eng.Execute(ctx, step.id, step.action, map[string]any{"amount": 10, "note": "ok"}); err != nil {
+			t.Fatalf("%s %s: %v", step.id, step.action, err)
+		}
+	}
+	// Internal streams are not aggregates and are not replayed
+	grant := &eventsource.Event{ID: "g1", Type: "RoleGranted", Data: json.RawMessage(`{"user":"alice","role":"manager"}`)}
+	if _, err := store.Append(ctx, "__role_assignments__", 0, []*eventsource.Event{grant}); err != nil {
+		t.Fatalf("grant: %v", err)
+	}
+
+	report, err := Check(ctx, store, v2, nil)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if report.Streams != 3 || report.Events != 5 || len(report.Failures) != 3 {
+		t.Errorf("expected every stream to fail without a plan, got %+v", report)
+	}
+
+	plan := Suggest(Compare(v1, v2), v1, v2)
+	report, err = Check(ctx, store, v2, plan)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if report.Upcast != 4 || len(report.Failures) != 1 || report.Failures[0].StreamID != "rejected" {
+		t.Fatalf("expected only the rejected stream to fail, got %+v", report)
+	}
+
+	// Upcast on read: the new model replays old history unchanged on disk
+	upcasting := NewEngine(v2, NewUpcastingStore(store, plan))
+	if err := upcasting.Execute(ctx, "pending", "approve", map[string]any{"approver": "carol"}); err != nil {
+		t.Fatalf("approve pending under v2: %v", err)
+	}
+	events, _ := store.Read(ctx, "pending", 0)
+	if events[0].Type != "Submited" || SchemaVersion(events[1]) != 2 {
+		t.Errorf("expected old events kept and new ones stamped, got %s v%d", events[0].Type, SchemaVersion(events[1]))
+	}
+
+	if err := store.DeleteStream(ctx, "rejected"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	rewritten := eventsource.NewMemoryStore()
+	n, err := Rewrite(ctx, store, rewritten, plan)
+	if err != nil {
+		t.Fatalf("rewrite: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 streams rewritten, got %d", n)
+	}
+	report, err = Check(ctx, rewritten, v2, nil)
+	if err != nil || !report.OK() || report.Streams != 2 || report.Events != 4 {
+		t.Errorf("expected rewritten streams to replay without a plan, got %+v %v", report, err)
+	}
+	events, _ = rewritten.Read(ctx, "approved", 0)
+	if events[0].Type != "Filed" || !strings.Contains(string(events[1].Data), `"approver":""`) {
+		t.Errorf("expected rewritten events, got %s %s", events[0].Type, events[1].Data)
+	}
+
+	// The source store is left as it was
+	events, _ = store.Read(ctx, "approved", 0)
+	if events[0].Type != "Submited" {
+		t.Errorf("expected source events untouched, got %s", events[0].Type)
+	}
+}
@@ -0,0 +1,125 @@
+package migrate
+
+import (
+	"context"
+	"maps"
+	"strconv"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/eventstore"
+)
+
+// UpcastingStore wraps an event store so reads return events upcast to a
+// plan's version and appends record that version, leaving stored history
+// untouched.
+type UpcastingStore struct {
+	eventsource.Store
+	plan *Plan
+}
+
+// NewUpcastingStore wraps store with plan's upcasters.
+func NewUpcastingStore(store eventsource.Store, plan *Plan) *UpcastingStore {
+	return &UpcastingStore{Store: store, plan: plan}
+}
+
+// Upcast wraps store with plan's upcasters like NewUpcastingStore, and keeps
+// the log positions of stores that report them, such as the Postgres store,
+// so projections and predictions can still checkpoint by position.
+func Upcast(store eventsource.Store, plan *Plan) eventsource.Store {
+	upcasting := NewUpcastingStore(store, plan)
+	if positioned, ok := store.(positionedStore); ok {
+		return &positionedUpcastingStore{UpcastingStore: upcasting, positioned: positioned}
+	}
+	return upcasting
+}
+
+// positionedStore is implemented by stores that report log positions.
+type positionedStore interface {
+	ReadAllPositioned(ctx context.Context, fromPosition int64, limit int) ([]eventstore.PositionedEvent, error)
+}
+
+// positionedUpcastingStore is an UpcastingStore over a positioned store.
+type positionedUpcastingStore struct {
+	*UpcastingStore
+	positioned positionedStore
+}
+
+// ReadAllPositioned returns events from all streams after fromPosition,
+// upcast, with their log positions.
+func (s *positionedUpcastingStore) ReadAllPositioned(ctx context.Context, fromPosition int64, limit int) ([]eventstore.PositionedEvent, error) {
+	entries, err := s.positioned.ReadAllPositioned(ctx, fromPosition, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]eventstore.PositionedEvent, len(entries))
+	for i, entry := range entries {
+		upcast, err := s.plan.Upcast(entry.Event)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = eventstore.PositionedEvent{Position: entry.Position, Event: upcast}
+	}
+	return out, nil
+}
+
+// Append stamps events without a schema version with the plan's version.
+// The stamped events are copies, so the fields the store sets on append
+// are copied back to the caller's events.
+func (s *UpcastingStore) Append(ctx context.Context, streamID string, expectedVersion int, events []*eventsource.Event) (int, error) {
+	if s.plan == nil {
+		return s.Store.Append(ctx, streamID, expectedVersion, events)
+	}
+	stamped := make([]*eventsource.Event, len(events))
+	for i, evt := range events {
+		stamped[i] = evt
+		if _, ok := evt.Metadata[SchemaVersionKey]; !ok {
+			e := *evt
+			e.Metadata = maps.Clone(evt.Metadata)
+			if e.Metadata == nil {
+				e.Metadata = make(map[string]string)
+			}
+			e.Metadata[SchemaVersionKey] = strconv.Itoa(s.plan.Version)
+			stamped[i] = &e
+		}
+	}
+	version, err := s.Store.Append(ctx, streamID, expectedVersion, stamped)
+	for i, evt := range events {
+		if stamped[i] != evt {
+			evt.ID = stamped[i].ID
+			evt.StreamID = stamped[i].StreamID
+			evt.Version = stamped[i].Version
+			evt.Timestamp = stamped[i].Timestamp
+		}
+	}
+	return version, err
+}
+
+// Read returns a stream's events after fromVersion, upcast.
+func (s *UpcastingStore) Read(ctx context.Context, streamID string, fromVersion int) ([]*eventsource.Event, error) {
+	events, err := s.Store.Read(ctx, streamID, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	return s.upcastAll(events)
+}
+
+// ReadAll returns events from all streams after fromPosition, upcast.
+func (s *UpcastingStore) ReadAll(ctx context.Context, fromPosition int64, limit int) ([]*eventsource.Event, error) {
+	events, err := s.Store.ReadAll(ctx, fromPosition, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.upcastAll(events)
+}
+
+func (s *UpcastingStore) upcastAll(events []*eventsource.Event) ([]*eventsource.Event, error) {
+	out := make([]*eventsource.Event, len(events))
+	for i, evt := range events {
+		upcast, err := s.plan.Upcast(evt)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = upcast
+	}
+	return out, nil
+}
@@ -0,0 +1,629 @@
+// Package saga runs durable multi-step workflows across aggregates.
+//
+// A Definition lists steps: actions that call registered handlers,
+// conditions evaluated against the instance's data, waits that park the
+// instance until a durable timer expires, and parallel groups that run
+// several actions at once. Each step continues to its OnSuccess or
+// OnFailure step, or to the next step in declaration order. A step that
+// has failed MaxFailures times in an instance no longer diverts to its
+// OnFailure step, so failure loops end.
+//
+// Every change to an instance is saved to a Store before the engine moves
+// on, so an instance interrupted by a restart is picked up again by
+// Resume. A step that was running when the process stopped runs again, so
+// handlers should be idempotent.
+//
+// When a step fails and has no OnFailure step, the engine compensates:
+// it calls the Compensate handler of every completed step in reverse
+// order. An instance whose compensation fails, or whose first step fails
+// with nothing to undo, is left failed until Retry is called.
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/dsl"
+)
+
+// MaxFailures is how many times a step may fail and continue with its
+// OnFailure step in one run of an instance. Past it the step fails as if
+// it had no OnFailure step. Retry starts the count again.
+const MaxFailures = 5
+
+// StepType is the kind of a workflow step.
+type StepType string
+
+const (
+	// StepAction calls the handler registered for the step's Action.
+	StepAction StepType = "action"
+
+	// StepCondition evaluates the step's Condition against the instance
+	// data, and fails when it is false.
+	StepCondition StepType = "condition"
+
+	// StepWait parks the instance until the step's Wait has elapsed.
+	StepWait StepType = "wait"
+
+	// StepParallel runs the steps listed in Parallel concurrently and
+	// succeeds when all of them do.
+	StepParallel StepType = "parallel"
+)
+
+// Step is one step of a workflow.
+type Step struct {
+	ID   string
+	Type StepType
+
+	// Action is the handler key for action steps, e.g. "order.reserve".
+	Action string
+
+	// Condition is a guard expression for condition steps.
+	Condition string
+
+	// Wait is how long a wait step parks the instance.
+	Wait time.Duration
+
+	// Input maps handler input keys to instance data keys.
+	Input map[string]string
+
+	// OnSuccess and OnFailure name the step to continue with. An empty
+	// OnSuccess continues with the next step in declaration order; an
+	// empty OnFailure compensates and stops.
+	OnSuccess string
+	OnFailure string
+
+	// Compensate is the handler key that undoes a completed action step.
+	// It receives the same input as the action.
+	Compensate string
+
+	// Parallel lists the steps a parallel step runs. They must be action
+	// or condition steps, and are skipped in declaration order.
+	Parallel []string
+}
+
+// Definition is a workflow. It starts at its first step that is not run
+// by a parallel step.
+type Definition struct {
+	ID    string
+	Name  string
+	Steps []Step
+}
+
+// Handler performs an action or compensation with its mapped input.
+type Handler func(ctx context.Context, input map[string]any) error
+
+// Status is the state of a workflow instance.
+type Status string
+
+const (
+	// StatusRunning instances are executing a step, or were when the
+	// process stopped.
+	StatusRunning Status = "running"
+
+	// StatusWaiting instances are parked on a wait step until WakeAt.
+	StatusWaiting Status = "waiting"
+
+	// StatusCompleted instances ran to the end of the workflow.
+	StatusCompleted Status = "completed"
+
+	// StatusCompensated instances failed and had their completed steps
+	// undone.
+	StatusCompensated Status = "compensated"
+
+	// StatusFailed instances are stuck: a step failed with nothing to
+	// undo, or a compensation failed. Retry picks them up again.
+	StatusFailed Status = "failed"
+)
+
+// Instance is a persisted run of a workflow.
+type Instance struct {
+	ID         string         `json:"id"`
+	WorkflowID string         `json:"workflow_id"`
+	Status     Status         `json:"status"`
+	Data       map[string]any `json:"data"`
+
+	// Step is the step to run next, or the step that failed.
+	Step string `json:"step,omitempty"`
+
+	// Completed lists completed steps with a Compensate handler, oldest
+	// first. Compensation pops them from the end.
+	Completed []string `json:"completed,omitempty"`
+
+	// Parallel lists the members of the current parallel step that have
+	// succeeded, so a retry runs only the others.
+	Parallel []string `json:"parallel,omitempty"`
+
+	// Failures counts how often each step has failed since the instance
+	// started or was last retried.
+	Failures map[string]int `json:"failures,omitempty"`
+
+	// Compensating is set once the instance has started undoing steps.
+	Compensating bool `json:"compensating,omitempty"`
+
+	// WakeAt is when a waiting instance resumes.
+	WakeAt time.Time `json:"wake_at,omitzero"`
+
+	// Attempts counts retries.
+	Attempts int `json:"attempts,omitempty"`
+
+	// Error is the failure that stopped or diverted the instance.
+	Error string `json:"error,omitempty"`
+
+	History   []Record  `json:"history,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Record is one entry of an instance's history.
+type Record struct {
+	Step   string    `json:"step"`
+	Result string    `json:"result"` // succeeded, failed, waiting, compensated
+	Error  string    `json:"error,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+var (
+	// ErrNotFound is returned for unknown instances.
+	ErrNotFound = errors.New("workflow instance not found")
+
+	// ErrBusy is returned when an instance is already being run.
+	ErrBusy = errors.New("workflow instance is already running")
+
+	// ErrNotFailed is returned when retrying an instance that is not failed.
+	ErrNotFailed = errors.New("only failed workflow instances can be retried")
+)
+
+// Engine runs workflow instances and persists them in a Store.
+type Engine struct {
+	store    Store
+	defs     map[string]*Definition
+	handlers map[string]Handler
+	now      func() time.Time
+
+	mu     sync.Mutex
+	active map[string]bool
+	seq    int64
+}
+
+// NewEngine creates an engine that persists instances in store.
+func NewEngine(store Store) *Engine {
+	return &Engine{
+		store:    store,
+		defs:     make(map[string]*Definition),
+		handlers: make(map[string]Handler),
+		now:      time.Now,
+		active:   make(map[string]bool),
+	}
+}
+
+// Handle registers the handler for an action or compensation key.
+func (e *Engine) Handle(key string, h Handler) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.handlers[key] = h
+}
+
+// Register adds a workflow definition after checking that every step it
+// references exists and that parallel groups hold only actions and
+// conditions.
+func (e *Engine) Register(def Definition) error {
+	if len(def.Steps) == 0 {
+		return fmt.Errorf("workflow %s has no steps", def.ID)
+	}
+	steps := make(map[string]*Step, len(def.Steps))
+	for i := range def.Steps {
+		s := &def.Steps[i]
+		if _, dup := steps[s.ID]; dup {
+			return fmt.Errorf("workflow %s: duplicate step %s", def.ID, s.ID)
+		}
+		steps[s.ID] = s
+	}
+	for _, s := range def.Steps {
+		for _, target := range []string{s.OnSuccess, s.OnFailure} {
+			if _, ok := steps[target]; target != "" && !ok {
+				return fmt.Errorf("workflow %s: step %s continues with unknown step %s", def.ID, s.ID, target)
+			}
+		}
+		switch s.Type {
+		case StepAction:
+			if s.Action == "" {
+				return fmt.Errorf("workflow %s: action step %s has no action", def.ID, s.ID)
+			}
+		case StepCondition, StepWait:
+		case StepParallel:
+			if len(s.Parallel) == 0 {
+				return fmt.Errorf("workflow %s: parallel step %s has no steps", def.ID, s.ID)
+			}
+			for _, id := range s.Parallel {
+				member, ok := steps[id]
+				if !ok {
+					return fmt.Errorf("workflow %s: parallel step %s runs unknown step %s", def.ID, s.ID, id)
+				}
+				if member.Type != StepAction && member.Type != StepCondition {
+					return fmt.Errorf("workflow %s: parallel step %s can only run actions and conditions, not %s", def.ID, s.ID, id)
+				}
+			}
+		default:
+			return fmt.Errorf("workflow %s: step %s has unknown type %q", def.ID, s.ID, s.Type)
+		}
+	}
+	if next(&def, "", "") == "" {
+		return fmt.Errorf("workflow %s has no steps outside parallel groups", def.ID)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.defs[def.ID] = &def
+	return nil
+}
+
+// Start creates an instance of a workflow with data and runs it until it
+// waits, completes or fails.
+func (e *Engine) Start(ctx context.Context, workflowID string, data map[string]any) (*Instance, error) {
+	e.mu.Lock()
+	def, ok := e.defs[workflowID]
+	e.seq++
+	seq := e.seq
+	e.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown workflow: %s", workflowID)
+	}
+
+	now := e.now()
+	inst := &Instance{
+		ID:         fmt.Sprintf("%s-%d-%d", workflowID, now.UnixNano(), seq),
+		WorkflowID: workflowID,
+		Status:     StatusRunning,
+		Data:       maps.Clone(data),
+		Step:       next(def, "", ""),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if inst.Data == nil {
+		inst.Data = make(map[string]any)
+	}
+
+	release, err := e.acquire(inst.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if err := e.save(ctx, inst); err != nil {
+		return nil, err
+	}
+	return e.run(ctx, inst.ID)
+}
+
+// Get returns an instance.
+func (e *Engine) Get(ctx context.Context, id string) (*Instance, error) {
+	return e.store.Load(ctx, id)
+}
+
+// List returns the instances with a status, or all instances when status
+// is empty.
+func (e *Engine) List(ctx context.Context, status Status) ([]*Instance, error) {
+	return e.store.List(ctx, status)
+}
+
+// Retry runs a failed instance again: the failed step, or the remaining
+// compensations if it was compensating.
+func (e *Engine) Retry(ctx context.Context, id string) (*Instance, error) {
+	release, err := e.acquire(id)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	inst, err := e.store.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if inst.Status != StatusFailed {
+		return nil, fmt.Errorf("instance %s is %s: %w", id, inst.Status, ErrNotFailed)
+	}
+	inst.Status = StatusRunning
+	inst.Attempts++
+	inst.Failures = nil
+	inst.Error = ""
+	if err := e.save(ctx, inst); err != nil {
+		return nil, err
+	}
+	return e.run(ctx, id)
+}
+
+// Resume runs every instance left running by a stopped process and every
+// waiting instance whose timer has expired. Call it at startup and then
+// periodically to fire durable timers. Instances of workflows not registered
+// with this engine belong to another engine sharing the store and are
+// skipped.
+func (e *Engine) Resume(ctx context.Context) error {
+	var errs []error
+	for _, status := range []Status{StatusRunning, StatusWaiting} {
+		instances, err := e.store.List(ctx, status)
+		if err != nil {
+			return err
+		}
+		for _, inst := range instances {
+			if inst.Status == StatusWaiting && e.now().Before(inst.WakeAt) {
+				continue
+			}
+			if !e.registered(inst.WorkflowID) {
+				continue
+			}
+			if _, err := e.Run(ctx, inst.ID); err != nil && !errors.Is(err, ErrBusy) {
+				errs = append(errs, fmt.Errorf("instance %s: %w", inst.ID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// registered reports whether a workflow is registered with the engine.
+func (e *Engine) registered(workflowID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, ok := e.defs[workflowID]
+	return ok
+}
+
+// Run advances an instance until it waits, completes or fails, saving it
+// after every step. Instances that are not running or due are returned
+// unchanged.
+func (e *Engine) Run(ctx context.Context, id string) (*Instance, error) {
+	release, err := e.acquire(id)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return e.run(ctx, id)
+}
+
+// acquire marks an instance as being run by this engine, and returns the
+// function that releases it.
+func (e *Engine) acquire(id string) (func(), error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.active[id] {
+		return nil, ErrBusy
+	}
+	e.active[id] = true
+	return func() {
+		e.mu.Lock()
+		delete(e.active, id)
+		e.mu.Unlock()
+	}, nil
+}
+
+func (e *Engine) run(ctx context.Context, id string) (*Instance, error) {
+	inst, err := e.store.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	def, ok := e.defs[inst.WorkflowID]
+	e.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown workflow: %s", inst.WorkflowID)
+	}
+
+	if inst.Status == StatusWaiting {
+		if e.now().Before(inst.WakeAt) {
+			return inst, nil
+		}
+		inst.Status = StatusRunning
+		inst.WakeAt = time.Time{}
+		e.record(inst, inst.Step, "succeeded", nil)
+		inst.Step = next(def, inst.Step, step(def, inst.Step).OnSuccess)
+		if err := e.save(ctx, inst); err != nil {
+			return nil, err
+		}
+	}
+
+	for inst.Status == StatusRunning {
+		if err := ctx.Err(); err != nil {
+			return inst, err
+		}
+		if inst.Compensating {
+			e.compensate(ctx, def, inst)
+		} else if inst.Step == "" {
+			inst.Status = StatusCompleted
+		} else {
+			e.advance(ctx, def, inst)
+		}
+		if err := e.save(ctx, inst); err != nil {
+			return nil, err
+		}
+	}
+	return inst, nil
+}
+
+// advance runs the instance's current step and moves it on.
+func (e *Engine) advance(ctx context.Context, def *Definition, inst *Instance) {
+	current := step(def, inst.Step)
+
+	if current.Type == StepWait {
+		inst.Status = StatusWaiting
+		inst.WakeAt = e.now().Add(current.Wait)
+		e.record(inst, current.ID, "waiting", nil)
+		return
+	}
+
+	var err error
+	if current.Type == StepParallel {
+		err = e.runParallel(ctx, def, inst, current)
+	} else {
+		err = e.runStep(ctx, inst, current)
+		if err == nil && current.Compensate != "" {
+			inst.Completed = append(inst.Completed, current.ID)
+		}
+	}
+	if err == nil {
+		e.record(inst, current.ID, "succeeded", nil)
+		inst.Step = next(def, current.ID, current.OnSuccess)
+		inst.Parallel = nil
+		return
+	}
+
+	e.record(inst, current.ID, "failed", err)
+	inst.Error = fmt.Sprintf("step %s: %v", current.ID, err)
+	if inst.Failures == nil {
+		inst.Failures = make(map[string]int)
+	}
+	inst.Failures[current.ID]++
+	switch {
+	case current.OnFailure != "" && inst.Failures[current.ID] <= MaxFailures:
+		inst.Step = current.OnFailure
+		inst.Parallel = nil
+	case len(inst.Completed) > 0:
+		inst.Compensating = true
+		inst.Parallel = nil
+	default:
+		inst.Status = StatusFailed
+	}
+}
+
+// runParallel runs a parallel group's steps concurrently, skipping members
+// that succeeded on an earlier attempt. Members that succeed are recorded
+// for compensation even when a sibling fails.
+func (e *Engine) runParallel(ctx context.Context, def *Definition, inst *Instance, group *Step) error {
+	errs := make([]error, len(group.Parallel))
+	var wg sync.WaitGroup
+	for i, id := range group.Parallel {
+		if slices.Contains(inst.Parallel, id) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = e.runStep(ctx, inst, step(def, id))
+		}()
+	}
+	wg.Wait()
+
+	var failed []error
+	for i, id := range group.Parallel {
+		if slices.Contains(inst.Parallel, id) {
+			continue
+		}
+		if errs[i] != nil {
+			e.record(inst, id, "failed", errs[i])
+			failed = append(failed, fmt.Errorf("%s: %w", id, errs[i]))
+			continue
+		}
+		e.record(inst, id, "succeeded", nil)
+		inst.Parallel = append(inst.Parallel, id)
+		if step(def, id).Compensate != "" {
+			inst.Completed = append(inst.Completed, id)
+		}
+	}
+	return errors.Join(failed...)
+}
+
+// runStep runs an action or condition step. It only reads the instance.
+func (e *Engine) runStep(ctx context.Context, inst *Instance, s *Step) error {
+	if s.Type == StepCondition {
+		ok, err := dsl.Evaluate(s.Condition, inst.Data, nil)
+		if err != nil {
+			return fmt.Errorf("evaluating condition: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("condition not met: %s", s.Condition)
+		}
+		return nil
+	}
+	return e.call(ctx, s.Action, inst, s)
+}
+
+// compensate undoes the most recently completed step, and finishes the
+// instance once nothing is left to undo.
+func (e *Engine) compensate(ctx context.Context, def *Definition, inst *Instance) {
+	if len(inst.Completed) == 0 {
+		inst.Status = StatusCompensated
+		return
+	}
+	last := step(def, inst.Completed[len(inst.Completed)-1])
+	if err := e.call(ctx, last.Compensate, inst, last); err != nil {
+		e.record(inst, last.ID, "failed", err)
+		inst.Error = fmt.Sprintf("compensating step %s: %v", last.ID, err)
+		inst.Status = StatusFailed
+		return
+	}
+	e.record(inst, last.ID, "compensated", nil)
+	inst.Completed = inst.Completed[:len(inst.Completed)-1]
+}
+
+// call runs the handler for key with the step's input mapped from the
+// instance data.
+func (e *Engine) call(ctx context.Context, key string, inst *Instance, s *Step) error {
+	e.mu.Lock()
+	h, ok := e.handlers[key]
+	e.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("handler not found: %s", key)
+	}
+	input := make(map[string]any, len(s.Input))
+	for k, v := range s.Input {
+		if val, ok := inst.Data[v]; ok {
+			input[k] = val
+		}
+	}
+	return h(ctx, input)
+}
+
+func (e *Engine) record(inst *Instance, stepID, result string, err error) {
+	r := Record{Step: stepID, Result: result, At: e.now()}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	inst.History = append(inst.History, r)
+}
+
+func (e *Engine) save(ctx context.Context, inst *Instance) error {
+	inst.UpdatedAt = e.now()
+	if err := e.store.Save(ctx, inst); err != nil {
+		return fmt.Errorf("saving instance: %w", err)
+	}
+	return nil
+}
+
+// step returns a registered step by ID.
+func step(def *Definition, id string) *Step {
+	for i := range def.Steps {
+		if def.Steps[i].ID == id {
+			return &def.Steps[i]
+		}
+	}
+	return nil
+}
+
+// next returns the step after id: onSuccess if set, otherwise the next
+// step in declaration order that is not a member of a parallel group.
+// With an empty id it returns the first such step, and at the end of the
+// workflow it returns "".
+func next(def *Definition, id, onSuccess string) string {
+	if onSuccess != "" {
+		return onSuccess
+	}
+	members := make(map[string]bool)
+	for _, s := range def.Steps {
+		for _, m := range s.Parallel {
+			members[m] = true
+		}
+	}
+	after := id == ""
+	for _, s := range def.Steps {
+		if after && !members[s.ID] {
+			return s.ID
+		}
+		if s.ID == id {
+			after = true
+		}
+	}
+	return ""
+}
@@ -0,0 +1,323 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// calls records handler invocations.
+type calls struct {
+	mu  sync.Mutex
+	log []string
+}
+
+func (c *calls) handler(key string, err error) Handler {
+	return func(ctx context.Context, input map[string]any) error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.log = append(c.log, key)
+		return err
+	}
+}
+
+func (c *calls) list() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.log...)
+}
+
+// order reserves stock, charges the card, then waits and ships.
+var order = Definition{
+	ID: "order",
+	Steps: []Step{
+		{ID: "reserve", Type: StepAction, Action: "stock.reserve", Compensate: "stock.release", Input: map[string]string{"sku": "sku"}},
+		{ID: "charge", Type: StepAction, Action: "card.charge", Compensate: "card.refund"},
+		{ID: "cool_off", Type: StepWait, Wait: time.Hour},
+		{ID: "ship", Type: StepAction, Action: "parcel.ship"},
+	},
+}
+
+func newEngine(t *testing.T, c *calls, failing ...string) (*Engine, *time.Time) {
+	t.Helper()
+	e := NewEngine(NewMemoryStore())
+	now := time.Unix(1700000000, 0)
+	e.now = func() time.Time { return now }
+	for _, key := range []string{"stock.reserve", "stock.release", "card.charge", "card.refund", "parcel.ship", "mail.send", "audit.log"} {
+		var err error
+		for _, f := range failing {
+			if f == key {
+				err = errors.New(key + " failed")
+			}
+		}
+		e.Handle(key, c.handler(key, err))
+	}
+	if err := e.Register(order); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	return e, &now
+}
+
+func TestEngineWaitsAndResumes(t *testing.T) {
+	ctx := context.Background()
+	c := &calls{}
+	e, now := newEngine(t, c)
+
+	inst, err := e.Start(ctx, "order", map[string]any{"sku": "A1"})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if inst.Status != StatusWaiting || inst.Step != "cool_off" {
+		t.Fatalf("expected waiting on cool_off, got %s on %s", inst.Status, inst.Step)
+	}
+	if !inst.WakeAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected wake at %v, got %v", now.Add(time.Hour), inst.WakeAt)
+	}
+
+	// A restarted engine over the same store picks the instance up once
+	// its timer expires
+	restarted := NewEngine(e.store)
+	restarted.now = func() time.Time { return *now }
+	restarted.handlers = e.handlers
+	restarted.Register(order)
+
+	if err := restarted.Resume(ctx); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if got, _ := restarted.Get(ctx, inst.ID); got.Status != StatusWaiting {
+		t.Fatalf("expected still waiting before the timer, got %s", got.Status)
+	}
+
+	*now = now.Add(time.Hour)
+	if err := restarted.Resume(ctx); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	got, _ := restarted.Get(ctx, inst.ID)
+	if got.Status != StatusCompleted {
+		t.Fatalf("expected completed, got %s (%s)", got.Status, got.Error)
+	}
+	if want := []string{"stock.reserve", "card.charge", "parcel.ship"}; !reflect.DeepEqual(c.list(), want) {
+		t.Errorf("expected calls %v, got %v", want, c.list())
+	}
+}
+
+func TestEngineResumeSkipsUnregisteredWorkflows(t *testing.T) {
+	ctx := context.Background()
+	c := &calls{}
+	e, now := newEngine(t, c)
+
+	inst, err := e.Start(ctx, "order", nil)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	*now = now.Add(time.Hour)
+
+	// Another service sharing the store does not own the instance
+	other := NewEngine(e.store)
+	other.now = func() time.Time { return *now }
+	if err := other.Resume(ctx); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if got, _ := other.Get(ctx, inst.ID); got.Status != StatusWaiting {
+		t.Errorf("expected instance left waiting, got %s", got.Status)
+	}
+}
+
+func TestEngineCompensates(t *testing.T) {
+	ctx := context.Background()
+	c := &calls{}
+	e, now := newEngine(t, c, "parcel.ship")
+
+	inst, _ := e.Start(ctx, "order", nil)
+	*now = now.Add(time.Hour)
+	inst, err := e.Run(ctx, inst.ID)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if inst.Status != StatusCompensated {
+		t.Fatalf("expected compensated, got %s", inst.Status)
+	}
+	if inst.Error != "step ship: parcel.ship failed" {
+		t.Errorf("unexpected error %q", inst.Error)
+	}
+	want := []string{"stock.reserve", "card.charge", "parcel.ship", "card.refund", "stock.release"}
+	if !reflect.DeepEqual(c.list(), want) {
+		t.Errorf("expected calls %v, got %v", want, c.list())
+	}
+}
+
+func TestEngineRetriesFailedCompensation(t *testing.T) {
+	ctx := context.Background()
+	c := &calls{}
+	e, _ := newEngine(t, c, "card.charge", "stock.release")
+
+	inst, err := e.Start(ctx, "order", nil)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if inst.Status != StatusFailed || !inst.Compensating {
+		t.Fatalf("expected failed while compensating, got %s", inst.Status)
+	}
+	if _, err := e.Retry(ctx, inst.ID); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+
+	// The compensation succeeds once its handler is fixed
+	e.Handle("stock.release", c.handler("stock.release", nil))
+	inst, err = e.Retry(ctx, inst.ID)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if inst.Status != StatusCompensated || inst.Attempts != 2 {
+		t.Fatalf("expected compensated after 2 attempts, got %s after %d", inst.Status, inst.Attempts)
+	}
+	if _, err := e.Retry(ctx, inst.ID); !errors.Is(err, ErrNotFailed) {
+		t.Errorf("expected ErrNotFailed retrying a compensated instance, got %v", err)
+	}
+}
+
+func TestEngineFollowsOnFailureAndParallel(t *testing.T) {
+	ctx := context.Background()
+	c := &calls{}
+	e, _ := newEngine(t, c, "card.charge")
+
+	def := Definition{
+		ID: "signup",
+		Steps: []Step{
+			{ID: "adult", Type: StepCondition, Condition: "age >= 18", OnFailure: "reject"},
+			{ID: "notify", Type: StepParallel, Parallel: []string{"mail", "audit"}, OnSuccess: "done"},
+			{ID: "mail", Type: StepAction, Action: "mail.send"},
+			{ID: "audit", Type: StepAction, Action: "audit.log"},
+			{ID: "reject", Type: StepAction, Action: "card.charge"},
+			{ID: "done", Type: StepAction, Action: "parcel.ship"},
+		},
+	}
+	if err := e.Register(def); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	inst, err := e.Start(ctx, "signup", map[string]any{"age": 30})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if inst.Status != StatusCompleted {
+		t.Fatalf("expected completed, got %s (%s)", inst.Status, inst.Error)
+	}
+	got := c.list()
+	if len(got) != 3 || got[2] != "parcel.ship" {
+		t.Errorf("expected both parallel steps then parcel.ship, got %v", got)
+	}
+
+	// A failed condition diverts to its OnFailure step, whose own failure
+	// leaves nothing to undo
+	inst, _ = e.Start(ctx, "signup", map[string]any{"age": 12})
+	if inst.Status != StatusFailed || inst.Step != "reject" {
+		t.Fatalf("expected failed at reject, got %s at %s", inst.Status, inst.Step)
+	}
+	failed, _ := e.List(ctx, StatusFailed)
+	if len(failed) != 1 || failed[0].ID != inst.ID {
+		t.Errorf("expected the instance to be listed as failed, got %v", failed)
+	}
+}
+
+func TestEngineRetriesOnlyFailedParallelMembers(t *testing.T) {
+	ctx := context.Background()
+	c := &calls{}
+	e, _ := newEngine(t, c, "audit.log")
+
+	def := Definition{
+		ID: "notify",
+		Steps: []Step{
+			{ID: "both", Type: StepParallel, Parallel: []string{"mail", "audit"}},
+			{ID: "mail", Type: StepAction, Action: "mail.send"},
+			{ID: "audit", Type: StepAction, Action: "audit.log"},
+		},
+	}
+	if err := e.Register(def); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	inst, err := e.Start(ctx, "notify", nil)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if inst.Status != StatusFailed || !reflect.DeepEqual(inst.Parallel, []string{"mail"}) {
+		t.Fatalf("expected failed with mail done, got %s with %v", inst.Status, inst.Parallel)
+	}
+
+	// The retry runs audit alone: mail already went out
+	e.Handle("audit.log", c.handler("audit.log", nil))
+	inst, err = e.Retry(ctx, inst.ID)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if inst.Status != StatusCompleted || inst.Parallel != nil {
+		t.Fatalf("expected completed, got %s with %v", inst.Status, inst.Parallel)
+	}
+	mails := 0
+	for _, key := range c.list() {
+		if key == "mail.send" {
+			mails++
+		}
+	}
+	if mails != 1 {
+		t.Errorf("expected mail.send once, got %d times in %v", mails, c.list())
+	}
+}
+
+func TestEngineCapsFailureLoops(t *testing.T) {
+	ctx := context.Background()
+	c := &calls{}
+	e, _ := newEngine(t, c, "card.charge")
+
+	// A failed charge sends a reminder and charges again
+	def := Definition{
+		ID: "dunning",
+		Steps: []Step{
+			{ID: "charge", Type: StepAction, Action: "card.charge", OnFailure: "remind"},
+			{ID: "remind", Type: StepAction, Action: "mail.send", OnSuccess: "charge"},
+		},
+	}
+	if err := e.Register(def); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	inst, err := e.Start(ctx, "dunning", nil)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if inst.Status != StatusFailed || inst.Step != "charge" || inst.Failures["charge"] != MaxFailures+1 {
+		t.Fatalf("expected failed at charge after %d failures, got %s at %s after %v", MaxFailures+1, inst.Status, inst.Step, inst.Failures)
+	}
+
+	// A retry starts the count again
+	inst, err = e.Retry(ctx, inst.ID)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if inst.Status != StatusFailed || inst.Failures["charge"] != MaxFailures+1 {
+		t.Errorf("expected the retry to loop %d times again, got %v", MaxFailures+1, inst.Failures)
+	}
+}
+
+func TestEngineRegisterValidates(t *testing.T) {
+	e := NewEngine(NewMemoryStore())
+	tests := []struct {
+		name string
+		def  Definition
+	}{
+		{"unknown next", Definition{ID: "w", Steps: []Step{{ID: "a", Type: StepWait, OnSuccess: "b"}}}},
+		{"parallel wait", Definition{ID: "w", Steps: []Step{
+			{ID: "a", Type: StepParallel, Parallel: []string{"b"}},
+			{ID: "b", Type: StepWait},
+		}}},
+		{"action without handler key", Definition{ID: "w", Steps: []Step{{ID: "a", Type: StepAction}}}},
+		{"unknown type", Definition{ID: "w", Steps: []Step{{ID: "a", Type: "loop"}}}},
+	}
+	for _, tt := range tests {
+		if err := e.Register(tt.def); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package saga
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// Store persists workflow instances.
+type Store interface {
+	// Save creates or replaces an instance.
+	Save(ctx context.Context, inst *Instance) error
+
+	// Load returns an instance, or ErrNotFound.
+	Load(ctx context.Context, id string) (*Instance, error)
+
+	// List returns the instances with a status, or all instances when
+	// status is empty, oldest first.
+	List(ctx context.Context, status Status) ([]*Instance, error)
+}
+
+// MemoryStore keeps instances in process memory. Instances do not survive
+// a restart; use SQLStore for durable workflows.
+type MemoryStore struct {
+	mu        sync.Mutex
+	instances map[string][]byte
+}
+
+// NewMemoryStore creates an in-memory instance store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{instances: make(map[string][]byte)}
+}
+
+// Save implements Store. It keeps a copy, so later changes to inst are
+// not seen until it is saved again.
+func (s *MemoryStore) Save(ctx context.Context, inst *Instance) error {
+	body, err := json.Marshal(inst)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.instances[inst.ID] = body
+	return nil
+}
+
+// Load implements Store.
+func (s *MemoryStore) Load(ctx context.Context, id string) (*Instance, error) {
+	s.mu.Lock()
+	body, ok := s.instances[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return decodeInstance(body)
+}
+
+// List implements Store.
+func (s *MemoryStore) List(ctx context.Context, status Status) ([]*Instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []*Instance
+	for _, body := range s.instances {
+		inst, err := decodeInstance(body)
+		if err != nil {
+			return nil, err
+		}
+		if status == "" || inst.Status == status {
+			out = append(out, inst)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// SQLStore keeps instances in a SQL table so workflows survive restarts.
+// Queries use "?" placeholders (SQLite, MySQL).
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore creates a SQL-backed instance store.
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+// InitSchema creates the workflow instance table.
+func (s *SQLStore) InitSchema() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS workflow_instances (
+			id TEXT PRIMARY KEY,
+			workflow_id TEXT NOT NULL,
+			status TEXT NOT NULL,
+			body TEXT NOT NULL,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_workflow_instances_status ON workflow_instances(status);
+	`)
+	return err
+}
+
+// Save implements Store.
+func (s *SQLStore) Save(ctx context.Context, inst *Instance) error {
+	body, err := json.Marshal(inst)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO workflow_instances (id, workflow_id, status, body, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET status = excluded.status, body = excluded.body, updated_at = excluded.updated_at
+	`, inst.ID, inst.WorkflowID, string(inst.Status), string(body), inst.CreatedAt.UnixNano(), inst.UpdatedAt.UnixNano())
+	return err
+}
+
+// Load implements Store.
+func (s *SQLStore) Load(ctx context.Context, id string) (*Instance, error) {
+	var body string
+	err := s.db.QueryRowContext(ctx, `SELECT body FROM workflow_instances WHERE id = ?`, id).Scan(&body)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeInstance([]byte(body))
+}
+
+// List implements Store.
+func (s *SQLStore) List(ctx context.Context, status Status) ([]*Instance, error) {
+	query := `SELECT body FROM workflow_instances`
+	var args []any
+	if status != "" {
+		query += ` WHERE status = ?`
+		args = append(args, string(status))
+	}
+	query += ` ORDER BY created_at, id`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Instance
+	for rows.Next() {
+		var body string
+		if err := rows.Scan(&body); err != nil {
+			return nil, err
+		}
+		inst, err := decodeInstance([]byte(body))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, inst)
+	}
+	return out, rows.Err()
+}
+
+func decodeInstance(body []byte) (*Instance, error) {
+	var inst Instance
+	if err := json.Unmarshal(body, &inst); err != nil {
+		return nil, err
+	}
+	return &inst, nil
+}
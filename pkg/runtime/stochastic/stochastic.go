@@ -0,0 +1,466 @@
+// Package stochastic runs discrete stochastic simulations of a model's
+// token net with Gillespie's direct method (SSA).
+//
+// Each replication fires one transition at a time, choosing the next
+// transition and the waiting time before it from the per-transition
+// rates. Reports summarize completion time, throughput and place
+// occupancy across replications. The random source is seeded, so a
+// given seed always produces the same report.
+package stochastic
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/dsl"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+// Kinetics selects how a transition's rate scales with its input tokens.
+type Kinetics string
+
+const (
+	// MassAction multiplies the rate by the number of ways the input
+	// tokens can be chosen, matching the ODE used for prediction.
+	MassAction Kinetics = "mass-action"
+
+	// SingleServer fires an enabled transition at its rate however many
+	// tokens are waiting, like a queue with one server.
+	SingleServer Kinetics = "single-server"
+)
+
+const (
+	// DefaultReplications is used when Options.Replications is zero.
+	DefaultReplications = 100
+
+	// MaxReplications bounds the replications callers may request.
+	MaxReplications = 100000
+
+	// DefaultMaxEvents bounds the firings of a single replication when
+	// Options.MaxEvents is zero.
+	DefaultMaxEvents = 100000
+)
+
+// Options configures a simulation.
+type Options struct {
+	Replications int                // Independent runs (default 100)
+	Duration     float64            // Simulated time per run; 0 runs until completion or MaxEvents
+	Until        string             // Completion condition over the marking, e.g. "done >= 10"; default: no transition enabled
+	Rates        map[string]float64 // Firings per time unit; transitions not listed fire at rate 1
+	Kinetics     Kinetics           // MassAction (default) or SingleServer
+	Marking      map[string]int     // Token counts replacing the schema's initial marking
+	Seed         int64              // Seed for the random source
+	MaxEvents    int                // Firings per run before it is cut off (default 100000)
+}
+
+// Summary describes the distribution of a quantity across replications.
+type Summary struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+	Min    float64 `json:"min"`
+	P50    float64 `json:"p50"`
+	P90    float64 `json:"p90"`
+	P95    float64 `json:"p95"`
+	P99    float64 `json:"p99"`
+	Max    float64 `json:"max"`
+}
+
+// Report is the result of a simulation.
+type Report struct {
+	Replications int      `json:"replications"`
+	Seed         int64    `json:"seed"`
+	Kinetics     Kinetics `json:"kinetics"`
+	Until        string   `json:"until,omitempty"`
+	Duration     float64  `json:"duration,omitempty"`
+
+	// Completed counts runs that reached the completion condition
+	Completed      int      `json:"completed"`
+	CompletionTime *Summary `json:"completionTime,omitempty"` // Over completed runs only
+
+	SimulatedTime Summary            `json:"simulatedTime"`
+	Events        Summary            `json:"events"`
+	Throughput    map[string]Summary `json:"throughput"` // Firings per time unit, by transition
+	Occupancy     map[string]Summary `json:"occupancy"`  // Time-averaged tokens, by place
+	Final         map[string]Summary `json:"final"`      // Tokens at the end of each run, by place
+}
+
+// Rates collects per-transition rates for a model: the rate declared on
+// each transition, overridden by the simulation's solver rates.
+func Rates(model *goflowmodel.Model, sim *metamodel.Simulation) map[string]float64 {
+	rates := make(map[string]float64)
+	for _, t := range model.Transitions {
+		if t.Rate > 0 {
+			rates[t.ID] = t.Rate
+		}
+	}
+	if sim != nil && sim.Solver != nil {
+		for id, rate := range sim.Solver.Rates {
+			rates[id] = rate
+		}
+	}
+	return rates
+}
+
+// Simulate runs opts.Replications replications of the schema's token net.
+// Data states, data arcs and guards are ignored.
+func Simulate(schema *metamodel.Schema, opts Options) (*Report, error) {
+	if opts.Replications == 0 {
+		opts.Replications = DefaultReplications
+	}
+	if opts.Replications < 0 || opts.Replications > MaxReplications {
+		return nil, fmt.Errorf("stochastic: replications must be between 1 and %d", MaxReplications)
+	}
+	if opts.Duration < 0 {
+		return nil, fmt.Errorf("stochastic: duration must not be negative")
+	}
+	if opts.MaxEvents == 0 {
+		opts.MaxEvents = DefaultMaxEvents
+	}
+	if opts.MaxEvents < 0 {
+		return nil, fmt.Errorf("stochastic: max events must not be negative")
+	}
+	switch opts.Kinetics {
+	case "":
+		opts.Kinetics = MassAction
+	case MassAction, SingleServer:
+	default:
+		return nil, fmt.Errorf("stochastic: unknown kinetics %q (use %s or %s)", opts.Kinetics, MassAction, SingleServer)
+	}
+
+	n, err := compile(schema, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	runs := make([]*run, opts.Replications)
+	for i := range runs {
+		runs[i] = n.simulate(rng, opts)
+		if runs[i].err != nil {
+			return nil, runs[i].err
+		}
+	}
+	return n.report(runs, opts), nil
+}
+
+// net is a schema's token net compiled to indexed form.
+type net struct {
+	places      []string
+	transitions []string
+	initial     []int
+	inputs      [][]arc // Consumed tokens, by transition
+	outputs     [][]arc // Produced tokens, by transition
+	inhibitors  [][]arc // Places that must hold fewer than Weight tokens, by transition
+	rates       []float64
+	until       *dsl.Compiled
+}
+
+type arc struct {
+	place  int
+	weight int
+}
+
+// compile indexes the schema's token states and actions.
+func compile(schema *metamodel.Schema, opts Options) (*net, error) {
+	n := &net{}
+	index := make(map[string]int)
+	for _, st := range schema.TokenStates() {
+		index[st.ID] = len(n.places)
+		n.places = append(n.places, st.ID)
+		n.initial = append(n.initial, st.InitialTokens())
+	}
+	for id, tokens := range opts.Marking {
+		i, ok := index[id]
+		if !ok {
+			return nil, fmt.Errorf("stochastic: unknown token place in marking: %s", id)
+		}
+		if tokens < 0 {
+			return nil, fmt.Errorf("stochastic: negative marking for %s", id)
+		}
+		n.initial[i] = tokens
+	}
+
+	actions := make(map[string]int)
+	for _, a := range schema.Actions {
+		rate := 1.0
+		if r, ok := opts.Rates[a.ID]; ok {
+			rate = r
+		}
+		if rate < 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+			return nil, fmt.Errorf("stochastic: invalid rate for %s: %v", a.ID, rate)
+		}
+		actions[a.ID] = len(n.transitions)
+		n.transitions = append(n.transitions, a.ID)
+		n.rates = append(n.rates, rate)
+	}
+	for id := range opts.Rates {
+		if _, ok := actions[id]; !ok {
+			return nil, fmt.Errorf("stochastic: unknown transition in rates: %s", id)
+		}
+	}
+
+	n.inputs = make([][]arc, len(n.transitions))
+	n.outputs = make([][]arc, len(n.transitions))
+	n.inhibitors = make([][]arc, len(n.transitions))
+	for _, a := range schema.Arcs {
+		weight := a.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		if t, ok := actions[a.Target]; ok {
+			p, ok := index[a.Source]
+			if !ok {
+				continue
+			}
+			if a.IsInhibitor() {
+				n.inhibitors[t] = append(n.inhibitors[t], arc{p, weight})
+			} else {
+				n.inputs[t] = append(n.inputs[t], arc{p, weight})
+			}
+		} else if t, ok := actions[a.Source]; ok {
+			if p, ok := index[a.Target]; ok {
+				n.outputs[t] = append(n.outputs[t], arc{p, weight})
+			}
+		}
+	}
+
+	if opts.Until != "" {
+		compiled, err := dsl.Compile(opts.Until)
+		if err != nil {
+			return nil, fmt.Errorf("stochastic: until: %w", err)
+		}
+		n.until = compiled
+	}
+	return n, nil
+}
+
+// run records one replication.
+type run struct {
+	time      float64
+	completed bool
+	events    int
+	firings   []int
+	area      []float64 // Integral of tokens over time, by place
+	final     []int
+	err       error
+}
+
+// simulate performs one replication with Gillespie's direct method.
+func (n *net) simulate(rng *rand.Rand, opts Options) *run {
+	tokens := append([]int(nil), n.initial...)
+	r := &run{
+		firings: make([]int, len(n.transitions)),
+		area:    make([]float64, len(n.places)),
+	}
+	propensities := make([]float64, len(n.transitions))
+
+	advance := func(to float64) {
+		for i, k := range tokens {
+			r.area[i] += float64(k) * (to - r.time)
+		}
+		r.time = to
+	}
+
+	for {
+		if n.until != nil {
+			done, err := n.done(tokens)
+			if err != nil {
+				r.err = err
+				return r
+			}
+			if done {
+				r.completed = true
+				break
+			}
+		}
+		if r.events >= opts.MaxEvents {
+			break
+		}
+
+		total := 0.0
+		for t := range n.transitions {
+			propensities[t] = n.propensity(t, tokens, opts.Kinetics)
+			total += propensities[t]
+		}
+		if total == 0 {
+			// Nothing can fire: the run is complete unless it waits on a condition
+			r.completed = n.until == nil
+			if opts.Duration > 0 && !r.completed {
+				advance(opts.Duration)
+			}
+			break
+		}
+
+		next := r.time + rng.ExpFloat64()/total
+		if opts.Duration > 0 && next > opts.Duration {
+			advance(opts.Duration)
+			break
+		}
+		advance(next)
+
+		pick := rng.Float64() * total
+		t := 0
+		for ; t < len(propensities)-1; t++ {
+			pick -= propensities[t]
+			if pick < 0 {
+				break
+			}
+		}
+		for propensities[t] == 0 {
+			t-- // Rounding left pick past the last enabled transition
+		}
+		for _, a := range n.inputs[t] {
+			tokens[a.place] -= a.weight
+		}
+		for _, a := range n.outputs[t] {
+			tokens[a.place] += a.weight
+		}
+		r.firings[t]++
+		r.events++
+	}
+
+	r.final = tokens
+	return r
+}
+
+// propensity is the rate at which transition t fires in the given marking.
+func (n *net) propensity(t int, tokens []int, kinetics Kinetics) float64 {
+	for _, a := range n.inhibitors[t] {
+		if tokens[a.place] >= a.weight {
+			return 0
+		}
+	}
+	p := n.rates[t]
+	for _, a := range n.inputs[t] {
+		k := tokens[a.place]
+		if k < a.weight {
+			return 0
+		}
+		if kinetics == MassAction {
+			p *= choose(k, a.weight)
+		}
+	}
+	return p
+}
+
+// done evaluates the completion condition against the marking.
+func (n *net) done(tokens []int) (bool, error) {
+	marking := make(dsl.Marking, len(n.places))
+	bindings := make(map[string]any, len(n.places))
+	for i, id := range n.places {
+		marking[id] = tokens[i]
+		bindings[id] = int64(tokens[i])
+	}
+	ok, err := dsl.EvalCompiled(n.until, bindings, dsl.MakeAggregates(marking))
+	if err != nil {
+		return false, fmt.Errorf("stochastic: until: %w", err)
+	}
+	return ok, nil
+}
+
+// report summarizes the replications.
+func (n *net) report(runs []*run, opts Options) *Report {
+	rep := &Report{
+		Replications: len(runs),
+		Seed:         opts.Seed,
+		Kinetics:     opts.Kinetics,
+		Until:        opts.Until,
+		Duration:     opts.Duration,
+		Throughput:   make(map[string]Summary, len(n.transitions)),
+		Occupancy:    make(map[string]Summary, len(n.places)),
+		Final:        make(map[string]Summary, len(n.places)),
+	}
+
+	var completion, times, events []float64
+	for _, r := range runs {
+		if r.completed {
+			rep.Completed++
+			completion = append(completion, r.time)
+		}
+		times = append(times, r.time)
+		events = append(events, float64(r.events))
+	}
+	if len(completion) > 0 {
+		s := summarize(completion)
+		rep.CompletionTime = &s
+	}
+	rep.SimulatedTime = summarize(times)
+	rep.Events = summarize(events)
+
+	values := make([]float64, len(runs))
+	for t, id := range n.transitions {
+		for i, r := range runs {
+			values[i] = 0
+			if r.time > 0 {
+				values[i] = float64(r.firings[t]) / r.time
+			}
+		}
+		rep.Throughput[id] = summarize(values)
+	}
+	for p, id := range n.places {
+		for i, r := range runs {
+			values[i] = float64(r.final[p])
+			if r.time > 0 {
+				values[i] = r.area[p] / r.time
+			}
+		}
+		rep.Occupancy[id] = summarize(values)
+		for i, r := range runs {
+			values[i] = float64(r.final[p])
+		}
+		rep.Final[id] = summarize(values)
+	}
+	return rep
+}
+
+// summarize computes the mean, standard deviation and nearest-rank percentiles.
+func summarize(values []float64) Summary {
+	if len(values) == 0 {
+		return Summary{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+	var sq float64
+	for _, v := range sorted {
+		sq += (v - mean) * (v - mean)
+	}
+
+	percentile := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+	return Summary{
+		Mean:   mean,
+		StdDev: math.Sqrt(sq / float64(len(sorted))),
+		Min:    sorted[0],
+		P50:    percentile(0.50),
+		P90:    percentile(0.90),
+		P95:    percentile(0.95),
+		P99:    percentile(0.99),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// choose returns the binomial coefficient C(n, k).
+func choose(n, k int) float64 {
+	if k == 1 {
+		return float64(n)
+	}
+	c := 1.0
+	for i := 0; i < k; i++ {
+		c = c * float64(n-i) / float64(i+1)
+	}
+	return c
+}
@@ -0,0 +1,141 @@
+package stochastic
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+)
+
+// queue builds a ticket queue: tickets arrive, wait, and are served into done.
+func queue(waiting int) *metamodel.Schema {
+	s := metamodel.NewSchema("queue")
+	s.AddTokenState("waiting", waiting)
+	s.AddTokenState("done", 0)
+	s.AddAction(metamodel.Action{ID: "serve"})
+	s.AddArc(metamodel.Arc{Source: "waiting", Target: "serve"})
+	s.AddArc(metamodel.Arc{Source: "serve", Target: "done"})
+	return s
+}
+
+func near(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= tolerance
+}
+
+func TestSimulateSingleServer(t *testing.T) {
+	rep, err := Simulate(queue(10), Options{
+		Replications: 2000,
+		Rates:        map[string]float64{"serve": 2},
+		Kinetics:     SingleServer,
+		Seed:         1,
+	})
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	if rep.Completed != 2000 || rep.CompletionTime == nil {
+		t.Fatalf("expected every run to drain the queue, got %d", rep.Completed)
+	}
+	// Ten exponential services at rate 2 take 5 time units on average
+	if !near(rep.CompletionTime.Mean, 5, 0.2) {
+		t.Errorf("expected mean completion near 5, got %v", rep.CompletionTime.Mean)
+	}
+	if rep.Events.Min != 10 || rep.Events.Max != 10 {
+		t.Errorf("expected ten services per run, got %+v", rep.Events)
+	}
+	if rep.Final["done"].Min != 10 || rep.Final["waiting"].Max != 0 {
+		t.Errorf("expected all tickets done, got %+v", rep.Final)
+	}
+	if c := rep.CompletionTime; !(c.Min <= c.P50 && c.P50 <= c.P90 && c.P90 <= c.P99 && c.P99 <= c.Max) {
+		t.Errorf("percentiles out of order: %+v", c)
+	}
+}
+
+func TestSimulateMassAction(t *testing.T) {
+	// With mass-action kinetics each waiting ticket is served at rate 1,
+	// so the queue drains like a pure death process: E[T] = H(10).
+	rep, err := Simulate(queue(10), Options{Replications: 2000, Seed: 2})
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	harmonic := 0.0
+	for k := 1; k <= 10; k++ {
+		harmonic += 1 / float64(k)
+	}
+	if rep.Kinetics != MassAction || !near(rep.CompletionTime.Mean, harmonic, 0.15) {
+		t.Errorf("expected mean completion near %.3f, got %+v", harmonic, rep.CompletionTime)
+	}
+}
+
+func TestSimulateUntilAndDuration(t *testing.T) {
+	rep, err := Simulate(queue(10), Options{Replications: 50, Until: "done >= 4", Seed: 3})
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	if rep.Completed != 50 || rep.Final["done"].Min != 4 || rep.Final["done"].Max != 4 {
+		t.Errorf("expected runs to stop at 4 done, got %+v", rep.Final["done"])
+	}
+
+	// Arrivals never stop, so runs are cut off at the duration
+	s := queue(0)
+	s.AddAction(metamodel.Action{ID: "arrive"})
+	s.AddArc(metamodel.Arc{Source: "arrive", Target: "waiting"})
+	rep, err = Simulate(s, Options{
+		Replications: 200,
+		Duration:     100,
+		Rates:        map[string]float64{"arrive": 1, "serve": 2},
+		Kinetics:     SingleServer,
+		Seed:         4,
+	})
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	if rep.Completed != 0 || rep.CompletionTime != nil || rep.SimulatedTime.Min != 100 {
+		t.Errorf("expected runs cut off at 100, got %+v", rep.SimulatedTime)
+	}
+	if !near(rep.Throughput["arrive"].Mean, 1, 0.05) {
+		t.Errorf("expected arrival throughput near 1, got %v", rep.Throughput["arrive"].Mean)
+	}
+	// M/M/1 with utilization 0.5 holds one ticket on average
+	if !near(rep.Occupancy["waiting"].Mean, 1, 0.2) {
+		t.Errorf("expected mean occupancy near 1, got %v", rep.Occupancy["waiting"].Mean)
+	}
+}
+
+func TestSimulateSeeded(t *testing.T) {
+	opts := Options{Replications: 20, Seed: 42}
+	a, err := Simulate(queue(5), opts)
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	b, _ := Simulate(queue(5), opts)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("expected the same seed to give the same report")
+	}
+	opts.Seed = 43
+	c, _ := Simulate(queue(5), opts)
+	if reflect.DeepEqual(a.CompletionTime, c.CompletionTime) {
+		t.Error("expected a different seed to give a different report")
+	}
+}
+
+func TestSimulateErrors(t *testing.T) {
+	for name, opts := range map[string]Options{
+		"kinetics":     {Kinetics: "fluid"},
+		"rate":         {Rates: map[string]float64{"missing": 1}},
+		"negative":     {Rates: map[string]float64{"serve": -1}},
+		"until":        {Until: "done >="},
+		"marking":      {Marking: map[string]int{"missing": 1}},
+		"replications": {Replications: MaxReplications + 1},
+	} {
+		if _, err := Simulate(queue(1), opts); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestChoose(t *testing.T) {
+	if choose(5, 2) != 10 || choose(4, 1) != 4 || choose(3, 3) != 1 {
+		t.Error("unexpected binomial coefficient")
+	}
+}
@@ -456,6 +456,12 @@ type contextKey string
 
 const userContextKey contextKey = "user"
 
+// UserFromContext returns the user added by Middleware or RequireAuth, or nil.
+func UserFromContext(ctx context.Context) *User {
+	user, _ := ctx.Value(userContextKey).(*User)
+	return user
+}
+
 // Helper functions
 
 func generateToken(length int) string {
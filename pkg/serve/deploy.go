@@ -0,0 +1,311 @@
+package serve
+
+// This file deploys new versions of a served model while it runs. A new
+// version is validated and checked against the event streams of existing
+// aggregates, then swapped in atomically. Existing aggregates stay on the
+// version they were created under until migrated.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/pflow-xyz/petri-pilot/pkg/lint"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/api"
+)
+
+// ModelLoader parses a model document into a spec.
+type ModelLoader func(data []byte) (ModelSpec, error)
+
+// ParseModelSpec reads a JSON model document, flat or v2, with its roles
+// and access rules. It has no files to import sub-nets from, so a document
+// with subnets is refused rather than served without them.
+func ParseModelSpec(data []byte) (ModelSpec, error) {
+	if _, _, err := metamodel.Flatten(data, nil); errors.Is(err, metamodel.ErrNoLoader) {
+		return ModelSpec{}, err
+	}
+	in, err := lint.Parse(data)
+	if err != nil {
+		return ModelSpec{}, err
+	}
+	return ModelSpec{Net: in.Net, Roles: in.Roles, Access: in.Access}, nil
+}
+
+// SetLoader sets how uploaded and watched model documents are parsed.
+func (s *ModelService) SetLoader(loader ModelLoader) {
+	s.loader = loader
+}
+
+// Deployment reports the outcome of deploying a model version.
+type Deployment struct {
+	Version  int `json:"version"`
+	Previous int `json:"previous"`
+
+	// Incompatible lists aggregates whose history the new version cannot
+	// replay. They stay on their version; migrating them will fail.
+	Incompatible []Incompatibility `json:"incompatible,omitempty"`
+}
+
+// Incompatibility describes an aggregate the new version cannot replay.
+type Incompatibility struct {
+	AggregateID  string `json:"aggregate_id"`
+	ModelVersion int    `json:"model_version"`
+
+	// Events are event types in the history with no transition in the
+	// new version.
+	Events []string `json:"events,omitempty"`
+	Error  string   `json:"error"`
+}
+
+// VersionInfo describes a deployed model version.
+type VersionInfo struct {
+	Version     int       `json:"version"`
+	DeployedAt  time.Time `json:"deployed_at"`
+	Current     bool      `json:"current"`
+	Aggregates  int       `json:"aggregates"`
+	Transitions []string  `json:"transitions"`
+}
+
+// Deploy validates a new version of the model and makes it current. New
+// aggregates run on it; existing ones stay pinned to their version.
+func (s *ModelService) Deploy(ctx context.Context, spec ModelSpec) (*Deployment, error) {
+	if spec.Net == nil {
+		return nil, fmt.Errorf("model is required")
+	}
+	name := spec.Name
+	if name == "" {
+		name = spec.Net.Name
+	}
+	if name != "" && name != s.name {
+		return nil, fmt.Errorf("model %q cannot replace %q", name, s.name)
+	}
+	spec.Name = s.name
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.vmu.RLock()
+	previous := len(s.versions)
+	pins := maps.Clone(s.pins)
+	s.vmu.RUnlock()
+
+	v, err := s.newVersion(spec, previous+1)
+	if err != nil {
+		return nil, fmt.Errorf("invalid model: %w", err)
+	}
+
+	deployment := &Deployment{Version: v.number, Previous: previous}
+	for _, id := range slices.Sorted(maps.Keys(pins)) {
+		if inc := s.check(ctx, v, id); inc != nil {
+			inc.ModelVersion = pins[id]
+			deployment.Incompatible = append(deployment.Incompatible, *inc)
+		}
+	}
+
+	s.vmu.Lock()
+	s.versions = append(s.versions, v)
+	s.handler = s.router().Build()
+	s.vmu.Unlock()
+
+	log.Printf("%s: deployed model version %d (%d incompatible aggregates)", s.name, v.number, len(deployment.Incompatible))
+	return deployment, nil
+}
+
+// check replays an aggregate's history under a version, returning nil if
+// the version can run the aggregate.
+func (s *ModelService) check(ctx context.Context, v *modelVersion, aggregateID string) *Incompatibility {
+	err := v.engine.Verify(ctx, aggregateID)
+	if err == nil {
+		return nil
+	}
+	inc := &Incompatibility{AggregateID: aggregateID, Error: err.Error()}
+	events, _ := s.events(ctx, aggregateID, 0)
+	for _, e := range events {
+		if _, ok := v.engine.ActionForEvent(e.Type); !ok && !slices.Contains(inc.Events, e.Type) {
+			inc.Events = append(inc.Events, e.Type)
+		}
+	}
+	return inc
+}
+
+// Migrate moves an aggregate to another version, 0 meaning the current
+// one. It fails if the version cannot replay the aggregate's history.
+func (s *ModelService) Migrate(ctx context.Context, aggregateID string, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.vmu.RLock()
+	if version == 0 {
+		version = len(s.versions)
+	}
+	if version < 1 || version > len(s.versions) {
+		s.vmu.RUnlock()
+		return fmt.Errorf("unknown model version: %d", version)
+	}
+	target := s.versions[version-1]
+	s.vmu.RUnlock()
+
+	if inc := s.check(ctx, target, aggregateID); inc != nil {
+		return fmt.Errorf("aggregate %s is incompatible with version %d: %s", aggregateID, version, inc.Error)
+	}
+
+	s.vmu.Lock()
+	s.pins[aggregateID] = version
+	s.handler = s.router().Build()
+	s.vmu.Unlock()
+	s.updates.Publish(aggregateID)
+	return nil
+}
+
+// Versions lists the deployed versions, oldest first.
+func (s *ModelService) Versions() []VersionInfo {
+	s.vmu.RLock()
+	defer s.vmu.RUnlock()
+
+	counts := make(map[int]int)
+	for _, n := range s.pins {
+		counts[n]++
+	}
+	infos := make([]VersionInfo, 0, len(s.versions))
+	for _, v := range s.versions {
+		info := VersionInfo{
+			Version:    v.number,
+			DeployedAt: v.deployedAt,
+			Current:    v.number == len(s.versions),
+			Aggregates: counts[v.number],
+		}
+		for _, t := range v.spec.Net.Transitions {
+			info.Transitions = append(info.Transitions, t.ID)
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// WatchModel polls a model file and deploys it whenever its content
+// changes, until ctx is done. A version that fails to load or validate is
+// logged and the running version kept.
+func (s *ModelService) WatchModel(ctx context.Context, path string, interval time.Duration) {
+	last, _ := os.ReadFile(path)
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil || info.ModTime().Equal(modTime) {
+			continue
+		}
+		modTime = info.ModTime()
+		data, err := os.ReadFile(path)
+		if err != nil || bytes.Equal(data, last) {
+			continue
+		}
+		last = data
+
+		spec, err := s.loader(data)
+		if err != nil {
+			log.Printf("%s: ignoring %s: %v", s.name, path, err)
+			continue
+		}
+		if _, err := s.Deploy(ctx, spec); err != nil {
+			log.Printf("%s: ignoring %s: %v", s.name, path, err)
+		}
+	}
+}
+
+// Admin routes
+
+func (s *ModelService) handleListVersions(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	api.JSON(w, http.StatusOK, map[string]any{"versions": s.Versions()})
+}
+
+func (s *ModelService) handleDeploy(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	spec, err := s.loader(data)
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, "INVALID_MODEL", err.Error())
+		return
+	}
+	deployment, err := s.Deploy(r.Context(), spec)
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, "INVALID_MODEL", err.Error())
+		return
+	}
+	api.JSON(w, http.StatusCreated, deployment)
+}
+
+func (s *ModelService) handleMigrate(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	var req struct {
+		AggregateID string `json:"aggregate_id"`
+		Version     int    `json:"version"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if req.AggregateID == "" {
+		api.Error(w, http.StatusBadRequest, "MISSING_ID", "aggregate_id is required")
+		return
+	}
+	if err := s.Migrate(r.Context(), req.AggregateID, req.Version); err != nil {
+		api.Error(w, http.StatusConflict, "MIGRATION_FAILED", err.Error())
+		return
+	}
+	state, err := s.state(r.Context(), req.AggregateID)
+	if err != nil {
+		api.Error(w, http.StatusInternalServerError, "LOAD_FAILED", err.Error())
+		return
+	}
+	api.JSON(w, http.StatusOK, state)
+}
+
+// requireAdmin writes an error and returns false unless the user holds one
+// of the current version's admin roles.
+func (s *ModelService) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	user := UserFromContext(r.Context())
+	if user == nil {
+		api.Error(w, http.StatusUnauthorized, "UNAUTHORIZED", ErrUnauthorized.Error())
+		return false
+	}
+	v := s.current()
+	for _, role := range v.spec.AdminRoles {
+		if v.hasRole(user, role, nil) {
+			return true
+		}
+	}
+	api.Error(w, http.StatusForbidden, "FORBIDDEN", ErrForbidden.Error())
+	return false
+}
@@ -4,13 +4,19 @@ package serve
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/gorilla/websocket"
 	gopflowgql "github.com/pflow-xyz/go-pflow/graphql"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/graphql"
 )
 
 // UnifiedGraphQL combines multiple service schemas into a single GraphQL endpoint.
@@ -18,7 +24,17 @@ type UnifiedGraphQL struct {
 	services      map[string]GraphQLService
 	schema        string
 	resolvers     map[string]resolverEntry
-	introspection map[string]any // cached introspection result
+	subscriptions map[string]GraphQLSubscriber
+
+	// executable is the combined schema requests are validated and
+	// executed against; schemaErr is set if it failed to parse.
+	executable *graphql.Schema
+	schemaErr  error
+
+	// limits bound the operations requests may run; persisted holds the
+	// automatic persisted queries, or is nil when they are off.
+	limits    graphql.Limits
+	persisted *PersistedQueries
 
 	// gopflowServer is set when using NewUnifiedGraphQLFromGoPflow
 	// When set, query execution is delegated to go-pflow's Server
@@ -33,8 +49,9 @@ type resolverEntry struct {
 // NewUnifiedGraphQL creates a new unified GraphQL handler from the given services.
 func NewUnifiedGraphQL(services []GraphQLService) *UnifiedGraphQL {
 	ug := &UnifiedGraphQL{
-		services:  make(map[string]GraphQLService),
-		resolvers: make(map[string]resolverEntry),
+		services:      make(map[string]GraphQLService),
+		resolvers:     make(map[string]resolverEntry),
+		subscriptions: make(map[string]GraphQLSubscriber),
 	}
 
 	for _, svc := range services {
@@ -48,17 +65,106 @@ func NewUnifiedGraphQL(services []GraphQLService) *UnifiedGraphQL {
 				resolver:    resolver,
 			}
 		}
+
+		// Register subscriptions under the names the combined schema gives
+		// them, so services sharing a field name do not collide
+		if subSvc, ok := svc.(GraphQLSubscriptionService); ok {
+			for field, subscriber := range subSvc.GraphQLSubscriptions() {
+				ug.subscriptions[namespaceRootFieldName(name, field)] = subscriber
+			}
+		}
 	}
 
 	// Combine schemas
 	ug.schema = ug.combineSchemas()
-
-	// Build introspection result from schema (using go-pflow's graphql package)
-	ug.introspection = gopflowgql.BuildIntrospection(ug.schema)
+	ug.executable, ug.schemaErr = graphql.ParseSchema(ug.schema)
 
 	return ug
 }
 
+// Size limits of requests to the unified endpoint, checked before a query
+// is parsed.
+const (
+	maxGraphQLBody  = 1 << 20  // Bytes of a POST body or WebSocket message
+	maxGraphQLQuery = 64 << 10 // Bytes of a query
+)
+
+// GraphQLOptions configure the unified GraphQL endpoint.
+type GraphQLOptions struct {
+	// Limits reject operations too deep or complex to run.
+	Limits graphql.Limits
+	// PersistedQueries is how many automatic persisted queries to keep.
+	// Zero turns them off.
+	PersistedQueries int
+}
+
+// DefaultGraphQLOptions returns limits that ordinary queries, and the
+// playground's introspection, stay well within.
+func DefaultGraphQLOptions() GraphQLOptions {
+	return GraphQLOptions{
+		Limits: graphql.Limits{
+			MaxDepth:      12,
+			MaxComplexity: 5000,
+		},
+		PersistedQueries: 1000,
+	}
+}
+
+// SetOptions configures limits and persisted queries. Call it before
+// serving requests.
+func (ug *UnifiedGraphQL) SetOptions(opts GraphQLOptions) {
+	ug.limits = opts.Limits
+	ug.persisted = nil
+	if opts.PersistedQueries > 0 {
+		ug.persisted = NewPersistedQueries(opts.PersistedQueries)
+	}
+}
+
+// GraphQLOperation describes an operation the unified endpoint ran.
+type GraphQLOperation struct {
+	// Name is the operation name the client sent, if any.
+	Name string
+	// Type is query, mutation or subscription, and Fields are the root
+	// fields selected; both are empty if the request failed validation.
+	Type   string
+	Fields []string
+	// Depth and Complexity are the measured size of the operation.
+	Depth      int
+	Complexity int
+	// Duration is how long the operation ran; a subscription runs until
+	// it ends or the client completes it.
+	Duration time.Duration
+	// Errors counts the errors in the response, or in all the results of
+	// a subscription.
+	Errors int
+	// Persisted is set when the client sent a persisted query's hash in
+	// place of the query.
+	Persisted bool
+}
+
+// graphQLObservers are called after each operation.
+var (
+	graphQLObservers   []func(GraphQLOperation)
+	graphQLObserversMu sync.RWMutex
+)
+
+// ObserveGraphQL registers a function to call after every operation the
+// unified endpoint runs over HTTP or WebSocket. Generated observability
+// code calls it from init() to export operation timings.
+func ObserveGraphQL(fn func(GraphQLOperation)) {
+	graphQLObserversMu.Lock()
+	defer graphQLObserversMu.Unlock()
+	graphQLObservers = append(graphQLObservers, fn)
+}
+
+func observeGraphQL(op GraphQLOperation) {
+	graphQLObserversMu.RLock()
+	defer graphQLObserversMu.RUnlock()
+	for _, fn := range graphQLObservers {
+		fn(op)
+	}
+}
+
 // Schema returns the combined GraphQL schema.
 func (ug *UnifiedGraphQL) Schema() string {
 	return ug.schema
@@ -68,6 +174,7 @@ func (ug *UnifiedGraphQL) Schema() string {
 func (ug *UnifiedGraphQL) combineSchemas() string {
 	var queries []string
 	var mutations []string
+	var subscriptions []string
 	var types []string
 
 	// Sort service names for deterministic output
@@ -85,9 +192,10 @@ func (ug *UnifiedGraphQL) combineSchemas() string {
 		}
 
 		// Extract and namespace the schema components
-		q, m, t := ug.extractAndNamespace(name, schema)
+		q, m, sub, t := ug.extractAndNamespace(name, schema)
 		queries = append(queries, q...)
 		mutations = append(mutations, m...)
+		subscriptions = append(subscriptions, sub...)
 		types = append(types, t...)
 	}
 
@@ -112,6 +220,15 @@ func (ug *UnifiedGraphQL) combineSchemas() string {
 		sb.WriteString("}\n\n")
 	}
 
+	// Subscription type
+	if len(subscriptions) > 0 {
+		sb.WriteString("type Subscription {\n")
+		for _, sub := range subscriptions {
+			sb.WriteString("  " + sub + "\n")
+		}
+		sb.WriteString("}\n\n")
+	}
+
 	// Type definitions
 	for _, t := range types {
 		sb.WriteString(t + "\n\n")
@@ -121,7 +238,7 @@ func (ug *UnifiedGraphQL) combineSchemas() string {
 }
 
 // extractAndNamespace parses a service schema and prefixes types/operations with the service name.
-func (ug *UnifiedGraphQL) extractAndNamespace(serviceName, schema string) (queries, mutations, types []string) {
+func (ug *UnifiedGraphQL) extractAndNamespace(serviceName, schema string) (queries, mutations, subscriptions, types []string) {
 	// Convert service name to prefix (e.g., "erc20-token" -> "Erc20token")
 	prefix := toPascalCase(strings.ReplaceAll(serviceName, "-", ""))
 	lowerPrefix := strings.ToLower(prefix)
@@ -132,7 +249,7 @@ func (ug *UnifiedGraphQL) extractAndNamespace(serviceName, schema string) (queri
 		trimmed := strings.TrimSpace(line)
 		if strings.HasPrefix(trimmed, "type ") && strings.HasSuffix(trimmed, "{") {
 			name := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(trimmed, "type "), "{"))
-			if name != "Query" && name != "Mutation" {
+			if name != "Query" && name != "Mutation" && name != "Subscription" {
 				definedTypes[name] = true
 			}
 		} else if strings.HasPrefix(trimmed, "input ") && strings.HasSuffix(trimmed, "{") {
@@ -169,6 +286,9 @@ func (ug *UnifiedGraphQL) extractAndNamespace(serviceName, schema string) (queri
 		} else if strings.HasPrefix(trimmed, "type Mutation") {
 			currentSection = "mutation"
 			continue
+		} else if strings.HasPrefix(trimmed, "type Subscription") {
+			currentSection = "subscription"
+			continue
 		} else if (strings.HasPrefix(trimmed, "type ") || strings.HasPrefix(trimmed, "input ")) && strings.HasSuffix(trimmed, "{") {
 			// Start of a type definition
 			inType = true
@@ -195,12 +315,12 @@ func (ug *UnifiedGraphQL) extractAndNamespace(serviceName, schema string) (queri
 			continue
 		}
 
-		// Skip empty lines and comments in query/mutation sections
+		// Skip empty lines and comments in operation sections
 		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
 			continue
 		}
 
-		// Skip closing braces for Query/Mutation types
+		// Skip closing braces for Query/Mutation/Subscription types
 		if trimmed == "}" {
 			currentSection = ""
 			continue
@@ -213,10 +333,13 @@ func (ug *UnifiedGraphQL) extractAndNamespace(serviceName, schema string) (queri
 		case "mutation":
 			field := namespaceMutationField(trimmed, lowerPrefix, prefix, sortedNames, renameMap)
 			mutations = append(mutations, field)
+		case "subscription":
+			field := namespaceQueryField(trimmed, lowerPrefix, prefix, sortedNames, renameMap)
+			subscriptions = append(subscriptions, field)
 		}
 	}
 
-	return queries, mutations, types
+	return queries, mutations, subscriptions, types
 }
 
 // applyTypeRenames replaces type references using word-boundary regexes,
@@ -251,6 +374,16 @@ func namespaceQueryField(field, lowerPrefix, prefix string, sortedNames []string
 	return result
 }
 
+// namespaceRootFieldName returns the name a service's query or subscription
+// field has in the combined schema, as namespaceQueryField prefixes it.
+func namespaceRootFieldName(serviceName, field string) string {
+	lowerPrefix := strings.ToLower(strings.ReplaceAll(serviceName, "-", ""))
+	if field == "" || strings.HasPrefix(field, lowerPrefix) {
+		return field
+	}
+	return lowerPrefix + strings.ToUpper(field[:1]) + field[1:]
+}
+
 // namespaceMutationField renames types and transforms mutation names.
 func namespaceMutationField(field, lowerPrefix, prefix string, sortedNames []string, renameMap map[string]string) string {
 	// Apply type renames
@@ -299,79 +432,212 @@ func (ug *UnifiedGraphQL) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Legacy implementation
-	if r.Method == http.MethodGet {
+	// Executed against the combined schema; subscriptions run over WebSocket
+	if websocket.IsWebSocketUpgrade(r) {
+		ug.serveWebSocket(w, r)
+		return
+	}
+	var req graphQLRequest
+	switch r.Method {
+	case http.MethodGet:
 		accept := r.Header.Get("Accept")
 		if strings.Contains(accept, "text/html") {
 			http.Redirect(w, r, "/graphql/i", http.StatusSeeOther)
 			return
 		}
+		// Queries may be sent as parameters, so persisted ones can be cached
+		params := r.URL.Query()
+		if !params.Has("query") && !params.Has("extensions") {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := req.decodeParams(params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case http.MethodPost:
+		r.Body = http.MaxBytesReader(w, r.Body, maxGraphQLBody)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req struct {
-		Query         string         `json:"query"`
-		OperationName string         `json:"operationName"`
-		Variables     map[string]any `json:"variables"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if len(req.Query) > maxGraphQLQuery {
+		http.Error(w, errQueryTooLarge.Error(), http.StatusRequestEntityTooLarge)
 		return
 	}
 
-	result := ug.executeGraphQL(r.Context(), req.Query, req.OperationName, req.Variables)
+	// GET requests must not change state
+	result := ug.serveRequest(r.Context(), req, r.Method == http.MethodGet)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(result)
 }
 
-// executeGraphQL executes a GraphQL query or mutation.
-func (ug *UnifiedGraphQL) executeGraphQL(ctx context.Context, query, _ string, variables map[string]any) map[string]any {
-	// Handle introspection queries (using go-pflow's graphql package)
-	if gopflowgql.IsIntrospectionQuery(query) {
-		return ug.introspection
+// graphQLRequest is a request as clients send it: a POST body, or the
+// parameters of a GET.
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+	Extensions    struct {
+		PersistedQuery *persistedQueryExtension `json:"persistedQuery"`
+	} `json:"extensions"`
+}
+
+// persistedQueryExtension names a persisted query by its hash, in the
+// automatic persisted queries protocol of Apollo clients.
+type persistedQueryExtension struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// decodeParams reads a request from GET parameters, where variables and
+// extensions are JSON.
+func (req *graphQLRequest) decodeParams(params url.Values) error {
+	req.Query = params.Get("query")
+	req.OperationName = params.Get("operationName")
+	if v := params.Get("variables"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+			return fmt.Errorf("variables: %w", err)
+		}
+	}
+	if v := params.Get("extensions"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Extensions); err != nil {
+			return fmt.Errorf("extensions: %w", err)
+		}
+	}
+	return nil
+}
+
+// errQueryTooLarge rejects a query longer than maxGraphQLQuery.
+var errQueryTooLarge = fmt.Errorf("query is larger than %d bytes", maxGraphQLQuery)
+
+// serveRequest runs a request, resolving a persisted query first, and
+// reports the operation to the observers.
+func (ug *UnifiedGraphQL) serveRequest(ctx context.Context, req graphQLRequest, readOnly bool) *graphql.Result {
+	start := time.Now()
+	query, result := ug.persistedQuery(req)
+	if result == nil {
+		result = ug.executeGraphQL(ctx, query, req.OperationName, req.Variables, readOnly)
 	}
 
-	result := make(map[string]any)
-	data := make(map[string]any)
-	var errors []map[string]any
+	op := newGraphQLOperation(req.OperationName, start, result)
+	op.Persisted = req.Query == "" && req.Extensions.PersistedQuery != nil
+	observeGraphQL(op)
+	return result
+}
 
-	// Find all operation names in the query (using go-pflow's graphql package)
-	knownOps := make([]string, 0, len(ug.resolvers))
-	for opName := range ug.resolvers {
-		knownOps = append(knownOps, opName)
+// newGraphQLOperation describes an operation that started at start and
+// ended with result, which is nil if it produced none.
+func newGraphQLOperation(name string, start time.Time, result *graphql.Result) GraphQLOperation {
+	op := GraphQLOperation{
+		Name:     name,
+		Duration: time.Since(start),
+	}
+	if result == nil {
+		return op
 	}
-	operations := gopflowgql.ParseOperationNames(query, knownOps)
+	op.Errors = len(result.Errors)
+	if cost := result.Cost; cost != nil {
+		op.Type = string(cost.Operation)
+		op.Fields = cost.Fields
+		op.Depth = cost.Depth
+		op.Complexity = cost.Complexity
+	}
+	return op
+}
 
-	for _, op := range operations {
-		entry, ok := ug.resolvers[op]
+// persistedQuery returns the query a request runs. A request with a
+// persisted query hash and no query runs the query registered under the
+// hash; one with both registers its query.
+func (ug *UnifiedGraphQL) persistedQuery(req graphQLRequest) (string, *graphql.Result) {
+	ext := req.Extensions.PersistedQuery
+	if ext == nil {
+		return req.Query, nil
+	}
+	if ug.persisted == nil {
+		return "", persistedQueryError("PersistedQueryNotSupported", "PERSISTED_QUERY_NOT_SUPPORTED")
+	}
+	if ext.Version != 1 {
+		return "", persistedQueryError("Unsupported persisted query version", "BAD_REQUEST")
+	}
+	hash := strings.ToLower(ext.Sha256Hash)
+	if req.Query == "" {
+		query, ok := ug.persisted.Get(hash)
 		if !ok {
-			continue
+			// The client retries with the query
+			return "", persistedQueryError("PersistedQueryNotFound", "PERSISTED_QUERY_NOT_FOUND")
 		}
+		return query, nil
+	}
+	if queryHash(req.Query) != hash {
+		return "", persistedQueryError("provided sha does not match query", "BAD_REQUEST")
+	}
+	ug.persisted.Put(hash, req.Query)
+	return req.Query, nil
+}
 
-		// Execute the resolver
-		res, err := entry.resolver(ctx, variables)
-		if err != nil {
-			errors = append(errors, map[string]any{"message": err.Error()})
-		} else {
-			data[op] = res
-		}
+func persistedQueryError(message, code string) *graphql.Result {
+	return &graphql.Result{Errors: []*graphql.Error{{
+		Message:    message,
+		Extensions: map[string]any{"code": code},
+	}}}
+}
+
+// executeGraphQL parses and validates a request against the combined
+// schema, checks it against the limits and executes it, calling each
+// service resolver with the arguments of its root field.
+func (ug *UnifiedGraphQL) executeGraphQL(ctx context.Context, query, operationName string, variables map[string]any, readOnly bool) *graphql.Result {
+	if ug.schemaErr != nil {
+		return ug.schemaError()
 	}
+	params := ug.graphQLParams(query, operationName, variables)
+	params.ReadOnly = readOnly
+	return graphql.Do(ctx, params)
+}
 
-	result["data"] = data
-	if len(errors) > 0 {
-		result["errors"] = errors
+// subscribeGraphQL starts a request against the combined schema. A
+// subscription yields a result for every event its service sends; queries
+// and mutations yield their single result.
+func (ug *UnifiedGraphQL) subscribeGraphQL(ctx context.Context, query, operationName string, variables map[string]any) (<-chan *graphql.Result, *graphql.Result) {
+	if ug.schemaErr != nil {
+		return nil, ug.schemaError()
 	}
+	return graphql.Subscribe(ctx, ug.graphQLParams(query, operationName, variables))
+}
 
-	return result
+func (ug *UnifiedGraphQL) graphQLParams(query, operationName string, variables map[string]any) graphql.Params {
+	resolvers := make(map[string]graphql.ResolverFunc, len(ug.resolvers))
+	for name, entry := range ug.resolvers {
+		resolvers[name] = graphql.ResolverFunc(entry.resolver)
+	}
+	subscriptions := make(map[string]graphql.SubscriptionFunc, len(ug.subscriptions))
+	for name, subscriber := range ug.subscriptions {
+		subscriptions[name] = graphql.SubscriptionFunc(subscriber)
+	}
+	return graphql.Params{
+		Schema:        ug.executable,
+		Query:         query,
+		OperationName: operationName,
+		Variables:     variables,
+		Resolvers:     resolvers,
+		Subscriptions: subscriptions,
+		Limits:        ug.limits,
+	}
 }
 
+func (ug *UnifiedGraphQL) schemaError() *graphql.Result {
+	return &graphql.Result{Errors: []*graphql.Error{{Message: "invalid combined schema: " + ug.schemaErr.Error()}}}
+}
 
 // Handler returns the HTTP handler for the unified GraphQL endpoint.
 func (ug *UnifiedGraphQL) Handler() http.Handler {
@@ -386,8 +652,6 @@ func (ug *UnifiedGraphQL) SchemaHandler() http.HandlerFunc {
 	}
 }
 
-// Note: Introspection is now provided by github.com/pflow-xyz/go-pflow/graphql.BuildIntrospection()
-
 // ToExternalService converts a GraphQLService to a go-pflow ExternalService.
 // This allows petri-pilot services to be used with go-pflow's GraphQL Server.
 func ToExternalService(svc GraphQLService) gopflowgql.ExternalService {
@@ -440,7 +704,6 @@ func NewUnifiedGraphQLFromGoPflow(services []GraphQLService) *UnifiedGraphQL {
 		services:      makeServiceMap(services),
 		schema:        server.Schema(),
 		resolvers:     resolverMap,
-		introspection: gopflowgql.BuildIntrospection(server.Schema()),
 		gopflowServer: server,
 	}
 }
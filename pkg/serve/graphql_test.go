@@ -0,0 +1,411 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestUnifiedGraphQLExecution(t *testing.T) {
+	svc := newApprovalService(t)
+	ug := NewUnifiedGraphQL([]GraphQLService{svc})
+
+	post := func(query, operationName string, variables map[string]any) map[string]any {
+		t.Helper()
+		body, _ := json.Marshal(map[string]any{"query": query, "operationName": operationName, "variables": variables})
+		rec := httptest.NewRecorder()
+		ug.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", rec.Code, rec.Body)
+		}
+		var resp map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return resp
+	}
+
+	created := post(`mutation { expense: expenseapproval_create { id ...counts } }
+		fragment counts on ExpenseapprovalAggregateState { places { draft } }`, "", nil)
+	expense, _ := created["data"].(map[string]any)["expense"].(map[string]any)
+	if expense == nil || expense["places"].(map[string]any)["draft"] != float64(1) {
+		t.Fatalf("create: %+v", created)
+	}
+	id := expense["id"].(string)
+
+	// Only the selected operation runs, with inline arguments.
+	resp := post(`
+		mutation Submit { expenseapproval_submit(input: {aggregateId: "`+id+`"}) { success } }
+		mutation Reject { expenseapproval_reject(input: {aggregateId: "`+id+`"}) { success } }`, "Submit", nil)
+	if got := resp["data"].(map[string]any)["expenseapproval_submit"].(map[string]any)["success"]; got != true {
+		t.Fatalf("submit: %+v", resp)
+	}
+
+	resp = post(`query State($id: ID!, $withEvents: Boolean = false) {
+		state: expenseapproval(id: $id) { version enabled: enabledTransitions }
+		expenseapprovalEvents(aggregateId: $id) @include(if: $withEvents) { type }
+	}`, "", map[string]any{"id": id})
+	want := map[string]any{"state": map[string]any{"version": float64(1), "enabled": []any{"approve", "reject"}}}
+	if got, _ := json.Marshal(resp["data"]); string(got) != mustJSON(t, want) {
+		t.Errorf("state = %s, want %s", got, mustJSON(t, want))
+	}
+
+	resp = post(`{ expenseapproval(id: "x") { missing } }`, "", nil)
+	errs, _ := resp["errors"].([]any)
+	if _, hasData := resp["data"]; hasData || len(errs) != 1 ||
+		errs[0].(map[string]any)["message"] != `Cannot query field "missing" on type "ExpenseapprovalAggregateState".` {
+		t.Errorf("expected a validation error without data, got %+v", resp)
+	}
+}
+
+func TestUnifiedGraphQLSubscriptions(t *testing.T) {
+	svc := newApprovalService(t)
+	ug := NewUnifiedGraphQL([]GraphQLService{svc})
+	if !strings.Contains(ug.Schema(), "type Subscription {\n  expenseapprovalUpdated(id: ID!): ExpenseapprovalAggregateState!\n}") {
+		t.Fatalf("combined schema has no subscription:\n%s", ug.Schema())
+	}
+	server := httptest.NewServer(ug)
+	defer server.Close()
+
+	dial := func(protocol string) *websocket.Conn {
+		t.Helper()
+		dialer := websocket.Dialer{Subprotocols: []string{protocol}}
+		conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("dial: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		if conn.Subprotocol() != protocol {
+			t.Fatalf("subprotocol = %q, want %q", conn.Subprotocol(), protocol)
+		}
+		return conn
+	}
+	send := func(conn *websocket.Conn, msg map[string]any) {
+		t.Helper()
+		if err := conn.WriteJSON(msg); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	receive := func(conn *websocket.Conn) string {
+		t.Helper()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		return strings.TrimSpace(string(data))
+	}
+	// waitSubscribed blocks until the aggregate has a subscriber, since
+	// operations start asynchronously.
+	waitSubscribed := func(id string) {
+		t.Helper()
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+			svc.updates.mu.Lock()
+			n := len(svc.updates.subs[id])
+			svc.updates.mu.Unlock()
+			if n > 0 {
+				return
+			}
+		}
+		t.Fatalf("no subscriber for %s", id)
+	}
+	ctx := context.Background()
+	updated := `subscription ($id: ID!) { expenseapprovalUpdated(id: $id) { version places { submitted } } }`
+
+	// graphql-transport-ws
+	conn := dial(graphQLTransportWS)
+	send(conn, map[string]any{"type": "connection_init"})
+	if got := receive(conn); got != `{"type":"connection_ack"}` {
+		t.Fatalf("init: %s", got)
+	}
+	send(conn, map[string]any{"id": "1", "type": "subscribe", "payload": map[string]any{"query": updated, "variables": map[string]any{"id": "a1"}}})
+	waitSubscribed("a1")
+	if _, err := svc.Fire(ctx, nil, "a1", "submit", nil); err != nil {
+		t.Fatalf("fire: %v", err)
+	}
+	if got, want := receive(conn), `{"id":"1","type":"next","payload":{"data":{"expenseapprovalUpdated":{"version":1,"places":{"submitted":1}}}}}`; got != want {
+		t.Errorf("next = %s, want %s", got, want)
+	}
+	send(conn, map[string]any{"id": "1", "type": "complete"})
+
+	send(conn, map[string]any{"id": "2", "type": "subscribe", "payload": map[string]any{"query": `{ expenseapproval(id: "a1") { version } }`}})
+	if got, want := receive(conn), `{"id":"2","type":"next","payload":{"data":{"expenseapproval":{"version":1}}}}`; got != want {
+		t.Errorf("query next = %s, want %s", got, want)
+	}
+	if got, want := receive(conn), `{"id":"2","type":"complete"}`; got != want {
+		t.Errorf("query complete = %s, want %s", got, want)
+	}
+	send(conn, map[string]any{"id": "3", "type": "subscribe", "payload": map[string]any{"query": `subscription { expenseapprovalUpdated { version } }`}})
+	if got, want := receive(conn), `{"id":"3","type":"error","payload":[{"message":"Field \"expenseapprovalUpdated\" argument \"id\" of type \"ID!\" is required, but it was not provided.","locations":[{"line":1,"column":16}]}]}`; got != want {
+		t.Errorf("error = %s, want %s", got, want)
+	}
+
+	// subscriptions-transport-ws, as the playground speaks it
+	legacy := dial(graphQLWS)
+	send(legacy, map[string]any{"type": "connection_init", "payload": map[string]any{}})
+	if got := receive(legacy); got != `{"type":"connection_ack"}` {
+		t.Fatalf("legacy init: %s", got)
+	}
+	send(legacy, map[string]any{"id": "1", "type": "start", "payload": map[string]any{"query": updated, "variables": map[string]any{"id": "a2"}}})
+	waitSubscribed("a2")
+	if _, err := svc.Fire(ctx, nil, "a2", "submit", nil); err != nil {
+		t.Fatalf("fire: %v", err)
+	}
+	if got, want := receive(legacy), `{"id":"1","type":"data","payload":{"data":{"expenseapprovalUpdated":{"version":1,"places":{"submitted":1}}}}}`; got != want {
+		t.Errorf("legacy data = %s, want %s", got, want)
+	}
+
+	// Operations need an acknowledged connection
+	unacked := dial(graphQLTransportWS)
+	send(unacked, map[string]any{"id": "1", "type": "subscribe", "payload": map[string]any{"query": updated}})
+	unacked.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, _, err := unacked.ReadMessage(); !websocket.IsCloseError(err, 4401) {
+		t.Errorf("expected close 4401, got %v", err)
+	}
+}
+
+func TestUnifiedGraphQLLimitsAndPersistedQueries(t *testing.T) {
+	svc := newApprovalService(t)
+	ug := NewUnifiedGraphQL([]GraphQLService{svc})
+	opts := DefaultGraphQLOptions()
+	opts.Limits.MaxDepth = 2
+	ug.SetOptions(opts)
+
+	var ops []GraphQLOperation
+	graphQLObserversMu.Lock()
+	saved := graphQLObservers
+	graphQLObserversMu.Unlock()
+	ObserveGraphQL(func(op GraphQLOperation) { ops = append(ops, op) })
+	defer func() {
+		graphQLObserversMu.Lock()
+		graphQLObservers = saved
+		graphQLObserversMu.Unlock()
+	}()
+
+	do := func(method string, req map[string]any) map[string]any {
+		t.Helper()
+		var r *http.Request
+		if method == http.MethodGet {
+			params := url.Values{}
+			for k, v := range req {
+				if s, ok := v.(string); ok {
+					params.Set(k, s)
+				} else {
+					params.Set(k, mustJSON(t, v))
+				}
+			}
+			r = httptest.NewRequest(method, "/graphql?"+params.Encode(), nil)
+		} else {
+			r = httptest.NewRequest(method, "/graphql", strings.NewReader(mustJSON(t, req)))
+		}
+		rec := httptest.NewRecorder()
+		ug.ServeHTTP(rec, r)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", rec.Code, rec.Body)
+		}
+		var resp map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return resp
+	}
+	firstError := func(resp map[string]any) map[string]any {
+		t.Helper()
+		errs, _ := resp["errors"].([]any)
+		if len(errs) == 0 {
+			t.Fatalf("expected an error, got %+v", resp)
+		}
+		return errs[0].(map[string]any)
+	}
+
+	resp := do(http.MethodPost, map[string]any{"query": `{ expenseapproval(id: "x") { places { draft } } }`})
+	if msg := firstError(resp)["message"]; msg != "Operation anonymous is 3 levels deep, more than the maximum of 2." {
+		t.Errorf("depth error = %v", msg)
+	}
+
+	created := do(http.MethodPost, map[string]any{"query": `mutation { expenseapproval_create { id } }`})
+	id := created["data"].(map[string]any)["expenseapproval_create"].(map[string]any)["id"].(string)
+
+	// Apollo clients send the hash first and the query only when it is unknown
+	query := `{ expenseapproval(id: "` + id + `") { id version } }`
+	ext := map[string]any{"persistedQuery": map[string]any{"version": 1, "sha256Hash": queryHash(query)}}
+	resp = do(http.MethodGet, map[string]any{"extensions": ext})
+	if code := firstError(resp)["extensions"].(map[string]any)["code"]; code != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Fatalf("unknown hash: %+v", resp)
+	}
+	do(http.MethodPost, map[string]any{"query": query, "extensions": ext})
+	resp = do(http.MethodGet, map[string]any{"extensions": ext})
+	want := map[string]any{"expenseapproval": map[string]any{"id": id, "version": float64(0)}}
+	if got := mustJSON(t, resp["data"]); got != mustJSON(t, want) {
+		t.Errorf("persisted query = %s, want %s", got, mustJSON(t, want))
+	}
+	last := ops[len(ops)-1]
+	if !last.Persisted || last.Type != "query" || strings.Join(last.Fields, ",") != "expenseapproval" || last.Depth != 2 {
+		t.Errorf("observed %+v", last)
+	}
+
+	resp = do(http.MethodPost, map[string]any{"query": `{ expenseapproval(id: "x") { id } }`, "extensions": ext})
+	if msg := firstError(resp)["message"]; msg != "provided sha does not match query" {
+		t.Errorf("hash mismatch: %+v", resp)
+	}
+
+	// GET must not change state
+	resp = do(http.MethodGet, map[string]any{"query": `mutation { expenseapproval_create { id } }`})
+	if msg := firstError(resp)["message"]; msg != "Only queries are allowed here, not a mutation." {
+		t.Errorf("mutation over GET: %+v", resp)
+	}
+
+	// Oversized bodies and queries are rejected before they are parsed
+	for name, body := range map[string]string{
+		"body":  `{"query": "{ __typename }", "variables": {"pad": "` + strings.Repeat("x", maxGraphQLBody) + `"}}`,
+		"query": mustJSON(t, map[string]any{"query": "{ __typename " + strings.Repeat(" ", maxGraphQLQuery) + "}"}),
+	} {
+		rec := httptest.NewRecorder()
+		ug.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+		if rec.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("oversized %s: expected 413, got %d", name, rec.Code)
+		}
+	}
+}
+
+func TestUnifiedGraphQLWebSocketLimits(t *testing.T) {
+	svc := newApprovalService(t)
+	ug := NewUnifiedGraphQL([]GraphQLService{svc})
+	server := httptest.NewServer(ug)
+	defer server.Close()
+
+	var mu sync.Mutex
+	var ops []GraphQLOperation
+	graphQLObserversMu.Lock()
+	saved := graphQLObservers
+	graphQLObserversMu.Unlock()
+	ObserveGraphQL(func(op GraphQLOperation) {
+		mu.Lock()
+		defer mu.Unlock()
+		ops = append(ops, op)
+	})
+	defer func() {
+		graphQLObserversMu.Lock()
+		graphQLObservers = saved
+		graphQLObserversMu.Unlock()
+	}()
+
+	dialer := websocket.Dialer{Subprotocols: []string{graphQLTransportWS}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	send := func(msg map[string]any) {
+		t.Helper()
+		if err := conn.WriteJSON(msg); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	receive := func() string {
+		t.Helper()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		return strings.TrimSpace(string(data))
+	}
+	send(map[string]any{"type": "connection_init"})
+	if got := receive(); got != `{"type":"connection_ack"}` {
+		t.Fatalf("init: %s", got)
+	}
+
+	// Operations over WebSocket are observed like those over HTTP
+	send(map[string]any{"id": "q", "type": "subscribe", "payload": map[string]any{"query": `query Lookup { expenseapproval(id: "a1") { version } }`, "operationName": "Lookup"}})
+	receive()
+	if got, want := receive(), `{"id":"q","type":"complete"}`; got != want {
+		t.Fatalf("query complete = %s, want %s", got, want)
+	}
+	mu.Lock()
+	if len(ops) != 1 || ops[0].Name != "Lookup" || ops[0].Type != "query" || strings.Join(ops[0].Fields, ",") != "expenseapproval" {
+		t.Errorf("observed %+v", ops)
+	}
+	mu.Unlock()
+
+	// A connection runs at most maxOperations at once
+	updated := `subscription { expenseapprovalUpdated(id: "a1") { version } }`
+	for i := 0; i < maxOperations; i++ {
+		send(map[string]any{"id": strconv.Itoa(i), "type": "subscribe", "payload": map[string]any{"query": updated}})
+	}
+	send(map[string]any{"id": "over", "type": "subscribe", "payload": map[string]any{"query": updated}})
+	if got, want := receive(), `{"id":"over","type":"error","payload":[{"message":"`+errTooManyOperations.Error()+`"}]}`; got != want {
+		t.Errorf("operation past the limit = %s, want %s", got, want)
+	}
+
+	send(map[string]any{"id": "big", "type": "subscribe", "payload": map[string]any{"query": "{ __typename " + strings.Repeat(" ", maxGraphQLQuery) + "}"}})
+	if got, want := receive(), `{"id":"big","type":"error","payload":[{"message":"`+errQueryTooLarge.Error()+`"}]}`; got != want {
+		t.Errorf("oversized query = %s, want %s", got, want)
+	}
+}
+
+// panickingService is a service whose instance query panics.
+type panickingService struct {
+	*ModelService
+}
+
+func (s panickingService) GraphQLResolvers() map[string]GraphQLResolver {
+	resolvers := s.ModelService.GraphQLResolvers()
+	resolvers["expenseapproval"] = func(context.Context, map[string]any) (any, error) {
+		panic("resolver bug")
+	}
+	return resolvers
+}
+
+func TestUnifiedGraphQLWebSocketRecoversPanics(t *testing.T) {
+	ug := NewUnifiedGraphQL([]GraphQLService{panickingService{newApprovalService(t)}})
+	server := httptest.NewServer(ug)
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{graphQLTransportWS}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	receive := func() string {
+		t.Helper()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		return strings.TrimSpace(string(data))
+	}
+	conn.WriteJSON(map[string]any{"type": "connection_init"})
+	receive()
+
+	conn.WriteJSON(map[string]any{"id": "boom", "type": "subscribe", "payload": map[string]any{"query": `{ expenseapproval(id: "a1") { version } }`}})
+	if got, want := receive(), `{"id":"boom","type":"error","payload":[{"message":"`+errOperationPanicked.Error()+`"}]}`; got != want {
+		t.Errorf("panicking operation = %s, want %s", got, want)
+	}
+
+	// The connection keeps serving other operations
+	conn.WriteJSON(map[string]any{"type": "ping"})
+	if got := receive(); got != `{"type":"pong"}` {
+		t.Errorf("ping after panic = %s", got)
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,898 @@
+package serve
+
+// This file serves a model without code generation. ModelService runs a
+// Petri net on the metamodel runtime engine and exposes the same kinds of
+// routes a generated service does: REST, an OpenAPI spec and GraphQL, with
+// the model's access rules enforced on every transition. New versions of
+// the model can be deployed while it runs; see deploy.go.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/dsl"
+	"github.com/pflow-xyz/petri-pilot/pkg/extensions"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/api"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/engine"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/migrate"
+)
+
+// ErrUnauthorized is returned when a transition requires a user and there is none.
+var ErrUnauthorized = errors.New("unauthorized: authentication required")
+
+// ErrForbidden is returned when the user lacks permission for a transition.
+var ErrForbidden = errors.New("forbidden: insufficient permissions")
+
+// ModelSpec describes a model to serve without code generation.
+type ModelSpec struct {
+	// Name is the service name and mount path; it defaults to the net's name.
+	Name string
+
+	Net    *goflowmodel.Model
+	Roles  []extensions.Role
+	Access []extensions.AccessRule
+
+	// AdminRoles may deploy versions and migrate aggregates (default: admin).
+	AdminRoles []string
+}
+
+// ModelService implements Service, GraphQLService and
+// GraphQLSubscriptionService by interpreting a model at runtime. Events are
+// kept in memory.
+//
+// Each deployed version of the model gets its own engine over the shared
+// event store. An aggregate is pinned to the version that was current when
+// its first event was persisted and keeps running on it until migrated.
+type ModelService struct {
+	name    string
+	store   eventsource.Store
+	loader  ModelLoader
+	updates *Publisher
+
+	// mu serializes transitions, deployments and migrations so each event
+	// is appended at the version its state was loaded from.
+	mu sync.Mutex
+
+	// vmu guards the fields below, which requests read concurrently.
+	vmu      sync.RWMutex
+	versions []*modelVersion
+	pins     map[string]int
+	handler  http.Handler
+
+	// written is the store without upcasting, for versions deployed before
+	// a plan was set; planned is the first version that reads through it.
+	written eventsource.Store
+	planned int
+}
+
+// modelVersion is one deployed version of a model.
+type modelVersion struct {
+	number     int
+	spec       ModelSpec
+	engine     *engine.Engine
+	roles      *extensions.RoleExtension
+	deployedAt time.Time
+}
+
+// NewModelService creates a service for a model. It fails if the model has
+// no name, its structure is invalid, a role inherits an unknown role, or an
+// access rule names a transition the net does not have.
+func NewModelService(spec ModelSpec) (*ModelService, error) {
+	if spec.Net == nil {
+		return nil, fmt.Errorf("model is required")
+	}
+	if spec.Name == "" {
+		spec.Name = spec.Net.Name
+	}
+	if spec.Name == "" {
+		return nil, fmt.Errorf("model has no name")
+	}
+
+	s := &ModelService{
+		name:    spec.Name,
+		store:   eventsource.NewMemoryStore(),
+		loader:  ParseModelSpec,
+		updates: NewPublisher(),
+		pins:    make(map[string]int),
+	}
+	v, err := s.newVersion(spec, 1)
+	if err != nil {
+		return nil, err
+	}
+	s.versions = []*modelVersion{v}
+	s.handler = s.router().Build()
+	return s, nil
+}
+
+// newVersion validates a spec and prepares its engine.
+func (s *ModelService) newVersion(spec ModelSpec, number int) (*modelVersion, error) {
+	if spec.Net == nil {
+		return nil, fmt.Errorf("model is required")
+	}
+	if err := metamodel.FromModel(spec.Net).Validate(); err != nil {
+		return nil, err
+	}
+
+	roles := extensions.NewRoleExtension()
+	for _, r := range spec.Roles {
+		roles.AddRole(r)
+	}
+	if err := roles.Validate(spec.Net); err != nil {
+		return nil, err
+	}
+	for _, rule := range spec.Access {
+		if rule.Action != "*" && !hasTransition(spec.Net, rule.Action) {
+			return nil, fmt.Errorf("access rule for unknown transition: %s", rule.Action)
+		}
+	}
+	if len(spec.AdminRoles) == 0 {
+		spec.AdminRoles = []string{"admin"}
+	}
+
+	return &modelVersion{
+		number:     number,
+		spec:       spec,
+		engine:     migrate.NewEngine(spec.Net, s.storeFor(number)),
+		roles:      roles,
+		deployedAt: time.Now(),
+	}, nil
+}
+
+// RegisterModel creates a model service and registers it under its name.
+func RegisterModel(spec ModelSpec) (*ModelService, error) {
+	svc, err := NewModelService(spec)
+	if err != nil {
+		return nil, err
+	}
+	Register(svc.Name(), func() (Service, error) {
+		return svc, nil
+	})
+	return svc, nil
+}
+
+// SetPlan reads events through plan's upcasters, so history written under
+// earlier model versions replays under the current one, which the plan
+// upcasts to, and under versions deployed after it. Versions deployed
+// before it keep reading history as written. Call it before the service
+// handles requests.
+func (s *ModelService) SetPlan(plan *migrate.Plan) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vmu.Lock()
+	defer s.vmu.Unlock()
+
+	if s.written == nil {
+		s.written = s.store
+	}
+	s.store = migrate.NewUpcastingStore(s.written, plan)
+	s.planned = len(s.versions)
+	for _, v := range s.versions {
+		v.engine = migrate.NewEngine(v.spec.Net, s.storeFor(v.number))
+	}
+}
+
+// storeFor returns the store a version's engine reads: through the plan
+// for versions at or after the one it was set on. Callers hold mu.
+func (s *ModelService) storeFor(number int) eventsource.Store {
+	if s.written != nil && number < s.planned {
+		return s.written
+	}
+	return s.store
+}
+
+func hasTransition(net *goflowmodel.Model, id string) bool {
+	for _, t := range net.Transitions {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the service name.
+func (s *ModelService) Name() string {
+	return s.name
+}
+
+// Close cleans up resources used by the service.
+func (s *ModelService) Close() error {
+	return s.store.Close()
+}
+
+// BuildHandler returns the HTTP handler for this service. It routes to
+// whichever version is current, so deployments take effect without
+// rebuilding the handler.
+func (s *ModelService) BuildHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.vmu.RLock()
+		handler := s.handler
+		s.vmu.RUnlock()
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// current returns the current version.
+func (s *ModelService) current() *modelVersion {
+	s.vmu.RLock()
+	defer s.vmu.RUnlock()
+	return s.versions[len(s.versions)-1]
+}
+
+// versionFor returns the version an aggregate is pinned to, or the current
+// version for aggregates not seen yet.
+func (s *ModelService) versionFor(aggregateID string) *modelVersion {
+	s.vmu.RLock()
+	defer s.vmu.RUnlock()
+	if n, ok := s.pins[aggregateID]; ok {
+		return s.versions[n-1]
+	}
+	return s.versions[len(s.versions)-1]
+}
+
+// pin pins an aggregate to a version if it is not pinned yet.
+func (s *ModelService) pin(aggregateID string, v *modelVersion) {
+	s.vmu.Lock()
+	defer s.vmu.Unlock()
+	if _, ok := s.pins[aggregateID]; !ok {
+		s.pins[aggregateID] = v.number
+	}
+}
+
+// router registers the REST routes under /api: the state and transition
+// routes of api.RouterFromModel, plus create, events, schema, OpenAPI and
+// the admin routes for deployment. Transitions only older versions have
+// stay routed while aggregates are pinned to those versions.
+func (s *ModelService) router() *api.Router {
+	r := api.RouterFromModel(s.routedNet(), s.handleTransition, s.handleGetState).WithPrefix("/api")
+	r.POST("/instances", "Create new "+s.name+" instance", s.handleCreate)
+	r.POST("/sequence/{id}", "Fire transitions in order, all or none", s.handleSequence)
+	r.GET("/events/{id}", "Get event history", s.handleGetEvents)
+	r.GET("/schema", "Get model schema", s.handleGetSchema)
+	r.GET("/openapi.json", "Get OpenAPI specification", s.handleOpenAPI)
+	r.GET("/admin/versions", "List deployed model versions", s.handleListVersions)
+	r.POST("/admin/versions", "Deploy a new model version", s.handleDeploy)
+	r.POST("/admin/migrate", "Migrate an aggregate to another model version", s.handleMigrate)
+	return r
+}
+
+// routedNet returns the current net with the transitions of older versions
+// that still have pinned aggregates appended. Callers hold vmu or own s.
+func (s *ModelService) routedNet() *goflowmodel.Model {
+	current := s.versions[len(s.versions)-1].spec.Net
+	net := *current
+	net.Transitions = slices.Clone(current.Transitions)
+
+	pinned := make(map[int]bool)
+	for _, n := range s.pins {
+		pinned[n] = true
+	}
+	for _, v := range s.versions[:len(s.versions)-1] {
+		if !pinned[v.number] {
+			continue
+		}
+		for _, t := range v.spec.Net.Transitions {
+			if !hasTransition(&net, t.ID) {
+				net.Transitions = append(net.Transitions, t)
+			}
+		}
+	}
+	return &net
+}
+
+func (s *ModelService) handleCreate(w http.ResponseWriter, r *http.Request) {
+	// The instance is pinned when its first event is persisted, so creating
+	// instances that never fire leaves nothing behind
+	id := uuid.New().String()
+	state, err := s.state(r.Context(), id)
+	if err != nil {
+		api.Error(w, http.StatusInternalServerError, "CREATE_FAILED", err.Error())
+		return
+	}
+	api.JSON(w, http.StatusCreated, state)
+}
+
+func (s *ModelService) handleGetState(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "MISSING_ID", "aggregate ID is required")
+		return
+	}
+	state, err := s.state(r.Context(), id)
+	if err != nil {
+		api.Error(w, http.StatusInternalServerError, "LOAD_FAILED", err.Error())
+		return
+	}
+	api.JSON(w, http.StatusOK, state)
+}
+
+func (s *ModelService) handleTransition(transitionID string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var req api.TransitionRequest
+		if err := api.DecodeJSON(r, &req); err != nil {
+			api.Error(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+		if req.AggregateID == "" {
+			api.Error(w, http.StatusBadRequest, "MISSING_ID", "aggregate_id is required")
+			return
+		}
+		bindings := make(metamodel.Bindings)
+		if len(req.Data) > 0 {
+			if err := json.Unmarshal(req.Data, &bindings); err != nil {
+				api.Error(w, http.StatusBadRequest, "INVALID_DATA", err.Error())
+				return
+			}
+		}
+
+		result, err := s.Fire(ctx, UserFromContext(ctx), req.AggregateID, transitionID, bindings)
+		switch {
+		case errors.Is(err, ErrUnauthorized):
+			api.Error(w, http.StatusUnauthorized, "UNAUTHORIZED", err.Error())
+		case errors.Is(err, ErrForbidden):
+			api.Error(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+		case err != nil:
+			api.Error(w, http.StatusConflict, "TRANSITION_FAILED", err.Error())
+		default:
+			api.JSON(w, http.StatusOK, result)
+		}
+	}
+}
+
+func (s *ModelService) handleSequence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req api.SequenceRequest
+	if err := api.DecodeJSON(r, &req); err != nil {
+		api.Error(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if len(req.Steps) > api.MaxSequenceSteps {
+		api.Error(w, http.StatusBadRequest, "TOO_MANY_STEPS", fmt.Sprintf("sequence has %d steps, at most %d are allowed", len(req.Steps), api.MaxSequenceSteps))
+		return
+	}
+	steps := make([]engine.Step, len(req.Steps))
+	for i, step := range req.Steps {
+		bindings := make(metamodel.Bindings)
+		if len(step.Data) > 0 {
+			if err := json.Unmarshal(step.Data, &bindings); err != nil {
+				api.Error(w, http.StatusBadRequest, "INVALID_DATA", fmt.Sprintf("step %d: %v", i, err))
+				return
+			}
+		}
+		steps[i] = engine.Step{ActionID: step.TransitionID, Bindings: bindings}
+	}
+
+	result, err := s.FireSequence(ctx, UserFromContext(ctx), r.PathValue("id"), steps)
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		api.Error(w, http.StatusUnauthorized, "UNAUTHORIZED", err.Error())
+	case errors.Is(err, ErrForbidden):
+		api.Error(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+	case errors.Is(err, eventsource.ErrConcurrency):
+		api.Error(w, http.StatusConflict, "CONCURRENT_MODIFICATION", err.Error())
+	case errors.Is(err, engine.ErrStoreFailed):
+		api.Error(w, http.StatusInternalServerError, "STORE_FAILED", err.Error())
+	case err != nil:
+		api.Error(w, http.StatusConflict, "SEQUENCE_FAILED", err.Error())
+	default:
+		api.JSON(w, http.StatusOK, result)
+	}
+}
+
+func (s *ModelService) handleGetEvents(w http.ResponseWriter, r *http.Request) {
+	events, err := s.events(r.Context(), r.PathValue("id"), 0)
+	if err != nil {
+		api.Error(w, http.StatusInternalServerError, "READ_FAILED", err.Error())
+		return
+	}
+	api.JSON(w, http.StatusOK, map[string]any{"events": events})
+}
+
+func (s *ModelService) handleGetSchema(w http.ResponseWriter, r *http.Request) {
+	api.JSON(w, http.StatusOK, s.current().spec.Net)
+}
+
+func (s *ModelService) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	net := s.current().spec.Net
+	version := net.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+	s.vmu.RLock()
+	router := s.router()
+	s.vmu.RUnlock()
+	api.JSON(w, http.StatusOK, router.OpenAPISpec(s.name, version, net.Description))
+}
+
+// Fire checks the user's access to a transition, fires it on an aggregate
+// under the version the aggregate is pinned to, and persists its event.
+func (s *ModelService) Fire(ctx context.Context, user *User, aggregateID, transitionID string, bindings metamodel.Bindings) (*api.TransitionResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := s.versionFor(aggregateID)
+	if err := s.checkAccess(ctx, v, user, aggregateID, transitionID); err != nil {
+		return nil, err
+	}
+	if err := v.engine.Execute(ctx, aggregateID, transitionID, bindings); err != nil {
+		return nil, err
+	}
+	s.pin(aggregateID, v)
+	s.updates.Publish(aggregateID)
+
+	state, err := s.state(ctx, aggregateID)
+	if err != nil {
+		return nil, err
+	}
+	return &api.TransitionResult{
+		Success:            true,
+		AggregateID:        aggregateID,
+		Version:            state.Version,
+		State:              state.Places,
+		EnabledTransitions: state.EnabledTransitions,
+	}, nil
+}
+
+// FireSequence fires transitions on an aggregate in order under the version
+// the aggregate is pinned to, persisting all of their events or none. Each
+// transition's access rules are checked against the state it fires from,
+// so a sequence may fire a transition that an earlier step grants access to.
+func (s *ModelService) FireSequence(ctx context.Context, user *User, aggregateID string, steps []engine.Step) (*api.SequenceResult, error) {
+	if len(steps) > api.MaxSequenceSteps {
+		return nil, fmt.Errorf("sequence has %d steps, at most %d are allowed", len(steps), api.MaxSequenceSteps)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := s.versionFor(aggregateID)
+	markings, err := v.engine.ExecuteSequence(ctx, aggregateID, steps, func(i int, rt *metamodel.Runtime) error {
+		rules := v.accessRules(steps[i].ActionID)
+		if len(rules) == 0 {
+			return nil
+		}
+		if user == nil {
+			return ErrUnauthorized
+		}
+		return v.authorize(rules, user, aggregateID, rt.Snapshot.Tokens, rt.Snapshot.Data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.pin(aggregateID, v)
+	s.updates.Publish(aggregateID)
+
+	state, err := s.state(ctx, aggregateID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", engine.ErrStoreFailed, err)
+	}
+	return &api.SequenceResult{
+		Success:            true,
+		AggregateID:        aggregateID,
+		Version:            state.Version,
+		Markings:           markings,
+		State:              state.Places,
+		EnabledTransitions: state.EnabledTransitions,
+	}, nil
+}
+
+// ModelState is an aggregate's state with the model version it runs on.
+type ModelState struct {
+	api.StateResponse
+	ModelVersion int `json:"model_version"`
+}
+
+// state loads an aggregate under its version. Aggregates without events
+// are in the initial state.
+func (s *ModelService) state(ctx context.Context, aggregateID string) (*ModelState, error) {
+	v := s.versionFor(aggregateID)
+	rt, err := v.engine.LoadState(ctx, aggregateID)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.store.StreamVersion(ctx, aggregateID)
+	if err != nil && err != eventsource.ErrStreamNotFound {
+		return nil, err
+	}
+	return &ModelState{
+		StateResponse: api.StateResponse{
+			AggregateID:        aggregateID,
+			Version:            version,
+			State:              rt.Snapshot.Data,
+			Places:             rt.Snapshot.Tokens,
+			EnabledTransitions: rt.EnabledActions(),
+		},
+		ModelVersion: v.number,
+	}, nil
+}
+
+func (s *ModelService) events(ctx context.Context, aggregateID string, from int) ([]*eventsource.Event, error) {
+	events, err := s.store.Read(ctx, aggregateID, from)
+	if err == eventsource.ErrStreamNotFound {
+		return []*eventsource.Event{}, nil
+	}
+	return events, err
+}
+
+// checkAccess applies a version's access rules for a transition, and those
+// for "*". A transition without rules is open to everyone. Otherwise the
+// user needs one of each rule's roles, inherited or granted dynamically,
+// and the rule's guard must hold over the user and the aggregate's state.
+func (s *ModelService) checkAccess(ctx context.Context, v *modelVersion, user *User, aggregateID, transitionID string) error {
+	rules := v.accessRules(transitionID)
+	if len(rules) == 0 {
+		return nil
+	}
+	if user == nil {
+		return ErrUnauthorized
+	}
+
+	state, err := s.state(ctx, aggregateID)
+	if err != nil {
+		return err
+	}
+	data, _ := state.State.(map[string]any)
+	return v.authorize(rules, user, aggregateID, state.Places, data)
+}
+
+// accessRules returns a version's access rules for a transition, and those
+// for "*".
+func (v *modelVersion) accessRules(transitionID string) []extensions.AccessRule {
+	var rules []extensions.AccessRule
+	for _, rule := range v.spec.Access {
+		if rule.Action == transitionID || rule.Action == "*" {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// authorize checks a user against access rules over an aggregate's tokens
+// and data.
+func (v *modelVersion) authorize(rules []extensions.AccessRule, user *User, aggregateID string, tokens map[string]int, data map[string]any) error {
+	bindings := map[string]any{
+		"user": map[string]any{
+			"id":    user.ID,
+			"login": user.Login,
+			"email": user.Email,
+			"roles": user.Roles,
+		},
+		"aggregate_id": aggregateID,
+	}
+	for k, v := range tokens {
+		bindings[k] = v
+	}
+	for k, v := range data {
+		bindings[k] = v
+	}
+
+	for _, rule := range rules {
+		if len(rule.Roles) > 0 && !slices.ContainsFunc(rule.Roles, func(role string) bool {
+			return v.hasRole(user, role, bindings)
+		}) {
+			return ErrForbidden
+		}
+		if rule.Guard != "" {
+			allowed, err := dsl.Evaluate(rule.Guard, bindings, nil)
+			if err != nil {
+				return fmt.Errorf("guard evaluation error: %w", err)
+			}
+			if !allowed {
+				return ErrForbidden
+			}
+		}
+	}
+	return nil
+}
+
+// hasRole checks whether the user holds a role directly, through
+// inheritance, or through the role's dynamic grant.
+func (v *modelVersion) hasRole(user *User, roleID string, bindings map[string]any) bool {
+	for _, held := range user.Roles {
+		if slices.Contains(v.roles.FlattenHierarchy(held), roleID) {
+			return true
+		}
+	}
+	if role := v.roles.RoleByID(roleID); role != nil && role.DynamicGrant != "" {
+		granted, err := dsl.Evaluate(role.DynamicGrant, bindings, nil)
+		return err == nil && granted
+	}
+	return false
+}
+
+// GraphQL
+
+// GraphQLSchema returns the GraphQL schema for this service, in the shape
+// generated services use: a state query, an events query, a create
+// mutation, one mutation per transition taking an input object, a
+// fireSequence mutation and a subscription to an aggregate's updates. It
+// describes the current version; the unified endpoint reads it once at
+// startup, so transitions added by later deployments are REST-only there.
+func (s *ModelService) GraphQLSchema() string {
+	net := s.current().spec.Net
+	prefix := s.graphQLPrefix()
+	pascal := toPascalCase(prefix)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# GraphQL schema for %s\n\nscalar Time\n\n", s.name)
+	sb.WriteString("type Query {\n")
+	fmt.Fprintf(&sb, "  # Get aggregate state by ID\n  %s(id: ID!): AggregateState\n\n", prefix)
+	fmt.Fprintf(&sb, "  # Event history for an aggregate\n  %sEvents(aggregateId: ID!, from: Int): [Event!]!\n}\n\n", prefix)
+
+	sb.WriteString("type Mutation {\n")
+	fmt.Fprintf(&sb, "  # Create a new %s instance\n  create%s: AggregateState!\n", s.name, pascal)
+	for _, t := range net.Transitions {
+		field := graphQLName(t.ID)
+		fmt.Fprintf(&sb, "\n  %s(input: %sInput!): TransitionResult!\n", field, graphQLTypeName(field))
+	}
+	sb.WriteString("\n  # Fire transitions in order, all of them or none\n  fireSequence(aggregateId: ID!, transitions: [TransitionInput!]!): SequenceResult!\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("type Subscription {\n")
+	fmt.Fprintf(&sb, "  # Aggregate state after each change\n  %sUpdated(id: ID!): AggregateState!\n}\n\n", prefix)
+
+	places := graphQLPlaces(net)
+	sb.WriteString("# Aggregate state representation\ntype AggregateState {\n  id: ID!\n  version: Int!\n")
+	if len(places) > 0 {
+		sb.WriteString("  places: Places!\n")
+	}
+	sb.WriteString("  data: String\n  enabledTransitions: [String!]!\n}\n\n")
+
+	if len(places) > 0 {
+		sb.WriteString("# Token counts for each place\ntype Places {\n")
+		for _, p := range places {
+			fmt.Fprintf(&sb, "  %s: Int!\n", p)
+		}
+		sb.WriteString("}\n\n")
+	}
+
+	sb.WriteString("# Result of a transition execution\ntype TransitionResult {\n  success: Boolean!\n  aggregateId: ID\n  version: Int\n")
+	if len(places) > 0 {
+		sb.WriteString("  state: Places\n")
+	}
+	sb.WriteString("  enabledTransitions: [String!]\n  error: String\n}\n\n")
+
+	sb.WriteString("# Result of firing a sequence of transitions\ntype SequenceResult {\n  success: Boolean!\n  aggregateId: ID\n  version: Int\n")
+	if len(places) > 0 {
+		sb.WriteString("  markings: [Places!]\n  state: Places\n")
+	}
+	sb.WriteString("  enabledTransitions: [String!]\n  error: String\n}\n\n")
+
+	sb.WriteString("# Event record\ntype Event {\n  id: ID!\n  streamId: String!\n  type: String!\n  version: Int!\n  timestamp: Time!\n  data: String!\n}\n\n")
+
+	sb.WriteString("# Input types for mutations\n")
+	sb.WriteString("\n# A transition of a sequence, with its bindings as a JSON object\ninput TransitionInput {\n  transition: String!\n  data: String\n}\n")
+	for _, t := range net.Transitions {
+		fmt.Fprintf(&sb, "\ninput %sInput {\n  aggregateId: ID!\n", graphQLTypeName(graphQLName(t.ID)))
+		for _, b := range t.Bindings {
+			fmt.Fprintf(&sb, "  %s: %s\n", graphQLName(b.Name), graphQLType(b.Type))
+		}
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
+
+// GraphQLResolvers returns the GraphQL resolvers for this service. Like
+// generated services, mutations are registered under both their schema
+// names and the prefix_name form of the unified endpoint.
+func (s *ModelService) GraphQLResolvers() map[string]GraphQLResolver {
+	prefix := s.graphQLPrefix()
+	resolvers := make(map[string]GraphQLResolver)
+
+	resolvers[prefix] = func(ctx context.Context, variables map[string]any) (any, error) {
+		id, _ := variables["id"].(string)
+		state, err := s.state(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return s.graphQLState(state), nil
+	}
+
+	resolvers[prefix+"Events"] = func(ctx context.Context, variables map[string]any) (any, error) {
+		id, _ := variables["aggregateId"].(string)
+		from, _ := variables["from"].(float64)
+		events, err := s.events(ctx, id, int(from))
+		if err != nil {
+			return nil, err
+		}
+		result := make([]map[string]any, 0, len(events))
+		for _, e := range events {
+			result = append(result, map[string]any{
+				"id":        e.ID,
+				"streamId":  e.StreamID,
+				"type":      e.Type,
+				"version":   e.Version,
+				"timestamp": e.Timestamp,
+				"data":      string(e.Data),
+			})
+		}
+		return result, nil
+	}
+
+	resolvers[prefix+"_create"] = func(ctx context.Context, _ map[string]any) (any, error) {
+		id := uuid.New().String()
+		state, err := s.state(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return s.graphQLState(state), nil
+	}
+	resolvers["create"+toPascalCase(prefix)] = resolvers[prefix+"_create"]
+
+	for _, t := range s.current().spec.Net.Transitions {
+		transitionID := t.ID
+		fields := make(map[string]string, len(t.Bindings))
+		for _, b := range t.Bindings {
+			fields[graphQLName(b.Name)] = b.Name
+		}
+		resolver := func(ctx context.Context, variables map[string]any) (any, error) {
+			input, _ := variables["input"].(map[string]any)
+			id, _ := input["aggregateId"].(string)
+			bindings := make(metamodel.Bindings)
+			for field, v := range input {
+				if name, ok := fields[field]; ok {
+					bindings[name] = v
+				}
+			}
+			result, err := s.Fire(ctx, UserFromContext(ctx), id, transitionID, bindings)
+			if err != nil {
+				return map[string]any{"success": false, "aggregateId": id, "error": err.Error()}, nil
+			}
+			return map[string]any{
+				"success":            true,
+				"aggregateId":        result.AggregateID,
+				"version":            result.Version,
+				"state":              s.graphQLTokens(result.State.(map[string]int)),
+				"enabledTransitions": result.EnabledTransitions,
+			}, nil
+		}
+		field := graphQLName(t.ID)
+		resolvers[field] = resolver
+		resolvers[prefix+"_"+field] = resolver
+	}
+
+	resolvers[prefix+"_fireSequence"] = func(ctx context.Context, variables map[string]any) (any, error) {
+		id, _ := variables["aggregateId"].(string)
+		transitions, _ := variables["transitions"].([]any)
+		steps := make([]engine.Step, len(transitions))
+		for i, t := range transitions {
+			input, _ := t.(map[string]any)
+			steps[i].ActionID, _ = input["transition"].(string)
+			steps[i].Bindings = make(metamodel.Bindings)
+			if data, ok := input["data"].(string); ok && data != "" {
+				if err := json.Unmarshal([]byte(data), &steps[i].Bindings); err != nil {
+					return map[string]any{"success": false, "aggregateId": id, "error": fmt.Sprintf("step %d: %v", i, err)}, nil
+				}
+			}
+		}
+		result, err := s.FireSequence(ctx, UserFromContext(ctx), id, steps)
+		if err != nil {
+			return map[string]any{"success": false, "aggregateId": id, "error": err.Error()}, nil
+		}
+		markings := make([]map[string]any, len(result.Markings))
+		for i, m := range result.Markings {
+			markings[i] = s.graphQLTokens(m)
+		}
+		return map[string]any{
+			"success":            true,
+			"aggregateId":        result.AggregateID,
+			"version":            result.Version,
+			"markings":           markings,
+			"state":              s.graphQLTokens(result.State.(map[string]int)),
+			"enabledTransitions": result.EnabledTransitions,
+		}, nil
+	}
+	resolvers["fireSequence"] = resolvers[prefix+"_fireSequence"]
+
+	return resolvers
+}
+
+// GraphQLSubscriptions returns the GraphQL subscriptions for this service.
+// prefixUpdated streams an aggregate's state each time a transition fires
+// on it or it migrates to another version.
+func (s *ModelService) GraphQLSubscriptions() map[string]GraphQLSubscriber {
+	return map[string]GraphQLSubscriber{
+		s.graphQLPrefix() + "Updated": func(ctx context.Context, variables map[string]any) (<-chan any, error) {
+			id, _ := variables["id"].(string)
+			return s.updates.Subscribe(ctx, id, func(ctx context.Context) (any, error) {
+				state, err := s.state(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				return s.graphQLState(state), nil
+			}), nil
+		},
+	}
+}
+
+// graphQLPrefix is the lowercase prefix the unified endpoint gives this
+// service's operations, e.g. "orderprocessing" for "order-processing".
+func (s *ModelService) graphQLPrefix() string {
+	return strings.ToLower(graphQLName(strings.ReplaceAll(s.name, "-", "")))
+}
+
+// graphQLPlaces returns the GraphQL field names of the token places.
+func graphQLPlaces(net *goflowmodel.Model) []string {
+	var fields []string
+	for _, p := range net.Places {
+		if p.Kind != goflowmodel.DataKind {
+			fields = append(fields, graphQLName(p.ID))
+		}
+	}
+	return fields
+}
+
+func (s *ModelService) graphQLTokens(tokens map[string]int) map[string]any {
+	places := make(map[string]any)
+	for _, p := range s.current().spec.Net.Places {
+		if p.Kind != goflowmodel.DataKind {
+			places[graphQLName(p.ID)] = tokens[p.ID]
+		}
+	}
+	return places
+}
+
+func (s *ModelService) graphQLState(state *ModelState) map[string]any {
+	result := map[string]any{
+		"id":                 state.AggregateID,
+		"version":            state.Version,
+		"places":             s.graphQLTokens(state.Places),
+		"enabledTransitions": state.EnabledTransitions,
+	}
+	if data, ok := state.State.(map[string]any); ok && len(data) > 0 {
+		encoded, err := json.Marshal(data)
+		if err == nil {
+			result["data"] = string(encoded)
+		}
+	}
+	return result
+}
+
+var invalidGraphQLChars = regexp.MustCompile(`[^_0-9A-Za-z]`)
+
+// graphQLName makes an identifier a valid GraphQL name.
+func graphQLName(id string) string {
+	name := invalidGraphQLChars.ReplaceAllString(id, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// graphQLTypeName turns a snake_case name into a PascalCase type name,
+// e.g. "take_item0" into "TakeItem0".
+func graphQLTypeName(name string) string {
+	var sb strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		sb.WriteString(toPascalCase(part))
+	}
+	return sb.String()
+}
+
+// graphQLType maps a binding type to a GraphQL scalar. Maps, lists and
+// unknown types are passed as strings.
+func graphQLType(typ string) string {
+	switch typ {
+	case "int", "int64", "uint", "uint64", "integer":
+		return "Int"
+	case "float", "float64", "number":
+		return "Float"
+	case "bool", "boolean":
+		return "Boolean"
+	default:
+		return "String"
+	}
+}
@@ -0,0 +1,520 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	goflowmodel "github.com/pflow-xyz/go-pflow/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/extensions"
+	"github.com/pflow-xyz/petri-pilot/pkg/metamodel"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/api"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/engine"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/migrate"
+)
+
+const approvalModel = `{
+	"name": "expense-approval",
+	"places": [{"id": "draft", "initial": 1}, {"id": "submitted"}, {"id": "approved"}],
+	"transitions": [{"id": "submit"}, {"id": "approve"}, {"id": "reject"}],
+	"arcs": [
+		{"from": "draft", "to": "submit"}, {"from": "submit", "to": "submitted"},
+		{"from": "submitted", "to": "approve"}, {"from": "approve", "to": "approved"},
+		{"from": "submitted", "to": "reject"}, {"from": "reject", "to": "draft"}
+	]
+}`
+
+func newApprovalService(t *testing.T) *ModelService {
+	t.Helper()
+	var net goflowmodel.Model
+	if err := json.Unmarshal([]byte(approvalModel), &net); err != nil {
+		t.Fatalf("parse model: %v", err)
+	}
+	svc, err := NewModelService(ModelSpec{
+		Net: &net,
+		Roles: []extensions.Role{
+			{ID: "clerk"},
+			{ID: "manager"},
+			{ID: "admin", Inherits: []string{"manager"}},
+		},
+		Access: []extensions.AccessRule{
+			{Action: "approve", Roles: []string{"manager"}},
+			{Action: "reject", Roles: []string{"manager"}, Guard: "user.login != 'mallory'"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewModelService: %v", err)
+	}
+	t.Cleanup(func() { svc.Close() })
+	return svc
+}
+
+func TestModelServiceREST(t *testing.T) {
+	t.Setenv("DEV_MODE", "true")
+	svc := newApprovalService(t)
+	handler := NewAuthHandler("").Middleware(svc.BuildHandler())
+
+	do := func(method, path, user, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if user != "" {
+			req.Header.Set("X-Dev-User", user)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("POST", "/api/instances", "", "")
+	var created api.StateResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || rec.Code != http.StatusCreated {
+		t.Fatalf("create: %d %s", rec.Code, rec.Body)
+	}
+	if created.Places["draft"] != 1 || strings.Join(created.EnabledTransitions, ",") != "submit" {
+		t.Errorf("expected a new instance in draft, got %+v", created)
+	}
+	if _, ok := svc.pins[created.AggregateID]; ok {
+		t.Error("expected a new instance to be pinned only once it has events")
+	}
+	body := `{"aggregate_id": "` + created.AggregateID + `"}`
+
+	// submit has no access rules
+	if rec := do("POST", "/api/transitions/submit", "", body); rec.Code != http.StatusOK {
+		t.Fatalf("submit: %d %s", rec.Code, rec.Body)
+	}
+	if n := svc.pins[created.AggregateID]; n != 1 {
+		t.Errorf("expected the instance pinned to version 1 by its first event, got %d", n)
+	}
+
+	for _, tc := range []struct {
+		user string
+		code int
+	}{
+		{"", http.StatusUnauthorized},
+		{"bob:clerk", http.StatusForbidden},
+		{"carol:admin", http.StatusOK},
+	} {
+		if rec := do("POST", "/api/transitions/approve", tc.user, body); rec.Code != tc.code {
+			t.Errorf("approve as %q: expected %d, got %d %s", tc.user, tc.code, rec.Code, rec.Body)
+		}
+	}
+
+	rec = do("GET", "/api/state/"+created.AggregateID, "", "")
+	var state api.StateResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &state); err != nil {
+		t.Fatalf("state: %v %s", err, rec.Body)
+	}
+	if state.Version != 2 || state.Places["approved"] != 1 || state.Places["submitted"] != 0 {
+		t.Errorf("expected approved at version 2, got %+v", state)
+	}
+
+	// Firing a disabled transition conflicts
+	if rec := do("POST", "/api/transitions/submit", "", body); rec.Code != http.StatusConflict {
+		t.Errorf("expected a disabled transition to conflict, got %d", rec.Code)
+	}
+
+	rec = do("GET", "/api/events/"+created.AggregateID, "", "")
+	var events struct {
+		Events []struct {
+			Type    string `json:"type"`
+			Version int    `json:"version"`
+		} `json:"events"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &events); err != nil || len(events.Events) != 2 {
+		t.Fatalf("expected 2 events, got %s", rec.Body)
+	}
+
+	rec = do("GET", "/api/openapi.json", "", "")
+	var spec struct {
+		Paths map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("openapi: %v", err)
+	}
+	for _, path := range []string{"/api/instances", "/api/state/{id}", "/api/transitions/approve", "/api/events/{id}"} {
+		if spec.Paths[path] == nil {
+			t.Errorf("expected %s in the OpenAPI spec", path)
+		}
+	}
+}
+
+func TestModelServiceAccessGuard(t *testing.T) {
+	svc := newApprovalService(t)
+	ctx := context.Background()
+	if _, err := svc.Fire(ctx, nil, "a1", "submit", nil); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if _, err := svc.Fire(ctx, &User{Login: "mallory", Roles: []string{"manager"}}, "a1", "reject", nil); err != ErrForbidden {
+		t.Errorf("expected the guard to forbid mallory, got %v", err)
+	}
+	result, err := svc.Fire(ctx, &User{Login: "dave", Roles: []string{"manager"}}, "a1", "reject", nil)
+	if err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if result.Version != 2 || result.State.(map[string]int)["draft"] != 1 {
+		t.Errorf("expected the instance back in draft at version 2, got %+v", result)
+	}
+
+	if _, err := NewModelService(ModelSpec{Net: svc.current().spec.Net, Access: []extensions.AccessRule{{Action: "publish"}}}); err == nil {
+		t.Error("expected an access rule for an unknown transition to fail")
+	}
+}
+
+func TestModelServiceSequence(t *testing.T) {
+	t.Setenv("DEV_MODE", "true")
+	svc := newApprovalService(t)
+	handler := NewAuthHandler("").Middleware(svc.BuildHandler())
+
+	do := func(id, user, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/sequence/"+id, strings.NewReader(body))
+		if user != "" {
+			req.Header.Set("X-Dev-User", user)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	version := func(id string) int {
+		state, err := svc.state(context.Background(), id)
+		if err != nil {
+			t.Fatalf("state: %v", err)
+		}
+		return state.Version
+	}
+
+	// A disabled step, or one the user may not fire, appends nothing
+	for _, tc := range []struct {
+		user, steps string
+		code        int
+	}{
+		{"", `[{"transition_id": "submit"}, {"transition_id": "submit"}]`, http.StatusConflict},
+		{"", `[{"transition_id": "submit"}, {"transition_id": "approve"}]`, http.StatusUnauthorized},
+		{"bob:clerk", `[{"transition_id": "submit"}, {"transition_id": "approve"}]`, http.StatusForbidden},
+		{"", `[]`, http.StatusConflict},
+		{"", "[" + strings.Repeat(`{"transition_id": "submit"},`, api.MaxSequenceSteps) + `{"transition_id": "submit"}]`, http.StatusBadRequest},
+	} {
+		if rec := do("s1", tc.user, `{"steps": `+tc.steps+`}`); rec.Code != tc.code {
+			t.Errorf("%s as %q: expected %d, got %d %s", tc.steps, tc.user, tc.code, rec.Code, rec.Body)
+		}
+		if v := version("s1"); v != 0 {
+			t.Fatalf("%s as %q: expected no events, got version %d", tc.steps, tc.user, v)
+		}
+	}
+
+	rec := do("s1", "carol:admin", `{"steps": [
+		{"transition_id": "submit"}, {"transition_id": "reject"},
+		{"transition_id": "submit"}, {"transition_id": "approve"}]}`)
+	var result api.SequenceResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("sequence: %d %s", rec.Code, rec.Body)
+	}
+	if result.Version != 4 || len(result.Markings) != 4 {
+		t.Fatalf("expected 4 events and markings, got %+v", result)
+	}
+	for i, place := range []string{"submitted", "draft", "submitted", "approved"} {
+		if result.Markings[i][place] != 1 {
+			t.Errorf("expected step %d to mark %s, got %v", i, place, result.Markings[i])
+		}
+	}
+
+	// Access is checked in the state each step fires from: reject is
+	// allowed from submitted, but mallory is never allowed to reject
+	ctx := context.Background()
+	mallory := &User{Login: "mallory", Roles: []string{"manager"}}
+	steps := []engine.Step{{ActionID: "submit"}, {ActionID: "reject"}}
+	if _, err := svc.FireSequence(ctx, mallory, "s2", steps); !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected the guard to forbid mallory's second step, got %v", err)
+	}
+	if v := version("s2"); v != 0 {
+		t.Errorf("expected no events after a forbidden step, got version %d", v)
+	}
+
+	// GraphQL takes each step's bindings as a JSON string
+	ug := NewUnifiedGraphQL([]GraphQLService{svc})
+	body, _ := json.Marshal(map[string]any{
+		"query": `mutation($id: ID!, $steps: [ExpenseapprovalTransitionInput!]!) {
+			expenseapproval_fireSequence(aggregateId: $id, transitions: $steps) {
+				success version markings { draft submitted } error
+			}
+		}`,
+		"variables": map[string]any{"id": "s3", "steps": []any{
+			map[string]any{"transition": "submit", "data": `{"note": "lunch"}`},
+			map[string]any{"transition": "reject"},
+		}},
+	})
+	gqlRec := httptest.NewRecorder()
+	ctx = context.WithValue(ctx, userContextKey, &User{Login: "dave", Roles: []string{"manager"}})
+	ug.ServeHTTP(gqlRec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))).WithContext(ctx))
+	var resp struct {
+		Data struct {
+			Sequence struct {
+				Success  bool             `json:"success"`
+				Version  int              `json:"version"`
+				Markings []map[string]int `json:"markings"`
+				Error    *string          `json:"error"`
+			} `json:"expenseapproval_fireSequence"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(gqlRec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	seq := resp.Data.Sequence
+	if !seq.Success || seq.Version != 2 || len(seq.Markings) != 2 || seq.Markings[0]["submitted"] != 1 || seq.Markings[1]["draft"] != 1 {
+		t.Errorf("expected submit then reject, got %s", gqlRec.Body)
+	}
+}
+
+// failingStore fails every append.
+type failingStore struct {
+	eventsource.Store
+}
+
+func (failingStore) Append(ctx context.Context, streamID string, expectedVersion int, events []*eventsource.Event) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestModelServiceSequenceStoreFailure(t *testing.T) {
+	t.Setenv("DEV_MODE", "true")
+	svc := newApprovalService(t)
+	v := svc.versions[0]
+	v.engine = migrate.NewEngine(v.spec.Net, failingStore{svc.store})
+	handler := NewAuthHandler("").Middleware(svc.BuildHandler())
+
+	req := httptest.NewRequest("POST", "/api/sequence/s1", strings.NewReader(`{"steps": [{"transition_id": "submit"}]}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "STORE_FAILED") {
+		t.Errorf("expected a failed append to be a store failure, got %d %s", rec.Code, rec.Body)
+	}
+}
+
+func TestModelServiceGraphQL(t *testing.T) {
+	svc := newApprovalService(t)
+	schema := svc.GraphQLSchema()
+	for _, want := range []string{
+		"expenseapproval(id: ID!): AggregateState",
+		"createExpenseapproval: AggregateState!",
+		"approve(input: ApproveInput!): TransitionResult!",
+		"input SubmitInput {",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("expected schema to contain %q:\n%s", want, schema)
+		}
+	}
+
+	// The schema survives the unified endpoint's namespacing
+	unified := NewUnifiedGraphQL([]GraphQLService{svc}).Schema()
+	if !strings.Contains(unified, "expenseapproval_approve(input: ExpenseapprovalApproveInput!)") {
+		t.Errorf("expected namespaced mutations in the unified schema:\n%s", unified)
+	}
+
+	resolvers := svc.GraphQLResolvers()
+	ctx := context.Background()
+	created, err := resolvers["expenseapproval_create"](ctx, nil)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	id := created.(map[string]any)["id"].(string)
+	input := map[string]any{"input": map[string]any{"aggregateId": id}}
+	if res, _ := resolvers["expenseapproval_submit"](ctx, input); res.(map[string]any)["success"] != true {
+		t.Fatalf("submit: %+v", res)
+	}
+	res, _ := resolvers["approve"](ctx, input)
+	if res.(map[string]any)["success"] != false || !strings.Contains(res.(map[string]any)["error"].(string), "unauthorized") {
+		t.Errorf("expected approve without a user to fail, got %+v", res)
+	}
+
+	ctx = context.WithValue(ctx, userContextKey, &User{Login: "carol", Roles: []string{"admin"}})
+	if res, _ := resolvers["approve"](ctx, input); res.(map[string]any)["success"] != true {
+		t.Fatalf("approve: %+v", res)
+	}
+	state, err := resolvers["expenseapproval"](ctx, map[string]any{"id": id})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if places := state.(map[string]any)["places"].(map[string]any); places["approved"] != 1 {
+		t.Errorf("expected approved, got %+v", state)
+	}
+	events, _ := resolvers["expenseapprovalEvents"](ctx, map[string]any{"aggregateId": id})
+	if n := len(events.([]map[string]any)); n != 2 {
+		t.Errorf("expected 2 events, got %d", n)
+	}
+}
+
+// approvalModelV2 drops reject: submitted expenses can only be approved.
+func TestModelServicePlan(t *testing.T) {
+	ctx := context.Background()
+	svc := newApprovalService(t)
+	if _, err := svc.Fire(ctx, nil, "current", "submit", nil); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	events, _ := svc.store.Read(ctx, "current", 0)
+	submitted := events[0].Type
+
+	// History written before submit's event was renamed
+	legacy := []*eventsource.Event{{ID: "e1", Type: "Sent", Data: json.RawMessage(`{}`)}}
+	if _, err := svc.store.Append(ctx, "legacy", 0, legacy); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	svc.SetPlan(&migrate.Plan{Version: 2, Upcasters: []migrate.Upcaster{{EventType: "Sent", Version: 1, RenameTo: submitted}}})
+
+	result, err := svc.Fire(ctx, &User{Login: "dave", Roles: []string{"manager"}}, "legacy", "approve", nil)
+	if err != nil {
+		t.Fatalf("approve legacy instance: %v", err)
+	}
+	if result.State["approved"] != 1 {
+		t.Errorf("expected the legacy instance approved, got %v", result.State)
+	}
+	events, _ = svc.store.Read(ctx, "legacy", 0)
+	if events[0].Type != submitted || migrate.SchemaVersion(events[1]) != 2 {
+		t.Errorf("expected upcast history and stamped appends, got %s v%d", events[0].Type, migrate.SchemaVersion(events[1]))
+	}
+}
+
+func TestModelServicePlanAfterDeploy(t *testing.T) {
+	ctx := context.Background()
+	svc := newApprovalService(t)
+	spec, err := ParseModelSpec([]byte(approvalModelV2))
+	if err != nil {
+		t.Fatalf("parse v2: %v", err)
+	}
+	if _, err := svc.Deploy(ctx, spec); err != nil {
+		t.Fatalf("deploy: %v", err)
+	}
+	legacy := []*eventsource.Event{{ID: "e1", Type: "Sent", Data: json.RawMessage(`{}`)}}
+	if _, err := svc.store.Append(ctx, "legacy", 0, legacy); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	svc.SetPlan(&migrate.Plan{Version: 2, Upcasters: []migrate.Upcaster{{EventType: "Sent", Version: 1, RenameTo: "Submitted"}}})
+
+	// Only the version the plan was set on, and later ones, read upcast
+	for number, want := range map[int]string{1: "Sent", 2: "Submitted"} {
+		events, err := svc.storeFor(number).Read(ctx, "legacy", 0)
+		if err != nil {
+			t.Fatalf("read under v%d: %v", number, err)
+		}
+		if events[0].Type != want {
+			t.Errorf("expected v%d to read %s, got %s", number, want, events[0].Type)
+		}
+	}
+}
+
+const approvalModelV2 = `{
+	"name": "expense-approval",
+	"places": [{"id": "draft", "initial": 1}, {"id": "submitted"}, {"id": "approved"}],
+	"transitions": [{"id": "submit"}, {"id": "approve"}],
+	"arcs": [
+		{"from": "draft", "to": "submit"}, {"from": "submit", "to": "submitted"},
+		{"from": "submitted", "to": "approve"}, {"from": "approve", "to": "approved"}
+	],
+	"roles": [{"id": "manager"}, {"id": "admin", "inherits": ["manager"]}],
+	"access": [{"transition": "approve", "roles": ["manager"]}]
+}`
+
+func TestModelServiceDeploy(t *testing.T) {
+	svc := newApprovalService(t)
+	ctx := context.Background()
+	dave := &User{Login: "dave", Roles: []string{"manager"}}
+	for _, step := range []struct{ id, transition string }{
+		{"a1", "submit"}, {"a1", "reject"}, {"a2", "submit"},
+	} {
+		if _, err := svc.Fire(ctx, dave, step.id, step.transition, nil); err != nil {
+			t.Fatalf("%s %s: %v", step.id, step.transition, err)
+		}
+	}
+
+	spec, err := ParseModelSpec([]byte(approvalModelV2))
+	if err != nil {
+		t.Fatalf("parse v2: %v", err)
+	}
+	deployment, err := svc.Deploy(ctx, spec)
+	if err != nil {
+		t.Fatalf("deploy: %v", err)
+	}
+	if deployment.Version != 2 || len(deployment.Incompatible) != 1 || deployment.Incompatible[0].AggregateID != "a1" {
+		t.Fatalf("expected a1 to be incompatible with version 2, got %+v", deployment)
+	}
+	if events := deployment.Incompatible[0].Events; len(events) != 1 {
+		t.Errorf("expected the reject event to be reported, got %v", events)
+	}
+
+	// a1 stays on version 1 and can still be rejected; new aggregates cannot
+	if _, err := svc.Fire(ctx, dave, "a1", "submit", nil); err != nil {
+		t.Fatalf("a1 submit on version 1: %v", err)
+	}
+	if _, err := svc.Fire(ctx, dave, "a1", "reject", nil); err != nil {
+		t.Errorf("expected a1 to keep version 1's reject, got %v", err)
+	}
+	if _, err := svc.Fire(ctx, dave, "a3", "submit", nil); err != nil {
+		t.Fatalf("a3 submit: %v", err)
+	}
+	if _, err := svc.Fire(ctx, dave, "a3", "reject", nil); err == nil {
+		t.Error("expected reject to fail on version 2")
+	}
+
+	if err := svc.Migrate(ctx, "a1", 0); err == nil {
+		t.Error("expected migrating a1 to fail")
+	}
+	if err := svc.Migrate(ctx, "a2", 0); err != nil {
+		t.Fatalf("migrate a2: %v", err)
+	}
+	if state, _ := svc.state(ctx, "a2"); state.ModelVersion != 2 || state.Places["submitted"] != 1 {
+		t.Errorf("expected a2 submitted on version 2, got %+v", state)
+	}
+
+	if _, err := svc.Deploy(ctx, ModelSpec{Net: &goflowmodel.Model{Name: "other"}}); err == nil {
+		t.Error("expected a different model to be refused")
+	}
+
+	// Without a model file to import from, subnets are refused, not dropped
+	if _, err := ParseModelSpec([]byte(`{"name": "approval", "subnets": [{"model": "../secret.json"}]}`)); !errors.Is(err, metamodel.ErrNoLoader) {
+		t.Errorf("expected subnets to be refused, got %v", err)
+	}
+}
+
+func TestModelServiceAdminRoutes(t *testing.T) {
+	t.Setenv("DEV_MODE", "true")
+	svc := newApprovalService(t)
+	handler := NewAuthHandler("").Middleware(svc.BuildHandler())
+
+	do := func(method, path, user, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if user != "" {
+			req.Header.Set("X-Dev-User", user)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do("POST", "/api/admin/versions", "bob:manager", approvalModelV2); rec.Code != http.StatusForbidden {
+		t.Errorf("expected a manager to be refused, got %d", rec.Code)
+	}
+	if rec := do("POST", "/api/admin/versions", "carol:admin", `{"name": "expense-approval", "places": [{"id": "p"}], "arcs": [{"from": "p", "to": "missing"}]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected an invalid model to be refused, got %d %s", rec.Code, rec.Body)
+	}
+	if rec := do("POST", "/api/admin/versions", "carol:admin", approvalModelV2); rec.Code != http.StatusCreated {
+		t.Fatalf("deploy: %d %s", rec.Code, rec.Body)
+	}
+
+	rec := do("POST", "/api/instances", "", "")
+	var created ModelState
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || created.ModelVersion != 2 {
+		t.Fatalf("expected new instances on version 2, got %s", rec.Body)
+	}
+	if rec := do("POST", "/api/transitions/reject", "carol:admin", `{"aggregate_id": "`+created.AggregateID+`"}`); rec.Code != http.StatusNotFound {
+		t.Errorf("expected reject to be unrouted once no aggregate needs it, got %d", rec.Code)
+	}
+
+	rec = do("GET", "/api/admin/versions", "carol:admin", "")
+	var versions struct {
+		Versions []VersionInfo `json:"versions"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &versions); err != nil || len(versions.Versions) != 2 || !versions.Versions[1].Current {
+		t.Errorf("expected two versions, the second current, got %s", rec.Body)
+	}
+}
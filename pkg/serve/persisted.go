@@ -0,0 +1,74 @@
+package serve
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// PersistedQueries is a registry of automatic persisted queries: once a
+// client has sent a query with its SHA-256 hash, it may send the hash
+// alone. The registry holds a bounded number of queries and drops the
+// least recently used when full, so clients cannot grow it without limit.
+type PersistedQueries struct {
+	mu     sync.Mutex
+	max    int
+	order  *list.List // of *persistedQuery, most recently used first
+	byHash map[string]*list.Element
+}
+
+type persistedQuery struct {
+	hash  string
+	query string
+}
+
+// NewPersistedQueries creates a registry holding up to max queries.
+func NewPersistedQueries(max int) *PersistedQueries {
+	return &PersistedQueries{
+		max:    max,
+		order:  list.New(),
+		byHash: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the query registered under a hash.
+func (p *PersistedQueries) Get(hash string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	elem, ok := p.byHash[hash]
+	if !ok {
+		return "", false
+	}
+	p.order.MoveToFront(elem)
+	return elem.Value.(*persistedQuery).query, true
+}
+
+// Put registers a query under its hash.
+func (p *PersistedQueries) Put(hash, query string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if elem, ok := p.byHash[hash]; ok {
+		p.order.MoveToFront(elem)
+		return
+	}
+	p.byHash[hash] = p.order.PushFront(&persistedQuery{hash: hash, query: query})
+	for p.order.Len() > p.max {
+		oldest := p.order.Back()
+		p.order.Remove(oldest)
+		delete(p.byHash, oldest.Value.(*persistedQuery).hash)
+	}
+}
+
+// Len returns the number of queries registered.
+func (p *PersistedQueries) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.order.Len()
+}
+
+// queryHash returns the hex SHA-256 hash clients persist a query under.
+func queryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
@@ -54,6 +54,7 @@ func PlaygroundHandler(endpoint string) http.HandlerFunc {
       text-transform: uppercase; letter-spacing: 0.8px;
     }
     #ops-sidebar .ops-section-title.mutation-section { color: #d4a054; }
+    #ops-sidebar .ops-section-title.subscription-section { color: #c586c0; }
     #ops-sidebar .ops-item {
       padding: 5px 16px 5px 32px; cursor: pointer; display: flex;
       align-items: center; gap: 8px; white-space: nowrap;
@@ -62,6 +63,7 @@ func PlaygroundHandler(endpoint string) http.HandlerFunc {
     #ops-sidebar .ops-item:hover { background: rgba(255,255,255,0.06); color: #fff; }
     #ops-sidebar .ops-item .play-icon { color: #6a9955; font-size: 10px; flex-shrink: 0; }
     #ops-sidebar .ops-item.mutation-item .play-icon { color: #d4a054; }
+    #ops-sidebar .ops-item.subscription-item .play-icon { color: #c586c0; }
     #ops-sidebar .ops-item .op-name { overflow: hidden; text-overflow: ellipsis; }
     #ops-filter-wrap {
       padding: 8px 12px; border-bottom: 1px solid rgba(255,255,255,0.06);
@@ -218,8 +220,12 @@ func PlaygroundHandler(endpoint string) http.HandlerFunc {
   <div id="models-panel"><div id="models-panel-resize"></div><div id="models-panel-inner"><div class="mp-header"><span>Models</span></div><div id="models-content"></div></div></div>
   <script>
     window.addEventListener('load', function() {
+      // Subscriptions run over a WebSocket on the same endpoint
+      var subscriptionEndpoint = new URL('` + endpoint + `', location.href);
+      subscriptionEndpoint.protocol = subscriptionEndpoint.protocol === 'https:' ? 'wss:' : 'ws:';
       GraphQLPlayground.init(document.getElementById('root'), {
         endpoint: '` + endpoint + `',
+        subscriptionEndpoint: subscriptionEndpoint.href,
         settings: {
           'editor.theme': 'dark',
           'editor.fontFamily': "'Source Code Pro', 'Consolas', 'Inconsolata', 'Droid Sans Mono', 'Monaco', monospace",
@@ -373,6 +379,9 @@ func PlaygroundHandler(endpoint string) http.HandlerFunc {
       if (kind === 'mutation') {
         return 'mutation {\n' + body + '\n}';
       }
+      if (kind === 'subscription') {
+        return 'subscription {\n' + body + '\n}';
+      }
       return '{\n' + body + '\n}';
     }
 
@@ -419,13 +428,14 @@ func PlaygroundHandler(endpoint string) http.HandlerFunc {
       return groups;
     }
 
-    function render(queries, mutations, types) {
+    function render(queries, mutations, subscriptions, types) {
       var container = document.getElementById('ops-content');
       container.innerHTML = '';
 
       var allOps = [
         { label: 'Queries', items: queries, kind: 'query' },
-        { label: 'Mutations', items: mutations, kind: 'mutation' }
+        { label: 'Mutations', items: mutations, kind: 'mutation' },
+        { label: 'Subscriptions', items: subscriptions, kind: 'subscription' }
       ];
 
       // Detect known service prefixes from mutations (they use underscore: blogpost_create)
@@ -436,12 +446,14 @@ func PlaygroundHandler(endpoint string) http.HandlerFunc {
       }
       var knownServices = Object.keys(knownSvcSet);
 
-      // Collect all services across queries and mutations
+      // Collect all services across queries, mutations and subscriptions
       var serviceSet = {};
       var qGroups = groupByService(queries, knownServices);
       var mGroups = groupByService(mutations, knownServices);
+      var sGroups = groupByService(subscriptions, knownServices);
       for (var k in qGroups) serviceSet[k] = true;
       for (var k in mGroups) serviceSet[k] = true;
+      for (var k in sGroups) serviceSet[k] = true;
       var services = Object.keys(serviceSet).sort();
 
       for (var si = 0; si < services.length; si++) {
@@ -465,6 +477,7 @@ func PlaygroundHandler(endpoint string) http.HandlerFunc {
 
         var svcQueries = qGroups[svc] || [];
         var svcMutations = mGroups[svc] || [];
+        var svcSubscriptions = sGroups[svc] || [];
 
         if (svcQueries.length > 0) {
           var sec = document.createElement('div');
@@ -510,6 +523,28 @@ func PlaygroundHandler(endpoint string) http.HandlerFunc {
           group.appendChild(msec);
         }
 
+        if (svcSubscriptions.length > 0) {
+          var ssec = document.createElement('div');
+          ssec.className = 'ops-section';
+          var ssecTitle = document.createElement('div');
+          ssecTitle.className = 'ops-section-title subscription-section';
+          ssecTitle.textContent = 'Subscriptions';
+          ssec.appendChild(ssecTitle);
+          for (var ssi = 0; ssi < svcSubscriptions.length; ssi++) {
+            var sitem = document.createElement('div');
+            sitem.className = 'ops-item subscription-item';
+            sitem.innerHTML = '<span class="play-icon">&#9654;</span><span class="op-name">' + svcSubscriptions[ssi].name + '</span>';
+            sitem.addEventListener('click', (function(op, kind) {
+              return function() {
+                setEditorValue(generateQuery(op, kind, types));
+                closeSidebar();
+              };
+            })(svcSubscriptions[ssi], 'subscription'));
+            ssec.appendChild(sitem);
+          }
+          group.appendChild(ssec);
+        }
+
         container.appendChild(group);
       }
     }
@@ -546,9 +581,11 @@ func PlaygroundHandler(endpoint string) http.HandlerFunc {
         var types = parseSDL(sdl);
         var queries = types['Query'] || [];
         var mutations = types['Mutation'] || [];
+        var subscriptions = types['Subscription'] || [];
         delete types['Query'];
         delete types['Mutation'];
-        render(queries, mutations, types);
+        delete types['Subscription'];
+        render(queries, mutations, subscriptions, types);
       })
       .catch(function(err) {
         console.error('Failed to load schema for ops explorer:', err);
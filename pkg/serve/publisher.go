@@ -0,0 +1,84 @@
+package serve
+
+import (
+	"context"
+	"sync"
+)
+
+// Publisher notifies GraphQL subscribers when aggregates change. A service
+// calls Publish after it writes an aggregate's events; each subscriber to
+// that aggregate then reloads it and receives the new state.
+//
+// Notifications coalesce: a subscriber that falls behind receives the
+// latest state once rather than every intermediate one.
+type Publisher struct {
+	mu   sync.Mutex
+	subs map[string]map[chan struct{}]struct{}
+}
+
+// NewPublisher creates a publisher with no subscribers.
+func NewPublisher() *Publisher {
+	return &Publisher{subs: make(map[string]map[chan struct{}]struct{})}
+}
+
+// Publish notifies the subscribers of an aggregate that it changed.
+func (p *Publisher) Publish(aggregateID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for notify := range p.subs[aggregateID] {
+		select {
+		case notify <- struct{}{}:
+		default:
+			// A notification is already pending
+		}
+	}
+}
+
+// Subscribe returns the event stream of a subscription to an aggregate:
+// each time the aggregate is published, load is called and its result
+// sent. A load error is sent as the event, which the executor reports as
+// an error on the subscription field. The stream ends when ctx is done.
+func (p *Publisher) Subscribe(ctx context.Context, aggregateID string, load func(context.Context) (any, error)) <-chan any {
+	notify := make(chan struct{}, 1)
+	p.mu.Lock()
+	if p.subs[aggregateID] == nil {
+		p.subs[aggregateID] = make(map[chan struct{}]struct{})
+	}
+	p.subs[aggregateID][notify] = struct{}{}
+	p.mu.Unlock()
+
+	events := make(chan any)
+	go func() {
+		defer close(events)
+		defer p.unsubscribe(aggregateID, notify)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-notify:
+			}
+			var event any
+			value, err := load(ctx)
+			if err != nil {
+				event = err
+			} else {
+				event = value
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+func (p *Publisher) unsubscribe(aggregateID string, notify chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.subs[aggregateID], notify)
+	if len(p.subs[aggregateID]) == 0 {
+		delete(p.subs, aggregateID)
+	}
+}
@@ -87,6 +87,22 @@ type GraphQLService interface {
 // GraphQLResolver is a function that handles a GraphQL operation.
 type GraphQLResolver func(ctx context.Context, variables map[string]any) (any, error)
 
+// GraphQLSubscriptionService is an optional interface for GraphQL services that
+// publish live updates. The service's schema declares a Subscription type, whose
+// fields clients subscribe to over a WebSocket on the unified endpoint.
+type GraphQLSubscriptionService interface {
+	GraphQLService
+
+	// GraphQLSubscriptions returns a map of subscriber functions for this service.
+	// Keys are subscription field names (e.g., "erc20tokenUpdated").
+	GraphQLSubscriptions() map[string]GraphQLSubscriber
+}
+
+// GraphQLSubscriber is a function that opens the event stream of a GraphQL
+// subscription. Each value sent on the channel is one event; the stream ends when
+// the channel is closed. Subscribers must stop sending once ctx is done.
+type GraphQLSubscriber func(ctx context.Context, variables map[string]any) (<-chan any, error)
+
 // ServiceFactory is a function that creates a new Service instance.
 type ServiceFactory func() (Service, error)
 
@@ -139,6 +155,7 @@ type Options struct {
 	WriteTimeout   time.Duration
 	IdleTimeout    time.Duration
 	CustomRoutes   RouteRegistrar // Optional function to register custom routes
+	GraphQL        GraphQLOptions // Limits and persisted queries for /graphql
 }
 
 // DefaultOptions returns sensible default options.
@@ -148,6 +165,7 @@ func DefaultOptions() Options {
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
+		GraphQL:      DefaultGraphQLOptions(),
 	}
 }
 
@@ -240,9 +258,10 @@ func RunMultiple(names []string, opts Options) error {
 		}
 	}
 	if len(graphqlServices) > 0 {
-		// Use go-pflow's GraphQL server for query execution
-		unifiedGraphQL := NewUnifiedGraphQLFromGoPflow(graphqlServices)
-		mux.Handle("/graphql", unifiedGraphQL.Handler())
+		// Validate and execute queries against the combined schema
+		unifiedGraphQL := NewUnifiedGraphQL(graphqlServices)
+		unifiedGraphQL.SetOptions(opts.GraphQL)
+		mux.Handle("/graphql", authHandler.Middleware(unifiedGraphQL.Handler()))
 		mux.HandleFunc("/graphql/i", PlaygroundHandler("/graphql"))
 		mux.HandleFunc("/schema", unifiedGraphQL.SchemaHandler())
 
@@ -297,7 +316,8 @@ func RunMultiple(names []string, opts Options) error {
 	// Check for custom frontends first and mount them at /{name}/
 	for i, svc := range services {
 		name := names[i]
-		handler := svc.BuildHandler()
+		// Services read the signed-in user with UserFromContext
+		handler := authHandler.Middleware(svc.BuildHandler())
 		prefix := "/" + name
 		
 		// Check if there's a custom frontend for this service
@@ -0,0 +1,275 @@
+package serve
+
+// This file serves GraphQL over WebSocket on the unified endpoint. Clients
+// may speak graphql-transport-ws, the protocol of the graphql-ws library,
+// or the older graphql-ws protocol of subscriptions-transport-ws, which the
+// GraphQL Playground uses. Either way a connection carries any number of
+// operations: subscriptions stream a result per event, and queries and
+// mutations send their single result.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pflow-xyz/petri-pilot/pkg/runtime/graphql"
+)
+
+// WebSocket subprotocols for GraphQL.
+const (
+	graphQLTransportWS = "graphql-transport-ws"
+	graphQLWS          = "graphql-ws"
+)
+
+// connectionInitTimeout is how long a client has to send connection_init.
+const connectionInitTimeout = 10 * time.Second
+
+// maxOperations is how many operations a connection may run at once.
+const maxOperations = 100
+
+var errTooManyOperations = fmt.Errorf("connection is running the maximum of %d operations", maxOperations)
+
+var errOperationPanicked = errors.New("internal server error")
+
+var graphQLUpgrader = websocket.Upgrader{
+	Subprotocols: []string{graphQLTransportWS, graphQLWS},
+}
+
+// wsMessage is a message received from a client.
+type wsMessage struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// wsReply is a message sent to a client.
+type wsReply struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Payload any    `json:"payload,omitempty"`
+}
+
+// wsSession is one WebSocket connection and its running operations.
+type wsSession struct {
+	ug   *UnifiedGraphQL
+	conn *websocket.Conn
+	// legacy is set for the subscriptions-transport-ws protocol.
+	legacy bool
+
+	writeMu sync.Mutex
+
+	mu  sync.Mutex
+	ops map[string]*wsOperation
+	wg  sync.WaitGroup
+}
+
+type wsOperation struct {
+	cancel context.CancelFunc
+}
+
+// serveWebSocket upgrades a request and runs the protocol until the client
+// disconnects. Operations run with the request's context, so resolvers see
+// the user the connection was authenticated as.
+func (ug *UnifiedGraphQL) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := graphQLUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has replied with an error
+		return
+	}
+	conn.SetReadLimit(maxGraphQLBody)
+	ctx, cancel := context.WithCancel(r.Context())
+	s := &wsSession{
+		ug:     ug,
+		conn:   conn,
+		legacy: conn.Subprotocol() == graphQLWS,
+		ops:    make(map[string]*wsOperation),
+	}
+	defer func() {
+		cancel()
+		s.wg.Wait()
+		conn.Close()
+	}()
+
+	acknowledged := false
+	conn.SetReadDeadline(time.Now().Add(connectionInitTimeout))
+	for {
+		var msg wsMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			var netErr net.Error
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			switch {
+			case !acknowledged && errors.As(err, &netErr) && netErr.Timeout():
+				s.close(4408, "Connection initialisation timeout")
+			case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+				s.close(4400, "Invalid message")
+			}
+			return
+		}
+
+		switch msg.Type {
+		case "connection_init":
+			if acknowledged {
+				s.close(4429, "Too many initialisation requests")
+				return
+			}
+			acknowledged = true
+			conn.SetReadDeadline(time.Time{})
+			s.send(wsReply{Type: "connection_ack"})
+		case "ping":
+			s.send(wsReply{Type: "pong", Payload: msg.Payload})
+		case "pong":
+		case "subscribe", "start":
+			if !acknowledged {
+				s.close(4401, "Unauthorized")
+				return
+			}
+			var req struct {
+				Query         string         `json:"query"`
+				OperationName string         `json:"operationName"`
+				Variables     map[string]any `json:"variables"`
+			}
+			if msg.ID == "" || json.Unmarshal(msg.Payload, &req) != nil {
+				s.close(4400, "Invalid message")
+				return
+			}
+			if len(req.Query) > maxGraphQLQuery {
+				s.fail(msg.ID, operationError(errQueryTooLarge))
+				continue
+			}
+			if !s.start(ctx, msg.ID, req.Query, req.OperationName, req.Variables) {
+				s.close(4409, "Subscriber for "+msg.ID+" already exists")
+				return
+			}
+		case "complete", "stop":
+			s.stop(msg.ID)
+		case "connection_terminate":
+			return
+		default:
+			s.close(4400, "Invalid message")
+			return
+		}
+	}
+}
+
+// start runs an operation until its results end, the client completes it
+// or the connection closes, and then reports it to the observers. It
+// reports false if the ID is in use; an operation past maxOperations, or
+// one that panics, fails with an error.
+func (s *wsSession) start(ctx context.Context, id, query, operationName string, variables map[string]any) bool {
+	s.mu.Lock()
+	if _, ok := s.ops[id]; ok {
+		s.mu.Unlock()
+		return false
+	}
+	if len(s.ops) >= maxOperations {
+		s.mu.Unlock()
+		s.fail(id, operationError(errTooManyOperations))
+		return true
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	op := &wsOperation{cancel: cancel}
+	s.ops[id] = op
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.finish(id, op)
+
+		start := time.Now()
+		defer func() {
+			// A panicking operation fails alone rather than the server
+			if recover() != nil {
+				failed := operationError(errOperationPanicked)
+				observeGraphQL(newGraphQLOperation(operationName, start, failed))
+				s.fail(id, failed)
+			}
+		}()
+		results, failed := s.ug.subscribeGraphQL(ctx, query, operationName, variables)
+		if failed != nil {
+			observeGraphQL(newGraphQLOperation(operationName, start, failed))
+			s.fail(id, failed)
+			return
+		}
+		next := "next"
+		if s.legacy {
+			next = "data"
+		}
+		var first *graphql.Result
+		errs := 0
+		for result := range results {
+			if first == nil {
+				first = result
+			}
+			errs += len(result.Errors)
+			s.send(wsReply{ID: id, Type: next, Payload: result})
+		}
+		observed := newGraphQLOperation(operationName, start, first)
+		observed.Errors = errs
+		observeGraphQL(observed)
+
+		// A client that completed the operation expects nothing more
+		if ctx.Err() == nil {
+			s.send(wsReply{ID: id, Type: "complete"})
+		}
+	}()
+	return true
+}
+
+// fail reports an operation that could not run.
+func (s *wsSession) fail(id string, failed *graphql.Result) {
+	if s.legacy {
+		// subscriptions-transport-ws reports request errors as data
+		s.send(wsReply{ID: id, Type: "data", Payload: failed})
+		s.send(wsReply{ID: id, Type: "complete"})
+		return
+	}
+	s.send(wsReply{ID: id, Type: "error", Payload: failed.Errors})
+}
+
+// operationError is the result of an operation rejected with err.
+func operationError(err error) *graphql.Result {
+	return &graphql.Result{Errors: []*graphql.Error{{Message: err.Error()}}}
+}
+
+// stop cancels an operation the client completed.
+func (s *wsSession) stop(id string) {
+	s.mu.Lock()
+	op := s.ops[id]
+	delete(s.ops, id)
+	s.mu.Unlock()
+	if op != nil {
+		op.cancel()
+	}
+}
+
+// finish releases an operation's ID once it has ended.
+func (s *wsSession) finish(id string, op *wsOperation) {
+	s.mu.Lock()
+	if s.ops[id] == op {
+		delete(s.ops, id)
+	}
+	s.mu.Unlock()
+	op.cancel()
+}
+
+func (s *wsSession) send(reply wsReply) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.conn.WriteJSON(reply)
+}
+
+// close ends the connection with a protocol close code.
+func (s *wsSession) close(code int, reason string) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	msg := websocket.FormatCloseMessage(code, reason)
+	s.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/pflow-xyz/go-pflow/petri"
 	"github.com/pflow-xyz/go-pflow/reachability"
 	mpetri "github.com/pflow-xyz/go-pflow/tokenmodel/petri"
+	ppmetamodel "github.com/pflow-xyz/petri-pilot/pkg/metamodel"
 )
 
 // Validator performs formal verification on Petri net models.
@@ -170,6 +171,15 @@ func (v *Validator) validateStructure(model *metamodel.Model) []metamodel.Valida
 		}
 	}
 
+	// Check colored places' colors and the inscriptions of their arcs
+	for _, err := range ppmetamodel.FromModel(model).CheckColors() {
+		errs = append(errs, metamodel.ValidationError{
+			Code:    "INVALID_COLORED_PLACE",
+			Message: err.Error(),
+			Fix:     "Declare the color as {field type, ...} and inscribe arcs with fields of that color",
+		})
+	}
+
 	return errs
 }
 
@@ -35,6 +35,11 @@ type Mutation {
   zkFireTransition(gameId: ID!, transition: Int!): ZKMoveResult!
 }
 
+type Subscription {
+  # ZK game state after each move
+  zkGameUpdated(id: ID!): ZKGameState!
+}
+
 # ZK game state
 type ZKGameState {
   id: ID!
@@ -167,6 +172,32 @@ func (z *ZKIntegration) ZKGraphQLResolvers() map[string]serve.GraphQLResolver {
 	return resolvers
 }
 
+// ZKGraphQLSubscriptions returns GraphQL subscriptions for ZK games.
+func (z *ZKIntegration) ZKGraphQLSubscriptions() map[string]serve.GraphQLSubscriber {
+	subscriptions := make(map[string]serve.GraphQLSubscriber)
+
+	// Subscription: zkGameUpdated
+	subscriptions["zkGameUpdated"] = func(ctx context.Context, vars map[string]any) (<-chan any, error) {
+		id, ok := vars["id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("missing id parameter")
+		}
+
+		return z.updates.Subscribe(ctx, id, func(context.Context) (any, error) {
+			z.mu.RLock()
+			defer z.mu.RUnlock()
+
+			game, ok := z.games[id]
+			if !ok {
+				return nil, fmt.Errorf("game not found: %s", id)
+			}
+			return z.gameToGraphQL(id, game), nil
+		}), nil
+	}
+
+	return subscriptions
+}
+
 // gameToGraphQL converts a PetriGame to GraphQL response format.
 func (z *ZKIntegration) gameToGraphQL(id string, game *PetriGame) map[string]any {
 	board := extractBoard(game.Marking)
@@ -221,6 +252,7 @@ func (z *ZKIntegration) handleMoveGraphQL(gameID string, position int) (map[stri
 			"error":   err.Error(),
 		}, nil
 	}
+	z.updates.Publish(gameID)
 
 	// Generate proof
 	assignment := witness.ToPetriTransitionAssignment()
@@ -319,6 +351,7 @@ func (z *ZKIntegration) handleFireTransitionGraphQL(gameID string, transition in
 			"error":   err.Error(),
 		}, nil
 	}
+	z.updates.Publish(gameID)
 
 	// Generate proof
 	assignment := witness.ToPetriTransitionAssignment()
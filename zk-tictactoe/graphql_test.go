@@ -219,3 +219,53 @@ func TestZKGraphQL_ListCircuits(t *testing.T) {
 		t.Fatal("expected win circuit")
 	}
 }
+
+func TestZKGraphQL_GameUpdated(t *testing.T) {
+	zk, err := NewZKIntegration()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolvers := zk.ZKGraphQLResolvers()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	createResult, err := resolvers["zkCreateGame"](ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gameID := createResult.(map[string]any)["id"].(string)
+
+	// Subscribe before moving
+	events, err := zk.ZKGraphQLSubscriptions()["zkGameUpdated"](ctx, map[string]any{
+		"id": gameID,
+	})
+	if err != nil {
+		t.Fatalf("zkGameUpdated failed: %v", err)
+	}
+
+	// X plays center
+	if _, err := resolvers["zkMove"](ctx, map[string]any{
+		"gameId":   gameID,
+		"position": float64(4),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	event := <-events
+	game, ok := event.(map[string]any)
+	if !ok {
+		t.Fatalf("expected game state, got %v", event)
+	}
+	if game["turnCount"].(int) != 1 {
+		t.Fatalf("expected turn count 1, got %v", game["turnCount"])
+	}
+	if game["board"].([]int)[4] != int(X) {
+		t.Fatalf("expected X at center, got %v", game["board"])
+	}
+
+	// The stream ends with the subscription
+	cancel()
+	for range events {
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/pflow-xyz/go-pflow/prover"
+	"github.com/pflow-xyz/petri-pilot/pkg/serve"
 )
 
 // ZKIntegration provides ZK proof endpoints for tic-tac-toe using full Petri net semantics.
@@ -18,6 +19,7 @@ type ZKIntegration struct {
 	service *prover.Service
 	games   map[string]*PetriGame // in-memory game state for ZK proofs
 	mu      sync.RWMutex
+	updates *serve.Publisher // notifies GraphQL subscribers of moves
 }
 
 // NewZKIntegration creates a new ZK integration with compiled Petri net circuits.
@@ -47,6 +49,7 @@ func NewZKIntegration() (*ZKIntegration, error) {
 		prover:  p,
 		service: prover.NewService(p, factory),
 		games:   make(map[string]*PetriGame),
+		updates: serve.NewPublisher(),
 	}, nil
 }
 
@@ -188,6 +191,7 @@ func (z *ZKIntegration) handleFireTransition(w http.ResponseWriter, r *http.Requ
 		})
 		return
 	}
+	z.updates.Publish(id)
 
 	// Generate proof
 	assignment := witness.ToPetriTransitionAssignment()
@@ -350,6 +354,7 @@ func (z *ZKIntegration) handleMove(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	z.updates.Publish(id)
 
 	// Generate proof using Petri net transition circuit
 	assignment := witness.ToPetriTransitionAssignment()
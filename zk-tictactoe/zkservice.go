@@ -88,3 +88,16 @@ func (s *ZKService) GraphQLResolvers() map[string]serve.GraphQLResolver {
 
 	return resolvers
 }
+
+// GraphQLSubscriptions returns the combined GraphQL subscriptions for this service.
+func (s *ZKService) GraphQLSubscriptions() map[string]serve.GraphQLSubscriber {
+	// Start with base subscriptions
+	subscriptions := s.base.GraphQLSubscriptions()
+
+	// Add ZK subscriptions
+	for name, subscriber := range s.zk.ZKGraphQLSubscriptions() {
+		subscriptions[name] = subscriber
+	}
+
+	return subscriptions
+}